@@ -8,11 +8,18 @@ import (
 // binaryEval evaluates two float array and returns float array
 // 1. capacity not equals, return nil
 // 2. merge two array based on binary operator, return other float array
-// NOTICE: make sure both left and right array are not nil and same capacity
+// 3. nil array is treated as empty array, like the result of comparison which filters all values
+// NOTICE: make sure both left and right array are same capacity
 func binaryEval(binaryOp stmt.BinaryOP, left, right collections.FloatArray) collections.FloatArray {
-	if left.IsEmpty() && right.IsEmpty() {
+	if isEmpty(left) && isEmpty(right) {
 		return nil
 	}
+	if left == nil {
+		left = collections.NewFloatArray(right.Capacity())
+	}
+	if right == nil {
+		right = collections.NewFloatArray(left.Capacity())
+	}
 
 	capacity := left.Capacity()
 	result := collections.NewFloatArray(capacity)
@@ -26,6 +33,11 @@ func binaryEval(binaryOp stmt.BinaryOP, left, right collections.FloatArray) coll
 	return result
 }
 
+// isEmpty tests if the float array is nil or has no value
+func isEmpty(values collections.FloatArray) bool {
+	return values == nil || values.IsEmpty()
+}
+
 // eval evaluates two values and returns another value
 func eval(binaryOp stmt.BinaryOP, left, right float64) float64 {
 	switch binaryOp {
@@ -44,3 +56,51 @@ func eval(binaryOp stmt.BinaryOP, left, right float64) float64 {
 		return 0
 	}
 }
+
+// scalarEval evaluates float array with scalar value and returns float array
+// 1. arithmetic operator, evaluates each value of array with the scalar, like f*100
+// 2. comparison operator, filters the values of array which the comparison is true, like f>10
+// NOTICE: the scalar is the left operand if scalarLeft is true, like 100-f or 10<f
+func scalarEval(binaryOp stmt.BinaryOP, values collections.FloatArray, scalar float64, scalarLeft bool) collections.FloatArray {
+	if isEmpty(values) {
+		return nil
+	}
+	result := collections.NewFloatArray(values.Capacity())
+	it := values.Iterator()
+	for it.HasNext() {
+		slot, value := it.Next()
+		left, right := value, scalar
+		if scalarLeft {
+			left, right = scalar, value
+		}
+		if !stmt.IsComparisonOP(binaryOp) {
+			result.SetValue(slot, eval(binaryOp, left, right))
+		} else if compare(binaryOp, left, right) {
+			result.SetValue(slot, value)
+		}
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
+// compare compares two values by comparison operator
+func compare(binaryOp stmt.BinaryOP, left, right float64) bool {
+	switch binaryOp {
+	case stmt.EQUAL:
+		return left == right
+	case stmt.NOTEQUAL:
+		return left != right
+	case stmt.LESS:
+		return left < right
+	case stmt.LESSEQUAL:
+		return left <= right
+	case stmt.GREATER:
+		return left > right
+	case stmt.GREATEREQUAL:
+		return left >= right
+	default:
+		return false
+	}
+}
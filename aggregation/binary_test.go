@@ -23,12 +23,23 @@ func TestBinary_eval(t *testing.T) {
 
 func TestBinaryEval(t *testing.T) {
 	assert.Nil(t, binaryEval(stmt.DIV, collections.NewFloatArray(10), collections.NewFloatArray(10)))
+	assert.Nil(t, binaryEval(stmt.ADD, nil, nil))
+	assert.Nil(t, binaryEval(stmt.ADD, nil, collections.NewFloatArray(10)))
+	// nil is treated as empty array
+	values := collections.NewFloatArray(10)
+	values.SetValue(2, 2.2)
+	result := binaryEval(stmt.ADD, nil, values)
+	assert.Equal(t, 1, result.Size())
+	assert.Equal(t, 2.2, result.GetValue(2))
+	result = binaryEval(stmt.SUB, values, nil)
+	assert.Equal(t, 1, result.Size())
+	assert.Equal(t, 2.2, result.GetValue(2))
 
 	fa := collections.NewFloatArray(10)
 	fa.SetValue(0, 1.1)
 	fa.SetValue(5, 5.5)
 	fa.SetValue(8, 9.9)
-	result := binaryEval(stmt.ADD, collections.NewFloatArray(10), fa)
+	result = binaryEval(stmt.ADD, collections.NewFloatArray(10), fa)
 	assert.Equal(t, 3, result.Size())
 	assert.Equal(t, fa, result)
 
@@ -79,3 +90,59 @@ func TestBinaryEval(t *testing.T) {
 	assert.Equal(t, 0.0, result.GetValue(5))
 	assert.Equal(t, 0.0, result.GetValue(8))
 }
+
+func TestBinary_compare(t *testing.T) {
+	assert.True(t, compare(stmt.EQUAL, 4, 4))
+	assert.False(t, compare(stmt.EQUAL, 4, 6))
+	assert.True(t, compare(stmt.NOTEQUAL, 4, 6))
+	assert.True(t, compare(stmt.LESS, 4, 6))
+	assert.False(t, compare(stmt.LESS, 4, 4))
+	assert.True(t, compare(stmt.LESSEQUAL, 4, 4))
+	assert.True(t, compare(stmt.GREATER, 6, 4))
+	assert.False(t, compare(stmt.GREATER, 4, 4))
+	assert.True(t, compare(stmt.GREATEREQUAL, 4, 4))
+
+	// wrong comparison operator
+	assert.False(t, compare(stmt.ADD, 4, 4))
+}
+
+func TestScalarEval(t *testing.T) {
+	assert.Nil(t, scalarEval(stmt.MUL, nil, 10, false))
+	assert.Nil(t, scalarEval(stmt.MUL, collections.NewFloatArray(10), 10, false))
+
+	fa := collections.NewFloatArray(10)
+	fa.SetValue(0, 1)
+	fa.SetValue(5, 5)
+	fa.SetValue(8, 10)
+	result := scalarEval(stmt.MUL, fa, 100, false)
+	assert.Equal(t, 3, result.Size())
+	assert.Equal(t, 100.0, result.GetValue(0))
+	assert.Equal(t, 500.0, result.GetValue(5))
+	assert.Equal(t, 1000.0, result.GetValue(8))
+	result = scalarEval(stmt.SUB, fa, 100, true)
+	assert.Equal(t, 3, result.Size())
+	assert.Equal(t, 99.0, result.GetValue(0))
+	assert.Equal(t, 95.0, result.GetValue(5))
+	assert.Equal(t, 90.0, result.GetValue(8))
+	result = scalarEval(stmt.DIV, fa, 10, true)
+	assert.Equal(t, 10.0, result.GetValue(0))
+	assert.Equal(t, 2.0, result.GetValue(5))
+	assert.Equal(t, 1.0, result.GetValue(8))
+
+	// filters the values
+	result = scalarEval(stmt.GREATER, fa, 1, false)
+	assert.Equal(t, 2, result.Size())
+	assert.False(t, result.HasValue(0))
+	assert.Equal(t, 5.0, result.GetValue(5))
+	assert.Equal(t, 10.0, result.GetValue(8))
+	// 5 < f
+	result = scalarEval(stmt.LESS, fa, 5, true)
+	assert.Equal(t, 1, result.Size())
+	assert.Equal(t, 10.0, result.GetValue(8))
+	result = scalarEval(stmt.LESSEQUAL, fa, 5, false)
+	assert.Equal(t, 2, result.Size())
+	assert.Equal(t, 1.0, result.GetValue(0))
+	assert.Equal(t, 5.0, result.GetValue(5))
+	// all values are filtered
+	assert.Nil(t, scalarEval(stmt.GREATER, fa, 10, false))
+}
@@ -1,6 +1,8 @@
 package aggregation
 
 import (
+	"strconv"
+
 	"github.com/lindb/lindb/aggregation/fields"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
@@ -29,6 +31,16 @@ type expression struct {
 	fieldStore map[string]fields.Field
 	resultSet  map[string]collections.FloatArray
 	pointCount int
+	shift      int64 // the shift of time shift function which is evaluating, 0 if not shifted
+}
+
+// ShiftedFieldName returns the name of field which is queried over the shifted time range, like f@604800000,
+// returns the field name if not shifted.
+func ShiftedFieldName(fieldName string, shift int64) string {
+	if shift == 0 {
+		return fieldName
+	}
+	return fieldName + "@" + strconv.FormatInt(shift, 10)
 }
 
 // NewExpression creates an expression
@@ -42,13 +54,25 @@ func NewExpression(timeSeries series.Iterator, pointCount int, selectItems []stm
 	}
 }
 
+// NewFieldsExpression creates an expression based on the prepared field store,
+// like the aggregated fields of sub query's results
+func NewFieldsExpression(fieldStore map[string]fields.Field, selectItems []stmt.Expr) Expression {
+	return &expression{
+		selectItems: selectItems,
+		fieldStore:  fieldStore,
+		resultSet:   make(map[string]collections.FloatArray),
+	}
+}
+
 // Eval evaluates the select item's expression
 func (e *expression) Eval() {
 	if len(e.selectItems) == 0 {
 		return
 	}
 	// prepare expression context
-	e.prepare()
+	if e.timeSeries != nil {
+		e.prepare()
+	}
 	if len(e.fieldStore) == 0 {
 		return
 	}
@@ -94,7 +118,7 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []collectio
 	case *stmt.BinaryExpr:
 		return e.binaryEval(ex)
 	case *stmt.FieldExpr:
-		fieldName := ex.Name
+		fieldName := ShiftedFieldName(ex.Name, e.shift)
 		fieldValues, ok := e.fieldStore[fieldName]
 		if !ok {
 			return nil
@@ -112,6 +136,29 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []collectio
 
 // funcCall calls the function
 func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
+	switch expr.FuncType {
+	case function.TopK, function.BottomK:
+		// selects the series after evaluating, only the expr param need eval
+		if len(expr.Params) < 2 {
+			return nil
+		}
+		return e.eval(nil, expr.Params[1])
+	case function.TimeShift:
+		// evaluates the expr param by the fields of shifted time range
+		if len(expr.Params) < 2 {
+			return nil
+		}
+		shift, ok := expr.Params[1].(*stmt.DurationLiteral)
+		if !ok {
+			return nil
+		}
+		e.shift = shift.Val
+		defer func() { e.shift = 0 }()
+		return e.eval(nil, expr.Params[0])
+	}
+	if function.IsMathFunc(expr.FuncType) {
+		return e.mathFuncCall(expr)
+	}
 	var params []collections.FloatArray
 	for _, param := range expr.Params {
 		paramValues := e.eval(expr, param)
@@ -127,10 +174,46 @@ func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
 	return []collections.FloatArray{result}
 }
 
+// mathFuncCall calls the math function for the series of first param, the other params are scalar numbers
+func (e *expression) mathFuncCall(expr *stmt.CallExpr) []collections.FloatArray {
+	if len(expr.Params) == 0 {
+		return nil
+	}
+	values := e.eval(nil, expr.Params[0])
+	if len(values) != 1 {
+		return nil
+	}
+	var args []float64
+	for _, param := range expr.Params[1:] {
+		arg, ok := param.(*stmt.NumberLiteral)
+		if !ok {
+			return nil
+		}
+		args = append(args, arg.Val)
+	}
+	result := function.MathFuncCall(expr.FuncType, values[0], args...)
+	if result == nil {
+		return nil
+	}
+	return []collections.FloatArray{result}
+}
+
 // binaryEval evaluates binary operator
 func (e *expression) binaryEval(expr *stmt.BinaryExpr) []collections.FloatArray {
 	binaryOP := expr.Operator
-	if binaryOP == stmt.ADD || binaryOP == stmt.SUB || binaryOP == stmt.DIV || binaryOP == stmt.MUL {
+	isArithmetic := binaryOP == stmt.ADD || binaryOP == stmt.SUB || binaryOP == stmt.DIV || binaryOP == stmt.MUL
+	if !isArithmetic && !stmt.IsComparisonOP(binaryOP) {
+		return nil
+	}
+	// evaluates series with scalar, like f*100 or f>10
+	if scalar, ok := expr.Left.(*stmt.NumberLiteral); ok {
+		return e.scalarEval(binaryOP, expr.Right, scalar.Val, true)
+	}
+	if scalar, ok := expr.Right.(*stmt.NumberLiteral); ok {
+		return e.scalarEval(binaryOP, expr.Left, scalar.Val, false)
+	}
+	// only supports comparing series with scalar
+	if isArithmetic {
 		left := e.eval(nil, expr.Left)
 		if len(left) != 1 {
 			return nil
@@ -145,3 +228,17 @@ func (e *expression) binaryEval(expr *stmt.BinaryExpr) []collections.FloatArray
 
 	return nil
 }
+
+// scalarEval evaluates the series expr with scalar value
+func (e *expression) scalarEval(binaryOP stmt.BinaryOP, expr stmt.Expr, scalar float64, scalarLeft bool) []collections.FloatArray {
+	values := e.eval(nil, expr)
+	if len(values) != 1 {
+		return nil
+	}
+	result := scalarEval(binaryOP, values[0], scalar, scalarLeft)
+	if result == nil {
+		// all values are filtered by comparison
+		return nil
+	}
+	return []collections.FloatArray{result}
+}
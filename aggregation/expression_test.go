@@ -7,7 +7,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/aggregation/fields"
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/field"
@@ -75,6 +78,105 @@ func TestExpression_Paren(t *testing.T) {
 	assert.Equal(t, 2.42, math.Floor(value.GetValue(4)*100)/100)
 }
 
+func TestExpression_TopK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeSeries := mockTimeSeries(ctrl, map[string]field.Type{
+		"f1": field.SumField,
+		"f2": field.MaxField,
+	})
+
+	query, _ := sql.Parse("select topk(5, f1+f2) as f from cpu")
+	expression := NewExpression(timeSeries, 10, query.SelectItems)
+	expression.Eval()
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 1, len(resultSet))
+	// evaluates the expr of topk, the series is selected by the result set builder
+	assert.NotNil(t, resultSet["f"])
+}
+
+func TestExpression_TimeShift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assert.Equal(t, "f", ShiftedFieldName("f", 0))
+	assert.Equal(t, "f@604800000", ShiftedFieldName("f", timeutil.OneWeek))
+
+	timeSeries := mockTimeSeries(ctrl, map[string]field.Type{
+		"f1":                                    field.SumField,
+		ShiftedFieldName("f1", timeutil.OneDay): field.MaxField,
+	})
+	query, _ := sql.Parse("select f1, time_shift(f1, 1d), f1-time_shift(f1, 1d), time_shift(f1, 1w) from cpu group by time(1h)")
+	exp := NewExpression(timeSeries, 10, query.SelectItems)
+	exp.Eval()
+	resultSet := exp.ResultSet()
+	// no data of shifted time range
+	assert.Equal(t, 3, len(resultSet))
+	assert.NotNil(t, resultSet["f1"])
+	assert.NotNil(t, resultSet["time_shift(f1,1d)"])
+	assert.NotNil(t, resultSet["f1-time_shift(f1,1d)"])
+	// the shift is reset after evaluating time shift function
+	assert.Equal(t, int64(0), exp.(*expression).shift)
+}
+
+func TestExpression_MathFunc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeSeries := mockTimeSeries(ctrl, map[string]field.Type{
+		"f1": field.SumField,
+	})
+	query, _ := sql.Parse("select round(f1*100/3, 1), ceil(f1), clamp_max(f1, 1), log(f1-1.1) from cpu")
+	exp := NewExpression(timeSeries, 10, query.SelectItems)
+	exp.Eval()
+	resultSet := exp.ResultSet()
+	assert.Equal(t, 36.7, resultSet["round(f1*100/3,1)"].GetValue(4))
+	assert.Equal(t, 2.0, resultSet["ceil(f1)"].GetValue(4))
+	assert.Equal(t, 1.0, resultSet["clamp_max(f1,1)"].GetValue(4))
+	// log(0) is dropped
+	assert.Nil(t, resultSet["log(f1-1.1)"])
+}
+
+func TestExpression_Comparison(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	timeSeries := mockTimeSeries(ctrl, map[string]field.Type{
+		"f1": field.SumField,
+	})
+	query, _ := sql.Parse("select f1>1, 1>f1, f1*10>=11 from cpu")
+	exp := NewExpression(timeSeries, 10, query.SelectItems)
+	exp.Eval()
+	resultSet := exp.ResultSet()
+	value := resultSet["f1>1"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 1.1, value.GetValue(4))
+	assert.Nil(t, resultSet["1>f1"])
+	value = resultSet["f1*10>=11"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 11.0, value.GetValue(4))
+
+	// evaluates the comparison which filters all values with other operators
+	timeSeries = mockTimeSeries(ctrl, map[string]field.Type{
+		"f1": field.SumField,
+	})
+	query, _ = sql.Parse("select (f1>1000)+f1, topk(5, (f1>1000)+f1), abs(f1>1000) from cpu")
+	exp = NewExpression(timeSeries, 10, query.SelectItems)
+	exp.Eval()
+	resultSet = exp.ResultSet()
+	assert.Nil(t, resultSet["(f1>1000)+f1"])
+	assert.Nil(t, resultSet["abs(f1>1000)"])
+
+	// not support comparing series with series
+	exp = NewExpression(nil, 10, nil)
+	assert.Nil(t, exp.(*expression).binaryEval(&stmt.BinaryExpr{
+		Left:     &stmt.FieldExpr{Name: "f1"},
+		Operator: stmt.GREATER,
+		Right:    &stmt.FieldExpr{Name: "f1"},
+	}))
+}
+
 func TestExpression_BinaryEval(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -198,3 +300,20 @@ func TestExpression_NotSupport_Expr(t *testing.T) {
 	resultSet := expression.ResultSet()
 	assert.Equal(t, 0, len(resultSet))
 }
+
+func TestFieldsExpression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	values := collections.NewFloatArray(10)
+	values.SetValue(4, 1.1)
+	f := fields.NewMockField(ctrl)
+	f.EXPECT().GetValues(function.Max).Return([]collections.FloatArray{values})
+
+	query, _ := sql.Parse("select max(f1) from cpu")
+	expression := NewFieldsExpression(map[string]fields.Field{"f1": f}, query.SelectItems)
+	expression.Eval()
+	resultSet := expression.ResultSet()
+	assert.Equal(t, 1, len(resultSet))
+	assert.Equal(t, values, resultSet["max(f1)"])
+}
@@ -1,6 +1,8 @@
 package aggregation
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/query/selector"
 	"github.com/lindb/lindb/tsdb/field"
@@ -17,13 +19,19 @@ type FieldAggregator interface {
 	Iterator() series.FieldIterator
 }
 
-// fieldAggregator implements field aggregator interface, aggregator field series based on aggregator spec
+// fieldAggregator implements field aggregator interface, aggregator field series based on aggregator spec,
+// the functions of spec which read the same stored primitive field share one scan, each value of the stored
+// primitive field is aggregated by all the primitive aggregators of it, like min/max/avg of sum field,
+// the time aggregators aggregate the timestamps of values for selecting the first/last value when merging.
 type fieldAggregator struct {
-	baseTime   int64
-	timeRange  timeutil.TimeRange
-	interval   int64
-	aggregates map[uint16]PrimitiveAggregator
-	pointCount int
+	baseTime     int64
+	timeRange    timeutil.TimeRange
+	interval     int64
+	primitiveIDs []uint16                         // aggregated primitive field ids, order by id
+	aggregates   map[uint16]PrimitiveAggregator   // aggregated primitive field id => aggregator
+	sources      map[uint16][]PrimitiveAggregator // stored primitive field id => aggregators
+	timeSources  map[uint16][]PrimitiveAggregator // stored primitive field id => time aggregators
+	pointCount   int
 
 	aggSpec  *AggregatorSpec
 	selector selector.SlotSelector
@@ -31,21 +39,62 @@ type fieldAggregator struct {
 
 // NewFieldAggregator creates a field aggregator
 func NewFieldAggregator(baseTime, interval, start, end int64, intervalRatio int, aggSpec *AggregatorSpec) FieldAggregator {
-	agg := &fieldAggregator{
-		baseTime:   baseTime,
-		interval:   interval,
-		pointCount: timeutil.CalPointCount(baseTime+interval*start, baseTime+interval*end, interval),
-		aggSpec:    aggSpec,
-		aggregates: make(map[uint16]PrimitiveAggregator),
+	pointCount := timeutil.CalPointCount(baseTime+interval*start, baseTime+interval*end, interval)
+	timeRange := timeutil.TimeRange{Start: baseTime + interval*start, End: baseTime + interval*int64(pointCount)}
+	return newFieldAggregator(baseTime, interval, timeRange, pointCount,
+		selector.NewIndexSlotSelector(int(start), intervalRatio), aggSpec)
+}
+
+// NewCalendarFieldAggregator creates a field aggregator for the interval of calendar unit, like month/year,
+// the value of time slot(baseTime+timeSlot*interval) is aggregated into the bucket which the time belongs to,
+// the aggregator allocates the points of buckets from the start bucket,
+// the time range is from the start time of first bucket to the start time of last bucket.
+func NewCalendarFieldAggregator(baseTime, interval int64, startBucket, numOfBuckets int, buckets []int64,
+	aggSpec *AggregatorSpec) FieldAggregator {
+	endBucket := startBucket + numOfBuckets - 1
+	if endBucket >= len(buckets) {
+		endBucket = len(buckets) - 1
 	}
+	timeRange := timeutil.TimeRange{Start: buckets[startBucket], End: buckets[endBucket]}
+	return newFieldAggregator(baseTime, interval, timeRange, numOfBuckets,
+		selector.NewCalendarSlotSelector(baseTime, interval, startBucket, buckets), aggSpec)
+}
 
-	agg.timeRange = timeutil.TimeRange{Start: baseTime + interval*start, End: baseTime + interval*int64(agg.pointCount)}
-	agg.selector = selector.NewIndexSlotSelector(int(start), intervalRatio)
+// newFieldAggregator creates a field aggregator with the slot selector which selects the index of time slot
+func newFieldAggregator(baseTime, interval int64, timeRange timeutil.TimeRange, pointCount int,
+	slotSelector selector.SlotSelector, aggSpec *AggregatorSpec) FieldAggregator {
+	agg := &fieldAggregator{
+		baseTime:    baseTime,
+		interval:    interval,
+		timeRange:   timeRange,
+		pointCount:  pointCount,
+		aggSpec:     aggSpec,
+		selector:    slotSelector,
+		aggregates:  make(map[uint16]PrimitiveAggregator),
+		sources:     make(map[uint16][]PrimitiveAggregator),
+		timeSources: make(map[uint16][]PrimitiveAggregator),
+	}
 
 	for funcType := range aggSpec.functions {
 		primitiveFields := field.GetPrimitiveFields(aggSpec.fieldType, funcType)
 		for id, aggType := range primitiveFields {
-			agg.aggregates[id] = newPrimitiveAggregator(id, agg.pointCount, field.GetAggFunc(aggType))
+			if _, ok := agg.aggregates[id]; ok {
+				// primitive field already aggregated for other function, like sum of sum/avg
+				continue
+			}
+			agg.aggregates[id] = newPrimitiveAggregator(id, agg.pointCount, aggType)
+			agg.primitiveIDs = append(agg.primitiveIDs, id)
+		}
+	}
+	sort.Slice(agg.primitiveIDs, func(i, j int) bool {
+		return agg.primitiveIDs[i] < agg.primitiveIDs[j]
+	})
+	for _, id := range agg.primitiveIDs {
+		sourceID := field.GetSourceFieldID(aggSpec.fieldType, id)
+		if field.IsTimeField(aggSpec.fieldType, id) {
+			agg.timeSources[sourceID] = append(agg.timeSources[sourceID], agg.aggregates[id])
+		} else {
+			agg.sources[sourceID] = append(agg.sources[sourceID], agg.aggregates[id])
 		}
 	}
 
@@ -59,13 +108,11 @@ func (a *fieldAggregator) TimeRange() timeutil.TimeRange {
 
 // Iterator returns an iterator for aggregator result
 func (a *fieldAggregator) Iterator() series.FieldIterator {
-	its := make([]series.PrimitiveIterator, len(a.aggregates))
-	idx := 0
-	for _, it := range a.aggregates {
-		its[idx] = it.Iterator()
-		idx++
+	its := make([]series.PrimitiveIterator, len(a.primitiveIDs))
+	for idx, id := range a.primitiveIDs {
+		its[idx] = a.aggregates[id].Iterator()
 	}
-	return newFieldIterator(a.aggSpec.fieldID, a.aggSpec.fieldType, its)
+	return newFieldIterator(a.aggSpec.fieldID, a.aggSpec.fieldName, a.aggSpec.fieldType, its)
 }
 
 // Aggregate aggregates the field series into current aggregator
@@ -78,9 +125,9 @@ func (a *fieldAggregator) Aggregate(it series.FieldIterator) {
 			continue
 		}
 		primitiveFieldID := primitiveIt.FieldID()
-		//TODO multi-aggs
-		aggregator, ok := a.aggregates[primitiveFieldID]
-		if !ok {
+		aggregators := a.sources[primitiveFieldID]
+		timeAggregators := a.timeSources[primitiveFieldID]
+		if len(aggregators) == 0 && len(timeAggregators) == 0 {
 			continue
 		}
 
@@ -93,7 +140,15 @@ func (a *fieldAggregator) Aggregate(it series.FieldIterator) {
 			if idx > a.pointCount {
 				break
 			}
-			aggregator.Aggregate(idx, value)
+			for _, aggregator := range aggregators {
+				aggregator.Aggregate(idx, value)
+			}
+			if len(timeAggregators) > 0 {
+				timestamp := float64(a.baseTime + a.interval*int64(timeSlot))
+				for _, aggregator := range timeAggregators {
+					aggregator.Aggregate(idx, timestamp)
+				}
+			}
 		}
 	}
 }
@@ -2,6 +2,7 @@ package aggregation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -79,3 +80,119 @@ func TestFieldAggregator_Aggregate(t *testing.T) {
 	assert.False(t, fieldIt.Next().HasNext())
 	assert.False(t, fieldIt.HasNext())
 }
+
+func TestFieldAggregator_CalendarInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	calendar, _ := timeutil.NewCalendarInterval(timeutil.MonthUnit, timeutil.OneMonth, loc)
+	baseTime := time.Date(2020, 1, 1, 0, 0, 0, 0, loc).UnixNano() / 1000000
+	buckets := calendar.Buckets(baseTime, time.Date(2020, 4, 1, 0, 0, 0, 0, loc).UnixNano()/1000000)
+	assert.Len(t, buckets, 3)
+
+	aggSpec := NewAggregatorSpec(uint16(15), "f", field.SumField)
+	aggSpec.AddFunctionType(function.Sum)
+
+	// aggregates the day slots from february
+	agg := NewCalendarFieldAggregator(baseTime, timeutil.OneDay, 1, 2, buckets, aggSpec)
+	it := MockSumFieldIterator(ctrl, uint16(1), map[int]interface{}{
+		30: 1.0, // 01-31, before the start bucket
+		31: 2.0, // 02-01
+		59: 3.0, // 02-29
+		60: 4.0, // 03-01
+		90: 5.0, // 03-31
+	})
+	agg.Aggregate(it)
+
+	fieldIt := agg.Iterator()
+	assert.True(t, fieldIt.HasNext())
+	AssertPrimitiveIt(t, fieldIt.Next(), map[int]float64{0: 5, 1: 9})
+	assert.Equal(t, timeutil.TimeRange{Start: buckets[1], End: buckets[2]}, agg.TimeRange())
+}
+
+func TestFieldAggregator_MultiFuncs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	baseTime, _ := timeutil.ParseTimestamp("20190729 10:00:00")
+
+	aggSpec := NewAggregatorSpec(uint16(15), "f", field.SumField)
+	aggSpec.AddFunctionType(function.Min)
+	aggSpec.AddFunctionType(function.Max)
+	aggSpec.AddFunctionType(function.Avg)
+	aggSpec.AddFunctionType(function.Sum)
+
+	agg := NewFieldAggregator(baseTime, 10*timeutil.OneSecond, 10, 50, 1, aggSpec)
+	// scans the stored primitive field once, feeds the values to all primitive aggregators
+	it := MockSumFieldIterator(ctrl, uint16(1), map[int]interface{}{
+		15: 5.5,
+		16: 1.5,
+		17: 3.0,
+	})
+	agg.Aggregate(it)
+
+	expects := []struct {
+		id     uint16
+		points map[int]float64
+	}{
+		{id: 1, points: map[int]float64{5: 5.5, 6: 1.5, 7: 3.0}},
+		{id: 2, points: map[int]float64{5: 5.5, 6: 1.5, 7: 3.0}},
+		{id: 3, points: map[int]float64{5: 5.5, 6: 1.5, 7: 3.0}},
+		{id: 4, points: map[int]float64{5: 1, 6: 1, 7: 1}},
+	}
+	it = MockSumFieldIterator(ctrl, uint16(1), map[int]interface{}{
+		15: 0.5,
+		16: 2.5,
+	})
+	agg.Aggregate(it)
+	expects[0].points = map[int]float64{5: 6, 6: 4, 7: 3.0}
+	expects[1].points = map[int]float64{5: 0.5, 6: 1.5, 7: 3.0}
+	expects[2].points = map[int]float64{5: 5.5, 6: 2.5, 7: 3.0}
+	expects[3].points = map[int]float64{5: 2, 6: 2, 7: 1}
+
+	fieldIt := agg.Iterator()
+	for _, expect := range expects {
+		assert.True(t, fieldIt.HasNext())
+		primitiveIt := fieldIt.Next()
+		assert.Equal(t, expect.id, primitiveIt.FieldID())
+		AssertPrimitiveIt(t, primitiveIt, expect.points)
+	}
+	assert.False(t, fieldIt.HasNext())
+}
+
+func TestFieldAggregator_FirstLast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aggSpec := NewAggregatorSpec(uint16(15), "f", field.SumField)
+	aggSpec.AddFunctionType(function.First)
+	aggSpec.AddFunctionType(function.Last)
+	aggSpec.AddFunctionType(function.Count)
+
+	interval := 10 * timeutil.OneSecond
+	agg := NewFieldAggregator(0, interval, 10, 50, 2, aggSpec)
+	it := MockSumFieldIterator(ctrl, uint16(1), map[int]interface{}{
+		10: 1.0,
+		11: 2.0,
+		12: 3.0,
+	})
+	agg.Aggregate(it)
+
+	expects := []struct {
+		id     uint16
+		points map[int]float64
+	}{
+		{id: 4, points: map[int]float64{0: 2, 1: 1}},
+		{id: 5, points: map[int]float64{0: 1.0, 1: 3.0}},
+		{id: 6, points: map[int]float64{0: float64(10 * interval), 1: float64(12 * interval)}},
+		{id: 7, points: map[int]float64{0: 2.0, 1: 3.0}},
+		{id: 8, points: map[int]float64{0: float64(11 * interval), 1: float64(12 * interval)}},
+	}
+	fieldIt := agg.Iterator()
+	for _, expect := range expects {
+		assert.True(t, fieldIt.HasNext())
+		primitiveIt := fieldIt.Next()
+		assert.Equal(t, expect.id, primitiveIt.FieldID())
+		AssertPrimitiveIt(t, primitiveIt, expect.points)
+	}
+	assert.False(t, fieldIt.HasNext())
+}
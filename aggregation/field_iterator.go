@@ -8,6 +8,7 @@ import (
 
 type fieldIterator struct {
 	id        uint16
+	name      string
 	fieldType field.Type
 
 	length int
@@ -15,9 +16,10 @@ type fieldIterator struct {
 	its    []series.PrimitiveIterator
 }
 
-func newFieldIterator(id uint16, fieldType field.Type, its []series.PrimitiveIterator) series.FieldIterator {
+func newFieldIterator(id uint16, name string, fieldType field.Type, its []series.PrimitiveIterator) series.FieldIterator {
 	return &fieldIterator{
 		id:        id,
+		name:      name,
 		fieldType: fieldType,
 		its:       its,
 		length:    len(its),
@@ -25,8 +27,7 @@ func newFieldIterator(id uint16, fieldType field.Type, its []series.PrimitiveIte
 }
 
 func (it *fieldIterator) FieldName() string {
-	//TODO need impl
-	return ""
+	return it.name
 }
 
 func (it *fieldIterator) FieldID() uint16 {
@@ -13,7 +13,7 @@ func TestFieldIterator(t *testing.T) {
 	primitiveIt := newPrimitiveIterator(uint16(10), generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
 	primitiveIt1 := newPrimitiveIterator(uint16(10), generateFloatArray([]float64{0, 10, 10.0, 100.4, 50.0}))
 
-	it := newFieldIterator(uint16(111), field.SumField, []series.PrimitiveIterator{primitiveIt, primitiveIt1})
+	it := newFieldIterator(uint16(111), "f", field.SumField, []series.PrimitiveIterator{primitiveIt, primitiveIt1})
 
 	expect := map[int]float64{0: 0, 1: 10, 2: 10.0, 3: 100.4, 4: 50.0}
 	assert.True(t, it.HasNext())
@@ -24,6 +24,7 @@ func TestFieldIterator(t *testing.T) {
 	assert.False(t, it.HasNext())
 	assert.Nil(t, it.Next())
 	assert.Equal(t, uint16(111), it.FieldID())
+	assert.Equal(t, "f", it.FieldName())
 	assert.Equal(t, field.SumField, it.FieldType())
 }
 
@@ -22,6 +22,8 @@ func mockSingleIterator(ctrl *gomock.Controller, fieldType field.Type) series.Fi
 	it.EXPECT().FieldType().Return(fieldType)
 	it.EXPECT().HasNext().Return(true)
 	it.EXPECT().Next().Return(primitiveIt)
+	it.EXPECT().HasNext().Return(false)
+	primitiveIt.EXPECT().FieldID().Return(uint16(1))
 	primitiveIt.EXPECT().HasNext().Return(true)
 	primitiveIt.EXPECT().Next().Return(4, 1.1)
 	primitiveIt.EXPECT().HasNext().Return(true)
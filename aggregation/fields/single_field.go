@@ -1,16 +1,20 @@
 package fields
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
 	"github.com/lindb/lindb/tsdb/field"
 	"github.com/lindb/lindb/tsdb/series"
 )
 
-// singleField represents the single field series
+// singleField represents the single field series, which includes the values of aggregated primitive fields
 type singleField struct {
-	value     collections.FloatArray
-	fieldType field.Type
+	capacity     int
+	fieldType    field.Type
+	primitiveIDs []uint16 // order by id
+	values       map[uint16]collections.FloatArray
 }
 
 // NewSingleField creates a single field series
@@ -19,31 +23,91 @@ func NewSingleField(capacity int, it series.FieldIterator) Field {
 	if fieldType == field.Unknown {
 		return nil
 	}
-	if it.HasNext() {
-		value := collections.NewFloatArray(capacity)
+	f := &singleField{capacity: capacity, fieldType: fieldType, values: make(map[uint16]collections.FloatArray)}
+	for it.HasNext() {
 		primitiveIt := it.Next()
+		if primitiveIt == nil {
+			continue
+		}
+		primitiveID := primitiveIt.FieldID()
+		value, ok := f.values[primitiveID]
+		if !ok {
+			value = collections.NewFloatArray(capacity)
+			f.values[primitiveID] = value
+			f.primitiveIDs = append(f.primitiveIDs, primitiveID)
+		}
 		for primitiveIt.HasNext() {
 			slot, val := primitiveIt.Next()
 			value.SetValue(slot, val)
 		}
-		return &singleField{fieldType: fieldType, value: value}
 	}
-	return nil
+	if len(f.primitiveIDs) == 0 {
+		return nil
+	}
+	sort.Slice(f.primitiveIDs, func(i, j int) bool {
+		return f.primitiveIDs[i] < f.primitiveIDs[j]
+	})
+	return f
+}
+
+// funcAggTypes represents the agg type of primitive field which values are the result of function
+var funcAggTypes = map[function.FuncType]field.AggType{
+	function.Sum:   field.Sum,
+	function.Min:   field.Min,
+	function.Max:   field.Max,
+	function.Count: field.Count,
+	function.First: field.First,
+	function.Last:  field.Last,
 }
 
 // GetValues returns the values which function call need by given function type and field type
 func (f *singleField) GetValues(funcType function.FuncType) []collections.FloatArray {
-	switch {
-	case funcType == function.Sum && f.fieldType == field.SumField:
-		return []collections.FloatArray{f.value}
-	case funcType == function.Max && f.fieldType == field.MaxField:
-		return []collections.FloatArray{f.value}
-	default:
+	primitiveFields := field.GetPrimitiveFields(f.fieldType, funcType)
+	if funcType == function.Avg {
+		return f.avg(primitiveFields)
+	}
+	aggType, ok := funcAggTypes[funcType]
+	if !ok {
+		return nil
+	}
+	value := f.valuesOf(primitiveFields, aggType)
+	if value == nil {
 		return nil
 	}
+	return []collections.FloatArray{value}
 }
 
-// GetDefaultValues returns the field default values which aggregation need by field type
+// GetDefaultValues returns the field default values which aggregation need by field type,
+// the default values are the values of stored primitive field which has the min id.
 func (f *singleField) GetDefaultValues() []collections.FloatArray {
-	return []collections.FloatArray{f.value}
+	return []collections.FloatArray{f.values[f.primitiveIDs[0]]}
+}
+
+// avg returns the avg values which computed by the sum and count primitive fields
+func (f *singleField) avg(primitiveFields map[uint16]field.AggType) []collections.FloatArray {
+	sum := f.valuesOf(primitiveFields, field.Sum)
+	count := f.valuesOf(primitiveFields, field.Count)
+	if sum == nil || count == nil {
+		return nil
+	}
+	avg := collections.NewFloatArray(f.capacity)
+	it := sum.Iterator()
+	for it.HasNext() {
+		slot, value := it.Next()
+		if !count.HasValue(slot) || count.GetValue(slot) == 0 {
+			continue
+		}
+		avg.SetValue(slot, value/count.GetValue(slot))
+	}
+	return []collections.FloatArray{avg}
+}
+
+// valuesOf returns the values of the primitive field which aggregated by the agg type, returns nil if not found
+func (f *singleField) valuesOf(primitiveFields map[uint16]field.AggType, aggType field.AggType) collections.FloatArray {
+	for primitiveID, primitiveAggType := range primitiveFields {
+		if primitiveAggType == aggType && !field.IsTimeField(f.fieldType, primitiveID) {
+			return f.values[primitiveID]
+		}
+	}
+	return nil
 }
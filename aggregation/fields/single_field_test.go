@@ -29,6 +29,8 @@ func TestNewSingleField(t *testing.T) {
 	primitiveIt := series.NewMockPrimitiveIterator(ctrl)
 	it.EXPECT().HasNext().Return(true)
 	it.EXPECT().Next().Return(primitiveIt)
+	it.EXPECT().HasNext().Return(false)
+	primitiveIt.EXPECT().FieldID().Return(uint16(1))
 	primitiveIt.EXPECT().HasNext().Return(false)
 	it.EXPECT().FieldType().Return(field.SumField).AnyTimes()
 
@@ -65,3 +67,66 @@ func TestSingleField_Max(t *testing.T) {
 	assertFieldValues(t, f.GetDefaultValues())
 	assertFieldValues(t, f.GetValues(function.Max))
 }
+
+func TestSingleField_MultiFuncs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it := series.NewMockFieldIterator(ctrl)
+	it.EXPECT().FieldType().Return(field.SumField)
+	primitives := map[uint16][]float64{1: {10, 20}, 2: {1, 2}, 3: {9, 18}, 4: {4, 5}}
+	for _, primitiveID := range []uint16{4, 1, 3, 2} {
+		primitiveIt := series.NewMockPrimitiveIterator(ctrl)
+		it.EXPECT().HasNext().Return(true)
+		it.EXPECT().Next().Return(primitiveIt)
+		primitiveIt.EXPECT().FieldID().Return(primitiveID)
+		for slot, value := range primitives[primitiveID] {
+			primitiveIt.EXPECT().HasNext().Return(true)
+			primitiveIt.EXPECT().Next().Return(slot, value)
+		}
+		primitiveIt.EXPECT().HasNext().Return(false)
+	}
+	// nil primitive iterator
+	it.EXPECT().HasNext().Return(true)
+	it.EXPECT().Next().Return(nil)
+	it.EXPECT().HasNext().Return(false)
+
+	f := NewSingleField(10, it)
+	assert.NotNil(t, f)
+	assert.Equal(t, 20.0, f.GetDefaultValues()[0].GetValue(1))
+	assert.Equal(t, 20.0, f.GetValues(function.Sum)[0].GetValue(1))
+	assert.Equal(t, 2.0, f.GetValues(function.Min)[0].GetValue(1))
+	assert.Equal(t, 18.0, f.GetValues(function.Max)[0].GetValue(1))
+	avg := f.GetValues(function.Avg)[0]
+	assert.Equal(t, 2.5, avg.GetValue(0))
+	assert.Equal(t, 4.0, avg.GetValue(1))
+	assert.Nil(t, f.GetValues(function.Histogram))
+}
+
+func TestSingleField_CountFirstLast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it := series.NewMockFieldIterator(ctrl)
+	it.EXPECT().FieldType().Return(field.SumField)
+	primitives := map[uint16]float64{4: 3, 5: 1.1, 6: 10, 7: 2.2, 8: 30}
+	for _, primitiveID := range []uint16{4, 5, 6, 7, 8} {
+		primitiveIt := series.NewMockPrimitiveIterator(ctrl)
+		it.EXPECT().HasNext().Return(true)
+		it.EXPECT().Next().Return(primitiveIt)
+		primitiveIt.EXPECT().FieldID().Return(primitiveID)
+		primitiveIt.EXPECT().HasNext().Return(true)
+		primitiveIt.EXPECT().Next().Return(1, primitives[primitiveID])
+		primitiveIt.EXPECT().HasNext().Return(false)
+	}
+	it.EXPECT().HasNext().Return(false)
+
+	f := NewSingleField(10, it)
+	assert.NotNil(t, f)
+	assert.Equal(t, 3.0, f.GetValues(function.Count)[0].GetValue(1))
+	// returns the values instead of timestamps
+	assert.Equal(t, 1.1, f.GetValues(function.First)[0].GetValue(1))
+	assert.Equal(t, 2.2, f.GetValues(function.Last)[0].GetValue(1))
+	assert.Nil(t, f.GetValues(function.Sum))
+	assert.Nil(t, f.GetValues(function.Stddev))
+}
@@ -1,11 +1,15 @@
 package function
 
-import "github.com/lindb/lindb/pkg/collections"
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
 
 // FuncCall calls the function calc by function type and params
 func FuncCall(funcType FuncType, params ...collections.FloatArray) collections.FloatArray {
 	switch funcType {
-	case Sum, Min, Max:
+	case Sum, Min, Max, Avg, Count, First, Last:
 		if len(params) == 0 {
 			return nil
 		}
@@ -14,3 +18,60 @@ func FuncCall(funcType FuncType, params ...collections.FloatArray) collections.F
 		return nil
 	}
 }
+
+// MathFuncCall calls the math function for each value of series, the args are the scalar params of function,
+// 1) round(f[, n]) rounds the value to n decimal places, n is 0 if not set
+// 2) clamp_min(f, min)/clamp_max(f, max) clamps the value to the bound
+// the point whose result is not a valid number is dropped, like log(0) or sqrt(-1).
+func MathFuncCall(funcType FuncType, values collections.FloatArray, args ...float64) collections.FloatArray {
+	if values == nil || !IsMathFunc(funcType) {
+		return nil
+	}
+	if (funcType == ClampMin || funcType == ClampMax) && len(args) == 0 {
+		return nil
+	}
+	result := collections.NewFloatArray(values.Capacity())
+	it := values.Iterator()
+	for it.HasNext() {
+		slot, value := it.Next()
+		value = mathCall(funcType, value, args)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		result.SetValue(slot, value)
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
+// mathCall calls the math function for the value
+func mathCall(funcType FuncType, value float64, args []float64) float64 {
+	switch funcType {
+	case Abs:
+		return math.Abs(value)
+	case Ceil:
+		return math.Ceil(value)
+	case Floor:
+		return math.Floor(value)
+	case Round:
+		if len(args) == 0 || args[0] == 0 {
+			return math.Round(value)
+		}
+		scale := math.Pow(10, args[0])
+		return math.Round(value*scale) / scale
+	case Log:
+		return math.Log(value)
+	case Log10:
+		return math.Log10(value)
+	case Sqrt:
+		return math.Sqrt(value)
+	case ClampMin:
+		return math.Max(value, args[0])
+	case ClampMax:
+		return math.Min(value, args[0])
+	default:
+		return math.NaN()
+	}
+}
@@ -24,3 +24,59 @@ func TestFuncCall_Sum(t *testing.T) {
 	result = FuncCall(Sum, array1, array2)
 	assert.Equal(t, array1, result)
 }
+
+func TestFuncCall_Avg(t *testing.T) {
+	result := FuncCall(Avg)
+	assert.Nil(t, result)
+
+	array := collections.NewFloatArray(10)
+	result = FuncCall(Avg, array)
+	assert.Equal(t, array, result)
+}
+
+func TestFuncCall_Last(t *testing.T) {
+	result := FuncCall(Last)
+	assert.Nil(t, result)
+
+	array := collections.NewFloatArray(10)
+	result = FuncCall(Last, array)
+	assert.Equal(t, array, result)
+}
+
+func TestMathFuncCall(t *testing.T) {
+	values := collections.NewFloatArray(5)
+	values.SetValue(0, -1.25)
+	values.SetValue(2, 4)
+	values.SetValue(4, 100)
+
+	assertValues := func(expect map[int]float64, result collections.FloatArray) {
+		assert.NotNil(t, result)
+		actual := make(map[int]float64)
+		it := result.Iterator()
+		for it.HasNext() {
+			slot, value := it.Next()
+			actual[slot] = value
+		}
+		assert.Equal(t, expect, actual)
+	}
+	assertValues(map[int]float64{0: 1.25, 2: 4, 4: 100}, MathFuncCall(Abs, values))
+	assertValues(map[int]float64{0: -1, 2: 4, 4: 100}, MathFuncCall(Ceil, values))
+	assertValues(map[int]float64{0: -2, 2: 4, 4: 100}, MathFuncCall(Floor, values))
+	assertValues(map[int]float64{0: -1, 2: 4, 4: 100}, MathFuncCall(Round, values))
+	assertValues(map[int]float64{0: -1.3, 2: 4, 4: 100}, MathFuncCall(Round, values, 1))
+	assertValues(map[int]float64{0: -1.25, 2: 4, 4: 100}, MathFuncCall(Round, values, 2))
+	assertValues(map[int]float64{0: 4, 2: 4, 4: 100}, MathFuncCall(ClampMin, values, 4))
+	assertValues(map[int]float64{0: -1.25, 2: 4, 4: 4}, MathFuncCall(ClampMax, values, 4))
+	// invalid number is dropped
+	assertValues(map[int]float64{2: 2, 4: 10}, MathFuncCall(Sqrt, values))
+	assertValues(map[int]float64{2: 0.6020599913279624, 4: 2}, MathFuncCall(Log10, values))
+	assertValues(map[int]float64{2: 1.3862943611198906, 4: 4.605170185988092}, MathFuncCall(Log, values))
+
+	values = collections.NewFloatArray(5)
+	values.SetValue(1, 0)
+	assert.Nil(t, MathFuncCall(Log, values))
+	// clamp without bound
+	assert.Nil(t, MathFuncCall(ClampMin, values))
+	assert.Nil(t, MathFuncCall(Abs, nil))
+	assert.Nil(t, MathFuncCall(Sum, values))
+}
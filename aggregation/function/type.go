@@ -10,6 +10,21 @@ const (
 	Avg
 	Histogram
 	Stddev
+	Count
+	First
+	Last
+	TopK
+	BottomK
+	TimeShift
+	Abs
+	Ceil
+	Floor
+	Round
+	Log
+	Log10
+	Sqrt
+	ClampMin
+	ClampMax
 
 	Unknown
 )
@@ -29,7 +44,60 @@ func FuncTypeString(funcType FuncType) string {
 		return "histogram"
 	case Stddev:
 		return "stddev"
+	case Count:
+		return "count"
+	case First:
+		return "first"
+	case Last:
+		return "last"
+	case TopK:
+		return "topk"
+	case BottomK:
+		return "bottomk"
+	case TimeShift:
+		return "time_shift"
+	case Abs:
+		return "abs"
+	case Ceil:
+		return "ceil"
+	case Floor:
+		return "floor"
+	case Round:
+		return "round"
+	case Log:
+		return "log"
+	case Log10:
+		return "log10"
+	case Sqrt:
+		return "sqrt"
+	case ClampMin:
+		return "clamp_min"
+	case ClampMax:
+		return "clamp_max"
 	default:
 		return "unknown"
 	}
 }
+
+// IsAggFunc returns if the function aggregates the values of field, like sum/min/max etc.,
+// the field under aggregate function is down sampling by the function,
+// others(like topk/bottomk) evaluate the field by the default down sampling of field.
+func IsAggFunc(funcType FuncType) bool {
+	switch funcType {
+	case Sum, Min, Max, Avg, Histogram, Stddev, Count, First, Last:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsMathFunc returns if the function transforms each value of series, like abs/round/clamp_min etc.,
+// the first param is the series, the others are scalar params(like the bound of clamp_min).
+func IsMathFunc(funcType FuncType) bool {
+	switch funcType {
+	case Abs, Ceil, Floor, Round, Log, Log10, Sqrt, ClampMin, ClampMax:
+		return true
+	default:
+		return false
+	}
+}
@@ -13,5 +13,40 @@ func TestFuncTypeString(t *testing.T) {
 	assert.Equal(t, "avg", FuncTypeString(Avg))
 	assert.Equal(t, "histogram", FuncTypeString(Histogram))
 	assert.Equal(t, "stddev", FuncTypeString(Stddev))
+	assert.Equal(t, "count", FuncTypeString(Count))
+	assert.Equal(t, "first", FuncTypeString(First))
+	assert.Equal(t, "last", FuncTypeString(Last))
+	assert.Equal(t, "topk", FuncTypeString(TopK))
+	assert.Equal(t, "bottomk", FuncTypeString(BottomK))
+	assert.Equal(t, "time_shift", FuncTypeString(TimeShift))
+	assert.Equal(t, "abs", FuncTypeString(Abs))
+	assert.Equal(t, "ceil", FuncTypeString(Ceil))
+	assert.Equal(t, "floor", FuncTypeString(Floor))
+	assert.Equal(t, "round", FuncTypeString(Round))
+	assert.Equal(t, "log", FuncTypeString(Log))
+	assert.Equal(t, "log10", FuncTypeString(Log10))
+	assert.Equal(t, "sqrt", FuncTypeString(Sqrt))
+	assert.Equal(t, "clamp_min", FuncTypeString(ClampMin))
+	assert.Equal(t, "clamp_max", FuncTypeString(ClampMax))
 	assert.Equal(t, "unknown", FuncTypeString(Unknown))
 }
+
+func TestIsAggFunc(t *testing.T) {
+	assert.True(t, IsAggFunc(Sum))
+	assert.True(t, IsAggFunc(Avg))
+	assert.True(t, IsAggFunc(Last))
+	assert.False(t, IsAggFunc(TopK))
+	assert.False(t, IsAggFunc(BottomK))
+	assert.False(t, IsAggFunc(TimeShift))
+	assert.False(t, IsAggFunc(Abs))
+	assert.False(t, IsAggFunc(Unknown))
+}
+
+func TestIsMathFunc(t *testing.T) {
+	assert.True(t, IsMathFunc(Abs))
+	assert.True(t, IsMathFunc(Log10))
+	assert.True(t, IsMathFunc(ClampMax))
+	assert.False(t, IsMathFunc(Sum))
+	assert.False(t, IsMathFunc(TopK))
+	assert.False(t, IsMathFunc(Unknown))
+}
@@ -76,8 +76,10 @@ func mockSingleIterator(ctrl *gomock.Controller, fieldName string, fieldType fie
 	primitiveIt := series.NewMockPrimitiveIterator(ctrl)
 	it.EXPECT().HasNext().Return(true)
 	it.EXPECT().Next().Return(primitiveIt)
+	it.EXPECT().HasNext().Return(false)
 	it.EXPECT().FieldType().Return(fieldType)
 	it.EXPECT().FieldName().Return(fieldName)
+	primitiveIt.EXPECT().FieldID().Return(uint16(1))
 	primitiveIt.EXPECT().HasNext().Return(true)
 	primitiveIt.EXPECT().Next().Return(4, 1.1)
 	primitiveIt.EXPECT().HasNext().Return(true)
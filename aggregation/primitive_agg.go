@@ -20,15 +20,17 @@ type primitiveAggregator struct {
 	id         uint16
 	values     collections.FloatArray
 	pointCount int
+	aggType    field.AggType
 	aggFunc    field.AggFunc
 }
 
-// newPrimitiveAggregator creates primitive aggregator
-func newPrimitiveAggregator(id uint16, pointCount int, aggFunc field.AggFunc) PrimitiveAggregator {
+// newPrimitiveAggregator creates primitive aggregator by agg type
+func newPrimitiveAggregator(id uint16, pointCount int, aggType field.AggType) PrimitiveAggregator {
 	return &primitiveAggregator{
 		id:         id,
 		pointCount: pointCount,
-		aggFunc:    aggFunc,
+		aggType:    aggType,
+		aggFunc:    field.GetAggFunc(aggType),
 	}
 }
 
@@ -45,6 +47,10 @@ func (agg *primitiveAggregator) Aggregate(idx int, value float64) {
 	if agg.values == nil {
 		agg.values = collections.NewFloatArray(agg.pointCount)
 	}
+	// count aggregator counts the values of primitive field
+	if agg.aggType == field.Count {
+		value = 1
+	}
 
 	if agg.values.HasValue(idx) {
 		agg.values.SetValue(idx, agg.aggFunc.AggregateFloat(agg.values.GetValue(idx), value))
@@ -7,7 +7,7 @@ import (
 )
 
 func TestPrimitiveSumFloatAgg(t *testing.T) {
-	agg := newPrimitiveAggregator(uint16(1), 5, field.GetAggFunc(field.Sum))
+	agg := newPrimitiveAggregator(uint16(1), 5, field.Sum)
 	agg.Aggregate(1, 10.0)
 	agg.Aggregate(1, 30.0)
 	agg.Aggregate(-1, 30.0)
@@ -17,3 +17,14 @@ func TestPrimitiveSumFloatAgg(t *testing.T) {
 	it := agg.Iterator()
 	AssertPrimitiveIt(t, it, expect)
 }
+
+func TestPrimitiveCountAgg(t *testing.T) {
+	agg := newPrimitiveAggregator(uint16(4), 5, field.Count)
+	agg.Aggregate(1, 10.0)
+	agg.Aggregate(1, 30.0)
+	agg.Aggregate(2, 30.0)
+
+	expect := map[int]float64{1: 2.0, 2: 1.0}
+	it := agg.Iterator()
+	AssertPrimitiveIt(t, it, expect)
+}
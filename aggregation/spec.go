@@ -52,7 +52,7 @@ func IsSupportFunc(fieldType field.Type, funcType function.FuncType) bool {
 	switch fieldType {
 	case field.SumField:
 		switch funcType {
-		case function.Sum, function.Min, function.Max:
+		case function.Sum, function.Min, function.Max, function.Avg, function.Count, function.First, function.Last:
 			return true
 		default:
 			return false
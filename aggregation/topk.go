@@ -0,0 +1,241 @@
+package aggregation
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// rangeSlot is the time slot of heap which ranks the series over the whole time range
+const rangeSlot = -1
+
+// TopKSpec represents the series selector function(topk/bottomk) of select item,
+// keeps only the k series with the highest(topk) or lowest(bottomk) values,
+// 1) per time slot, if without rank function, like topk(5, f)
+// 2) over the whole time range, ranks the series by rank function, like topk(5, f, avg())
+type TopKSpec struct {
+	Name     string            // the result name of select item
+	K        int               // the number of series kept
+	Bottom   bool              // keeps the series with the lowest values if true
+	RankFunc function.FuncType // ranks the series over the whole time range if set
+	Expr     stmt.Expr         // the expr evaluated for each series
+}
+
+// NewTopKSpecs returns the topk/bottomk specs of select list, returns nil if no series selector function
+func NewTopKSpecs(selectItems []stmt.Expr) []*TopKSpec {
+	var specs []*TopKSpec
+	for _, item := range selectItems {
+		selectItem, ok := item.(*stmt.SelectItem)
+		if !ok {
+			continue
+		}
+		spec := newTopKSpec(selectItem)
+		if spec != nil {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// newTopKSpec creates the topk/bottomk spec of select item, returns nil if not series selector function
+func newTopKSpec(selectItem *stmt.SelectItem) *TopKSpec {
+	callExpr, ok := selectItem.Expr.(*stmt.CallExpr)
+	if !ok || (callExpr.FuncType != function.TopK && callExpr.FuncType != function.BottomK) {
+		return nil
+	}
+	if len(callExpr.Params) < 2 {
+		return nil
+	}
+	k, ok := callExpr.Params[0].(*stmt.NumberLiteral)
+	if !ok || k.Val < 1 {
+		return nil
+	}
+	spec := &TopKSpec{
+		Name:   selectItem.Alias,
+		K:      int(k.Val),
+		Bottom: callExpr.FuncType == function.BottomK,
+		Expr:   callExpr.Params[1],
+	}
+	if len(spec.Name) == 0 {
+		spec.Name = selectItem.Rewrite()
+	}
+	if len(callExpr.Params) > 2 {
+		if rankFunc, ok := callExpr.Params[2].(*stmt.CallExpr); ok {
+			spec.RankFunc = rankFunc.FuncType
+		}
+	}
+	return spec
+}
+
+// NewSelector creates the selector which selects the series by the spec
+func (s *TopKSpec) NewSelector() *TopKSelector {
+	return &TopKSelector{
+		spec:  s,
+		heaps: make(map[int]*rankHeap),
+	}
+}
+
+// TopKSelector selects the series by bounded heaps, only keeps k candidates for each time slot,
+// NOTICE: not thread-safe, adds all series before getting the selected series.
+type TopKSelector struct {
+	spec     *TopKSpec
+	heaps    map[int]*rankHeap        // time slot => heap
+	selected map[int]map[int]struct{} // series => selected time slots
+}
+
+// Add adds the evaluated values of series, the series is identified by the index given by caller
+func (s *TopKSelector) Add(seriesIdx int, values collections.FloatArray) {
+	if values == nil || values.IsEmpty() {
+		return
+	}
+	s.selected = nil
+	if s.spec.RankFunc > 0 {
+		rank, ok := rankValue(s.spec.RankFunc, values)
+		if ok {
+			s.push(rangeSlot, seriesIdx, rank)
+		}
+		return
+	}
+	it := values.Iterator()
+	for it.HasNext() {
+		slot, value := it.Next()
+		s.push(slot, seriesIdx, value)
+	}
+}
+
+// push pushes the value of series into the heap of time slot, replaces the worst one if heap is full
+func (s *TopKSelector) push(slot, seriesIdx int, value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+	h, ok := s.heaps[slot]
+	if !ok {
+		h = &rankHeap{bottom: s.spec.Bottom}
+		s.heaps[slot] = h
+	}
+	item := rankItem{series: seriesIdx, value: value}
+	if h.Len() < s.spec.K {
+		heap.Push(h, item)
+		return
+	}
+	// the top of heap is the worst one of kept candidates, keeps the earlier series if same value
+	if h.less(h.items[0].value, value) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+// IsSelected tests if the series is selected at any time slot
+func (s *TopKSelector) IsSelected(seriesIdx int) bool {
+	_, ok := s.selectedSeries()[seriesIdx]
+	return ok
+}
+
+// Filter returns the values of series at selected time slots, returns nil if series not selected
+func (s *TopKSelector) Filter(seriesIdx int, values collections.FloatArray) collections.FloatArray {
+	slots, ok := s.selectedSeries()[seriesIdx]
+	if !ok || values == nil {
+		return nil
+	}
+	if s.spec.RankFunc > 0 {
+		return values
+	}
+	result := collections.NewFloatArray(values.Capacity())
+	for slot := range slots {
+		if values.HasValue(slot) {
+			result.SetValue(slot, values.GetValue(slot))
+		}
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
+// selectedSeries returns the selected series with the selected time slots
+func (s *TopKSelector) selectedSeries() map[int]map[int]struct{} {
+	if s.selected != nil {
+		return s.selected
+	}
+	s.selected = make(map[int]map[int]struct{})
+	for slot, h := range s.heaps {
+		for _, item := range h.items {
+			slots, ok := s.selected[item.series]
+			if !ok {
+				slots = make(map[int]struct{})
+				s.selected[item.series] = slots
+			}
+			slots[slot] = struct{}{}
+		}
+	}
+	return s.selected
+}
+
+// rankValue calculates the rank value of series over the whole time range by rank function
+func rankValue(rankFunc function.FuncType, values collections.FloatArray) (float64, bool) {
+	var result float64
+	count := 0
+	it := values.Iterator()
+	for it.HasNext() {
+		_, value := it.Next()
+		switch {
+		case count == 0:
+			result = value
+		case rankFunc == function.Min:
+			result = math.Min(result, value)
+		case rankFunc == function.Max:
+			result = math.Max(result, value)
+		case rankFunc == function.Last:
+			result = value
+		default:
+			result += value
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	if rankFunc == function.Avg {
+		result /= float64(count)
+	}
+	return result, true
+}
+
+// rankItem represents the candidate series with its value in heap
+type rankItem struct {
+	series int
+	value  float64
+}
+
+// rankHeap represents the bounded heap of candidates, the top is the worst one of kept candidates,
+// min-heap for topk, max-heap for bottomk.
+type rankHeap struct {
+	bottom bool
+	items  []rankItem
+}
+
+// less tests if value a is worse than value b
+func (h *rankHeap) less(a, b float64) bool {
+	if h.bottom {
+		return a > b
+	}
+	return a < b
+}
+
+func (h *rankHeap) Len() int           { return len(h.items) }
+func (h *rankHeap) Less(i, j int) bool { return h.less(h.items[i].value, h.items[j].value) }
+func (h *rankHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *rankHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(rankItem))
+}
+
+func (h *rankHeap) Pop() interface{} {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+	return item
+}
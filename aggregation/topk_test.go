@@ -0,0 +1,105 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestNewTopKSpecs(t *testing.T) {
+	specs := NewTopKSpecs([]stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 2}, &stmt.FieldExpr{Name: "f"}}}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.BottomK,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 3}, &stmt.FieldExpr{Name: "f"}, &stmt.CallExpr{FuncType: function.Avg}}},
+			Alias: "b"},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+		&stmt.FieldExpr{Name: "f"},
+	})
+	assert.Equal(t, []*TopKSpec{
+		{Name: "topk(2,f)", K: 2, Expr: &stmt.FieldExpr{Name: "f"}},
+		{Name: "b", K: 3, Bottom: true, RankFunc: function.Avg, Expr: &stmt.FieldExpr{Name: "f"}},
+	}, specs)
+	assert.Nil(t, NewTopKSpecs(nil))
+}
+
+func TestTopKSelector_PerSlot(t *testing.T) {
+	spec := &TopKSpec{K: 2}
+	selector := spec.NewSelector()
+	series := []collections.FloatArray{
+		generateFloatArray([]float64{1, 5, 3}),
+		generateFloatArray([]float64{2, 1, 3}),
+		generateFloatArray([]float64{3, 4, 3}),
+		nil,
+	}
+	for idx, values := range series {
+		selector.Add(idx, values)
+	}
+	assert.True(t, selector.IsSelected(0))
+	assert.True(t, selector.IsSelected(1))
+	assert.True(t, selector.IsSelected(2))
+	assert.False(t, selector.IsSelected(3))
+	// keeps the earlier series if same value
+	assert.Equal(t, []int{1, 2}, slots(selector.Filter(0, series[0])))
+	assert.Equal(t, []int{0, 2}, slots(selector.Filter(1, series[1])))
+	assert.Equal(t, []int{0, 1}, slots(selector.Filter(2, series[2])))
+	assert.Nil(t, selector.Filter(3, nil))
+
+	spec = &TopKSpec{K: 1, Bottom: true}
+	selector = spec.NewSelector()
+	for idx, values := range series {
+		selector.Add(idx, values)
+	}
+	assert.Equal(t, []int{0, 2}, slots(selector.Filter(0, series[0])))
+	assert.Equal(t, []int{1}, slots(selector.Filter(1, series[1])))
+	assert.False(t, selector.IsSelected(2))
+}
+
+func TestTopKSelector_Range(t *testing.T) {
+	series := []collections.FloatArray{
+		generateFloatArray([]float64{1, 10, 1}),
+		generateFloatArray([]float64{4, 4, 4}),
+		generateFloatArray([]float64{5, 1, 2}),
+	}
+	cases := []struct {
+		rankFunc function.FuncType
+		bottom   bool
+		selected int
+	}{
+		{rankFunc: function.Sum, selected: 0},
+		{rankFunc: function.Avg, selected: 0},
+		{rankFunc: function.Max, selected: 0},
+		{rankFunc: function.Min, selected: 1},
+		{rankFunc: function.Last, selected: 1},
+		{rankFunc: function.Sum, bottom: true, selected: 2},
+	}
+	for _, c := range cases {
+		spec := &TopKSpec{K: 1, Bottom: c.bottom, RankFunc: c.rankFunc}
+		selector := spec.NewSelector()
+		for idx, values := range series {
+			selector.Add(idx, values)
+		}
+		for idx, values := range series {
+			if idx == c.selected {
+				assert.Equal(t, values, selector.Filter(idx, values))
+			} else {
+				assert.Nil(t, selector.Filter(idx, values))
+			}
+		}
+	}
+}
+
+func slots(values collections.FloatArray) []int {
+	var result []int
+	it := values.Iterator()
+	for it.HasNext() {
+		slot, _ := it.Next()
+		result = append(result, slot)
+	}
+	return result
+}
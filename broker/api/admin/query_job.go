@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/parallel"
+)
+
+// QueryJobAPI represents the running query job admin rest api, for finding and killing the query
+type QueryJobAPI struct {
+	jobManager parallel.JobManager
+}
+
+// NewQueryJobAPI creates the query job api instance
+func NewQueryJobAPI(jobManager parallel.JobManager) *QueryJobAPI {
+	return &QueryJobAPI{
+		jobManager: jobManager,
+	}
+}
+
+// List returns all running query jobs, includes sql, database, start time, nodes and progress
+func (q *QueryJobAPI) List(w http.ResponseWriter, r *http.Request) {
+	api.OK(w, q.jobManager.ListJobs())
+}
+
+// Kill kills the running query job by job id
+func (q *QueryJobAPI) Kill(w http.ResponseWriter, r *http.Request) {
+	id, err := api.GetParamsFromRequest("id", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	jobID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		api.Error(w, fmt.Errorf("invalid job id: %s", id))
+		return
+	}
+	if !q.jobManager.KillJob(jobID) {
+		api.NotFound(w)
+		return
+	}
+	api.NoContent(w)
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+)
+
+func TestQueryJobAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := parallel.NewMockJobManager(ctrl)
+	api := NewQueryJobAPI(jobManager)
+
+	// list jobs
+	jobs := []*models.JobInfo{{JobID: 1, Database: "test", SQL: "select f from cpu", StartTime: 10,
+		Nodes: []string{"1.1.1.1:9000"}, NumOfTask: 1}}
+	jobManager.EXPECT().ListJobs().Return(jobs)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/job/list",
+		HandlerFunc:    api.List,
+		ExpectHTTPCode: 200,
+		ExpectResponse: jobs,
+	})
+
+	// no job id
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/query/job",
+		HandlerFunc:    api.Kill,
+		ExpectHTTPCode: 500,
+	})
+	// invalid job id
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/query/job?id=abc",
+		HandlerFunc:    api.Kill,
+		ExpectHTTPCode: 500,
+	})
+	// job not exist
+	jobManager.EXPECT().KillJob(int64(1)).Return(false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/query/job?id=1",
+		HandlerFunc:    api.Kill,
+		ExpectHTTPCode: 404,
+	})
+	// kill success
+	jobManager.EXPECT().KillJob(int64(1)).Return(true)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/query/job?id=1",
+		HandlerFunc:    api.Kill,
+		ExpectHTTPCode: 204,
+	})
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/lindb/lindb/models"
+)
+
+const (
+	csvFormat    = "csv"
+	ndjsonFormat = "ndjson"
+)
+
+// timestampColumn represents the column name of timestamp in exported rows
+const timestampColumn = "timestamp"
+
+// ExportErrorTrailer represents the http trailer of export api, which carries the error message
+// if the export fails after the rows are partially written
+const ExportErrorTrailer = "X-Lindb-Export-Error"
+
+// exportRow represents a row of exported ndjson, includes the values of a group at a timestamp
+type exportRow struct {
+	Timestamp int64              `json:"timestamp"`
+	Tags      map[string]string  `json:"tags,omitempty"`
+	Fields    map[string]float64 `json:"fields"`
+}
+
+// rowEncoder encodes a row of exported result set
+type rowEncoder interface {
+	// writeHeader writes the header based on the metadata of result set
+	writeHeader(resultSet *models.ResultSet) error
+	// writeRow writes the values of a group at the timestamp
+	writeRow(row *exportRow) error
+	// flush flushes the buffered rows
+	flush() error
+}
+
+// exportWriter writes the result set as rows, one row per timestamp/group,
+// the rows of each group are ordered by timestamp, flushes as soon as the group is written.
+type exportWriter struct {
+	w       http.ResponseWriter
+	format  string
+	encoder rowEncoder
+	fields  []string
+
+	wroteHeader bool // if the response header is written, the failure can't be sent by status
+}
+
+// newExportWriter creates the export writer with the format(csv/ndjson)
+func newExportWriter(w http.ResponseWriter, format string) *exportWriter {
+	var encoder rowEncoder
+	switch format {
+	case ndjsonFormat:
+		encoder = &ndjsonEncoder{encoder: json.NewEncoder(w)}
+	default:
+		encoder = &csvEncoder{writer: csv.NewWriter(w)}
+	}
+	return &exportWriter{
+		w:       w,
+		format:  format,
+		encoder: encoder,
+	}
+}
+
+// WriteMeta writes the response header and the header row of exported result set
+func (ew *exportWriter) WriteMeta(resultSet *models.ResultSet) error {
+	ew.fields = resultSet.Fields
+	contentType := "text/csv; charset=utf-8"
+	if ew.format == ndjsonFormat {
+		contentType = "application/x-ndjson; charset=utf-8"
+	}
+	ew.w.Header().Set("Content-Type", contentType)
+	ew.w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.%s", resultSet.MetricName, ew.format)))
+	ew.w.WriteHeader(http.StatusOK)
+	ew.wroteHeader = true
+	if err := ew.encoder.writeHeader(resultSet); err != nil {
+		return err
+	}
+	return ew.encoder.flush()
+}
+
+// WriteSeries writes the rows of a group, a row for each timestamp which has any value
+func (ew *exportWriter) WriteSeries(series *models.Series) error {
+	var timestamps []int64
+	exist := make(map[int64]struct{})
+	for _, points := range series.Fields {
+		for timestamp := range points {
+			if _, ok := exist[timestamp]; !ok {
+				exist[timestamp] = struct{}{}
+				timestamps = append(timestamps, timestamp)
+			}
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+	for _, timestamp := range timestamps {
+		row := &exportRow{
+			Timestamp: timestamp,
+			Tags:      series.Tags,
+			Fields:    make(map[string]float64),
+		}
+		for _, fieldName := range ew.fields {
+			if value, ok := series.Fields[fieldName][timestamp]; ok {
+				row.Fields[fieldName] = value
+			}
+		}
+		if err := ew.encoder.writeRow(row); err != nil {
+			return err
+		}
+	}
+	if err := ew.encoder.flush(); err != nil {
+		return err
+	}
+	if flusher, ok := ew.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// csvEncoder encodes the rows as csv, the columns are timestamp, group by tags and fields,
+// the column is empty if the field has no value at the timestamp.
+type csvEncoder struct {
+	writer  *csv.Writer
+	groupBy []string
+	fields  []string
+}
+
+// writeHeader writes the header row of csv
+func (e *csvEncoder) writeHeader(resultSet *models.ResultSet) error {
+	e.groupBy = resultSet.GroupBy
+	e.fields = resultSet.Fields
+	header := []string{timestampColumn}
+	header = append(header, e.groupBy...)
+	header = append(header, e.fields...)
+	return e.writer.Write(header)
+}
+
+// writeRow writes the row as a csv record
+func (e *csvEncoder) writeRow(row *exportRow) error {
+	record := make([]string, 0, 1+len(e.groupBy)+len(e.fields))
+	record = append(record, strconv.FormatInt(row.Timestamp, 10))
+	for _, tagKey := range e.groupBy {
+		record = append(record, row.Tags[tagKey])
+	}
+	for _, fieldName := range e.fields {
+		value, ok := row.Fields[fieldName]
+		if !ok {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return e.writer.Write(record)
+}
+
+// flush flushes the buffered records of csv writer
+func (e *csvEncoder) flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// ndjsonEncoder encodes the rows as newline delimited json, no header line
+type ndjsonEncoder struct {
+	encoder *json.Encoder
+}
+
+// writeHeader does nothing, because each line of ndjson is self-described
+func (e *ndjsonEncoder) writeHeader(resultSet *models.ResultSet) error {
+	return nil
+}
+
+// writeRow writes the row as a json line
+func (e *ndjsonEncoder) writeRow(row *exportRow) error {
+	return e.encoder.Encode(row)
+}
+
+// flush does nothing, because json encoder writes the line directly
+func (e *ndjsonEncoder) flush() error {
+	return nil
+}
+
+// isExportFormat checks if the format is supported by export
+func isExportFormat(format string) bool {
+	return format == csvFormat || format == ndjsonFormat
+}
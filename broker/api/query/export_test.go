@@ -0,0 +1,68 @@
+package query
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+func mockExportResultSet() (*models.ResultSet, *models.Series) {
+	resultSet := models.NewResultSet()
+	resultSet.MetricName = "cpu"
+	resultSet.GroupBy = []string{"host"}
+	resultSet.Fields = []string{"f", "g"}
+	s := models.NewSeries(map[string]string{"host": "1.1.1.1"})
+	s.AddField("f", map[int64]float64{2000: 2.5, 1000: 10})
+	s.AddField("g", map[int64]float64{3000: 3})
+	return resultSet, s
+}
+
+func TestExportWriter_CSV(t *testing.T) {
+	resultSet, s := mockExportResultSet()
+	rr := httptest.NewRecorder()
+	writer := newExportWriter(rr, csvFormat)
+	assert.NoError(t, writer.WriteMeta(resultSet))
+	assert.NoError(t, writer.WriteSeries(s))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, rr.Flushed)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="cpu.csv"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, "timestamp,host,f,g\n"+
+		"1000,1.1.1.1,10,\n"+
+		"2000,1.1.1.1,2.5,\n"+
+		"3000,1.1.1.1,,3\n", rr.Body.String())
+}
+
+func TestExportWriter_NDJSON(t *testing.T) {
+	resultSet, s := mockExportResultSet()
+	rr := httptest.NewRecorder()
+	writer := newExportWriter(rr, ndjsonFormat)
+	assert.NoError(t, writer.WriteMeta(resultSet))
+	assert.NoError(t, writer.WriteSeries(s))
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="cpu.ndjson"`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, `{"timestamp":1000,"tags":{"host":"1.1.1.1"},"fields":{"f":10}}`+"\n"+
+		`{"timestamp":2000,"tags":{"host":"1.1.1.1"},"fields":{"f":2.5}}`+"\n"+
+		`{"timestamp":3000,"tags":{"host":"1.1.1.1"},"fields":{"g":3}}`+"\n", rr.Body.String())
+}
+
+func TestExportWriter_WriteError(t *testing.T) {
+	resultSet, s := mockExportResultSet()
+	writer := newExportWriter(&failResponseWriter{ResponseRecorder: httptest.NewRecorder()}, csvFormat)
+	assert.Error(t, writer.WriteMeta(resultSet))
+	assert.Error(t, writer.WriteSeries(s))
+
+	writer = newExportWriter(&failResponseWriter{ResponseRecorder: httptest.NewRecorder()}, ndjsonFormat)
+	assert.NoError(t, writer.WriteMeta(resultSet))
+	assert.Error(t, writer.WriteSeries(s))
+}
+
+func TestIsExportFormat(t *testing.T) {
+	assert.True(t, isExportFormat("csv"))
+	assert.True(t, isExportFormat("ndjson"))
+	assert.False(t, isExportFormat("json"))
+}
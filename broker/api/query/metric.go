@@ -1,15 +1,28 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/logger"
 )
 
+// failOnIncompleteParam represents the param which fails the query if the result is incomplete,
+// like shards unavailable or nodes failed, otherwise returns the partial result with warnings(default)
+const failOnIncompleteParam = "failOnIncomplete"
+
+// priorityParam represents the param of query priority in admission queue, like alerting/adhoc(default)
+const priorityParam = "priority"
+
+// traceParam represents the param which returns the span tree of distributed query with the result set
+const traceParam = "trace"
+
 // MetricAPI represents the metric query api
 type MetricAPI struct {
 	replicaStateMachine replica.StatusStateMachine
@@ -29,7 +42,9 @@ func NewMetricAPI(replicaStateMachine replica.StatusStateMachine, nodeStateMachi
 	}
 }
 
-// Search searches the metric data based on database and sql.
+// Search searches the metric data based on database and sql, responses the result set of query,
+// streams the result set as ndjson if stream param is true.
+// The result set is marked as partial with warnings if incomplete, unless failOnIncomplete param is true.
 func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 	db, err := api.GetParamsFromRequest("db", r, "", true)
 	if err != nil {
@@ -41,17 +56,118 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	exec := m.executorFactory.NewBrokerExecutor(db, sql, m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
-	results := exec.Execute()
-	if results != nil {
-		for result := range results {
-			//TODO need handle result
-			fmt.Println(result)
+	streaming, err := api.GetParamsFromRequest("stream", r, "false", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	ctx, err := queryContext(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql, m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
+	_ = exec.Execute()
+	if streaming == "true" {
+		if err := exec.Error(); err != nil {
+			api.Error(w, err)
+			return
 		}
+		stream(w, exec)
+		return
+	}
+	resultSet := exec.ResultSet()
+	if err := exec.Error(); err != nil {
+		api.Error(w, err)
+		return
+	}
+	// returns the execution statistics if explain query
+	if stats := exec.Statistics(); stats != nil {
+		api.OK(w, stats)
+		return
+	}
+	api.OK(w, resultSet)
+}
+
+// Export exports the result set of query as csv or ndjson(default csv) based on database and sql,
+// writes a row for each timestamp/group, if fails after the rows are written, the error is sent by the
+// error trailer(ExportErrorTrailer), the client must check it to detect the truncated result.
+func (m *MetricAPI) Export(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	sql, err := api.GetParamsFromRequest("sql", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	format, err := api.GetParamsFromRequest("format", r, csvFormat, false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !isExportFormat(format) {
+		api.Error(w, fmt.Errorf("not support export format: %s", format))
+		return
 	}
+	ctx, err := queryContext(r)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql, m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
+	_ = exec.Execute()
 	if err := exec.Error(); err != nil {
 		api.Error(w, err)
 		return
 	}
-	api.OK(w, "ok")
+	// declares the error trailer before the header written
+	w.Header().Set("Trailer", ExportErrorTrailer)
+	writer := newExportWriter(w, format)
+	if err := exec.StreamResultSet(writer); err != nil {
+		log.Error("export result set error", logger.Error(err))
+		if !writer.wroteHeader {
+			api.Error(w, err)
+			return
+		}
+		w.Header().Set(ExportErrorTrailer, err.Error())
+		return
+	}
+	// the exported rows have no place for warnings
+	if partial := exec.Partial(); partial != nil {
+		log.Warn("export partial result set", logger.String("db", db), logger.String("sql", sql),
+			logger.Any("warnings", partial.Warnings))
+	}
+}
+
+// queryContext returns the context of query request, which fails the query on incomplete result
+// if failOnIncomplete param is true, carries the priority of query for admission control,
+// and traces the query if trace param is true
+func queryContext(r *http.Request) (context.Context, error) {
+	failOnIncomplete, err := api.GetParamsFromRequest(failOnIncompleteParam, r, "false", false)
+	if err != nil {
+		return nil, err
+	}
+	trace, err := api.GetParamsFromRequest(traceParam, r, "false", false)
+	if err != nil {
+		return nil, err
+	}
+	priorityName, err := api.GetParamsFromRequest(priorityParam, r, "", false)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := models.ParseQueryPriority(priorityName)
+	if err != nil {
+		return nil, err
+	}
+	ctx := models.WithQueryPriority(r.Context(), priority)
+	if failOnIncomplete == "true" {
+		ctx = models.WithFailOnIncomplete(ctx)
+	}
+	if trace == "true" {
+		ctx = models.WithTrace(ctx, models.NewTrace("query"))
+	}
+	return ctx, nil
 }
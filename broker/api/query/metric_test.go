@@ -1,16 +1,21 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
-	"time"
 
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
 	"github.com/lindb/lindb/tsdb/series"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMetricAPI_Search(t *testing.T) {
@@ -36,10 +41,11 @@ func TestMetricAPI_Search(t *testing.T) {
 		ExpectHTTPCode: 500,
 	})
 
-	exec := parallel.NewMockExecutor(ctrl)
+	exec := parallel.NewMockBrokerExecutor(ctrl)
 	executorFactory.EXPECT().
-		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
 	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().ResultSet().Return(nil)
 	exec.EXPECT().Error().Return(fmt.Errorf("err"))
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
@@ -49,20 +55,228 @@ func TestMetricAPI_Search(t *testing.T) {
 	})
 
 	ch := make(chan series.GroupedIterator)
-
-	time.AfterFunc(10*time.Millisecond, func() {
-		ch <- series.NewMockGroupedIterator(ctrl)
-		close(ch)
+	resultSet := models.NewResultSet()
+	resultSet.MetricName = "cpu"
+	resultSet.Fields = []string{"f"}
+	s := models.NewSeries(map[string]string{"host": "1.1.1.1"})
+	s.AddField("f", map[int64]float64{1000: 10})
+	resultSet.AddSeries(s)
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().ResultSet().Return(resultSet)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Statistics().Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 200,
+		ExpectResponse: resultSet,
 	})
 
+	// streaming
 	executorFactory.EXPECT().
-		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&stream=true",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
 	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil).Times(2)
+	exec.EXPECT().StreamResultSet(gomock.Any()).Return(nil)
+	exec.EXPECT().Partial().Return(nil)
+	exec.EXPECT().Statistics().Return(nil)
+	exec.EXPECT().Trace().Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&stream=true",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 200,
+	})
+
+	// fails on incomplete result
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, database string, sql string,
+			replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+			jobManager parallel.JobManager) parallel.BrokerExecutor {
+			assert.True(t, models.FailOnIncompleteFromContext(ctx))
+			return exec
+		})
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().ResultSet().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("query result is incomplete"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&failOnIncomplete=true",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// alerting query
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, database string, sql string,
+			replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+			jobManager parallel.JobManager) parallel.BrokerExecutor {
+			assert.Equal(t, models.AlertingPriority, models.QueryPriorityFromContext(ctx))
+			return exec
+		})
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().ResultSet().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("too many queries"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&priority=alerting",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+	// unknown priority
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&priority=abc",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// traced query
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, database string, sql string,
+			replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+			jobManager parallel.JobManager) parallel.BrokerExecutor {
+			trace := models.TraceFromContext(ctx)
+			assert.NotNil(t, trace)
+			assert.NotEmpty(t, trace.TraceID)
+			return exec
+		})
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().ResultSet().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/broker/state?db=test&sql=select f from cpu&trace=true",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+
+	// explain query
+	stats := models.NewQueryStats(models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1}))
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().ResultSet().Return(nil)
 	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Statistics().Return(stats)
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
-		URL:            "/broker/state?db=test&sql=select f from cpu",
+		URL:            "/broker/state?db=test&sql=explain select f from cpu",
 		HandlerFunc:    api.Search,
 		ExpectHTTPCode: 200,
+		ExpectResponse: stats,
+	})
+}
+
+func TestMetricAPI_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	api := NewMetricAPI(nil, nil, executorFactory, nil)
+
+	// param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 500,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export?db=test",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 500,
+	})
+	// not support format
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export?db=test&sql=select f from cpu&format=xml",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 500,
+	})
+
+	// execute error
+	exec := parallel.NewMockBrokerExecutor(ctrl)
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export?db=test&sql=select f from cpu",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 500,
+	})
+
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().StreamResultSet(gomock.Any()).DoAndReturn(func(writer parallel.ResultSetWriter) error {
+		resultSet, s := mockExportResultSet()
+		if err := writer.WriteMeta(resultSet); err != nil {
+			return err
+		}
+		return writer.WriteSeries(s)
+	})
+	exec.EXPECT().Partial().Return(models.NewPartialResult([]int32{1}, nil))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export?db=test&sql=select f from cpu&format=ndjson",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 200,
+	})
+
+	// stream error before the header written
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().StreamResultSet(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/export?db=test&sql=select f from cpu",
+		HandlerFunc:    api.Export,
+		ExpectHTTPCode: 500,
+	})
+
+	// stream error after the rows written, the error is sent by trailer
+	executorFactory.EXPECT().
+		NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().StreamResultSet(gomock.Any()).DoAndReturn(func(writer parallel.ResultSetWriter) error {
+		resultSet, s := mockExportResultSet()
+		if err := writer.WriteMeta(resultSet); err != nil {
+			return err
+		}
+		if err := writer.WriteSeries(s); err != nil {
+			return err
+		}
+		return fmt.Errorf("query is running over the timeout[1m]")
 	})
+	req := httptest.NewRequest(http.MethodGet, "/query/export?db=test&sql=select+f+from+cpu", nil)
+	rr := httptest.NewRecorder()
+	api.Export(rr, req)
+	resp := rr.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, rr.Body.String())
+	assert.Equal(t, "query is running over the timeout[1m]", resp.Trailer.Get(ExportErrorTrailer))
 }
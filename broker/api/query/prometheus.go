@@ -0,0 +1,271 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/promql"
+)
+
+const (
+	promStatusSuccess = "success"
+	promStatusError   = "error"
+
+	promErrorBadData   = "bad_data"
+	promErrorExecution = "execution"
+
+	promResultMatrix = "matrix"
+	promResultVector = "vector"
+)
+
+// promResponse represents the response of prometheus http api
+type promResponse struct {
+	Status    string    `json:"status"`
+	Data      *promData `json:"data,omitempty"`
+	ErrorType string    `json:"errorType,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Warnings  []string  `json:"warnings,omitempty"` // warnings of partial result, like shards unavailable
+}
+
+// promData represents the query result of prometheus http api
+type promData struct {
+	ResultType string      `json:"resultType"`
+	Result     interface{} `json:"result"`
+}
+
+// promSeries represents the series of range query result(matrix)
+type promSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values [][2]interface{}  `json:"values"`
+}
+
+// promSample represents the sample of instant query result(vector)
+type promSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+// PrometheusAPI represents the prometheus compatible query api,
+// which translates the prometheus query language into lindb's query.
+type PrometheusAPI struct {
+	replicaStateMachine replica.StatusStateMachine
+	nodeStateMachine    broker.NodeStateMachine
+	executorFactory     parallel.ExecutorFactory
+	jobManager          parallel.JobManager
+	defaultDB           string
+}
+
+// NewPrometheusAPI creates the prometheus compatible query api,
+// the default database is queried if the db param is absent, empty means the db param is required.
+func NewPrometheusAPI(replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager, defaultDB string) *PrometheusAPI {
+	return &PrometheusAPI{
+		replicaStateMachine: replicaStateMachine,
+		nodeStateMachine:    nodeStateMachine,
+		executorFactory:     executorFactory,
+		jobManager:          jobManager,
+		defaultDB:           defaultDB,
+	}
+}
+
+// Query evaluates the instant query at the given time(default now), responses the vector result.
+func (m *PrometheusAPI) Query(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetParamsFromRequest("db", r, m.defaultDB, len(m.defaultDB) == 0)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	ql, err := api.GetParamsFromRequest("query", r, "", true)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	timestamp, err := parsePromTime(r, "time", timeutil.Now())
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	query, err := promql.Translate(ql, timestamp, timestamp, 0)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	ctx, err := queryContext(r)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	results, warnings, err := m.execute(ctx, db, query)
+	if err != nil {
+		promError(w, promErrorExecution, err)
+		return
+	}
+	vector := make([]promSample, 0, len(results))
+	for _, result := range results {
+		// instant query has only one sample
+		sample := result.Samples[len(result.Samples)-1]
+		vector = append(vector, promSample{Metric: result.Labels, Value: promPoint(sample)})
+	}
+	promOK(w, &promData{ResultType: promResultVector, Result: vector}, warnings)
+}
+
+// QueryRange evaluates the range query by step, responses the matrix result.
+func (m *PrometheusAPI) QueryRange(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetParamsFromRequest("db", r, m.defaultDB, len(m.defaultDB) == 0)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	ql, err := api.GetParamsFromRequest("query", r, "", true)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	start, err := parsePromTime(r, "start", 0)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	end, err := parsePromTime(r, "end", 0)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	step, err := parsePromDuration(r, "step")
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	query, err := promql.Translate(ql, start, end, step)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	ctx, err := queryContext(r)
+	if err != nil {
+		promError(w, promErrorBadData, err)
+		return
+	}
+	results, warnings, err := m.execute(ctx, db, query)
+	if err != nil {
+		promError(w, promErrorExecution, err)
+		return
+	}
+	matrix := make([]promSeries, 0, len(results))
+	for _, result := range results {
+		s := promSeries{Metric: result.Labels}
+		for _, sample := range result.Samples {
+			s.Values = append(s.Values, promPoint(sample))
+		}
+		matrix = append(matrix, s)
+	}
+	promOK(w, &promData{ResultType: promResultMatrix, Result: matrix}, warnings)
+}
+
+// execute executes the query statement, then evaluates the prometheus query based on the result set,
+// returns the warnings if the result is partial
+func (m *PrometheusAPI) execute(ctx context.Context, db string, query *promql.Query) ([]*promql.Result, []string, error) {
+	exec := m.executorFactory.NewBrokerQueryExecutor(ctx, db, query.Statement,
+		m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
+	resultSet := exec.Execute()
+	var series []*promql.Series
+	if resultSet != nil {
+		pointCount := query.PointCount()
+		for it := range resultSet {
+			if it == nil {
+				continue
+			}
+			expression := aggregation.NewExpression(it, pointCount, query.Statement.SelectItems)
+			expression.Eval()
+			values, ok := expression.ResultSet()[query.ResultName()]
+			if ok {
+				series = append(series, &promql.Series{Tags: it.Tags(), Values: values})
+			}
+		}
+	}
+	if err := exec.Error(); err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	if partial := exec.Partial(); partial != nil {
+		warnings = partial.Warnings
+	}
+	return query.Eval(series), warnings, nil
+}
+
+// parsePromTime parses the time param as prometheus does, supports unix timestamp(seconds) and rfc3339 format,
+// returns the default value if param not exist.
+func parsePromTime(r *http.Request, name string, defaultValue int64) (int64, error) {
+	value, err := api.GetParamsFromRequest(name, r, "", defaultValue <= 0)
+	if err != nil {
+		return 0, err
+	}
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return int64(math.Round(seconds * float64(timeutil.OneSecond))), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time param[%s]: %s", name, value)
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+// parsePromDuration parses the duration param as prometheus does, supports seconds and duration format
+func parsePromDuration(r *http.Request, name string) (int64, error) {
+	value, err := api.GetParamsFromRequest(name, r, "", true)
+	if err != nil {
+		return 0, err
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		duration := int64(math.Round(seconds * float64(timeutil.OneSecond)))
+		if duration <= 0 {
+			return 0, fmt.Errorf("invalid duration param[%s]: %s", name, value)
+		}
+		return duration, nil
+	}
+	return promql.ParseDuration(value)
+}
+
+// promPoint returns the point of sample in prometheus format, [unix timestamp(seconds), "value"]
+func promPoint(sample promql.Sample) [2]interface{} {
+	return [2]interface{}{
+		float64(sample.Timestamp) / float64(timeutil.OneSecond),
+		strconv.FormatFloat(sample.Value, 'f', -1, 64),
+	}
+}
+
+// promOK responses the query result with success status, includes the warnings if the result is partial
+func promOK(w http.ResponseWriter, data *promData, warnings []string) {
+	promResponseJSON(w, http.StatusOK, &promResponse{Status: promStatusSuccess, Data: data, Warnings: warnings})
+}
+
+// promError responses the error with error type, bad data => 400, execution error => 422
+func promError(w http.ResponseWriter, errorType string, err error) {
+	httpCode := http.StatusBadRequest
+	if errorType == promErrorExecution {
+		httpCode = http.StatusUnprocessableEntity
+	}
+	promResponseJSON(w, httpCode, &promResponse{Status: promStatusError, ErrorType: errorType, Error: err.Error()})
+}
+
+// promResponseJSON responses json body of prometheus http api
+func promResponseJSON(w http.ResponseWriter, httpCode int, resp *promResponse) {
+	b, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpCode)
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,229 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestPrometheusAPI_Query(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	api := NewPrometheusAPI(nil, nil, executorFactory, nil, "")
+
+	// param error
+	for _, url := range []string{
+		"/api/v1/query",
+		"/api/v1/query?db=test",
+		"/api/v1/query?db=test&query=cpu&time=abc",
+		"/api/v1/query?db=test&query=cpu[5m]",
+	} {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodGet,
+			URL:            url,
+			HandlerFunc:    api.Query,
+			ExpectHTTPCode: 400,
+		})
+	}
+
+	// execute error
+	exec := parallel.NewMockBrokerExecutor(ctrl)
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query?db=test&query=cpu",
+		HandlerFunc:    api.Query,
+		ExpectHTTPCode: 422,
+		ExpectResponse: &promResponse{Status: "error", ErrorType: "execution", Error: "err"},
+	})
+
+	ch := make(chan series.GroupedIterator)
+	go func() {
+		ch <- nil
+		ch <- mockPromSeries(ctrl, map[string]string{"host": "1.1.1.1"}, 10.5)
+		close(ch)
+	}()
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(models.NewPartialResult(nil, map[string]string{"1.1.1.1:9000": "err"}))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query?db=test&query=cpu&time=1500",
+		HandlerFunc:    api.Query,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &promResponse{
+			Status:   "success",
+			Warnings: []string{"node 1.1.1.1:9000 failed: err"},
+			Data: &promData{
+				ResultType: "vector",
+				Result: []promSample{{
+					Metric: map[string]string{"__name__": "cpu", "host": "1.1.1.1"},
+					Value:  [2]interface{}{1500, "10.5"},
+				}},
+			},
+		},
+	})
+}
+
+func TestPrometheusAPI_QueryRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	api := NewPrometheusAPI(nil, nil, executorFactory, nil, "")
+
+	// param error
+	for _, url := range []string{
+		"/api/v1/query_range",
+		"/api/v1/query_range?db=test",
+		"/api/v1/query_range?db=test&query=cpu",
+		"/api/v1/query_range?db=test&query=cpu&start=1500",
+		"/api/v1/query_range?db=test&query=cpu&start=1500&end=abc",
+		"/api/v1/query_range?db=test&query=cpu&start=1500&end=1600",
+		"/api/v1/query_range?db=test&query=cpu&start=1500&end=1600&step=0",
+		"/api/v1/query_range?db=test&query=cpu&start=1500&end=1600&step=1x",
+		"/api/v1/query_range?db=test&query=cpu&start=1600&end=1500&step=60",
+	} {
+		mock.DoRequest(t, &mock.HTTPHandler{
+			Method:         http.MethodGet,
+			URL:            url,
+			HandlerFunc:    api.QueryRange,
+			ExpectHTTPCode: 400,
+		})
+	}
+
+	exec := parallel.NewMockBrokerExecutor(ctrl)
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query_range?db=test&query=cpu&start=1500&end=1600&step=1m",
+		HandlerFunc:    api.QueryRange,
+		ExpectHTTPCode: 422,
+	})
+
+	ch := make(chan series.GroupedIterator)
+	go func() {
+		ch <- mockPromSeries(ctrl, map[string]string{"host": "1.1.1.1"}, 120)
+		close(ch)
+	}()
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method: http.MethodGet,
+		URL: "/api/v1/query_range?db=test&query=sum+by+(host)+(rate(cpu[1m]))" +
+			"&start=1970-01-01T00:25:00Z&end=1560&step=60",
+		HandlerFunc:    api.QueryRange,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &promResponse{
+			Status: "success",
+			Data: &promData{
+				ResultType: "matrix",
+				Result: []promSeries{{
+					Metric: map[string]string{"host": "1.1.1.1"},
+					Values: [][2]interface{}{{1500, "2"}},
+				}},
+			},
+		},
+	})
+
+	// empty result
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query_range?db=test&query=cpu&start=1500&end=1560&step=60",
+		HandlerFunc:    api.QueryRange,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &promResponse{Status: "success", Data: &promData{ResultType: "matrix", Result: []interface{}{}}},
+	})
+}
+
+func TestPrometheusAPI_DefaultDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	api := NewPrometheusAPI(nil, nil, executorFactory, nil, "default_db")
+	exec := parallel.NewMockBrokerExecutor(ctrl)
+	exec.EXPECT().Execute().Return(nil).Times(3)
+	exec.EXPECT().Error().Return(nil).Times(3)
+	exec.EXPECT().Partial().Return(nil).Times(3)
+
+	// uses default database if db param is absent
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "default_db", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(exec).Times(2)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query?query=cpu",
+		HandlerFunc:    api.Query,
+		ExpectHTTPCode: 200,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query_range?query=cpu&start=1500&end=1560&step=60",
+		HandlerFunc:    api.QueryRange,
+		ExpectHTTPCode: 200,
+	})
+	// db param overrides default database
+	executorFactory.EXPECT().
+		NewBrokerQueryExecutor(gomock.Any(), "test", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/api/v1/query?db=test&query=cpu",
+		HandlerFunc:    api.Query,
+		ExpectHTTPCode: 200,
+	})
+}
+
+// mockPromSeries mocks the grouped series which has one field with the value of the first time slot
+func mockPromSeries(ctrl *gomock.Controller, tags map[string]string, value float64) series.GroupedIterator {
+	primitiveIt := series.NewMockPrimitiveIterator(ctrl)
+	primitiveIt.EXPECT().FieldID().Return(uint16(1)).AnyTimes()
+	gomock.InOrder(
+		primitiveIt.EXPECT().HasNext().Return(true),
+		primitiveIt.EXPECT().Next().Return(0, value),
+		primitiveIt.EXPECT().HasNext().Return(false),
+	)
+	fieldIt := series.NewMockFieldIterator(ctrl)
+	fieldIt.EXPECT().FieldName().Return("value").AnyTimes()
+	fieldIt.EXPECT().FieldID().Return(uint16(1)).AnyTimes()
+	fieldIt.EXPECT().FieldType().Return(field.SumField).AnyTimes()
+	gomock.InOrder(
+		fieldIt.EXPECT().HasNext().Return(true),
+		fieldIt.EXPECT().Next().Return(primitiveIt),
+		fieldIt.EXPECT().HasNext().Return(false).MaxTimes(1),
+	)
+	it := series.NewMockGroupedIterator(ctrl)
+	it.EXPECT().Tags().Return(tags).AnyTimes()
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Next().Return(fieldIt),
+		it.EXPECT().HasNext().Return(false),
+	)
+	return it
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+var log = logger.GetLogger("broker", "QueryAPI")
+
+// streamLine represents a line of streaming result, only one of the fields is set in a line
+type streamLine struct {
+	Meta    *models.ResultSet     `json:"meta,omitempty"`
+	Series  *models.Series        `json:"series,omitempty"`
+	Partial *models.PartialResult `json:"partial,omitempty"`
+	Stats   *models.QueryStats    `json:"stats,omitempty"`
+	Trace   *models.Trace         `json:"trace,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// streamWriter writes the result set as newline delimited json(ndjson) with chunked transfer encoding,
+// 1) the first line is the metadata of result set
+// 2) a line for each group, flushes as soon as the group is written
+// 3) the partial line with warnings if the result is incomplete
+// 4) the execution statistics line if explain query, the trace line if trace requested
+// 5) the error line as the last line if execution fails
+type streamWriter struct {
+	w           http.ResponseWriter
+	encoder     *json.Encoder
+	wroteHeader bool
+}
+
+// newStreamWriter creates the streaming result set writer
+func newStreamWriter(w http.ResponseWriter) *streamWriter {
+	return &streamWriter{
+		w:       w,
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// WriteMeta writes the metadata of result set as the first line
+func (sw *streamWriter) WriteMeta(resultSet *models.ResultSet) error {
+	meta := *resultSet
+	meta.Series = nil
+	return sw.writeLine(&streamLine{Meta: &meta})
+}
+
+// WriteSeries writes the series of a group as a line
+func (sw *streamWriter) WriteSeries(series *models.Series) error {
+	return sw.writeLine(&streamLine{Series: series})
+}
+
+// writeLine writes the line, then flushes it to client
+func (sw *streamWriter) writeLine(line *streamLine) error {
+	if !sw.wroteHeader {
+		sw.w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		sw.w.WriteHeader(http.StatusOK)
+		sw.wroteHeader = true
+	}
+	// encoder appends a newline after each json value
+	if err := sw.encoder.Encode(line); err != nil {
+		return err
+	}
+	if flusher, ok := sw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// stream streams the result set of execution, the error is written as the last line because the status is sent
+func stream(w http.ResponseWriter, exec parallel.BrokerExecutor) {
+	writer := newStreamWriter(w)
+	err := exec.StreamResultSet(writer)
+	if err == nil {
+		err = exec.Error()
+	}
+	if err == nil {
+		if partial := exec.Partial(); partial != nil {
+			err = writer.writeLine(&streamLine{Partial: partial})
+		}
+	}
+	if err == nil {
+		if stats := exec.Statistics(); stats != nil {
+			err = writer.writeLine(&streamLine{Stats: stats})
+		}
+	}
+	if err == nil {
+		if trace := exec.Trace(); trace != nil {
+			err = writer.writeLine(&streamLine{Trace: trace})
+		}
+	}
+	if err != nil {
+		if writeErr := writer.writeLine(&streamLine{Error: err.Error()}); writeErr != nil {
+			log.Error("write streaming result set error", logger.Error(writeErr))
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+)
+
+func TestStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exec := parallel.NewMockBrokerExecutor(ctrl)
+	exec.EXPECT().StreamResultSet(gomock.Any()).DoAndReturn(func(writer parallel.ResultSetWriter) error {
+		resultSet := models.NewResultSet()
+		resultSet.MetricName = "cpu"
+		resultSet.Fields = []string{"f"}
+		if err := writer.WriteMeta(resultSet); err != nil {
+			return err
+		}
+		s := models.NewSeries(map[string]string{"host": "1.1.1.1"})
+		s.AddField("f", map[int64]float64{1000: 10})
+		return writer.WriteSeries(s)
+	})
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(models.NewPartialResult([]int32{1}, nil))
+	exec.EXPECT().Statistics().Return(nil)
+	exec.EXPECT().Trace().Return(nil)
+	rr := httptest.NewRecorder()
+	stream(rr, exec)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, rr.Flushed)
+	assert.Equal(t, "application/x-ndjson; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `{"meta":{"metricName":"cpu","fields":["f"],"startTime":0,"endTime":0,"interval":0,"series":null}}`+"\n"+
+		`{"series":{"tags":{"host":"1.1.1.1"},"fields":{"f":{"1000":10}}}}`+"\n"+
+		`{"partial":{"missingShards":[1],"warnings":["shards [1] are unavailable"]}}`+"\n", rr.Body.String())
+
+	// explain query
+	stats := models.NewQueryStats(models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1}))
+	exec.EXPECT().StreamResultSet(gomock.Any()).Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(nil)
+	exec.EXPECT().Statistics().Return(stats)
+	exec.EXPECT().Trace().Return(nil)
+	rr = httptest.NewRecorder()
+	stream(rr, exec)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{"stats":{"physicalPlan":`)
+
+	// traced query
+	exec.EXPECT().StreamResultSet(gomock.Any()).Return(nil)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Partial().Return(nil)
+	exec.EXPECT().Statistics().Return(nil)
+	exec.EXPECT().Trace().Return(models.NewTraceWithID("abc", "query"))
+	rr = httptest.NewRecorder()
+	stream(rr, exec)
+	assert.Contains(t, rr.Body.String(), `{"trace":{"traceID":"abc","root":{"name":"query"`)
+
+	// execute error
+	exec.EXPECT().StreamResultSet(gomock.Any()).Return(nil)
+	exec.EXPECT().Error().Return(errors.New("err"))
+	rr = httptest.NewRecorder()
+	stream(rr, exec)
+	assert.Equal(t, `{"error":"err"}`+"\n", rr.Body.String())
+
+	// write error
+	exec.EXPECT().StreamResultSet(gomock.Any()).DoAndReturn(func(writer parallel.ResultSetWriter) error {
+		return writer.WriteMeta(models.NewResultSet())
+	})
+	stream(&failResponseWriter{ResponseRecorder: httptest.NewRecorder()}, exec)
+}
+
+// failResponseWriter represents the response writer which fails when writing body
+type failResponseWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *failResponseWriter) Write(buf []byte) (int, error) {
+	return 0, errors.New("write error")
+}
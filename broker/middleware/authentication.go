@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
 
 	jwt "github.com/dgrijalva/jwt-go"
 )
@@ -20,6 +21,8 @@ type Authentication interface {
 	CreateToken(user config.User) (string, error)
 	// Validate validates the token
 	Validate(next http.Handler) http.Handler
+	// ExtractUser extracts the user from the token without rejecting the request
+	ExtractUser(next http.Handler) http.Handler
 }
 
 // userAuthentication represents user authentication using jwt
@@ -56,7 +59,8 @@ func (u *userAuthentication) Validate(next http.Handler) http.Handler {
 		if len(token) > 0 {
 			claims := parseToken(token, u.user)
 			if claims.UserName == u.user.UserName && claims.Password == u.user.Password {
-				next.ServeHTTP(w, r)
+				// passes the authenticated user to the handler, like recording the user of slow query
+				next.ServeHTTP(w, r.WithContext(models.WithUser(r.Context(), claims.UserName)))
 				return
 			}
 		}
@@ -68,6 +72,22 @@ func (u *userAuthentication) Validate(next http.Handler) http.Handler {
 	})
 }
 
+// ExtractUser creates middleware which passes the authenticated user to the handler if the token is valid,
+// the request without valid token isn't rejected, like the query api for recording the user of slow query
+// and limiting the concurrent queries of each user
+func (u *userAuthentication) ExtractUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if len(token) > 0 {
+			claims := parseToken(token, u.user)
+			if claims.UserName == u.user.UserName && claims.Password == u.user.Password {
+				r = r.WithContext(models.WithUser(r.Context(), claims.UserName))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // ParseToken returns jwt claims by token
 // get secret key use Md5Encrypt method with username and password
 // then jwt parse token by secret key
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -40,7 +41,9 @@ func TestUserAuthentication_Validate(t *testing.T) {
 	user := config.User{UserName: "admin", Password: "admin123"}
 	auth := NewAuthentication(user)
 
+	var userName string
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userName = models.UserFromContext(r.Context())
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = io.WriteString(w, "ok")
@@ -62,4 +65,29 @@ func TestUserAuthentication_Validate(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "ok", rr.Body.String())
+	assert.Equal(t, "admin", userName)
+}
+
+func TestUserAuthentication_ExtractUser(t *testing.T) {
+	auth := NewAuthentication(config.User{UserName: "admin", Password: "admin123"})
+	var userName string
+	handler := auth.ExtractUser(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userName = models.UserFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// invalid token isn't rejected
+	req, _ := http.NewRequest("GET", "/query/metric", nil)
+	req.Header.Set("Authorization", "Bearer abc123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Empty(t, userName)
+
+	req, _ = http.NewRequest("GET", "/query/metric", nil)
+	req.Header.Set("Authorization", tokenStr)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "admin", userName)
 }
@@ -57,11 +57,13 @@ type factory struct {
 type apiHandler struct {
 	storageClusterAPI *admin.StorageClusterAPI
 	databaseAPI       *admin.DatabaseAPI
+	queryJobAPI       *admin.QueryJobAPI
 	loginAPI          *api.LoginAPI
 	storageStateAPI   *stateAPI.StorageAPI
 	brokerStateAPI    *stateAPI.BrokerAPI
 	masterAPI         *masterAPI.MasterAPI
 	metricAPI         *queryAPI.MetricAPI
+	prometheusAPI     *queryAPI.PrometheusAPI
 	writeAPI          *writeAPI.WriteAPI
 }
 
@@ -166,6 +168,7 @@ func (r *runtime) Run() error {
 	if err := r.stateMachines.Start(); err != nil {
 		return fmt.Errorf("start state machines error:%s", err)
 	}
+	r.buildJobDependency()
 
 	masterCfg := &coordinator.MasterCfg{
 		Ctx:                 r.ctx,
@@ -293,11 +296,6 @@ func (r *runtime) buildServiceDependency() {
 	// hard code create channel first.
 	cm := replication.NewChannelManager(r.config.ReplicationChannel, rpc.NewClientStreamFactory(r.node), replicatorService)
 	taskManager := parallel.NewTaskManager(r.node, r.factory.taskClient, r.factory.taskServer)
-	jobManager := parallel.NewJobManager(taskManager)
-
-	//TODO (stone100)close it????
-	taskReceiver := parallel.NewTaskReceiver(jobManager)
-	r.factory.taskClient.SetTaskReceiver(taskReceiver)
 
 	srv := srv{
 		storageClusterService: service.NewStorageClusterService(r.repo),
@@ -307,22 +305,50 @@ func (r *runtime) buildServiceDependency() {
 		replicatorService:     replicatorService,
 		channelManager:        cm,
 		taskManager:           taskManager,
-		jobManager:            jobManager,
 	}
 	r.srv = srv
 }
 
+// buildJobDependency builds the job manager of query after state machines started,
+// the job manager retries the failed or timeout leaf task on the alternative replicas based on the replica status
+func (r *runtime) buildJobDependency() {
+	r.srv.jobManager = parallel.NewJobManager(r.srv.taskManager, r.stateMachines.ReplicaStatusSM,
+		r.config.LeafTask.GetTimeout())
+
+	//TODO (stone100)close it????
+	taskReceiver := parallel.NewTaskReceiver(r.srv.jobManager)
+	r.factory.taskClient.SetTaskReceiver(taskReceiver)
+}
+
 // buildAPIDependency builds broker api dependency
 func (r *runtime) buildAPIDependency() {
+	// the result cache is shared by all query apis
+	resultCache := query.NewResultCache(r.config.ResultCache.GetBucketSize(),
+		r.config.ResultCache.GetWriteWindow(), r.config.ResultCache.MaxMemorySize)
+	// the concurrent limits of admission control are shared by all query apis
+	admission := query.NewAdmissionController(r.config.Admission.MaxConcurrency,
+		r.config.Admission.MaxConcurrencyPerUser, r.config.Admission.MaxConcurrencyPerDatabase,
+		r.config.Admission.MaxQueueSize, r.config.Admission.GetQueueTimeout(), r.config.Admission.AlertingUsers)
+	// the executor factory is shared by all query apis
+	executorFactory := query.NewExecutorFactory(query.ExecutorOption{
+		DatabaseService:    r.srv.databaseService,
+		Limit:              r.config.Query,
+		SlowQueryThreshold: r.config.SlowQuery.GetThreshold(),
+		ResultCache:        resultCache,
+		Admission:          admission,
+	})
 	handlers := apiHandler{
 		storageClusterAPI: admin.NewStorageClusterAPI(r.srv.storageClusterService),
 		databaseAPI:       admin.NewDatabaseAPI(r.srv.databaseService),
+		queryJobAPI:       admin.NewQueryJobAPI(r.srv.jobManager),
 		loginAPI:          api.NewLoginAPI(r.config.User, r.middleware.authentication),
 		storageStateAPI:   stateAPI.NewStorageAPI(r.stateMachines.StorageSM),
 		brokerStateAPI:    stateAPI.NewBrokerAPI(r.stateMachines.NodeSM),
 		masterAPI:         masterAPI.NewMasterAPI(r.master),
 		metricAPI: queryAPI.NewMetricAPI(r.stateMachines.ReplicaStatusSM,
-			r.stateMachines.NodeSM, query.NewExecutorFactory(), r.srv.jobManager),
+			r.stateMachines.NodeSM, executorFactory, r.srv.jobManager),
+		prometheusAPI: queryAPI.NewPrometheusAPI(r.stateMachines.ReplicaStatusSM,
+			r.stateMachines.NodeSM, executorFactory, r.srv.jobManager, r.config.Prometheus.DefaultDatabase),
 		writeAPI: writeAPI.NewWriteAPI(r.srv.channelManager),
 	}
 
@@ -338,12 +364,20 @@ func (r *runtime) buildAPIDependency() {
 	api.AddRoutes("GetDatabase", http.MethodGet, "/database", handlers.databaseAPI.GetByName)
 	api.AddRoutes("ListDatabase", http.MethodGet, "/database/list", handlers.databaseAPI.List)
 
+	api.AddRoutes("ListQueryJobs", http.MethodGet, "/query/job/list", handlers.queryJobAPI.List)
+	api.AddRoutes("KillQueryJob", http.MethodDelete, "/query/job", handlers.queryJobAPI.Kill)
+
 	api.AddRoutes("ListStorageClusterState", http.MethodGet, "/storage/state/list", handlers.storageStateAPI.ListStorageCluster)
 	api.AddRoutes("ListBrokerNodesState", http.MethodGet, "/broker/node/state", handlers.brokerStateAPI.ListBrokerNodes)
 
 	api.AddRoutes("GetMasterState", http.MethodGet, "/cluster/master", handlers.masterAPI.GetMaster)
 
 	api.AddRoutes("QueryMetric", http.MethodGet, "/query/metric", handlers.metricAPI.Search)
+	api.AddRoutes("ExportMetric", http.MethodGet, "/query/export", handlers.metricAPI.Export)
+	api.AddRoutes("PromQuery", http.MethodGet, "/api/v1/query", handlers.prometheusAPI.Query)
+	api.AddRoutes("PromQueryPost", http.MethodPost, "/api/v1/query", handlers.prometheusAPI.Query)
+	api.AddRoutes("PromQueryRange", http.MethodGet, "/api/v1/query_range", handlers.prometheusAPI.QueryRange)
+	api.AddRoutes("PromQueryRangePost", http.MethodPost, "/api/v1/query_range", handlers.prometheusAPI.QueryRange)
 
 	api.AddRoutes("WriteSumMetric", http.MethodPut, "/metric/sum", handlers.writeAPI.Sum)
 }
@@ -358,6 +392,16 @@ func (r *runtime) buildMiddlewareDependency() {
 	if err == nil {
 		api.AddMiddleware(r.middleware.authentication.Validate, validate)
 	}
+	// the query jobs of any user can be listed and killed, so the job apis require authentication
+	queryJobAPI, err := regexp.Compile("^/query/job")
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.Validate, queryJobAPI)
+	}
+	// the query apis are open, the user is extracted if authenticated
+	queryAPI, err := regexp.Compile("^(/query/(metric|export)|/api/v1/)")
+	if err == nil {
+		api.AddMiddleware(r.middleware.authentication.ExtractUser, queryAPI)
+	}
 }
 
 // startTCPServer starts the TCP server
@@ -1,13 +1,18 @@
 package broker
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/check.v1"
 
+	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/server"
 )
 
@@ -58,3 +63,53 @@ func (ts *testBrokerRuntimeSuite) TestBrokerRun(c *check.C) {
 	_ = broker.Stop()
 	c.Assert(server.Terminated, check.Equals, broker.State())
 }
+
+func TestBrokerRuntime_QueryAPIUser(t *testing.T) {
+	r := NewBrokerRuntime(config.NewDefaultBrokerCfg()).(*runtime)
+	r.buildMiddlewareDependency()
+	var user string
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		user = models.UserFromContext(req.Context())
+		w.WriteHeader(http.StatusOK)
+	}
+	api.AddRoutes("TestQueryUser", http.MethodGet, "/query/metric/test-user", handler)
+	api.AddRoutes("TestPromQueryUser", http.MethodGet, "/api/v1/test-user", handler)
+	router := api.NewRouter()
+	token, err := r.middleware.authentication.CreateToken(r.config.User)
+	assert.NoError(t, err)
+
+	for _, path := range []string{"/query/metric/test-user", "/api/v1/test-user"} {
+		// passes the authenticated user to the query api
+		user = ""
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", token)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "admin", user)
+
+		// the query api is open for the request without token
+		user = ""
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, user)
+	}
+}
+
+func TestBrokerRuntime_QueryJobAPIAuth(t *testing.T) {
+	r := NewBrokerRuntime(config.NewDefaultBrokerCfg()).(*runtime)
+	r.buildMiddlewareDependency()
+	called := false
+	api.AddRoutes("TestKillQueryJob", http.MethodDelete, "/query/job/test-auth", func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	router := api.NewRouter()
+
+	// the job api rejects the request without token, unlike the query api
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/query/job/test-auth", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.False(t, called)
+}
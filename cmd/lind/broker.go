@@ -65,6 +65,9 @@ func serveBroker(cmd *cobra.Command, args []string) error {
 	if err := fileutil.LoadConfig(cfg, defaultBrokerCfgFile, &brokerCfg); err != nil {
 		return fmt.Errorf("decode config file error: %s", err)
 	}
+	if err := brokerCfg.Validation(); err != nil {
+		return fmt.Errorf("invalid config: %s", err)
+	}
 	if err := logger.InitLogger(brokerCfg.Logging); err != nil {
 		return fmt.Errorf("init logger error: %s", err)
 	}
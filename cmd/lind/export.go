@@ -0,0 +1,86 @@
+package lind
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	queryAPI "github.com/lindb/lindb/broker/api/query"
+)
+
+const defaultBrokerEndpoint = "http://localhost:9000"
+
+var (
+	exportBroker string
+	exportDB     string
+	exportSQL    string
+	exportFormat string
+	exportOutput string
+)
+
+// newExportCmd returns a new export-cmd
+func newExportCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Run a query and export the result as csv or ndjson",
+		RunE:  exportQueryResult,
+	}
+	exportCmd.Flags().StringVar(&exportBroker, "broker", defaultBrokerEndpoint,
+		"http endpoint of broker")
+	exportCmd.Flags().StringVar(&exportDB, "db", "", "database name")
+	exportCmd.Flags().StringVar(&exportSQL, "sql", "", "query sql")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "export format, csv or ndjson")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"output file path, default is stdout")
+	_ = exportCmd.MarkFlagRequired("db")
+	_ = exportCmd.MarkFlagRequired("sql")
+	return exportCmd
+}
+
+// exportQueryResult requests the export api of broker, then copies the result to output,
+// returns error if the broker fails after the rows are partially written, the output is truncated
+func exportQueryResult(cmd *cobra.Command, args []string) error {
+	params := url.Values{}
+	params.Set("db", exportDB)
+	params.Set("sql", exportSQL)
+	params.Set("format", exportFormat)
+	endpoint := fmt.Sprintf("%s/query/export?%s", strings.TrimSuffix(exportBroker, "/"), params.Encode())
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("request broker error: %s", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("export error, status: %d, message: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var output io.Writer = os.Stdout
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("create output file error: %s", err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		output = f
+	}
+	if _, err := io.Copy(output, resp.Body); err != nil {
+		return fmt.Errorf("write export result error: %s", err)
+	}
+	// the trailer is available after the body is read
+	if errMsg := resp.Trailer.Get(queryAPI.ExportErrorTrailer); errMsg != "" {
+		return fmt.Errorf("export result is truncated, error: %s", errMsg)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package lind
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	queryAPI "github.com/lindb/lindb/broker/api/query"
+)
+
+func TestExportQueryResult(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	assert.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	var status int
+	var errMsg string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/query/export", r.URL.Path)
+		assert.Equal(t, "test_db", r.URL.Query().Get("db"))
+		assert.Equal(t, "select f from cpu", r.URL.Query().Get("sql"))
+		assert.Equal(t, "csv", r.URL.Query().Get("format"))
+		w.Header().Set("Trailer", queryAPI.ExportErrorTrailer)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("timestamp,f\n1,1\n"))
+		if errMsg != "" {
+			w.Header().Set(queryAPI.ExportErrorTrailer, errMsg)
+		}
+	}))
+	defer server.Close()
+
+	exportBroker = server.URL + "/"
+	exportDB = "test_db"
+	exportSQL = "select f from cpu"
+	exportFormat = "csv"
+	exportOutput = filepath.Join(dir, "cpu.csv")
+
+	// exports the result into output file
+	status = http.StatusOK
+	assert.NoError(t, exportQueryResult(nil, nil))
+	result, err := ioutil.ReadFile(exportOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, "timestamp,f\n1,1\n", string(result))
+
+	// fails after the rows are partially written
+	errMsg = "query is running over the timeout[1m]"
+	err = exportQueryResult(nil, nil)
+	assert.EqualError(t, err, "export result is truncated, error: query is running over the timeout[1m]")
+
+	// broker returns error status
+	status = http.StatusInternalServerError
+	errMsg = ""
+	assert.Error(t, exportQueryResult(nil, nil))
+
+	// create output file failure
+	status = http.StatusOK
+	exportOutput = filepath.Join(dir, "not_exist", "cpu.csv")
+	assert.Error(t, exportQueryResult(nil, nil))
+
+	// request broker failure
+	server.Close()
+	assert.Error(t, exportQueryResult(nil, nil))
+}
@@ -69,6 +69,9 @@ func serveStandalone(cmd *cobra.Command, args []string) error {
 	if err := fileutil.LoadConfig(cfg, defaultStandaloneCfgFile, &standaloneCfg); err != nil {
 		return fmt.Errorf("decode config file error: %s", err)
 	}
+	if err := standaloneCfg.Validation(); err != nil {
+		return fmt.Errorf("invalid config: %s", err)
+	}
 	if err := logger.InitLogger(standaloneCfg.Logging); err != nil {
 		return fmt.Errorf("init logger error: %s", err)
 	}
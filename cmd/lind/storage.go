@@ -65,6 +65,9 @@ func serveStorage(cmd *cobra.Command, args []string) error {
 	if err := fileutil.LoadConfig(cfg, defaultStorageCfgFile, &storageCfg); err != nil {
 		return fmt.Errorf("decode config file error: %s", err)
 	}
+	if err := storageCfg.Validation(); err != nil {
+		return fmt.Errorf("invalid config: %s", err)
+	}
 	if err := logger.InitLogger(storageCfg.Logging); err != nil {
 		return fmt.Errorf("init logger error: %s", err)
 	}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
+	"time"
+
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 // BrokerKernel represents a broker configuration
@@ -12,6 +17,29 @@ type BrokerKernel struct {
 	GRPC               GRPC               `toml:"grpc"`
 	TCP                TCP                `toml:"tcp"`
 	ReplicationChannel ReplicationChannel `toml:"replicationChannel"`
+	Query              option.QueryLimit  `toml:"query"`
+	SlowQuery          SlowQuery          `toml:"slowQuery"`
+	ResultCache        ResultCache        `toml:"resultCache"`
+	Admission          Admission          `toml:"admission"`
+	LeafTask           LeafTask           `toml:"leafTask"`
+	Prometheus         Prometheus         `toml:"prometheus"`
+}
+
+// Validation validates broker config if valid, the invalid config fails the startup of broker
+func (b BrokerKernel) Validation() error {
+	if err := b.Query.Validation(); err != nil {
+		return err
+	}
+	if err := b.SlowQuery.Validation(); err != nil {
+		return err
+	}
+	if err := b.ResultCache.Validation(); err != nil {
+		return err
+	}
+	if err := b.Admission.Validation(); err != nil {
+		return err
+	}
+	return b.LeafTask.Validation()
 }
 
 // Broker represents a broker configuration with common settings
@@ -50,6 +78,128 @@ type ReplicationChannel struct {
 	BufferSizeLimit int `toml:"bufferSizeLimit"`
 }
 
+// SlowQuery represents the config of slow query log in broker
+type SlowQuery struct {
+	// records the query whose execution exceeds the threshold(like 10s) into slow query log, empty means disable
+	Threshold string `toml:"threshold"`
+}
+
+// Validation validates slow query config if valid
+func (s SlowQuery) Validation() error {
+	if err := option.ValidateInterval(s.Threshold, false); err != nil {
+		return fmt.Errorf("threshold of slow query is invalid, err:%s", err)
+	}
+	return nil
+}
+
+// GetThreshold returns the threshold of slow query, returns 0 if disable or invalid
+func (s SlowQuery) GetThreshold() time.Duration {
+	threshold, _ := timeutil.ParseInterval(s.Threshold)
+	return time.Duration(threshold) * time.Millisecond
+}
+
+// ResultCache represents the config of query result cache in broker,
+// caches the result of down-sampled query in time aligned buckets
+type ResultCache struct {
+	// time range of cached bucket(like 1h), empty means disable
+	BucketSize string `toml:"bucketSize"`
+	// the buckets within the write window(like 5m) are not cached, because the data is still being written
+	WriteWindow string `toml:"writeWindow"`
+	// max memory size(bytes) of cached buckets, evicts the least recently used buckets if exceeds,
+	// the memory size is estimated by the tags and points of cached series(about 40 bytes per point)
+	MaxMemorySize int `toml:"maxMemorySize"`
+}
+
+// Validation validates result cache config if valid
+func (c ResultCache) Validation() error {
+	if err := option.ValidateInterval(c.BucketSize, false); err != nil {
+		return fmt.Errorf("bucket size of result cache is invalid, err:%s", err)
+	}
+	if err := option.ValidateInterval(c.WriteWindow, false); err != nil {
+		return fmt.Errorf("write window of result cache is invalid, err:%s", err)
+	}
+	if c.MaxMemorySize < 0 {
+		return fmt.Errorf("max memory size of result cache cannot be negative")
+	}
+	return nil
+}
+
+// GetBucketSize returns the time range of cached bucket, returns 0 if disable or invalid
+func (c ResultCache) GetBucketSize() time.Duration {
+	bucketSize, _ := timeutil.ParseInterval(c.BucketSize)
+	return time.Duration(bucketSize) * time.Millisecond
+}
+
+// GetWriteWindow returns the write window which the buckets within it are not cached, returns 0 if invalid
+func (c ResultCache) GetWriteWindow() time.Duration {
+	writeWindow, _ := timeutil.ParseInterval(c.WriteWindow)
+	return time.Duration(writeWindow) * time.Millisecond
+}
+
+// Admission represents the config of query admission control in broker,
+// the queries exceeding the concurrent limits wait in the queue by priority
+type Admission struct {
+	// max num. of concurrent queries in broker, 0 means no limit
+	MaxConcurrency int `toml:"maxConcurrency"`
+	// max num. of concurrent queries of each authenticated user, 0 means no limit,
+	// NOTICE: the anonymous queries(without valid token) aren't limited by it, only by the global/per-database limits
+	MaxConcurrencyPerUser int `toml:"maxConcurrencyPerUser"`
+	// max num. of concurrent queries of each database, 0 means no limit
+	MaxConcurrencyPerDatabase int `toml:"maxConcurrencyPerDatabase"`
+	// max num. of queries waiting in the queue, rejects the query if the queue is full
+	MaxQueueSize int `toml:"maxQueueSize"`
+	// max wait time(like 10s) of query in the queue, empty means waiting until the query timeout
+	QueueTimeout string `toml:"queueTimeout"`
+	// authenticated users which are allowed to run the alerting priority queries, empty means any authenticated user,
+	// the alerting priority of anonymous query or other users falls back to ad-hoc priority
+	AlertingUsers []string `toml:"alertingUsers"`
+}
+
+// Validation validates admission config if valid
+func (a Admission) Validation() error {
+	if a.MaxConcurrency < 0 || a.MaxConcurrencyPerUser < 0 || a.MaxConcurrencyPerDatabase < 0 || a.MaxQueueSize < 0 {
+		return fmt.Errorf("max concurrency/queue size of admission cannot be negative")
+	}
+	if err := option.ValidateInterval(a.QueueTimeout, false); err != nil {
+		return fmt.Errorf("queue timeout of admission is invalid, err:%s", err)
+	}
+	return nil
+}
+
+// GetQueueTimeout returns the max wait time of query in the queue, returns 0 if no limit or invalid
+func (a Admission) GetQueueTimeout() time.Duration {
+	queueTimeout, _ := timeutil.ParseInterval(a.QueueTimeout)
+	return time.Duration(queueTimeout) * time.Millisecond
+}
+
+// LeafTask represents the config of leaf task which the broker sends to storage node
+type LeafTask struct {
+	// max wait time(like 30s) of leaf task's result, the leaf task is retried on the alternative replicas
+	// if exceeds, like the storage node hangs, empty means waiting until the query timeout
+	Timeout string `toml:"timeout"`
+}
+
+// Validation validates leaf task config if valid
+func (t LeafTask) Validation() error {
+	if err := option.ValidateInterval(t.Timeout, false); err != nil {
+		return fmt.Errorf("timeout of leaf task is invalid, err:%s", err)
+	}
+	return nil
+}
+
+// GetTimeout returns the max wait time of leaf task's result, returns 0 if no limit or invalid
+func (t LeafTask) GetTimeout() time.Duration {
+	timeout, _ := timeutil.ParseInterval(t.Timeout)
+	return time.Duration(timeout) * time.Millisecond
+}
+
+// Prometheus represents the config of prometheus compatible query api in broker
+type Prometheus struct {
+	// database which is queried if the db param is absent, like grafana's prometheus data source,
+	// empty means the db param is required
+	DefaultDatabase string `toml:"defaultDatabase"`
+}
+
 // NewDefaultBrokerCfg creates broker default config
 func NewDefaultBrokerCfg() Broker {
 	return Broker{
@@ -80,7 +230,27 @@ func NewDefaultBrokerCfg() Broker {
 				CheckFlushIntervalInSecond: 1,
 				FlushIntervalInSecond:      5,
 				BufferSizeLimit:            128 * 1024,
-			}},
+			},
+			Query: NewDefaultQueryLimit(),
+			SlowQuery: SlowQuery{
+				Threshold: "10s",
+			},
+			ResultCache: ResultCache{
+				BucketSize:    "1h",
+				WriteWindow:   "5m",
+				MaxMemorySize: 64 * 1024 * 1024,
+			},
+			Admission: Admission{
+				MaxConcurrency:            256,
+				MaxConcurrencyPerUser:     64,
+				MaxConcurrencyPerDatabase: 128,
+				MaxQueueSize:              1024,
+				QueueTimeout:              "30s",
+			},
+			LeafTask: LeafTask{
+				Timeout: "30s",
+			},
+		},
 		Logging: NewDefaultLoggingCfg(),
 	}
 }
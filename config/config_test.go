@@ -1,9 +1,99 @@
 package config
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func Test_NewConfig(t *testing.T) {
 	_ = NewDefaultBrokerCfg()
 	_ = NewDefaultStandaloneCfg()
 	_ = NewDefaultStorageCfg()
 }
+
+func TestSlowQuery_GetThreshold(t *testing.T) {
+	assert.Equal(t, 10*time.Second, NewDefaultBrokerCfg().SlowQuery.GetThreshold())
+	assert.Equal(t, time.Duration(0), SlowQuery{}.GetThreshold())
+	assert.Equal(t, time.Duration(0), SlowQuery{Threshold: "abc"}.GetThreshold())
+}
+
+func TestResultCache(t *testing.T) {
+	cfg := NewDefaultBrokerCfg().ResultCache
+	assert.Equal(t, time.Hour, cfg.GetBucketSize())
+	assert.Equal(t, 5*time.Minute, cfg.GetWriteWindow())
+	assert.Equal(t, time.Duration(0), ResultCache{}.GetBucketSize())
+	assert.Equal(t, time.Duration(0), ResultCache{WriteWindow: "abc"}.GetWriteWindow())
+}
+
+func TestAdmission_GetQueueTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, NewDefaultBrokerCfg().Admission.GetQueueTimeout())
+	assert.Equal(t, time.Duration(0), Admission{}.GetQueueTimeout())
+	assert.Equal(t, time.Duration(0), Admission{QueueTimeout: "abc"}.GetQueueTimeout())
+}
+
+func TestLeafTask_GetTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, NewDefaultBrokerCfg().LeafTask.GetTimeout())
+	assert.Equal(t, time.Duration(0), LeafTask{}.GetTimeout())
+}
+
+func TestTaskPayload_GetCompressThreshold(t *testing.T) {
+	assert.Equal(t, 1024, NewDefaultStorageCfg().TaskPayload.GetCompressThreshold())
+	assert.Equal(t, -1, TaskPayload{CompressThreshold: 1024}.GetCompressThreshold())
+}
+
+func TestBroker_Validation(t *testing.T) {
+	assert.NoError(t, NewDefaultBrokerCfg().Validation())
+	assert.NoError(t, BrokerKernel{}.Validation())
+
+	cfg := NewDefaultBrokerCfg()
+	cfg.Query.Timeout = "abc"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.SlowQuery.Threshold = "10"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.ResultCache.BucketSize = "1x"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.ResultCache.WriteWindow = "abc"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.ResultCache.MaxMemorySize = -1
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.Admission.MaxQueueSize = -1
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.Admission.QueueTimeout = "0s"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultBrokerCfg()
+	cfg.LeafTask.Timeout = "abc"
+	assert.Error(t, cfg.Validation())
+}
+
+func TestStorage_Validation(t *testing.T) {
+	assert.NoError(t, NewDefaultStorageCfg().Validation())
+
+	cfg := NewDefaultStorageCfg()
+	cfg.Query.MaxTimeRange = "abc"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultStorageCfg()
+	cfg.QueryPool.ScanWorkers = -1
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultStorageCfg()
+	cfg.TaskPayload.CompressThreshold = -1
+	assert.Error(t, cfg.Validation())
+}
+
+func TestStandalone_Validation(t *testing.T) {
+	assert.NoError(t, NewDefaultStandaloneCfg().Validation())
+
+	cfg := NewDefaultStandaloneCfg()
+	cfg.Broker.SlowQuery.Threshold = "abc"
+	assert.Error(t, cfg.Validation())
+	cfg = NewDefaultStandaloneCfg()
+	cfg.Storage.Query.Timeout = "abc"
+	assert.Error(t, cfg.Validation())
+}
@@ -1,5 +1,9 @@
 package config
 
+import (
+	"github.com/lindb/lindb/pkg/option"
+)
+
 // RepoState represents state repository config
 type RepoState struct {
 	Namespace   string   `toml:"namespace" json:"namespace"`
@@ -12,3 +16,13 @@ type StorageCluster struct {
 	Name   string    `json:"name"`
 	Config RepoState `json:"config"`
 }
+
+// NewDefaultQueryLimit creates the default global query limit
+func NewDefaultQueryLimit() option.QueryLimit {
+	return option.QueryLimit{
+		MaxSeries:    1000000,
+		MaxPoints:    100000000,
+		MaxTimeRange: "366d",
+		Timeout:      "1m",
+	}
+}
@@ -18,6 +18,14 @@ type ETCD struct {
 	URL string `toml:"url"`
 }
 
+// Validation validates the config of broker and storage if valid
+func (s Standalone) Validation() error {
+	if err := s.Broker.Validation(); err != nil {
+		return err
+	}
+	return s.Storage.Validation()
+}
+
 // NewDefaultStandaloneCfg creates define config of standalone mode
 func NewDefaultStandaloneCfg() Standalone {
 	return Standalone{
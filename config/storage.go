@@ -1,15 +1,33 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
+	"runtime"
+
+	"github.com/lindb/lindb/pkg/option"
 )
 
 // Storage represents a storage configuration
 type StorageKernel struct {
-	Coordinator RepoState   `toml:"coordinator"`
-	GRPC        GRPC        `toml:"grpc"`
-	Engine      Engine      `toml:"engine"`
-	Replication Replication `toml:"replication"`
+	Coordinator RepoState         `toml:"coordinator"`
+	GRPC        GRPC              `toml:"grpc"`
+	Engine      Engine            `toml:"engine"`
+	Replication Replication       `toml:"replication"`
+	Query       option.QueryLimit `toml:"query"`
+	QueryPool   QueryPool         `toml:"queryPool"`
+	TaskPayload TaskPayload       `toml:"taskPayload"`
+}
+
+// Validation validates storage config if valid, the invalid config fails the startup of storage
+func (s StorageKernel) Validation() error {
+	if err := s.Query.Validation(); err != nil {
+		return err
+	}
+	if err := s.QueryPool.Validation(); err != nil {
+		return err
+	}
+	return s.TaskPayload.Validation()
 }
 
 // Storage represents a storage configuration with common settings
@@ -34,6 +52,50 @@ type Engine struct {
 	Dir string `toml:"path"`
 }
 
+// QueryPool represents the bounded worker pools of leaf tasks and scanning in storage
+type QueryPool struct {
+	// num. of workers executing the leaf tasks, 0 means no limit(a goroutine for each task)
+	Workers int `toml:"workers"`
+	// max num. of leaf tasks waiting for worker, the task is rejected if the queue is full
+	QueueSize int `toml:"queueSize"`
+	// num. of workers scanning the shards and data families concurrently, shared by all leaf tasks,
+	// 0 means scanning sequentially in the leaf task
+	ScanWorkers int `toml:"scanWorkers"`
+}
+
+// Validation validates query pool config if valid
+func (p QueryPool) Validation() error {
+	if p.Workers < 0 || p.QueueSize < 0 || p.ScanWorkers < 0 {
+		return fmt.Errorf("workers/queue size of query pool cannot be negative")
+	}
+	return nil
+}
+
+// TaskPayload represents the config of the payload of leaf task's response which is sent to broker
+type TaskPayload struct {
+	// compresses the payload by snappy if true
+	Compress bool `toml:"compress"`
+	// min size(bytes) of payload which is compressed, the small payload isn't compressed,
+	// because the cost of compression is more than the saved bytes
+	CompressThreshold int `toml:"compressThreshold"`
+}
+
+// Validation validates task payload config if valid
+func (p TaskPayload) Validation() error {
+	if p.CompressThreshold < 0 {
+		return fmt.Errorf("compress threshold of task payload cannot be negative")
+	}
+	return nil
+}
+
+// GetCompressThreshold returns the min size of payload which is compressed, returns -1 if compression disabled
+func (p TaskPayload) GetCompressThreshold() int {
+	if !p.Compress {
+		return -1
+	}
+	return p.CompressThreshold
+}
+
 // NewDefaultStorageCfg creates storage define config
 func NewDefaultStorageCfg() Storage {
 	return Storage{
@@ -48,7 +110,16 @@ func NewDefaultStorageCfg() Storage {
 			Engine: Engine{
 				Dir: filepath.Join(defaultParentDir, "storage/data")},
 			Replication: Replication{
-				Dir: filepath.Join(defaultParentDir, "storage/replication")}},
+				Dir: filepath.Join(defaultParentDir, "storage/replication")},
+			Query: NewDefaultQueryLimit(),
+			QueryPool: QueryPool{
+				Workers:     64,
+				QueueSize:   1024,
+				ScanWorkers: runtime.NumCPU()},
+			TaskPayload: TaskPayload{
+				Compress:          true,
+				CompressThreshold: 1024,
+			}},
 		Logging: NewDefaultLoggingCfg(),
 	}
 }
@@ -24,6 +24,10 @@ type StatusStateMachine interface {
 	// and chooses the fastest replica if the shard has multi-replica.
 	// returns storage node => shard id list
 	GetQueryableReplicas(database string) map[string][]int32
+	// GetAlternativeReplicas returns the queryable replicas of the given shards excluding the given nodes,
+	// and chooses the fastest replica if the shard has multi-replica, the shard is absent if no alternative.
+	// returns storage node => shard id list
+	GetAlternativeReplicas(database string, shardIDs []int32, excludeNodes map[string]struct{}) map[string][]int32
 	// GetReplicas returns the replica state list under this broker by broker's indicator
 	GetReplicas(broker string) models.BrokerReplicaState
 	// Close closes state machine, stops watch change event
@@ -75,12 +79,41 @@ func NewStatusStateMachine(ctx context.Context, factory discovery.Factory) (Stat
 // GetQueryableReplicas returns the queryable replicas
 // returns storage node => shard id list
 func (sm *statusStateMachine) GetQueryableReplicas(database string) map[string][]int32 {
+	return sm.chooseReplicas(database, func(replica models.ReplicaState) bool {
+		return true
+	})
+}
+
+// GetAlternativeReplicas returns the queryable replicas of the given shards excluding the given nodes,
+// for retrying the shards of failed node.
+// returns storage node => shard id list
+func (sm *statusStateMachine) GetAlternativeReplicas(database string,
+	shardIDs []int32, excludeNodes map[string]struct{},
+) map[string][]int32 {
+	shardSet := make(map[int32]struct{})
+	for _, shardID := range shardIDs {
+		shardSet[shardID] = struct{}{}
+	}
+	return sm.chooseReplicas(database, func(replica models.ReplicaState) bool {
+		if _, ok := shardSet[replica.ShardID]; !ok {
+			return false
+		}
+		_, excluded := excludeNodes[replica.Target.Indicator()]
+		return !excluded
+	})
+}
+
+// chooseReplicas chooses the fastest replica of each shard from the replicas which match the filter
+// returns storage node => shard id list
+func (sm *statusStateMachine) chooseReplicas(database string,
+	filter func(replica models.ReplicaState) bool,
+) map[string][]int32 {
 	// 1. find shards by given database's name
 	shards := make(map[string][]models.ReplicaState)
 	sm.mutex.RLock()
 	for _, brokerReplicaState := range sm.brokers {
 		for _, replica := range brokerReplicaState.Replicas {
-			if replica.Database != database {
+			if replica.Database != database || !filter(replica) {
 				continue
 			}
 			shardID := replica.ShardIndicator()
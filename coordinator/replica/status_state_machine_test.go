@@ -129,6 +129,13 @@ func TestStatusStateMachine(t *testing.T) {
 	r = sm.GetQueryableReplicas("test_db_not_exist")
 	assert.Nil(t, r)
 
+	// chooses the alternative replicas excluding the failed node
+	r = sm.GetAlternativeReplicas("test_db", []int32{1}, map[string]struct{}{"1.1.1.3:2090": {}})
+	assert.Equal(t, map[string][]int32{"1.1.1.2:2090": {1}}, r)
+	r = sm.GetAlternativeReplicas("test_db", []int32{1, 2},
+		map[string]struct{}{"1.1.1.2:2090": {}, "1.1.1.3:2090": {}})
+	assert.Nil(t, r)
+
 	discovery1.EXPECT().Close()
 	err = sm.Close()
 	if err != nil {
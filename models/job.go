@@ -0,0 +1,32 @@
+package models
+
+// JobInfo represents the running distribution query job info on root node, for finding and killing the query
+type JobInfo struct {
+	JobID          int64    `json:"jobID"`          // job id allocated by root node
+	Database       string   `json:"database"`       // database name
+	SQL            string   `json:"sql"`            // query sql, empty if the query is translated from other query language
+	StartTime      int64    `json:"startTime"`      // start time(ms) of job
+	Nodes          []string `json:"nodes"`          // nodes' indicator involved in job, includes intermediate/leaf nodes
+	NumOfTask      int32    `json:"numOfTask"`      // num. of sub tasks that root node waits for
+	NumOfCompleted int32    `json:"numOfCompleted"` // num. of sub tasks completed
+}
+
+// NewJobInfo creates the job info based on physical plan, collects the nodes involved in job
+func NewJobInfo(jobID int64, sql string, plan *PhysicalPlan) *JobInfo {
+	info := &JobInfo{
+		JobID: jobID,
+		SQL:   sql,
+	}
+	if plan == nil {
+		return info
+	}
+	info.Database = plan.Database
+	info.NumOfTask = plan.Root.NumOfTask
+	for _, intermediate := range plan.Intermediates {
+		info.Nodes = append(info.Nodes, intermediate.Indicator)
+	}
+	for _, leaf := range plan.Leafs {
+		info.Nodes = append(info.Nodes, leaf.Indicator)
+	}
+	return info
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobInfo(t *testing.T) {
+	info := NewJobInfo(1, "select f from cpu", nil)
+	assert.Equal(t, &JobInfo{JobID: 1, SQL: "select f from cpu"}, info)
+
+	plan := NewPhysicalPlan(Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	plan.Database = "test_db"
+	plan.AddIntermediate(Intermediate{BaseNode: BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.4:8000"}, NumOfTask: 2})
+	plan.AddLeaf(Leaf{BaseNode: BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.1:9000"}})
+	plan.AddLeaf(Leaf{BaseNode: BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.2:9000"}})
+	info = NewJobInfo(2, "select f from cpu", plan)
+	assert.Equal(t, "test_db", info.Database)
+	assert.Equal(t, int32(1), info.NumOfTask)
+	assert.Equal(t, []string{"1.1.1.4:8000", "1.1.1.1:9000", "1.1.1.2:9000"}, info.Nodes)
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// PartialResult represents the incomplete part of query result, like the unavailable shards and the failed nodes,
+// the missing data of partial result isn't a real drop to zero.
+type PartialResult struct {
+	MissingShards []int32           `json:"missingShards,omitempty"` // shards of database without queryable replica
+	FailedNodes   map[string]string `json:"failedNodes,omitempty"`   // failed node => error message
+	Warnings      []string          `json:"warnings"`                // readable warnings of the incomplete part
+}
+
+// NewPartialResult creates the partial result with the missing shards and failed nodes,
+// returns nil if the result is complete
+func NewPartialResult(missingShards []int32, failedNodes map[string]string) *PartialResult {
+	if len(missingShards) == 0 && len(failedNodes) == 0 {
+		return nil
+	}
+	partial := &PartialResult{
+		MissingShards: missingShards,
+		FailedNodes:   failedNodes,
+	}
+	if len(missingShards) > 0 {
+		partial.Warnings = append(partial.Warnings, fmt.Sprintf("shards %v are unavailable", missingShards))
+	}
+	nodes := make([]string, 0, len(failedNodes))
+	for node := range failedNodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		partial.Warnings = append(partial.Warnings, fmt.Sprintf("node %s failed: %s", node, failedNodes[node]))
+	}
+	return partial
+}
+
+// failOnIncompleteKey represents the key of fail on incomplete flag in context
+type failOnIncompleteKey struct{}
+
+// WithFailOnIncomplete returns a copy of the context which fails the query if the result is incomplete,
+// otherwise the partial result is returned with warnings
+func WithFailOnIncomplete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, failOnIncompleteKey{}, true)
+}
+
+// FailOnIncompleteFromContext returns if the query fails when the result is incomplete
+func FailOnIncompleteFromContext(ctx context.Context) bool {
+	failOnIncomplete, _ := ctx.Value(failOnIncompleteKey{}).(bool)
+	return failOnIncomplete
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPartialResult(t *testing.T) {
+	assert.Nil(t, NewPartialResult(nil, nil))
+
+	partial := NewPartialResult([]int32{1, 3}, map[string]string{
+		"1.1.1.2:9000": "send task request error",
+		"1.1.1.1:9000": "not found database",
+	})
+	assert.Equal(t, []string{
+		"shards [1 3] are unavailable",
+		"node 1.1.1.1:9000 failed: not found database",
+		"node 1.1.1.2:9000 failed: send task request error",
+	}, partial.Warnings)
+}
+
+func TestFailOnIncompleteFromContext(t *testing.T) {
+	assert.False(t, FailOnIncompleteFromContext(context.TODO()))
+	assert.True(t, FailOnIncompleteFromContext(WithFailOnIncomplete(context.TODO())))
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"fmt"
+)
+
+// QueryPriority represents the priority of query in the admission queue of broker
+type QueryPriority int
+
+// Defines all priorities of query
+const (
+	// AdHocPriority is the default priority, like dashboard or explore queries
+	AdHocPriority QueryPriority = iota
+	// AlertingPriority is the priority of alerting queries, which preempt the ad-hoc queries
+	AlertingPriority
+)
+
+// String returns the name of query priority
+func (p QueryPriority) String() string {
+	switch p {
+	case AlertingPriority:
+		return "alerting"
+	default:
+		return "adhoc"
+	}
+}
+
+// ParseQueryPriority parses the query priority by name, empty means ad-hoc priority
+func ParseQueryPriority(name string) (QueryPriority, error) {
+	switch name {
+	case "", "adhoc":
+		return AdHocPriority, nil
+	case "alerting":
+		return AlertingPriority, nil
+	default:
+		return AdHocPriority, fmt.Errorf("unknown query priority: %s", name)
+	}
+}
+
+// queryPriorityKey represents the key of query priority in context
+type queryPriorityKey struct{}
+
+// WithQueryPriority returns a copy of the context with the priority of query
+func WithQueryPriority(ctx context.Context, priority QueryPriority) context.Context {
+	return context.WithValue(ctx, queryPriorityKey{}, priority)
+}
+
+// QueryPriorityFromContext returns the priority of query from the context, returns ad-hoc priority if not set
+func QueryPriorityFromContext(ctx context.Context) QueryPriority {
+	priority, _ := ctx.Value(queryPriorityKey{}).(QueryPriority)
+	return priority
+}
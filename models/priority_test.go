@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryPriority(t *testing.T) {
+	priority, err := ParseQueryPriority("")
+	assert.NoError(t, err)
+	assert.Equal(t, AdHocPriority, priority)
+	priority, err = ParseQueryPriority("adhoc")
+	assert.NoError(t, err)
+	assert.Equal(t, "adhoc", priority.String())
+	priority, err = ParseQueryPriority("alerting")
+	assert.NoError(t, err)
+	assert.Equal(t, AlertingPriority, priority)
+	assert.Equal(t, "alerting", priority.String())
+	_, err = ParseQueryPriority("abc")
+	assert.Error(t, err)
+}
+
+func TestQueryPriorityFromContext(t *testing.T) {
+	assert.Equal(t, AdHocPriority, QueryPriorityFromContext(context.TODO()))
+	assert.Equal(t, AlertingPriority, QueryPriorityFromContext(WithQueryPriority(context.TODO(), AlertingPriority)))
+}
@@ -0,0 +1,45 @@
+package models
+
+// ResultSet represents the result set of metric query, includes the metadata of query and the series of groups
+type ResultSet struct {
+	MetricName string    `json:"metricName"`         // metric name, joined by comma if cross-metric query
+	GroupBy    []string  `json:"groupBy,omitempty"`  // group by tag keys
+	Fields     []string  `json:"fields"`             // result field names of select list, alias if set
+	StartTime  int64     `json:"startTime"`          // start time of query time range
+	EndTime    int64     `json:"endTime"`            // end time of query time range
+	Interval   int64     `json:"interval"`           // down sampling interval of points
+	TimeZone   string    `json:"timeZone,omitempty"` // time zone of query, empty means local
+	Series     []*Series `json:"series"`             // series of each group
+
+	Partial *PartialResult `json:"partial,omitempty"` // set if the result is incomplete, like shards unavailable
+	Trace   *Trace         `json:"trace,omitempty"`   // span tree of query, set if requested
+}
+
+// NewResultSet creates the result set
+func NewResultSet() *ResultSet {
+	return &ResultSet{Series: []*Series{}}
+}
+
+// AddSeries adds the series of group into result set
+func (rs *ResultSet) AddSeries(series *Series) {
+	rs.Series = append(rs.Series, series)
+}
+
+// Series represents the series of group, includes the group tags and the points of each field
+type Series struct {
+	Tags   map[string]string            `json:"tags,omitempty"` // group tags, empty if no group by
+	Fields map[string]map[int64]float64 `json:"fields"`         // field name => timestamp => value
+}
+
+// NewSeries creates the series of group
+func NewSeries(tags map[string]string) *Series {
+	return &Series{
+		Tags:   tags,
+		Fields: make(map[string]map[int64]float64),
+	}
+}
+
+// AddField adds the points(timestamp => value) of field
+func (s *Series) AddField(fieldName string, points map[int64]float64) {
+	s.Fields[fieldName] = points
+}
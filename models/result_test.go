@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultSet(t *testing.T) {
+	rs := NewResultSet()
+	assert.Empty(t, rs.Series)
+	rs.MetricName = "cpu"
+	rs.GroupBy = []string{"host"}
+	rs.Fields = []string{"f"}
+	rs.StartTime = 1000
+	rs.EndTime = 2000
+	rs.Interval = 1000
+
+	s := NewSeries(map[string]string{"host": "1.1.1.1"})
+	s.AddField("f", map[int64]float64{1000: 10, 2000: 20})
+	rs.AddSeries(s)
+	assert.Len(t, rs.Series, 1)
+
+	data, err := json.Marshal(rs)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"metricName":"cpu","groupBy":["host"],"fields":["f"],"startTime":1000,"endTime":2000,`+
+		`"interval":1000,"series":[{"tags":{"host":"1.1.1.1"},"fields":{"f":{"1000":10,"2000":20}}}]}`, string(data))
+	rs2 := &ResultSet{}
+	err = json.Unmarshal(data, rs2)
+	assert.NoError(t, err)
+	assert.Equal(t, rs, rs2)
+}
@@ -0,0 +1,77 @@
+package models
+
+// QueryStats represents the query execution statistics for explain query,
+// includes the physical plan and the stats of each storage node
+type QueryStats struct {
+	PhysicalPlan *PhysicalPlan            `json:"physicalPlan"` // distribution query's physical plan
+	TotalCost    int64                    `json:"totalCost"`    // total cost(ns) of the query in broker side
+	StorageNodes map[string]*StorageStats `json:"storageNodes"` // storage node's indicator => storage stats
+}
+
+// NewQueryStats creates the query execution statistics with physical plan
+func NewQueryStats(physicalPlan *PhysicalPlan) *QueryStats {
+	return &QueryStats{
+		PhysicalPlan: physicalPlan,
+		StorageNodes: make(map[string]*StorageStats),
+	}
+}
+
+// MergeStorageStats merges the storage node's stats into query stats,
+// sums the stats if storage node's stats exist(cross-metric query executes a job for each metric)
+func (s *QueryStats) MergeStorageStats(nodeID string, stats *StorageStats) {
+	if stats == nil {
+		return
+	}
+	if exist, ok := s.StorageNodes[nodeID]; ok {
+		stats = mergeStorageStats(exist, stats)
+	}
+	s.StorageNodes[nodeID] = stats
+}
+
+// StorageStats represents the query execution statistics of storage node,
+// the shard level stats are only collected for explain query
+type StorageStats struct {
+	TotalCost   int64                 `json:"totalCost"`   // total cost(ns) of storage executor
+	PlanCost    int64                 `json:"planCost"`    // cost(ns) of storage execute plan
+	NumOfSeries uint64                `json:"numOfSeries"` // num. of series scanned by storage node
+	Shards      map[int32]*ShardStats `json:"shards"`      // shard id => shard stats
+}
+
+// NewStorageStats creates the query execution statistics of storage node
+func NewStorageStats() *StorageStats {
+	return &StorageStats{
+		Shards: make(map[int32]*ShardStats),
+	}
+}
+
+// ShardStats represents the query execution statistics of shard level
+type ShardStats struct {
+	SeriesFilterCost int64  `json:"seriesFilterCost"` // cost(ns) of filtering series ids by tag filter
+	NumOfSeries      uint64 `json:"numOfSeries"`      // num. of series matched by tag filter
+	ScanCost         int64  `json:"scanCost"`         // cost(ns) of scanning data families and aggregation
+	NumOfFamilies    int    `json:"numOfFamilies"`    // num. of data families scanned
+	NumOfPoints      int    `json:"numOfPoints"`      // num. of data points decoded
+}
+
+// mergeStorageStats returns the sum of two storage stats, doesn't modify the given stats
+func mergeStorageStats(stats1, stats2 *StorageStats) *StorageStats {
+	result := NewStorageStats()
+	for _, stats := range []*StorageStats{stats1, stats2} {
+		result.TotalCost += stats.TotalCost
+		result.PlanCost += stats.PlanCost
+		result.NumOfSeries += stats.NumOfSeries
+		for shardID, shardStats := range stats.Shards {
+			merged, ok := result.Shards[shardID]
+			if !ok {
+				merged = &ShardStats{}
+				result.Shards[shardID] = merged
+			}
+			merged.SeriesFilterCost += shardStats.SeriesFilterCost
+			merged.NumOfSeries += shardStats.NumOfSeries
+			merged.ScanCost += shardStats.ScanCost
+			merged.NumOfFamilies += shardStats.NumOfFamilies
+			merged.NumOfPoints += shardStats.NumOfPoints
+		}
+	}
+	return result
+}
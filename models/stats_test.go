@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryStats(t *testing.T) {
+	physicalPlan := NewPhysicalPlan(Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	stats := NewQueryStats(physicalPlan)
+	stats.MergeStorageStats("1.1.1.1:9000", nil)
+	assert.Empty(t, stats.StorageNodes)
+
+	storageStats := NewStorageStats()
+	storageStats.Shards[1] = &ShardStats{NumOfSeries: 10, NumOfFamilies: 2, NumOfPoints: 100}
+	stats.MergeStorageStats("1.1.1.1:9000", storageStats)
+	assert.Equal(t, storageStats, stats.StorageNodes["1.1.1.1:9000"])
+
+	// merges stats of same storage node
+	storageStats2 := NewStorageStats()
+	storageStats2.TotalCost = 10
+	storageStats2.Shards[1] = &ShardStats{NumOfSeries: 5, NumOfFamilies: 1, NumOfPoints: 50}
+	storageStats2.Shards[2] = &ShardStats{NumOfSeries: 1}
+	stats.MergeStorageStats("1.1.1.1:9000", storageStats2)
+	merged := stats.StorageNodes["1.1.1.1:9000"]
+	assert.Equal(t, int64(10), merged.TotalCost)
+	assert.Equal(t, &ShardStats{NumOfSeries: 15, NumOfFamilies: 3, NumOfPoints: 150}, merged.Shards[1])
+	assert.Equal(t, &ShardStats{NumOfSeries: 1}, merged.Shards[2])
+	// not modify the given stats
+	assert.Equal(t, uint64(10), storageStats.Shards[1].NumOfSeries)
+
+	data, _ := json.Marshal(stats)
+	stats1 := &QueryStats{}
+	_ = json.Unmarshal(data, stats1)
+	assert.Equal(t, stats, stats1)
+}
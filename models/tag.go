@@ -48,3 +48,24 @@ func TagsAsString(tags map[string]string) string {
 
 	return builder.String()
 }
+
+// TagsAsKeyValues converts tags to the string of key/value pairs order by key, like host=alpha,ip=1.1.1.1,
+// which can be parsed by NewTags.
+func TagsAsKeyValues(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for idx, key := range keys {
+		if idx > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(tags[key])
+	}
+	return builder.String()
+}
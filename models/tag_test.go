@@ -18,3 +18,11 @@ func TestTagsAsString(t *testing.T) {
 
 	assert.Equal(t, "t1v1t2v2", tagsStr)
 }
+
+func TestTagsAsKeyValues(t *testing.T) {
+	assert.Equal(t, "", TagsAsKeyValues(nil))
+
+	tagsStr := TagsAsKeyValues(map[string]string{"ip": "1.1.1.1", "host": "alpha"})
+	assert.Equal(t, "host=alpha,ip=1.1.1.1", tagsStr)
+	assert.Equal(t, []Tag{{Key: "host", Value: "alpha"}, {Key: "ip", Value: "1.1.1.1"}}, NewTags(tagsStr))
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Trace represents the trace of distributed query, the trace id is propagated to all nodes of query,
+// each node records the spans of its operations, then returns the span tree to the parent node
+type Trace struct {
+	TraceID string `json:"traceID"`
+	Root    *Span  `json:"root"`
+}
+
+// NewTrace creates the trace of query with a random trace id
+func NewTrace(name string) *Trace {
+	return NewTraceWithID(newTraceID(), name)
+}
+
+// NewTraceWithID creates the trace with the trace id propagated from the parent node
+func NewTraceWithID(traceID string, name string) *Trace {
+	return &Trace{
+		TraceID: traceID,
+		Root:    NewSpan(name),
+	}
+}
+
+// StartSpan starts a span under the root span, returns nil if the query isn't traced
+func (t *Trace) StartSpan(name string) *Span {
+	if t == nil {
+		return nil
+	}
+	return t.Root.StartChild(name)
+}
+
+// ID returns the trace id, returns empty if the query isn't traced
+func (t *Trace) ID() string {
+	if t == nil {
+		return ""
+	}
+	return t.TraceID
+}
+
+// newTraceID generates a random trace id
+func newTraceID() string {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id[:])
+}
+
+// Span represents a timed operation of query, like planning, task send, shard search and family scan,
+// all methods are safe for nil span, so the operations of query without trace skip recording.
+type Span struct {
+	Name      string            `json:"name"`
+	Node      string            `json:"node,omitempty"` // indicator of the node which records the span
+	StartTime int64             `json:"startTime"`      // start time(unix ns)
+	Duration  int64             `json:"duration"`       // cost(ns), 0 if not finished
+	Tags      map[string]string `json:"tags,omitempty"`
+	Children  []*Span           `json:"children,omitempty"`
+
+	mutex sync.Mutex
+}
+
+// NewSpan creates and starts the span
+func NewSpan(name string) *Span {
+	return &Span{
+		Name:      name,
+		StartTime: time.Now().UnixNano(),
+	}
+}
+
+// StartChild creates and starts the child span, returns nil if the span is nil
+func (s *Span) StartChild(name string) *Span {
+	if s == nil {
+		return nil
+	}
+	child := NewSpan(name)
+	s.AddChildren(child)
+	return child
+}
+
+// AddChildren adds the child spans, like the spans returned by the sub tasks
+func (s *Span) AddChildren(children ...*Span) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Children = append(s.Children, children...)
+}
+
+// SetNode sets the node which records the span
+func (s *Span) SetNode(node string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Node = node
+}
+
+// SetTag sets the tag of span, like shard id, num. of series
+func (s *Span) SetTag(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.Tags == nil {
+		s.Tags = make(map[string]string)
+	}
+	s.Tags[key] = value
+}
+
+// Finish finishes the span, records the duration of span
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.Duration == 0 {
+		s.Duration = time.Now().UnixNano() - s.StartTime
+	}
+}
+
+// traceKey represents the key of trace in context
+type traceKey struct{}
+
+// WithTrace returns a copy of the context with the trace of query
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// TraceFromContext returns the trace of query from the context, returns nil if the query isn't traced
+func TraceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
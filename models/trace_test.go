@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestTrace(t *testing.T) {
+	trace := NewTrace("query")
+	assert.Len(t, trace.ID(), 16)
+	assert.NotEqual(t, trace.ID(), NewTrace("query").ID())
+	span := trace.StartSpan("plan")
+	span.SetNode("1.1.1.1:9000")
+	span.SetTag("metric", "cpu")
+	span.Finish()
+	assert.True(t, span.Duration > 0)
+	duration := span.Duration
+	// finish twice
+	span.Finish()
+	assert.Equal(t, duration, span.Duration)
+
+	child := NewTraceWithID(trace.ID(), "leaf task")
+	assert.Equal(t, trace.ID(), child.ID())
+	child.Root.Finish()
+	span.AddChildren(child.Root)
+
+	var result Trace
+	assert.NoError(t, encoding.JSONUnmarshal(encoding.JSONMarshal(trace), &result))
+	assert.Equal(t, trace.ID(), result.TraceID)
+	assert.Equal(t, "query", result.Root.Name)
+	assert.Equal(t, "plan", result.Root.Children[0].Name)
+	assert.Equal(t, "1.1.1.1:9000", result.Root.Children[0].Node)
+	assert.Equal(t, map[string]string{"metric": "cpu"}, result.Root.Children[0].Tags)
+	assert.Equal(t, "leaf task", result.Root.Children[0].Children[0].Name)
+}
+
+func TestTrace_Nil(t *testing.T) {
+	var trace *Trace
+	assert.Empty(t, trace.ID())
+	span := trace.StartSpan("plan")
+	assert.Nil(t, span)
+	assert.Nil(t, span.StartChild("child"))
+	span.SetNode("1.1.1.1:9000")
+	span.SetTag("metric", "cpu")
+	span.AddChildren(NewSpan("child"))
+	span.Finish()
+}
+
+func TestTraceFromContext(t *testing.T) {
+	assert.Nil(t, TraceFromContext(context.TODO()))
+	trace := NewTrace("query")
+	assert.Equal(t, trace, TraceFromContext(WithTrace(context.TODO(), trace)))
+}
@@ -0,0 +1,17 @@
+package models
+
+import "context"
+
+// userKey represents the key of user name in context
+type userKey struct{}
+
+// WithUser returns a copy of the context with the user name, which is authenticated by broker
+func WithUser(ctx context.Context, userName string) context.Context {
+	return context.WithValue(ctx, userKey{}, userName)
+}
+
+// UserFromContext returns the user name from the context, returns empty if not authenticated
+func UserFromContext(ctx context.Context) string {
+	userName, _ := ctx.Value(userKey{}).(string)
+	return userName
+}
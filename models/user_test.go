@@ -0,0 +1,13 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFromContext(t *testing.T) {
+	assert.Empty(t, UserFromContext(context.TODO()))
+	assert.Equal(t, "admin", UserFromContext(WithUser(context.TODO(), "admin")))
+}
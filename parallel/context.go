@@ -1,40 +1,267 @@
 package parallel
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/series"
 )
 
+//go:generate mockgen -source=./context.go -destination=./context_mock.go -package=parallel
+
 type TaskType int
 
 const (
 	RootTask TaskType = iota + 1
 	IntermediateTask
+	// RetryTask is the sub task of root task, which retries the failed leaf tasks on the alternative replicas
+	RetryTask
 )
 
 type JobContext interface {
+	// Context returns the context of the job, which is done if the job is canceled or completed
+	Context() context.Context
 	Plan() *models.PhysicalPlan
+	// Query returns the query statement of the job
+	Query() *stmt.Query
+	// SQL returns the query sql of the job, empty if the query is translated from other query language
+	SQL() string
+	// StartTime returns the start time of the job
+	StartTime() time.Time
+	// ReceiveResult marks a sub task of the job completed, for tracking the progress of job
+	ReceiveResult()
+	// NumOfCompleted returns the num. of sub tasks completed
+	NumOfCompleted() int32
+	// ReceiveStats merges the execution statistics of storage node
+	ReceiveStats(nodeID string, stats *models.StorageStats)
+	// ReceivePayload merges the grouped series of sub task's result, which are emitted when the job completed
+	ReceivePayload(payload []byte) error
+	// ReceiveFailure records the node which fails to execute the task, the result of job is incomplete
+	ReceiveFailure(nodeID string, errMsg string)
+	// Failures returns the failed nodes of the job, node => error message
+	Failures() map[string]string
+	// ReceiveError records the failure of query reported by the sub task, like exceeding the limit or timeout,
+	// the job fails and is canceled
+	ReceiveError(errMsg string)
+	// Error returns the first failure of query reported by the sub tasks, nil if no failure
+	Error() error
+	// Statistics returns the execution statistics of the job, the shard level stats only for explain query
+	Statistics() *models.QueryStats
+	// Span returns the span of the job under the trace of query, returns nil if the query isn't traced
+	Span() *models.Span
+	// ReceiveSpans adds the spans recorded by the sub tasks under the span of the job
+	ReceiveSpans(spans []byte)
+	// Complete completes the job, closes the result set, it's idempotent for job completed and canceled
 	Complete()
+	// Completed returns if the job is completed
+	Completed() bool
+	// Kill kills the job, the job context is done, then the job is canceled by job manager
+	Kill()
+	// Killed returns if the job is killed
+	Killed() bool
 }
 
 type jobContext struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
 	resultSet chan series.GroupedIterator
 	plan      *models.PhysicalPlan
+	query     *stmt.Query
+	sql       string
+	startTime time.Time
+
+	stats    *models.QueryStats
+	span     *models.Span // nil if the query isn't traced
+	failures map[string]string
+	err      error
+	merger   *resultMerger
+	mutex    sync.Mutex
+
+	numOfCompleted int32
+	completed      int32
+	killed         int32
+	completeOnce   sync.Once
+}
+
+// NewJobContext creates the job context, the job is canceled if the parent context is done before completed,
+// records the span of the job if the query is traced
+func NewJobContext(parent context.Context, resultSet chan series.GroupedIterator,
+	plan *models.PhysicalPlan, query *stmt.Query, sql string) JobContext {
+	ctx, cancel := context.WithCancel(parent)
+	span := models.TraceFromContext(parent).StartSpan("job")
+	if query != nil {
+		span.SetTag("metric", query.MetricName)
+	}
+	jobCtx := &jobContext{
+		ctx:       ctx,
+		cancel:    cancel,
+		resultSet: resultSet,
+		plan:      plan,
+		query:     query,
+		sql:       sql,
+		startTime: time.Now(),
+		stats:     models.NewQueryStats(plan),
+		merger:    newResultMerger(nil),
+		span:      span,
+	}
+	return jobCtx
 }
 
-func NewJobContext(resultSet chan series.GroupedIterator, plan *models.PhysicalPlan) JobContext {
-	return &jobContext{resultSet: resultSet, plan: plan}
+// Context returns the context of the job, which is done if the job is canceled or completed
+func (c *jobContext) Context() context.Context {
+	return c.ctx
 }
 
 func (c *jobContext) Plan() *models.PhysicalPlan {
 	return c.plan
 }
 
+// Query returns the query statement of the job
+func (c *jobContext) Query() *stmt.Query {
+	return c.query
+}
+
+// SQL returns the query sql of the job, empty if the query is translated from other query language
+func (c *jobContext) SQL() string {
+	return c.sql
+}
+
+// StartTime returns the start time of the job
+func (c *jobContext) StartTime() time.Time {
+	return c.startTime
+}
+
+// ReceiveResult marks a sub task of the job completed, for tracking the progress of job
+func (c *jobContext) ReceiveResult() {
+	atomic.AddInt32(&c.numOfCompleted, 1)
+}
+
+// NumOfCompleted returns the num. of sub tasks completed
+func (c *jobContext) NumOfCompleted() int32 {
+	return atomic.LoadInt32(&c.numOfCompleted)
+}
+
+// ReceiveStats merges the execution statistics of storage node
+func (c *jobContext) ReceiveStats(nodeID string, stats *models.StorageStats) {
+	if c.stats == nil {
+		return
+	}
+	c.mutex.Lock()
+	c.stats.MergeStorageStats(nodeID, stats)
+	c.mutex.Unlock()
+}
+
+// ReceivePayload merges the grouped series of sub task's result, which are emitted when the job completed
+func (c *jobContext) ReceivePayload(payload []byte) error {
+	return c.merger.mergePayload(payload)
+}
+
+// ReceiveFailure records the node which fails to execute the task, the result of job is incomplete
+func (c *jobContext) ReceiveFailure(nodeID string, errMsg string) {
+	c.mutex.Lock()
+	if c.failures == nil {
+		c.failures = make(map[string]string)
+	}
+	c.failures[nodeID] = errMsg
+	c.mutex.Unlock()
+}
+
+// Failures returns the failed nodes of the job, node => error message
+func (c *jobContext) Failures() map[string]string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	failures := make(map[string]string, len(c.failures))
+	for nodeID, errMsg := range c.failures {
+		failures[nodeID] = errMsg
+	}
+	return failures
+}
+
+// ReceiveError records the failure of query reported by the sub task, like exceeding the limit or timeout,
+// the job fails with the first failure, then it's canceled because the result is useless
+func (c *jobContext) ReceiveError(errMsg string) {
+	c.mutex.Lock()
+	if c.err == nil {
+		c.err = errors.New(errMsg)
+	}
+	c.mutex.Unlock()
+	c.cancel()
+}
+
+// Error returns the first failure of query reported by the sub tasks, nil if no failure
+func (c *jobContext) Error() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// Statistics returns the execution statistics of the job, the shard level stats only for explain query
+func (c *jobContext) Statistics() *models.QueryStats {
+	return c.stats
+}
+
+// Span returns the span of the job under the trace of query, returns nil if the query isn't traced
+func (c *jobContext) Span() *models.Span {
+	return c.span
+}
+
+// ReceiveSpans adds the spans recorded by the sub tasks under the span of the job,
+// the invalid spans are ignored, because the trace is only for diagnosis
+func (c *jobContext) ReceiveSpans(spans []byte) {
+	if c.span == nil {
+		return
+	}
+	if subSpans, err := decodeSpans(spans); err == nil {
+		c.span.AddChildren(subSpans...)
+	}
+}
+
+// Complete completes the job, emits the merged groups then closes the result set,
+// it's idempotent for job completed and canceled
 func (c *jobContext) Complete() {
-	//TODO send result
-	close(c.resultSet)
+	c.completeOnce.Do(func() {
+		atomic.StoreInt32(&c.completed, 1)
+		if c.resultSet != nil {
+			aggSpan := c.span.StartChild("aggregation")
+			c.emitResultSet()
+			aggSpan.Finish()
+			close(c.resultSet)
+		}
+		c.span.Finish()
+		c.cancel()
+	})
+}
+
+// emitResultSet emits the merged groups into the result set, stops if the job is canceled
+func (c *jobContext) emitResultSet() {
+	for _, it := range c.merger.resultSet() {
+		select {
+		case c.resultSet <- it:
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// Completed returns if the job is completed
+func (c *jobContext) Completed() bool {
+	return atomic.LoadInt32(&c.completed) == 1
+}
+
+// Kill kills the job, the job context is done, then the job is canceled by job manager
+func (c *jobContext) Kill() {
+	atomic.StoreInt32(&c.killed, 1)
+	c.cancel()
+}
+
+// Killed returns if the job is killed
+func (c *jobContext) Killed() bool {
+	return atomic.LoadInt32(&c.killed) == 1
 }
 
 // TaskContext represents the task context for distribution query and computing
@@ -47,8 +274,11 @@ type TaskContext interface {
 	ParentNode() string
 	// ParentTaskID returns the parent node's task id for tracking task
 	ParentTaskID() string
-	// ReceiveResult marks receive result, decreases the num. of task tracking
-	ReceiveResult()
+	// ReceiveResult marks receive result, decreases the num. of task tracking,
+	// returns true if all sub tasks completed by this result
+	ReceiveResult() bool
+	// AddSubTask increases the num. of task tracking for the sub task added after submitted, like retry task
+	AddSubTask()
 	// Completed returns if the task is completes
 	Completed() bool
 }
@@ -96,12 +326,19 @@ func (c *taskContext) TaskID() string {
 }
 
 // ReceiveResult marks receive result, decreases the num. of task tracking,
-// if no pending task marks this task completed
-func (c *taskContext) ReceiveResult() {
+// if no pending task marks this task completed, returns true only for the last result
+func (c *taskContext) ReceiveResult() bool {
 	pendingTask := atomic.AddInt32(&c.expectResults, -1)
 	if pendingTask == 0 {
 		c.completed = true
+		return true
 	}
+	return false
+}
+
+// AddSubTask increases the num. of task tracking for the sub task added after submitted, like retry task
+func (c *taskContext) AddSubTask() {
+	atomic.AddInt32(&c.expectResults, 1)
 }
 
 // Completed returns if the task is completes
@@ -3,7 +3,32 @@ package parallel
 import "errors"
 
 var errUnmarshalPlan = errors.New("unmarshal physical plan error")
+var errUnmarshalQuery = errors.New("unmarshal query statement error")
 var errWrongRequest = errors.New("not found task of current node from physical plan")
 var errNoSendStream = errors.New("not found send stream")
 var errTaskSend = errors.New("send task request error")
 var errNoDatabase = errors.New("not found database")
+var errNoShard = errors.New("not found shard")
+var errTooManyTasks = errors.New("too many leaf tasks, the task queue is full")
+var errLeafTimeout = errors.New("no result of leaf task within the timeout")
+var errIntermediateTimeout = errors.New("no result of intermediate task within the timeout")
+
+// unavailableErrors are the failures of the node which is unavailable for the task, not the failure of query,
+// like node down or hangs, shard not loaded or task queue full, only the result of job is incomplete
+var unavailableErrors = map[string]struct{}{
+	errNoSendStream.Error(): {},
+	errTaskSend.Error():     {},
+	errNoDatabase.Error():   {},
+	errNoShard.Error():      {},
+	errTooManyTasks.Error(): {},
+	errLeafTimeout.Error():  {},
+
+	errIntermediateTimeout.Error(): {},
+}
+
+// isUnavailable returns if the error message of task is the failure of unavailable node,
+// else it's the failure of query, like exceeding the limit or timeout, which fails the job
+func isUnavailable(errMsg string) bool {
+	_, ok := unavailableErrors[errMsg]
+	return ok
+}
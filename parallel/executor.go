@@ -1,6 +1,7 @@
 package parallel
 
 import (
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/tsdb/series"
 )
 
@@ -24,3 +25,38 @@ type Executor interface {
 	// Error returns the execution error
 	Error() error
 }
+
+// StorageExecutor represents the query executor in storage side
+type StorageExecutor interface {
+	Executor
+
+	// Statistics returns the execution statistics of storage node, the shard level stats only for explain query
+	Statistics() *models.StorageStats
+}
+
+// BrokerExecutor represents the query executor in broker side
+type BrokerExecutor interface {
+	Executor
+
+	// Statistics returns the execution statistics of the query, returns nil if not explain query
+	Statistics() *models.QueryStats
+	// Partial returns the incomplete part of the query result, like the unavailable shards and the failed nodes,
+	// returns nil if the result is complete
+	Partial() *models.PartialResult
+	// ResultSet drains the results of execution, returns the result set which evaluates the select list
+	// of each group, returns nil if execute failure
+	ResultSet() *models.ResultSet
+	// StreamResultSet streams the results of execution to the writer, writes each group as soon as it's emitted,
+	// keeps draining the results if writer fails, returns the error of writer
+	StreamResultSet(writer ResultSetWriter) error
+	// Trace returns the span tree of the query if requested by client, returns nil if not requested
+	Trace() *models.Trace
+}
+
+// ResultSetWriter represents the writer which writes the result set of query in streaming
+type ResultSetWriter interface {
+	// WriteMeta writes the metadata of result set(without series) before all series
+	WriteMeta(resultSet *models.ResultSet) error
+	// WriteSeries writes the series of a group
+	WriteSeries(series *models.Series) error
+}
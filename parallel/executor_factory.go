@@ -1,6 +1,8 @@
 package parallel
 
 import (
+	"context"
+
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/sql/stmt"
@@ -12,9 +14,14 @@ import (
 // ExecutorFactory represents the executor factory that creates storage/broker executor
 type ExecutorFactory interface {
 	// NewStorageExecutor creates the storage executor based on params
-	NewStorageExecutor(engine tsdb.Engine, shardIDs []int32, query *stmt.Query) Executor
+	NewStorageExecutor(ctx context.Context, engine tsdb.Engine, shardIDs []int32, query *stmt.Query) StorageExecutor
 	// NewBrokerExecutor creates the broker executor based on params
-	NewBrokerExecutor(database string, sql string,
+	NewBrokerExecutor(ctx context.Context, database string, sql string,
+		replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+		jobManager JobManager) BrokerExecutor
+	// NewBrokerQueryExecutor creates the broker executor based on the parsed query statement,
+	// like the query translated from other query language
+	NewBrokerQueryExecutor(ctx context.Context, database string, query *stmt.Query,
 		replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
-		jobManager JobManager) Executor
+		jobManager JobManager) BrokerExecutor
 }
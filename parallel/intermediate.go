@@ -1,87 +1,365 @@
 package parallel
 
 import (
+	"sync"
+	"time"
+
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
 	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 // intermediateTask represents the intermediate node's task,
 // 1. only created for group by query
 // 2. exchanges leaf task
-// 3. receives leaf task's result
+// 3. receives leaf task's result, merges the grouped series of leaf nodes
+// 4. sends a single reduced result to parent node when all leaf tasks completed, with the stats of leaf nodes
+// 5. reports the leaf task as failed if no result within the leaf timeout, then the parent retries it
 type intermediateTask struct {
 	curNode     models.Node
 	curNodeID   string
 	taskManager TaskManager
+	leafTimeout time.Duration // max wait time of leaf task's result, 0 means waiting until the task done
+
+	mutex     sync.Mutex
+	deadlines map[leafDeadlineKey]*leafDeadline // leaf tasks sent by current node => deadline of result
+
+	tasks   sync.Map // parent task id => task id of current node, for canceling the task
+	results sync.Map // task id of current node => *resultMerger, merges the results of leaf nodes
+	stats   sync.Map // task id of current node => *leafStats, collects the stats of leaf nodes
+	traces  sync.Map // task id of current node => *models.Trace, only for the traced query
 }
 
-// newIntermediateTask creates the intermediate task
-func newIntermediateTask(curNode models.Node, taskManger TaskManager) *intermediateTask {
+// newIntermediateTask creates the intermediate task, the leaf task is treated as failed if no result within
+// the leaf timeout, like the storage node hangs
+func newIntermediateTask(curNode models.Node, taskManger TaskManager, leafTimeout time.Duration) *intermediateTask {
 	return &intermediateTask{
 		curNode:     curNode,
 		curNodeID:   (&curNode).Indicator(),
 		taskManager: taskManger,
+		leafTimeout: leafTimeout,
+		deadlines:   make(map[leafDeadlineKey]*leafDeadline),
 	}
 }
 
 // Process processes the task request, sends task request to leaf nodes based on physical plan,
-// and tracks the task state
+// and tracks the task state, if cancel request, cancels the task and the leaf tasks
 func (p *intermediateTask) Process(req *pb.TaskRequest) error {
 	physicalPlan := models.PhysicalPlan{}
 	if err := encoding.JSONUnmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
 	}
-	taskSubmitted := false
+	if req.RequestType == pb.RequestType_Cancel {
+		p.cancel(physicalPlan, req)
+		return nil
+	}
+	var taskCtx TaskContext
 	for _, intermediate := range physicalPlan.Intermediates {
 		if intermediate.Indicator == p.curNodeID {
 			taskID := p.taskManager.AllocTaskID()
 			//TODO set task id
-			taskCtx := newTaskContext(taskID, IntermediateTask, req.ParentTaskID, intermediate.Parent, intermediate.NumOfTask)
+			taskCtx = newTaskContext(taskID, IntermediateTask, req.ParentTaskID, intermediate.Parent, intermediate.NumOfTask)
 			p.taskManager.Submit(taskCtx)
-			taskSubmitted = true
+			p.tasks.Store(req.ParentTaskID, taskID)
+			p.results.Store(taskID, newResultMerger(newTopKPruner(decodeQuery(req.Payload))))
+			p.stats.Store(taskID, newLeafStats())
+			if req.TraceID != "" {
+				trace := models.NewTraceWithID(req.TraceID, "intermediate task")
+				trace.Root.SetNode(p.curNodeID)
+				p.traces.Store(taskID, trace)
+			}
 			break
 		}
 	}
-	if !taskSubmitted {
+	if taskCtx == nil {
 		return errWrongRequest
 	}
+	return p.sendLeafTasks(taskCtx, physicalPlan, req)
+}
 
-	if err := p.sendLeafTasks(physicalPlan, req); err != nil {
-		return err
+// sendLeafTasks sends the task request to the related leaf nodes,
+// if fails to send, reports the failed leaf node to parent node as the leaf task is completed with error
+func (p *intermediateTask) sendLeafTasks(taskCtx TaskContext, physicalPlan models.PhysicalPlan, req *pb.TaskRequest) error {
+	trace := p.taskTrace(taskCtx.TaskID())
+	for _, leaf := range physicalPlan.Leafs {
+		if leaf.Parent == p.curNodeID {
+			sendSpan := trace.StartSpan("send task")
+			sendSpan.SetTag("node", leaf.Indicator)
+			p.watchLeaf(taskCtx, req, leaf.Indicator)
+			err := p.taskManager.SendRequest(leaf.Indicator, req)
+			sendSpan.Finish()
+			if err != nil {
+				p.receiveLeaf(taskCtx.TaskID(), leaf.Indicator)
+				if err := p.receive(taskCtx, &pb.TaskResponse{
+					JobID:     req.JobID,
+					TaskID:    taskCtx.TaskID(),
+					Completed: true,
+					ErrMsg:    err.Error(),
+					SendNode:  leaf.Indicator,
+				}); err != nil {
+					return err
+				}
+			}
+		}
 	}
 	return nil
 }
 
-// sendLeafTasks sends the task request to the related leaf nodes, if failure return error
-func (p *intermediateTask) sendLeafTasks(physicalPlan models.PhysicalPlan, req *pb.TaskRequest) error {
+// cancel cancels the task of current node, and sends the cancel request to the related leaf nodes
+func (p *intermediateTask) cancel(physicalPlan models.PhysicalPlan, req *pb.TaskRequest) {
+	var leafs []string
 	for _, leaf := range physicalPlan.Leafs {
 		if leaf.Parent == p.curNodeID {
-			if err := p.taskManager.SendRequest(leaf.Indicator, req); err != nil {
-				//TODO kill sent leaf task???
-				return err
-			}
+			leafs = append(leafs, leaf.Indicator)
 		}
 	}
-	return nil
+	p.cancelLeafTasks(leafs, req)
+	p.completeTask(req.ParentTaskID)
+}
+
+// cancelLeafTasks sends the cancel request to the leaf nodes
+func (p *intermediateTask) cancelLeafTasks(leafs []string, req *pb.TaskRequest) {
+	cancelReq := newCancelRequest(req)
+	for _, leaf := range leafs {
+		if err := p.taskManager.SendRequest(leaf, cancelReq); err != nil {
+			log.Warn("send cancel task request error",
+				logger.String("target", leaf), logger.Int64("jobID", req.JobID), logger.Error(err))
+		}
+	}
+}
+
+// decodeQuery decodes the query of task request, returns nil if failure
+func decodeQuery(payload []byte) *stmt.Query {
+	query := &stmt.Query{}
+	if err := encoding.JSONUnmarshal(payload, query); err != nil {
+		return nil
+	}
+	return query
+}
+
+// completeTask completes the task of current node by parent task id
+func (p *intermediateTask) completeTask(parentTaskID string) {
+	taskID, ok := p.tasks.Load(parentTaskID)
+	if !ok {
+		return
+	}
+	p.tasks.Delete(parentTaskID)
+	p.stopLeafDeadlines(taskID.(string))
+	p.results.Delete(taskID)
+	p.stats.Delete(taskID)
+	p.traces.Delete(taskID)
+	p.taskManager.Complete(taskID.(string))
 }
 
-// Receive receives the sub task's result, and merges the results
+// Receive receives the sub task's result, and merges the results,
+// the result of timeout leaf task is ignored, because the leaf task has been reported as failed
 func (p *intermediateTask) Receive(resp *pb.TaskResponse) error {
 	taskID := resp.TaskID
 	taskCtx := p.taskManager.Get(taskID)
 	if taskCtx == nil {
 		return nil
 	}
-	//TODO impl result handler
-	taskCtx.ReceiveResult()
+	if resp.Completed && !p.receiveLeaf(taskID, resp.SendNode) {
+		return nil
+	}
+	return p.receive(taskCtx, resp)
+}
+
+// watchLeaf sets the deadline of the leaf task's result before sending, if no result within the leaf timeout,
+// cancels the leaf task and reports the timeout to parent node as the leaf task is completed with error,
+// then the parent retries the leaf task on the alternative replicas
+func (p *intermediateTask) watchLeaf(taskCtx TaskContext, req *pb.TaskRequest, leafNode string) {
+	if p.leafTimeout <= 0 {
+		return
+	}
+	taskID := taskCtx.TaskID()
+	timer := time.AfterFunc(p.leafTimeout, func() {
+		if !p.expireLeaf(taskID, leafNode) || p.taskManager.Get(taskID) == nil {
+			return
+		}
+		log.Warn("leaf task of intermediate node is timeout", logger.Int64("jobID", req.JobID),
+			logger.String("taskID", taskID), logger.String("leafNode", leafNode))
+		p.cancelLeafTasks([]string{leafNode}, req)
+		if err := p.receive(taskCtx, &pb.TaskResponse{
+			JobID:     req.JobID,
+			TaskID:    taskID,
+			Completed: true,
+			ErrMsg:    errLeafTimeout.Error(),
+			SendNode:  leafNode,
+		}); err != nil {
+			log.Warn("report timeout leaf task error", logger.Int64("jobID", req.JobID), logger.Error(err))
+		}
+	})
+	p.mutex.Lock()
+	p.deadlines[leafDeadlineKey{taskID: taskID, leafNode: leafNode}] = &leafDeadline{timer: timer}
+	p.mutex.Unlock()
+}
+
+// receiveLeaf removes the deadline of leaf task if the result received before expired,
+// returns false if the deadline expired, true if no deadline
+func (p *intermediateTask) receiveLeaf(taskID string, leafNode string) bool {
+	key := leafDeadlineKey{taskID: taskID, leafNode: leafNode}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	deadline, ok := p.deadlines[key]
+	if !ok {
+		return true
+	}
+	if deadline.expired {
+		return false
+	}
+	deadline.timer.Stop()
+	delete(p.deadlines, key)
+	return true
+}
+
+// expireLeaf marks the deadline of leaf task expired, returns false if the result has been received
+func (p *intermediateTask) expireLeaf(taskID string, leafNode string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	deadline, ok := p.deadlines[leafDeadlineKey{taskID: taskID, leafNode: leafNode}]
+	if !ok || deadline.expired {
+		return false
+	}
+	deadline.expired = true
+	return true
+}
+
+// stopLeafDeadlines stops the deadlines of the leaf tasks when the task done
+func (p *intermediateTask) stopLeafDeadlines(taskID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for key, deadline := range p.deadlines {
+		if key.taskID == taskID {
+			deadline.timer.Stop()
+			delete(p.deadlines, key)
+		}
+	}
+}
+
+// receive receives the leaf task's result, merges the result of leaf node,
+// forwards the failure of leaf node to parent node,
+// if all leaf tasks completed, sends the merged result to parent node
+func (p *intermediateTask) receive(taskCtx TaskContext, resp *pb.TaskResponse) error {
+	errMsg := resp.ErrMsg
+	if len(errMsg) == 0 && len(resp.Payload) > 0 {
+		if err := p.mergeResult(taskCtx.TaskID(), resp.Payload); err != nil {
+			errMsg = err.Error()
+		}
+	}
+	if len(resp.Stats) > 0 {
+		p.mergeStats(taskCtx.TaskID(), resp.SendNode, resp.Stats)
+	}
+	if trace := p.taskTrace(taskCtx.TaskID()); trace != nil {
+		if spans, err := decodeSpans(resp.Spans); err == nil {
+			trace.Root.AddChildren(spans...)
+		}
+	}
+	if len(errMsg) > 0 {
+		if err := p.taskManager.SendResponse(taskCtx.ParentNode(), &pb.TaskResponse{
+			JobID:    resp.JobID,
+			TaskID:   taskCtx.ParentTaskID(),
+			ErrMsg:   errMsg,
+			SendNode: resp.SendNode,
+		}); err != nil {
+			return err
+		}
+	}
 
-	if taskCtx.Completed() {
-		p.taskManager.Complete(taskID)
+	if taskCtx.ReceiveResult() {
+		p.taskManager.Complete(taskCtx.TaskID())
+		p.tasks.Delete(taskCtx.ParentTaskID())
+		p.stopLeafDeadlines(taskCtx.TaskID())
+		completedResp := &pb.TaskResponse{
+			JobID:     resp.JobID,
+			TaskID:    taskCtx.ParentTaskID(),
+			Completed: true,
+			SendNode:  p.curNodeID,
+		}
+		if merger, ok := p.results.Load(taskCtx.TaskID()); ok {
+			p.results.Delete(taskCtx.TaskID())
+			payload, err := merger.(*resultMerger).payload(defaultPayloadCompressThreshold)
+			if err != nil {
+				completedResp.ErrMsg = err.Error()
+			}
+			completedResp.Payload = payload
+		}
+		if stats, ok := p.stats.Load(taskCtx.TaskID()); ok {
+			p.stats.Delete(taskCtx.TaskID())
+			completedResp.Stats = stats.(*leafStats).encode()
+		}
+		if trace := p.taskTrace(taskCtx.TaskID()); trace != nil {
+			p.traces.Delete(taskCtx.TaskID())
+			trace.Root.Finish()
+			completedResp.TraceID = trace.TraceID
+			completedResp.Spans = encodeSpans(trace.Root)
+		}
 		// if task complete, need send task's result to parent node, if exist parent node
-		if err := p.taskManager.SendResponse(taskCtx.ParentNode(), &pb.TaskResponse{TaskID: taskCtx.ParentTaskID()}); err != nil {
+		if err := p.taskManager.SendResponse(taskCtx.ParentNode(), completedResp); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// taskTrace returns the trace of the task, returns nil if the query isn't traced
+func (p *intermediateTask) taskTrace(taskID string) *models.Trace {
+	trace, ok := p.traces.Load(taskID)
+	if !ok {
+		return nil
+	}
+	return trace.(*models.Trace)
+}
+
+// mergeStats merges the stats of leaf node into the stats of the task, the invalid stats are ignored
+func (p *intermediateTask) mergeStats(taskID string, leafNode string, payload []byte) {
+	stats, ok := p.stats.Load(taskID)
+	if !ok {
+		return
+	}
+	storageStats := &models.StorageStats{}
+	if err := encoding.JSONUnmarshal(payload, storageStats); err != nil {
+		return
+	}
+	stats.(*leafStats).merge(leafNode, storageStats)
+}
+
+// mergeResult merges the grouped series of leaf node into the result of the task
+func (p *intermediateTask) mergeResult(taskID string, payload []byte) error {
+	merger, ok := p.results.Load(taskID)
+	if !ok {
+		return nil
+	}
+	return merger.(*resultMerger).mergePayload(payload)
+}
+
+// leafStats collects the execution statistics of leaf nodes under the intermediate node,
+// which are forwarded to parent node by leaf node, so the stats of each storage node are kept
+type leafStats struct {
+	stats *models.QueryStats
+	mutex sync.Mutex
+}
+
+// newLeafStats creates the stats collector of leaf nodes
+func newLeafStats() *leafStats {
+	return &leafStats{stats: models.NewQueryStats(nil)}
+}
+
+// merge merges the stats of leaf node
+func (s *leafStats) merge(leafNode string, stats *models.StorageStats) {
+	s.mutex.Lock()
+	s.stats.MergeStorageStats(leafNode, stats)
+	s.mutex.Unlock()
+}
+
+// encode returns the stats of leaf nodes(leaf node => storage stats), returns nil if no stats
+func (s *leafStats) encode() []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.stats.StorageNodes) == 0 {
+		return nil
+	}
+	return encoding.JSONMarshal(s.stats.StorageNodes)
+}
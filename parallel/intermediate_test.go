@@ -1,15 +1,21 @@
 package parallel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/field"
 )
 
 func TestIntermediate_Process(t *testing.T) {
@@ -20,7 +26,7 @@ func TestIntermediate_Process(t *testing.T) {
 	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	processor := newIntermediateTask(currentNode, taskManager)
+	processor := newIntermediateTask(currentNode, taskManager, 0)
 
 	// unmarshal error
 	err := processor.Process(&pb.TaskRequest{PhysicalPlan: nil})
@@ -34,16 +40,37 @@ func TestIntermediate_Process(t *testing.T) {
 	assert.Equal(t, errWrongRequest, err)
 
 	plan2, _ := json.Marshal(&models.PhysicalPlan{
-		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}, NumOfTask: 1}},
 		Leafs: []models.Leaf{
 			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
 		},
 	})
 	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
-	// send request error
+	// send request error, reports the failed leaf node, then completes the task
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	gomock.InOrder(
+		taskManager.EXPECT().SendResponse(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+				assert.Equal(t, "err", resp.ErrMsg)
+				assert.Equal(t, "1.1.1.5:8000", resp.SendNode)
+				assert.False(t, resp.Completed)
+				return nil
+			}),
+		taskManager.EXPECT().Complete("taskID"),
+		taskManager.EXPECT().SendResponse(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+				assert.Empty(t, resp.ErrMsg)
+				assert.True(t, resp.Completed)
+				return nil
+			}),
+	)
 	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan2})
-	assert.NotNil(t, err)
+	assert.NoError(t, err)
+	// fails to report the failed leaf node
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	taskManager.EXPECT().SendResponse(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan2})
+	assert.Error(t, err)
 
 	// normal
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
@@ -62,6 +89,53 @@ func TestIntermediate_Process(t *testing.T) {
 	}
 }
 
+func TestIntermediate_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager, 0)
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}, NumOfTask: 2}},
+		Leafs: []models.Leaf{
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.6:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.7:8000"}},
+		},
+	})
+	// keeps the sent leaf task if fails to send to other leaf node, reports the failed leaf node
+	gomock.InOrder(
+		taskManager.EXPECT().SendRequest("1.1.1.5:8000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().SendRequest("1.1.1.6:8000", gomock.Any()).Return(fmt.Errorf("err")),
+		taskManager.EXPECT().SendResponse(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	err := processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan})
+	assert.NoError(t, err)
+
+	// cancels the task and all leaf tasks of current node
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	err = processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan})
+	assert.NoError(t, err)
+	gomock.InOrder(
+		taskManager.EXPECT().SendRequest("1.1.1.5:8000", gomock.Any()).Return(fmt.Errorf("err")),
+		taskManager.EXPECT().SendRequest("1.1.1.6:8000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().Complete("taskID"),
+	)
+	err = processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan,
+		RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+
+	// task not exist
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	err = processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan,
+		RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+}
+
 func TestIntermediate_Receive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -69,7 +143,7 @@ func TestIntermediate_Receive(t *testing.T) {
 	taskManager := NewMockTaskManager(ctrl)
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	receiver := newIntermediateTask(currentNode, taskManager)
+	receiver := newIntermediateTask(currentNode, taskManager, 0)
 	taskManager.EXPECT().Get("taskID").Return(nil)
 	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID"})
 	if err != nil {
@@ -93,4 +167,262 @@ func TestIntermediate_Receive(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// forwards the failure of leaf node to parent node
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", IntermediateTask, "parentTaskID", "parentNode", 2)).Times(2)
+	taskManager.EXPECT().SendResponse("parentNode", gomock.Any()).
+		DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+			assert.Equal(t, "parentTaskID", resp.TaskID)
+			assert.Equal(t, "1.1.1.5:8000", resp.SendNode)
+			assert.Equal(t, "err", resp.ErrMsg)
+			return nil
+		})
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, ErrMsg: "err", SendNode: "1.1.1.5:8000"})
+	assert.NoError(t, err)
+	taskManager.EXPECT().SendResponse("parentNode", gomock.Any()).Return(fmt.Errorf("err"))
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, ErrMsg: "err", SendNode: "1.1.1.5:8000"})
+	assert.Error(t, err)
+}
+
+func TestIntermediateTask_MergeResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager, 0)
+
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Parent: "1.1.1.1:8000",
+			Indicator: "1.1.1.3:8000"}, NumOfTask: 3}},
+		Leafs: []models.Leaf{
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.6:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.7:8000"}},
+		},
+	})
+	err := processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan})
+	assert.NoError(t, err)
+	taskCtx := newTaskContext("taskID", IntermediateTask, "parentTaskID", "1.1.1.1:8000", 3)
+	taskManager.EXPECT().Get("taskID").Return(taskCtx).AnyTimes()
+
+	leafResult := func(value float64) []byte {
+		merger := newResultMerger(nil)
+		merger.mergeSeries(newTestSeries(map[string]string{"host": "1.1.1.1"},
+			&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+				{ID: 1, Slots: []int{1}, Values: []float64{value}},
+			}}))
+		payload, err := merger.payload(defaultPayloadCompressThreshold)
+		assert.NoError(t, err)
+		return payload
+	}
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		Payload: leafResult(1), SendNode: "1.1.1.5:8000"}))
+	// invalid payload is forwarded as the failure of leaf node
+	taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+		DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+			assert.Equal(t, "1.1.1.6:8000", resp.SendNode)
+			assert.NotEmpty(t, resp.ErrMsg)
+			assert.Empty(t, resp.Payload)
+			return nil
+		})
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		Payload: []byte{1, 2, 3}, SendNode: "1.1.1.6:8000"}))
+	// sends the merged result to parent node when all leaf tasks completed
+	taskManager.EXPECT().Complete("taskID")
+	taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+		DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+			assert.Equal(t, "parentTaskID", resp.TaskID)
+			assert.True(t, resp.Completed)
+			assert.Equal(t, leafResult(3), resp.Payload)
+			return nil
+		})
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		Payload: leafResult(2), SendNode: "1.1.1.7:8000"}))
+	_, ok := processor.results.Load("taskID")
+	assert.False(t, ok)
+}
+
+func TestIntermediateTask_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager, 0)
+
+	physicalPlan := &models.PhysicalPlan{
+		Root: models.Root{Indicator: "1.1.1.1:8000", NumOfTask: 1},
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Parent: "1.1.1.1:8000",
+			Indicator: "1.1.1.3:8000"}, NumOfTask: 2}},
+		Leafs: []models.Leaf{
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.6:8000"}},
+		},
+	}
+	err := processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: encoding.JSONMarshal(physicalPlan)})
+	assert.NoError(t, err)
+	taskManager.EXPECT().Get("taskID").Return(newTaskContext("taskID", IntermediateTask, "parentTaskID",
+		"1.1.1.1:8000", 2)).AnyTimes()
+
+	leafStats := func(cost int64) []byte {
+		stats := models.NewStorageStats()
+		stats.TotalCost = cost
+		return encoding.JSONMarshal(stats)
+	}
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		Stats: leafStats(10), SendNode: "1.1.1.5:8000"}))
+	// forwards the stats of leaf nodes to parent node when all leaf tasks completed
+	var completedResp *pb.TaskResponse
+	taskManager.EXPECT().Complete("taskID")
+	taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+		DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+			completedResp = resp
+			return nil
+		})
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		Stats: leafStats(20), SendNode: "1.1.1.6:8000"}))
+	_, ok := processor.stats.Load("taskID")
+	assert.False(t, ok)
+
+	// the root keeps the stats of each leaf node under intermediate node
+	jobManager := NewMockJobManager(ctrl)
+	jobManager.EXPECT().GetTaskManager().Return(taskManager).AnyTimes()
+	jobManager.EXPECT().ReceiveLeafResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	jobCtx := NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, "")
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	taskManager.EXPECT().Get("parentTaskID").Return(newTaskContext("parentTaskID", RootTask, "", "", 1))
+	taskManager.EXPECT().Complete("parentTaskID")
+	assert.NoError(t, NewTaskReceiver(jobManager).Receive(completedResp))
+	storageNodes := jobCtx.Statistics().StorageNodes
+	assert.Len(t, storageNodes, 2)
+	assert.Equal(t, int64(10), storageNodes["1.1.1.5:8000"].TotalCost)
+	assert.Equal(t, int64(20), storageNodes["1.1.1.6:8000"].TotalCost)
+}
+
+func TestIntermediateTask_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager, 0)
+
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Parent: "1.1.1.1:8000",
+			Indicator: "1.1.1.3:8000"}, NumOfTask: 2}},
+		Leafs: []models.Leaf{
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.6:8000"}},
+		},
+	})
+	err := processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan, TraceID: "traceID"})
+	assert.NoError(t, err)
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", IntermediateTask, "parentTaskID", "1.1.1.1:8000", 2)).AnyTimes()
+
+	leafSpan := models.NewSpan("leaf task")
+	leafSpan.SetNode("1.1.1.5:8000")
+	leafSpan.Finish()
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		SendNode: "1.1.1.5:8000", TraceID: "traceID", Spans: encodeSpans(leafSpan)}))
+	// sends the spans of intermediate task to parent node when all leaf tasks completed
+	taskManager.EXPECT().Complete("taskID")
+	taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+		DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+			assert.Equal(t, "traceID", resp.TraceID)
+			spans, err := decodeSpans(resp.Spans)
+			assert.NoError(t, err)
+			assert.Len(t, spans, 1)
+			assert.Equal(t, "intermediate task", spans[0].Name)
+			assert.Equal(t, "1.1.1.3:8000", spans[0].Node)
+			// 2 send task spans + 1 leaf task span
+			assert.Len(t, spans[0].Children, 3)
+			return nil
+		})
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		SendNode: "1.1.1.6:8000", Spans: []byte{1, 2, 3}}))
+	_, ok := processor.traces.Load("taskID")
+	assert.False(t, ok)
+}
+
+func TestIntermediateTask_LeafTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	var mutex sync.Mutex
+	tasks := make(map[string]TaskContext)
+	taskManager.EXPECT().Submit(gomock.Any()).Do(func(taskCtx TaskContext) {
+		mutex.Lock()
+		tasks[taskCtx.TaskID()] = taskCtx
+		mutex.Unlock()
+	}).AnyTimes()
+	taskManager.EXPECT().Get(gomock.Any()).DoAndReturn(func(taskID string) TaskContext {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return tasks[taskID]
+	}).AnyTimes()
+	taskManager.EXPECT().Complete("taskID").Do(func(taskID string) {
+		mutex.Lock()
+		delete(tasks, taskID)
+		mutex.Unlock()
+	})
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager, 100*time.Millisecond)
+
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Parent: "1.1.1.1:8000",
+			Indicator: "1.1.1.3:8000"}, NumOfTask: 2}},
+		Leafs: []models.Leaf{
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}},
+			{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.6:8000"}},
+		},
+	})
+	completed := make(chan struct{})
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("taskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.5:8000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().SendRequest("1.1.1.6:8000", gomock.Any()).Return(nil),
+		// cancels the hung leaf task, then reports the timeout to parent node for retrying
+		taskManager.EXPECT().SendRequest("1.1.1.6:8000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+				return nil
+			}),
+		taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+			DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+				assert.False(t, resp.Completed)
+				assert.Equal(t, "parentTaskID", resp.TaskID)
+				assert.Equal(t, "1.1.1.6:8000", resp.SendNode)
+				assert.Equal(t, errLeafTimeout.Error(), resp.ErrMsg)
+				return nil
+			}),
+		taskManager.EXPECT().SendResponse("1.1.1.1:8000", gomock.Any()).
+			DoAndReturn(func(parentNode string, resp *pb.TaskResponse) error {
+				assert.True(t, resp.Completed)
+				assert.Equal(t, "1.1.1.3:8000", resp.SendNode)
+				close(completed)
+				return nil
+			}),
+	)
+	err := processor.Process(&pb.TaskRequest{ParentTaskID: "parentTaskID", PhysicalPlan: plan})
+	assert.NoError(t, err)
+	// the result received before the deadline
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, SendNode: "1.1.1.5:8000"}))
+	<-completed
+	// the result of timeout leaf task is ignored
+	assert.NoError(t, processor.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, SendNode: "1.1.1.6:8000"}))
+	processor.mutex.Lock()
+	assert.Empty(t, processor.deadlines)
+	processor.mutex.Unlock()
 }
@@ -1,37 +1,97 @@
 package parallel
 
 import (
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 )
 
 //go:generate mockgen -source=./job_manager.go -destination=./job_manager_mock.go -package=parallel
 
+var log = logger.GetLogger("parallel", "JobManager")
+
 // JobManager represents the job manager for the root broker node
 type JobManager interface {
 	// SubmitJob submits the distribution query job based on physical plan
 	SubmitJob(ctx JobContext) error
 	// GetJob returns job context by job id
 	GetJob(jobID int64) JobContext
+	// ListJobs returns the info of all running jobs
+	ListJobs() []*models.JobInfo
+	// KillJob kills the running job by job id, returns false if the job not exist
+	KillJob(jobID int64) bool
+	// RetryTask retries the failed leaf task of the job on the alternative replicas,
+	// records the failure into the job context if the shards of failed node can't be retried completely,
+	// only the failure of unavailable node makes the result incomplete, other failures fail the job
+	RetryTask(jobID int64, taskID string, failedNode string, errMsg string)
+	// ReceiveLeafResult marks the leaf task sent by root completed before the deadline,
+	// returns false if the leaf task is timeout, the result is ignored because the leaf task has been retried
+	ReceiveLeafResult(jobID int64, taskID string, leafNode string) bool
 	// GetTaskManager return the task manager
 	GetTaskManager() TaskManager
 }
 
 // jobManager implements the job manager for managing the query job
 type jobManager struct {
-	taskManager TaskManager
+	taskManager         TaskManager
+	replicaStateMachine replica.StatusStateMachine
+	leafTimeout         time.Duration // max wait time of leaf task's result, 0 means waiting until the job done
 
-	seq  int64
-	jobs sync.Map
+	seq      int64
+	jobs     sync.Map
+	rootJobs sync.Map // job id => *rootJob, for retrying and canceling the sub tasks
 }
 
-// NewJobManager creates the job manager
-func NewJobManager(taskManger TaskManager) JobManager {
+// rootJob represents the running job of root node, tracks the retry tasks of the failed leaf nodes
+type rootJob struct {
+	ctx     JobContext
+	taskCtx TaskContext // root task
+	req     *pb.TaskRequest
+
+	mutex               sync.Mutex
+	retries             map[string]*retryTask             // retry task id => retry task
+	failedNodes         map[string]struct{}               // failed leaf nodes, excluded when retrying
+	failedIntermediates map[string]struct{}               // failed intermediate nodes, the leafs are re-dispatched
+	deadlines           map[leafDeadlineKey]*leafDeadline // sub tasks sent by root => deadline of result
+}
+
+// leafDeadlineKey represents the key of sub task sent by the parent node, the task is root task or retry task of root,
+// or the task of intermediate node
+type leafDeadlineKey struct {
+	taskID   string
+	leafNode string
+}
+
+// leafDeadline represents the deadline of leaf task's result, the leaf task is retried if expired
+type leafDeadline struct {
+	timer   *time.Timer
+	expired bool
+}
+
+// retryTask represents the task which re-plans the shards of failed leaf node onto the alternative replicas
+type retryTask struct {
+	plan *models.PhysicalPlan
+	req  *pb.TaskRequest
+}
+
+// NewJobManager creates the job manager,
+// retries the failed leaf task on the alternative replicas based on the replica status if replica state machine set,
+// the leaf task is treated as failed if no result within the leaf timeout, like the storage node hangs,
+// the intermediate task is treated as failed if no result within twice the leaf timeout,
+// because the intermediate node times out its leaf tasks first
+func NewJobManager(taskManger TaskManager, replicaStateMachine replica.StatusStateMachine,
+	leafTimeout time.Duration) JobManager {
 	return &jobManager{
-		taskManager: taskManger,
+		taskManager:         taskManger,
+		replicaStateMachine: replicaStateMachine,
+		leafTimeout:         leafTimeout,
 	}
 }
 
@@ -48,51 +108,492 @@ func (j *jobManager) GetJob(jobID int64) JobContext {
 	return jobCtx
 }
 
+// ListJobs returns the info of all running jobs, includes sql, database, start time, nodes and progress
+func (j *jobManager) ListJobs() []*models.JobInfo {
+	var jobs []*models.JobInfo
+	j.jobs.Range(func(key, value interface{}) bool {
+		jobCtx, ok := value.(JobContext)
+		if !ok {
+			return true
+		}
+		info := models.NewJobInfo(key.(int64), jobCtx.SQL(), jobCtx.Plan())
+		info.StartTime = jobCtx.StartTime().UnixNano() / 1000000
+		info.NumOfCompleted = jobCtx.NumOfCompleted()
+		jobs = append(jobs, info)
+		return true
+	})
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].JobID < jobs[k].JobID
+	})
+	return jobs
+}
+
+// KillJob kills the running job by job id, the sub tasks are canceled when the job context is done,
+// returns false if the job not exist
+func (j *jobManager) KillJob(jobID int64) bool {
+	jobCtx := j.GetJob(jobID)
+	if jobCtx == nil {
+		return false
+	}
+	jobCtx.Kill()
+	return true
+}
+
 // SubmitJob submits the distribution query job based on physical plan,
 // 1. if has intermediate nodes, sends the request to the intermediate nodes
 // 2. else sends the request to the leaf node directly
+// if fails to send, retries the failed leaf task on the alternative replicas, else records the failed node,
+// the result of job is incomplete, returns err if fails to send to all nodes and can't retry.
+// After submitted, the job is canceled if the context of job is done before completed, like client disconnect or timeout.
 func (j *jobManager) SubmitJob(ctx JobContext) (err error) {
 	plan := ctx.Plan()
 	planPayload := encoding.JSONMarshal(plan)
 	jobID := atomic.AddInt64(&j.seq, 1)
 
-	defer func() {
-		if err == nil {
-			j.jobs.Store(jobID, ctx)
-		}
-	}()
-
 	taskID := j.taskManager.AllocTaskID()
 
-	// TODO need add param
 	req := &pb.TaskRequest{
 		JobID:        jobID,
 		ParentTaskID: taskID,
 		PhysicalPlan: planPayload,
+		Payload:      encoding.JSONMarshal(ctx.Query()),
+		TraceID:      models.TraceFromContext(ctx.Context()).ID(),
 	}
 
 	taskCtx := newTaskContext(taskID, RootTask, "", "", plan.Root.NumOfTask)
 	j.taskManager.Submit(taskCtx)
+	job := &rootJob{
+		ctx:         ctx,
+		taskCtx:     taskCtx,
+		req:         req,
+		retries:     make(map[string]*retryTask),
+		failedNodes: make(map[string]struct{}),
+		deadlines:   make(map[leafDeadlineKey]*leafDeadline),
+
+		failedIntermediates: make(map[string]struct{}),
+	}
+	// stores the job before sending, the result may be received before all requests sent
+	j.jobs.Store(jobID, ctx)
+	j.rootJobs.Store(jobID, job)
+
+	targets := j.getTargetNodes(plan)
+	failures := make(map[string]string)
+	for _, target := range targets {
+		if len(plan.Intermediates) == 0 {
+			j.watchLeaf(job, taskID, target)
+		} else {
+			j.watchIntermediate(job, taskID, target)
+		}
+		if err = j.sendRequest(ctx, target, req); err != nil {
+			job.receiveLeaf(taskID, target)
+			failures[target] = err.Error()
+		}
+	}
+	retried := false
+	for target, errMsg := range failures {
+		if j.retryTask(job, taskID, target, errMsg) {
+			retried = true
+		}
+	}
+	if len(failures) > 0 && len(failures) == len(targets) && !retried {
+		j.taskManager.Complete(taskID)
+		j.jobs.Delete(jobID)
+		j.rootJobs.Delete(jobID)
+		return err
+	}
+	// the failed node is treated as completed task
+	for range failures {
+		receiveResult(j.taskManager, taskCtx, ctx)
+	}
+	go j.watchJob(jobID, ctx, job, targets)
+	return nil
+}
+
+// RetryTask retries the failed leaf task of the job on the alternative replicas,
+// records the failure into the job context if the shards of failed node can't be retried completely
+func (j *jobManager) RetryTask(jobID int64, taskID string, failedNode string, errMsg string) {
+	job, ok := j.rootJobs.Load(jobID)
+	if !ok {
+		return
+	}
+	j.retryTask(job.(*rootJob), taskID, failedNode, errMsg)
+}
+
+// retryTask re-plans the shards of the failed leaf node onto the alternative replicas,
+// then submits the retry task which sends the task request to the alternative nodes within the same job,
+// records the failure if the shards can't be retried completely, returns if the retry task submitted.
+// If the failed node is the intermediate node, re-dispatches its leaf tasks to the leaf nodes directly.
+func (j *jobManager) retryTask(job *rootJob, taskID string, failedNode string, errMsg string) bool {
+	if !isUnavailable(errMsg) {
+		// the failure of query is deterministic, like exceeding the limit, retrying on other replica is useless
+		job.fail(failedNode, errMsg)
+		return false
+	}
+	if leafs, ok := job.failIntermediate(taskID, failedNode); ok {
+		if len(leafs) == 0 {
+			return false
+		}
+		log.Warn("re-dispatch leaf tasks of failed intermediate node",
+			logger.Int64("jobID", job.req.JobID), logger.String("failedNode", failedNode), logger.String("error", errMsg))
+		j.submitRetryTask(job, leafs)
+		return true
+	}
+	if job.isRedispatched(taskID, failedNode) {
+		// the failure of leaf node forwarded by the failed intermediate node, the leaf task has been re-dispatched
+		return false
+	}
+	leaf, excludeNodes, ok := job.failLeaf(taskID, failedNode)
+	if !ok || j.replicaStateMachine == nil {
+		job.fail(failedNode, errMsg)
+		return false
+	}
+	plan := job.ctx.Plan()
+	alternatives := j.replicaStateMachine.GetAlternativeReplicas(plan.Database, leaf.ShardIDs, excludeNodes)
+	numOfShards := 0
+	for _, shardIDs := range alternatives {
+		numOfShards += len(shardIDs)
+	}
+	if numOfShards < len(leaf.ShardIDs) {
+		job.fail(failedNode, errMsg)
+	}
+	if len(alternatives) == 0 {
+		return false
+	}
+	log.Warn("retry failed leaf task on alternative replicas",
+		logger.Int64("jobID", job.req.JobID), logger.String("failedNode", failedNode),
+		logger.String("error", errMsg), logger.Any("alternatives", alternatives))
+
+	nodeIDs := make([]string, 0, len(alternatives))
+	for nodeID := range alternatives {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	leafs := make([]models.Leaf, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		leafs = append(leafs, models.Leaf{
+			BaseNode:  models.BaseNode{Indicator: nodeID},
+			ShardIDs:  alternatives[nodeID],
+			Receivers: leaf.Receivers,
+		})
+	}
+	j.submitRetryTask(job, leafs)
+	return true
+}
+
+// submitRetryTask submits the retry task which sends the task request to the leaf nodes directly,
+// the retry task is the sub task of root task within the same job.
+func (j *jobManager) submitRetryTask(job *rootJob, leafs []models.Leaf) {
+	plan := job.ctx.Plan()
+	retryPlan := models.NewPhysicalPlan(models.Root{Indicator: plan.Root.Indicator, NumOfTask: int32(len(leafs))})
+	retryPlan.Database = plan.Database
+	for _, leaf := range leafs {
+		leaf.Parent = plan.Root.Indicator
+		retryPlan.AddLeaf(leaf)
+	}
+	retryTaskID := j.taskManager.AllocTaskID()
+	req := &pb.TaskRequest{
+		JobID:        job.req.JobID,
+		ParentTaskID: retryTaskID,
+		PhysicalPlan: encoding.JSONMarshal(retryPlan),
+		Payload:      job.req.Payload,
+		TraceID:      job.req.TraceID,
+	}
+	// the retry task is the sub task of root task, adds it before the failed task completed
+	job.taskCtx.AddSubTask()
+	retryTaskCtx := newTaskContext(retryTaskID, RetryTask, job.taskCtx.TaskID(), "", retryPlan.Root.NumOfTask)
+	j.taskManager.Submit(retryTaskCtx)
+	job.addRetryTask(retryTaskID, &retryTask{plan: retryPlan, req: req})
+
+	for _, leaf := range retryPlan.Leafs {
+		j.watchLeaf(job, retryTaskID, leaf.Indicator)
+		if err := j.sendRequest(job.ctx, leaf.Indicator, req); err != nil {
+			job.receiveLeaf(retryTaskID, leaf.Indicator)
+			j.retryTask(job, retryTaskID, leaf.Indicator, err.Error())
+			receiveResult(j.taskManager, retryTaskCtx, job.ctx)
+		}
+	}
+}
+
+// ReceiveLeafResult marks the leaf task sent by root completed before the deadline,
+// returns false if the leaf task is timeout, the result is ignored because the leaf task has been retried
+func (j *jobManager) ReceiveLeafResult(jobID int64, taskID string, leafNode string) bool {
+	job, ok := j.rootJobs.Load(jobID)
+	if !ok {
+		return true
+	}
+	return job.(*rootJob).receiveLeaf(taskID, leafNode)
+}
+
+// watchLeaf sets the deadline of the leaf task's result before sending, if no result within the leaf timeout,
+// like the storage node hangs, cancels the leaf task and retries it on the alternative replicas
+func (j *jobManager) watchLeaf(job *rootJob, taskID string, leafNode string) {
+	j.watchTask(job, taskID, leafNode, j.leafTimeout, errLeafTimeout.Error())
+}
+
+// watchIntermediate sets the deadline of the intermediate task's result before sending,
+// if no result within twice the leaf timeout, like the intermediate node hangs,
+// cancels the intermediate task and re-dispatches its leaf tasks to the leaf nodes directly
+func (j *jobManager) watchIntermediate(job *rootJob, taskID string, intermediateNode string) {
+	j.watchTask(job, taskID, intermediateNode, 2*j.leafTimeout, errIntermediateTimeout.Error())
+}
+
+// watchTask sets the deadline of the sub task's result, retries the sub task with the error if expired
+func (j *jobManager) watchTask(job *rootJob, taskID string, node string, timeout time.Duration, errMsg string) {
+	if timeout <= 0 {
+		return
+	}
+	job.addLeafDeadline(taskID, node, time.AfterFunc(timeout, func() {
+		if job.ctx.Context().Err() != nil || !job.expireLeaf(taskID, node) {
+			return
+		}
+		log.Warn("sub task is timeout", logger.Int64("jobID", job.req.JobID),
+			logger.String("taskID", taskID), logger.String("node", node))
+		if req := job.taskRequest(taskID); req != nil {
+			j.cancelTasks([]string{node}, req)
+		}
+		j.retryTask(job, taskID, node, errMsg)
+		// the timeout leaf task is treated as completed task
+		job.ctx.ReceiveResult()
+		if taskCtx := j.taskManager.Get(taskID); taskCtx != nil {
+			receiveResult(j.taskManager, taskCtx, job.ctx)
+		}
+	}))
+}
+
+// sendRequest sends the task request to the target node, records the span of sending if the query is traced
+func (j *jobManager) sendRequest(ctx JobContext, target string, req *pb.TaskRequest) error {
+	span := ctx.Span().StartChild("send task")
+	span.SetTag("node", target)
+	err := j.taskManager.SendRequest(target, req)
+	if err != nil {
+		span.SetTag("error", err.Error())
+	}
+	span.Finish()
+	return err
+}
 
+// getTargetNodes returns the nodes which the root sends the task request to,
+// intermediate nodes if has, else leaf nodes
+func (j *jobManager) getTargetNodes(plan *models.PhysicalPlan) []string {
+	var targets []string
 	if len(plan.Intermediates) > 0 {
 		for _, intermediate := range plan.Intermediates {
-			if err = j.taskManager.SendRequest(intermediate.Indicator, req); err != nil {
-				//TODO kill sent leaf task???
-				return err
-			}
+			targets = append(targets, intermediate.Indicator)
 		}
-	} else if len(plan.Leafs) > 0 {
+	} else {
 		for _, leaf := range plan.Leafs {
-			if err = j.taskManager.SendRequest(leaf.Indicator, req); err != nil {
-				//TODO kill sent leaf task???
-				return err
-			}
+			targets = append(targets, leaf.Indicator)
 		}
 	}
-	return err
+	return targets
+}
+
+// watchJob waits for the job done, removes the job, if the job is canceled before completed,
+// sends the cancel request to all sub tasks includes the retry tasks, then completes the job.
+func (j *jobManager) watchJob(jobID int64, ctx JobContext, job *rootJob, targets []string) {
+	<-ctx.Context().Done()
+	j.jobs.Delete(jobID)
+	j.rootJobs.Delete(jobID)
+	job.stopLeafDeadlines()
+	if ctx.Completed() {
+		return
+	}
+	j.cancelTasks(targets, job.req)
+	for retryTaskID, retry := range job.getRetryTasks() {
+		var leafs []string
+		for _, leaf := range retry.plan.Leafs {
+			leafs = append(leafs, leaf.Indicator)
+		}
+		j.cancelTasks(leafs, retry.req)
+		j.taskManager.Complete(retryTaskID)
+	}
+	j.taskManager.Complete(job.taskCtx.TaskID())
+	ctx.Complete()
+}
+
+// cancelTasks sends the cancel request of the task to the target nodes
+func (j *jobManager) cancelTasks(targets []string, req *pb.TaskRequest) {
+	cancelReq := newCancelRequest(req)
+	for _, target := range targets {
+		if err := j.taskManager.SendRequest(target, cancelReq); err != nil {
+			log.Warn("send cancel task request error",
+				logger.String("target", target), logger.Int64("jobID", req.JobID), logger.Error(err))
+		}
+	}
+}
+
+// newCancelRequest creates the cancel request for the task request
+func newCancelRequest(req *pb.TaskRequest) *pb.TaskRequest {
+	return &pb.TaskRequest{
+		JobID:        req.JobID,
+		ParentTaskID: req.ParentTaskID,
+		PhysicalPlan: req.PhysicalPlan,
+		RequestType:  pb.RequestType_Cancel,
+	}
 }
 
 // GetTaskManager return the task manager
 func (j *jobManager) GetTaskManager() TaskManager {
 	return j.taskManager
 }
+
+// failLeaf marks the leaf node of the task failed, returns the leaf and the failed nodes of the job
+// which are excluded when retrying, returns false if the failed node isn't the leaf of the task.
+func (j *rootJob) failLeaf(taskID string, nodeID string) (leaf models.Leaf, excludeNodes map[string]struct{}, ok bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	plan := j.ctx.Plan()
+	if taskID != j.taskCtx.TaskID() {
+		retry, exist := j.retries[taskID]
+		if !exist {
+			return leaf, nil, false
+		}
+		plan = retry.plan
+	}
+	for _, l := range plan.Leafs {
+		if l.Indicator == nodeID {
+			leaf = l
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return leaf, nil, false
+	}
+	j.failedNodes[nodeID] = struct{}{}
+	excludeNodes = make(map[string]struct{}, len(j.failedNodes))
+	for failedNode := range j.failedNodes {
+		excludeNodes[failedNode] = struct{}{}
+	}
+	return leaf, excludeNodes, true
+}
+
+// failIntermediate marks the intermediate node of root task failed, returns the leafs of the intermediate node
+// which aren't failed, they are re-dispatched to the leaf nodes directly,
+// returns false if the failed node isn't the intermediate node of root task.
+func (j *rootJob) failIntermediate(taskID string, nodeID string) (leafs []models.Leaf, ok bool) {
+	if taskID != j.taskCtx.TaskID() || !isIntermediate(j.ctx.Plan(), nodeID) {
+		return nil, false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if _, failed := j.failedIntermediates[nodeID]; failed {
+		return nil, true
+	}
+	j.failedIntermediates[nodeID] = struct{}{}
+	for _, leaf := range j.ctx.Plan().Leafs {
+		if _, failed := j.failedNodes[leaf.Indicator]; leaf.Parent != nodeID || failed {
+			continue
+		}
+		leafs = append(leafs, leaf)
+	}
+	return leafs, true
+}
+
+// isRedispatched returns if the leaf task of root task has been re-dispatched because its intermediate node failed
+func (j *rootJob) isRedispatched(taskID string, nodeID string) bool {
+	if taskID != j.taskCtx.TaskID() {
+		return false
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	for _, leaf := range j.ctx.Plan().Leafs {
+		if leaf.Indicator != nodeID {
+			continue
+		}
+		if _, failed := j.failedIntermediates[leaf.Parent]; failed {
+			return true
+		}
+	}
+	return false
+}
+
+// fail records the failure of the leaf node which can't be retried, the result of job is incomplete
+// if the node is unavailable, else the job fails with the failure of query, like exceeding the limit or timeout
+func (j *rootJob) fail(nodeID string, errMsg string) {
+	if isUnavailable(errMsg) {
+		j.ctx.ReceiveFailure(nodeID, errMsg)
+		return
+	}
+	j.ctx.ReceiveError(errMsg)
+}
+
+// addRetryTask adds the retry task of the job
+func (j *rootJob) addRetryTask(taskID string, retry *retryTask) {
+	j.mutex.Lock()
+	j.retries[taskID] = retry
+	j.mutex.Unlock()
+}
+
+// taskRequest returns the request of root task or retry task, returns nil if not exist
+func (j *rootJob) taskRequest(taskID string) *pb.TaskRequest {
+	if taskID == j.taskCtx.TaskID() {
+		return j.req
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if retry, ok := j.retries[taskID]; ok {
+		return retry.req
+	}
+	return nil
+}
+
+// addLeafDeadline adds the deadline of leaf task's result
+func (j *rootJob) addLeafDeadline(taskID string, leafNode string, timer *time.Timer) {
+	j.mutex.Lock()
+	j.deadlines[leafDeadlineKey{taskID: taskID, leafNode: leafNode}] = &leafDeadline{timer: timer}
+	j.mutex.Unlock()
+}
+
+// receiveLeaf removes the deadline of leaf task if the result received before expired,
+// returns false if the deadline expired, true if no deadline
+func (j *rootJob) receiveLeaf(taskID string, leafNode string) bool {
+	key := leafDeadlineKey{taskID: taskID, leafNode: leafNode}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	deadline, ok := j.deadlines[key]
+	if !ok {
+		return true
+	}
+	if deadline.expired {
+		return false
+	}
+	deadline.timer.Stop()
+	delete(j.deadlines, key)
+	return true
+}
+
+// expireLeaf marks the deadline of leaf task expired, returns false if the result has been received
+func (j *rootJob) expireLeaf(taskID string, leafNode string) bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	deadline, ok := j.deadlines[leafDeadlineKey{taskID: taskID, leafNode: leafNode}]
+	if !ok || deadline.expired {
+		return false
+	}
+	deadline.expired = true
+	return true
+}
+
+// stopLeafDeadlines stops the deadlines of all leaf tasks when the job done
+func (j *rootJob) stopLeafDeadlines() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	for _, deadline := range j.deadlines {
+		deadline.timer.Stop()
+	}
+}
+
+// getRetryTasks returns the retry tasks of the job
+func (j *rootJob) getRetryTasks() map[string]*retryTask {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	retries := make(map[string]*retryTask, len(j.retries))
+	for taskID, retry := range j.retries {
+		retries[taskID] = retry
+	}
+	return retries
+}
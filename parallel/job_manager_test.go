@@ -1,13 +1,21 @@
 package parallel
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/series"
 )
 
 func TestJobManager_SubmitJob(t *testing.T) {
@@ -17,8 +25,9 @@ func TestJobManager_SubmitJob(t *testing.T) {
 	taskManager := NewMockTaskManager(ctrl)
 	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
 	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
 
-	jobManager := NewJobManager(taskManager)
+	jobManager := NewJobManager(taskManager, nil, 0)
 	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
 	physicalPlan.AddLeaf(models.Leaf{
 		BaseNode: models.BaseNode{
@@ -28,16 +37,49 @@ func TestJobManager_SubmitJob(t *testing.T) {
 		ShardIDs: []int32{1, 2, 4},
 	})
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	err := jobManager.SubmitJob(NewJobContext(nil, physicalPlan))
+	err := jobManager.SubmitJob(NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, ""))
 	assert.NotNil(t, err)
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(nil, physicalPlan))
+	err = jobManager.SubmitJob(NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, ""))
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestJobManager_SubmitJob_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
+
+	jobManager := NewJobManager(taskManager, nil, 0)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"}})
+	trace := models.NewTrace("query")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(target string, req *pb.TaskRequest) error {
+			assert.Equal(t, trace.TraceID, req.TraceID)
+			return nil
+		})
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	jobCtx := NewJobContext(models.WithTrace(context.Background(), trace), nil, physicalPlan, &stmt.Query{}, "")
+	err := jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	// records the span of each task send
+	sendSpans := jobCtx.Span().Children
+	assert.Len(t, sendSpans, 2)
+	assert.Equal(t, "send task", sendSpans[0].Name)
+	assert.Equal(t, map[string]string{"node": "1.1.1.1:9000"}, sendSpans[0].Tags)
+	assert.Equal(t, map[string]string{"node": "1.1.1.2:9000", "error": "err"}, sendSpans[1].Tags)
+	jobCtx.Complete()
+	assert.True(t, jobCtx.Span().Duration > 0)
+}
+
 func TestJobManager_SubmitJob_2(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -45,8 +87,9 @@ func TestJobManager_SubmitJob_2(t *testing.T) {
 	taskManager := NewMockTaskManager(ctrl)
 	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
 	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+	taskManager.EXPECT().Complete(gomock.Any()).AnyTimes()
 
-	jobManager := NewJobManager(taskManager)
+	jobManager := NewJobManager(taskManager, nil, 0)
 	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
 	physicalPlan.AddIntermediate(models.Intermediate{
 		BaseNode: models.BaseNode{
@@ -56,23 +99,78 @@ func TestJobManager_SubmitJob_2(t *testing.T) {
 	})
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	err := jobManager.SubmitJob(NewJobContext(nil, physicalPlan))
+	err := jobManager.SubmitJob(NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, ""))
 	assert.NotNil(t, err)
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(nil, physicalPlan))
+	err = jobManager.SubmitJob(NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, ""))
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.NotNil(t, jobManager.GetTaskManager())
 }
 
+func TestJobManager_CancelJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+
+	jobManager1 := NewJobManager(taskManager, nil, 0)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"}})
+
+	// records the failed node if fails to send, keeps the sent task
+	gomock.InOrder(
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(errTaskSend),
+	)
+	jobCtx := NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, "")
+	err := jobManager1.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"1.1.1.2:9000": errTaskSend.Error()}, jobCtx.Failures())
+	jobCtx.Complete()
+	time.Sleep(10 * time.Millisecond)
+
+	// cancels the tasks if the job is canceled before completed
+	ctx, cancel := context.WithCancel(context.Background())
+	resultSet := make(chan series.GroupedIterator)
+	jobCtx = NewJobContext(ctx, resultSet, physicalPlan, &stmt.Query{}, "")
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	err = jobManager1.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	assert.NotNil(t, jobManager1.GetJob(2))
+	gomock.InOrder(
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(fmt.Errorf("err")),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().Complete("TaskID"),
+	)
+	cancel()
+	// the result set is closed after canceled
+	_, ok := <-resultSet
+	assert.False(t, ok)
+	assert.True(t, jobCtx.Completed())
+	assert.Nil(t, jobManager1.GetJob(2))
+
+	// completed job doesn't send cancel request
+	jobCtx = NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, "")
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	err = jobManager1.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	jobCtx.Complete()
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, jobManager1.GetJob(3))
+}
+
 func TestJobManager_GetTaskManager(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	taskManager := NewMockTaskManager(ctrl)
-	jobManager1 := NewJobManager(taskManager)
+	jobManager1 := NewJobManager(taskManager, nil, 0)
 	manager := jobManager1.(*jobManager)
 	manager.jobs.Store(int64(1), &jobContext{})
 	job := jobManager1.GetJob(1)
@@ -83,3 +181,379 @@ func TestJobManager_GetTaskManager(t *testing.T) {
 	job = jobManager1.GetJob(2)
 	assert.Nil(t, job)
 }
+
+func TestJobManager_ListAndKillJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+
+	jobManager1 := NewJobManager(taskManager, nil, 0)
+	assert.Empty(t, jobManager1.ListJobs())
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.Database = "test_db"
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"}})
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	resultSet := make(chan series.GroupedIterator)
+	jobCtx := NewJobContext(context.Background(), resultSet, physicalPlan, &stmt.Query{}, "select f from cpu")
+	jobCtx.ReceiveResult()
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	assert.NoError(t, jobManager1.SubmitJob(NewJobContext(context.Background(), nil, physicalPlan, &stmt.Query{}, "")))
+	// ignores the wrong job
+	jobManager1.(*jobManager).jobs.Store(int64(100), "test")
+
+	jobs := jobManager1.ListJobs()
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, int64(1), jobs[0].JobID)
+	assert.Equal(t, "select f from cpu", jobs[0].SQL)
+	assert.Equal(t, "test_db", jobs[0].Database)
+	assert.Equal(t, []string{"1.1.1.1:9000", "1.1.1.2:9000"}, jobs[0].Nodes)
+	assert.Equal(t, int32(2), jobs[0].NumOfTask)
+	assert.Equal(t, int32(1), jobs[0].NumOfCompleted)
+	assert.True(t, jobs[0].StartTime > 0)
+	assert.Equal(t, int64(2), jobs[1].JobID)
+
+	// kill not exist job
+	assert.False(t, jobManager1.KillJob(3))
+	// kill the job, cancels the sub tasks
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(target string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			return nil
+		}).Times(2)
+	taskManager.EXPECT().Complete("TaskID")
+	assert.True(t, jobManager1.KillJob(1))
+	_, ok := <-resultSet
+	assert.False(t, ok)
+	assert.True(t, jobCtx.Killed())
+	assert.Nil(t, jobManager1.GetJob(1))
+}
+
+func TestJobManager_RetryTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	tasks := make(map[string]TaskContext)
+	taskManager.EXPECT().Submit(gomock.Any()).Do(func(taskCtx TaskContext) {
+		tasks[taskCtx.TaskID()] = taskCtx
+	}).AnyTimes()
+	taskManager.EXPECT().Get(gomock.Any()).DoAndReturn(func(taskID string) TaskContext {
+		return tasks[taskID]
+	}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	jobManager1 := NewJobManager(taskManager, replicaStateMachine, 0)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.Database = "test_db"
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1, 2}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"},
+		ShardIDs: []int32{3}})
+
+	// retries the shards of failed node on the alternative replicas
+	ctx, cancel := context.WithCancel(context.Background())
+	jobCtx := NewJobContext(ctx, make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("TaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(errTaskSend),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(nil),
+		replicaStateMachine.EXPECT().
+			GetAlternativeReplicas("test_db", []int32{1, 2}, map[string]struct{}{"1.1.1.1:9000": {}}).
+			Return(map[string][]int32{"1.1.1.2:9000": {2}, "1.1.1.4:9000": {1}}),
+		taskManager.EXPECT().AllocTaskID().Return("RetryTaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				assert.Equal(t, "RetryTaskID", req.ParentTaskID)
+				plan := &models.PhysicalPlan{}
+				_ = encoding.JSONUnmarshal(req.PhysicalPlan, plan)
+				assert.Equal(t, "1.1.1.3:8000", plan.Leafs[0].Parent)
+				assert.Equal(t, []int32{2}, plan.Leafs[0].ShardIDs)
+				assert.Equal(t, []int32{1}, plan.Leafs[1].ShardIDs)
+				return nil
+			}),
+		taskManager.EXPECT().SendRequest("1.1.1.4:9000", gomock.Any()).Return(nil),
+	)
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	// all shards are retried, the result is complete
+	assert.Empty(t, jobCtx.Failures())
+
+	// records the failure if no alternative replica
+	replicaStateMachine.EXPECT().
+		GetAlternativeReplicas("test_db", []int32{1},
+			map[string]struct{}{"1.1.1.1:9000": {}, "1.1.1.4:9000": {}}).
+		Return(nil)
+	jobManager1.RetryTask(1, "RetryTaskID", "1.1.1.4:9000", errNoShard.Error())
+	assert.Equal(t, map[string]string{"1.1.1.4:9000": errNoShard.Error()}, jobCtx.Failures())
+	// records the failure if not leaf node of the task
+	jobManager1.RetryTask(1, "TaskID", "1.1.1.5:8000", errNoShard.Error())
+	jobManager1.RetryTask(1, "NotExistTaskID", "1.1.1.2:9000", errNoShard.Error())
+	assert.Len(t, jobCtx.Failures(), 3)
+	// ignores the not exist job
+	jobManager1.RetryTask(100, "TaskID", "1.1.1.2:9000", "err")
+
+	// cancels the tasks includes the retry tasks
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(target string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			return nil
+		}).Times(4)
+	taskManager.EXPECT().Complete("RetryTaskID")
+	taskManager.EXPECT().Complete("TaskID")
+	cancel()
+	<-jobCtx.Context().Done()
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, jobCtx.Completed())
+	assert.Nil(t, jobManager1.GetJob(1))
+
+	// fails to send the retry task, retries again, completes the job if no pending task
+	jobCtx = NewJobContext(context.Background(), make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("TaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(errTaskSend),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(errTaskSend),
+	)
+	replicaStateMachine.EXPECT().GetAlternativeReplicas("test_db", []int32{1, 2}, gomock.Any()).
+		Return(map[string][]int32{"1.1.1.4:9000": {1, 2}})
+	replicaStateMachine.EXPECT().GetAlternativeReplicas("test_db", []int32{3}, gomock.Any()).Return(nil)
+	replicaStateMachine.EXPECT().GetAlternativeReplicas("test_db", []int32{1, 2}, gomock.Any()).Return(nil)
+	taskManager.EXPECT().AllocTaskID().Return("RetryTaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.4:9000", gomock.Any()).Return(errNoSendStream)
+	taskManager.EXPECT().Complete("RetryTaskID")
+	taskManager.EXPECT().Complete("TaskID")
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	assert.True(t, jobCtx.Completed())
+	assert.Equal(t, map[string]string{"1.1.1.2:9000": errTaskSend.Error(), "1.1.1.4:9000": errNoSendStream.Error()}, jobCtx.Failures())
+}
+
+func TestJobManager_RetryTask_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID")
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	taskManager.EXPECT().Complete("TaskID").AnyTimes()
+	jobManager1 := NewJobManager(taskManager, nil, 0)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1}})
+	jobCtx := NewJobContext(context.Background(), make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+
+	// the failure of query fails the job, the result isn't incomplete
+	jobManager1.RetryTask(1, "TaskID", "1.1.1.1:9000", "num. of series exceeds the limit")
+	assert.EqualError(t, jobCtx.Error(), "num. of series exceeds the limit")
+	assert.Empty(t, jobCtx.Failures())
+	<-jobCtx.Context().Done()
+}
+
+func TestJobManager_RetryTask_NotRetryQueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID")
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	taskManager.EXPECT().Complete("TaskID").AnyTimes()
+	// no alternative replica is queried for the failure of query
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	jobManager1 := NewJobManager(taskManager, replicaStateMachine, 0)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1}})
+	jobCtx := NewJobContext(context.Background(), make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	jobManager1.RetryTask(1, "TaskID", "1.1.1.1:9000", "time range span of query exceeds the limit")
+	assert.EqualError(t, jobCtx.Error(), "time range span of query exceeds the limit")
+	<-jobCtx.Context().Done()
+}
+
+func TestJobManager_LeafTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	var mutex sync.Mutex
+	tasks := make(map[string]TaskContext)
+	taskManager.EXPECT().Submit(gomock.Any()).Do(func(taskCtx TaskContext) {
+		mutex.Lock()
+		tasks[taskCtx.TaskID()] = taskCtx
+		mutex.Unlock()
+	}).AnyTimes()
+	taskManager.EXPECT().Get(gomock.Any()).DoAndReturn(func(taskID string) TaskContext {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return tasks[taskID]
+	}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	jobManager1 := NewJobManager(taskManager, replicaStateMachine, 100*time.Millisecond)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.Database = "test_db"
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"},
+		ShardIDs: []int32{2}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jobCtx := NewJobContext(ctx, make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	retried := make(chan struct{})
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("TaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).Return(nil),
+		// cancels the hung leaf task, then retries it on the alternative replica
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+				return nil
+			}),
+		replicaStateMachine.EXPECT().
+			GetAlternativeReplicas("test_db", []int32{1}, map[string]struct{}{"1.1.1.1:9000": {}}).
+			Return(map[string][]int32{"1.1.1.4:9000": {1}}),
+		taskManager.EXPECT().AllocTaskID().Return("RetryTaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.4:9000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				close(retried)
+				return nil
+			}),
+	)
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	// the result received before the deadline
+	assert.True(t, jobManager1.ReceiveLeafResult(1, "TaskID", "1.1.1.2:9000"))
+	<-retried
+	// the result of timeout leaf task is ignored
+	assert.False(t, jobManager1.ReceiveLeafResult(1, "TaskID", "1.1.1.1:9000"))
+	assert.True(t, jobManager1.ReceiveLeafResult(1, "RetryTaskID", "1.1.1.4:9000"))
+	assert.True(t, jobManager1.ReceiveLeafResult(2, "TaskID", "1.1.1.1:9000"))
+	assert.Empty(t, jobCtx.Failures())
+
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	taskManager.EXPECT().Complete("RetryTaskID")
+	taskManager.EXPECT().Complete("TaskID")
+	cancel()
+	<-jobCtx.Context().Done()
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, jobCtx.Completed())
+}
+
+func TestJobManager_IntermediateTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	var mutex sync.Mutex
+	tasks := make(map[string]TaskContext)
+	taskManager.EXPECT().Submit(gomock.Any()).Do(func(taskCtx TaskContext) {
+		mutex.Lock()
+		tasks[taskCtx.TaskID()] = taskCtx
+		mutex.Unlock()
+	}).AnyTimes()
+	taskManager.EXPECT().Get(gomock.Any()).DoAndReturn(func(taskID string) TaskContext {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return tasks[taskID]
+	}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	jobManager1 := NewJobManager(taskManager, replicaStateMachine, 50*time.Millisecond)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	physicalPlan.Database = "test_db"
+	physicalPlan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.4:8000"}, NumOfTask: 2})
+	physicalPlan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.5:8000"}, NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.2:9000"},
+		ShardIDs: []int32{2}})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.5:8000", Indicator: "1.1.1.6:9000"},
+		ShardIDs: []int32{3}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	jobCtx := NewJobContext(ctx, make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	redispatched := make(chan struct{})
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("TaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.4:8000", gomock.Any()).Return(nil),
+		taskManager.EXPECT().SendRequest("1.1.1.5:8000", gomock.Any()).Return(nil),
+		// the failure of leaf node forwarded by intermediate node, retries on the alternative replica
+		replicaStateMachine.EXPECT().
+			GetAlternativeReplicas("test_db", []int32{2}, map[string]struct{}{"1.1.1.2:9000": {}}).
+			Return(map[string][]int32{"1.1.1.7:9000": {2}}),
+		taskManager.EXPECT().AllocTaskID().Return("RetryTaskID1"),
+		taskManager.EXPECT().SendRequest("1.1.1.7:9000", gomock.Any()).Return(nil),
+		// cancels the hung intermediate task, then re-dispatches the leaf tasks which aren't failed
+		taskManager.EXPECT().SendRequest("1.1.1.4:8000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+				return nil
+			}),
+		taskManager.EXPECT().AllocTaskID().Return("RetryTaskID2"),
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+			DoAndReturn(func(target string, req *pb.TaskRequest) error {
+				retryPlan := &models.PhysicalPlan{}
+				assert.NoError(t, encoding.JSONUnmarshal(req.PhysicalPlan, retryPlan))
+				assert.Equal(t, []models.Leaf{{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000",
+					Indicator: "1.1.1.1:9000"}, ShardIDs: []int32{1}}}, retryPlan.Leafs)
+				close(redispatched)
+				return nil
+			}),
+	)
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	assert.True(t, jobManager1.ReceiveLeafResult(1, "TaskID", "1.1.1.5:8000"))
+	jobManager1.RetryTask(1, "TaskID", "1.1.1.2:9000", errTaskSend.Error())
+	assert.True(t, jobManager1.ReceiveLeafResult(1, "RetryTaskID1", "1.1.1.7:9000"))
+	<-redispatched
+	assert.True(t, jobManager1.ReceiveLeafResult(1, "RetryTaskID2", "1.1.1.1:9000"))
+	// the result of timeout intermediate task is ignored
+	assert.False(t, jobManager1.ReceiveLeafResult(1, "TaskID", "1.1.1.4:8000"))
+	// the failure forwarded by the timeout intermediate task is ignored, the leaf task has been re-dispatched
+	jobManager1.RetryTask(1, "TaskID", "1.1.1.1:9000", errLeafTimeout.Error())
+	assert.Empty(t, jobCtx.Failures())
+
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	taskManager.EXPECT().Complete("RetryTaskID1")
+	taskManager.EXPECT().Complete("RetryTaskID2")
+	taskManager.EXPECT().Complete("TaskID")
+	cancel()
+	<-jobCtx.Context().Done()
+	time.Sleep(10 * time.Millisecond)
+	assert.True(t, jobCtx.Completed())
+}
+
+func TestJobManager_SendIntermediateFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().Get(gomock.Any()).Return(nil).AnyTimes()
+	jobManager1 := NewJobManager(taskManager, nil, 0)
+
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.4:8000"}, NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{BaseNode: models.BaseNode{Parent: "1.1.1.4:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1}})
+	jobCtx := NewJobContext(context.Background(), make(chan series.GroupedIterator), physicalPlan, &stmt.Query{}, "")
+	gomock.InOrder(
+		taskManager.EXPECT().AllocTaskID().Return("TaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.4:8000", gomock.Any()).Return(errTaskSend),
+		// re-dispatches the leaf tasks of the intermediate node which fails to send
+		taskManager.EXPECT().AllocTaskID().Return("RetryTaskID"),
+		taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil),
+	)
+	assert.NoError(t, jobManager1.SubmitJob(jobCtx))
+	assert.Empty(t, jobCtx.Failures())
+	assert.False(t, jobCtx.Completed())
+}
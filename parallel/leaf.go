@@ -1,12 +1,16 @@
 package parallel
 
 import (
+	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 // leafTask represents the leaf node's task, the leaf node is always storage node
@@ -17,22 +21,71 @@ type leafTask struct {
 	storageService    service.StorageService
 	executorFactory   ExecutorFactory
 	taskServerFactory rpc.TaskServerFactory
+	compressThreshold int // compresses the payload of response if exceeds, negative means no compression
+
+	running sync.Map // leafTaskKey => context.CancelFunc, for canceling the running or queued task
+}
+
+// leafTaskKey represents the key of the running leaf task
+type leafTaskKey struct {
+	jobID        int64
+	parentTaskID string
 }
 
 // newLeafTask creates the leaf task
 func newLeafTask(currentNode models.Node,
 	storageService service.StorageService,
-	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory) TaskProcessor {
+	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory, compressThreshold int) *leafTask {
 	return &leafTask{
 		currentNodeID:     (&currentNode).Indicator(),
 		storageService:    storageService,
 		executorFactory:   executorFactory,
 		taskServerFactory: taskServerFactory,
+		compressThreshold: compressThreshold,
 	}
 }
 
-// Process processes the task request, searches the metric's data from time series engine
+// Process processes the task request, searches the metric's data from time series engine,
+// if cancel request, cancels the running or queued task
 func (p *leafTask) Process(req *pb.TaskRequest) error {
+	if req.RequestType == pb.RequestType_Cancel {
+		p.cancel(req)
+		return nil
+	}
+	return p.process(p.register(req), req)
+}
+
+// register registers the cancel func of the task before queued or processed, returns the context of the task,
+// so the task canceled when queued is skipped
+func (p *leafTask) register(req *pb.TaskRequest) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.running.Store(leafTaskKey{jobID: req.JobID, parentTaskID: req.ParentTaskID}, cancel)
+	return ctx
+}
+
+// unregister removes the cancel func of the task when the task done or rejected
+func (p *leafTask) unregister(req *pb.TaskRequest) {
+	key := leafTaskKey{jobID: req.JobID, parentTaskID: req.ParentTaskID}
+	if cancel, ok := p.running.Load(key); ok {
+		p.running.Delete(key)
+		cancel.(context.CancelFunc)()
+	}
+}
+
+// cancel cancels the running or queued task
+func (p *leafTask) cancel(req *pb.TaskRequest) {
+	if cancel, ok := p.running.Load(leafTaskKey{jobID: req.JobID, parentTaskID: req.ParentTaskID}); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
+
+// process processes the registered task, skips the task if canceled when queued
+func (p *leafTask) process(ctx context.Context, req *pb.TaskRequest) error {
+	defer p.unregister(req)
+	if err := ctx.Err(); err != nil {
+		// the job is canceled by parent node, no result need to send
+		return err
+	}
 	physicalPlan := models.PhysicalPlan{}
 	if err := json.Unmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
@@ -50,23 +103,102 @@ func (p *leafTask) Process(req *pb.TaskRequest) error {
 	if !foundTask {
 		return errWrongRequest
 	}
+	query := stmt.Query{}
+	if err := encoding.JSONUnmarshal(req.Payload, &query); err != nil {
+		return errUnmarshalQuery
+	}
 	engine := p.storageService.GetEngine(physicalPlan.Database)
 	if engine == nil {
+		// reports the failure to parent node, the parent retries the task on the alternative replica,
+		// like the database isn't loaded when the node restarting
+		p.sendFailure(curLeaf.Parent, req, errNoDatabase)
 		return errNoDatabase
 	}
+	for _, shardID := range curLeaf.ShardIDs {
+		if engine.GetShard(shardID) == nil {
+			// reports the failure to parent node, the shard may be loaded on the alternative replica
+			p.sendFailure(curLeaf.Parent, req, errNoShard)
+			return errNoShard
+		}
+	}
 
 	stream := p.taskServerFactory.GetStream(curLeaf.Parent)
 	if stream == nil {
 		return errNoSendStream
 	}
+
+	// records the spans of storage execution under the trace propagated from the parent node
+	var trace *models.Trace
+	if req.TraceID != "" {
+		trace = models.NewTraceWithID(req.TraceID, "leaf task")
+		trace.Root.SetNode(p.currentNodeID)
+		ctx = models.WithTrace(ctx, trace)
+	}
+
+	exec := p.executorFactory.NewStorageExecutor(ctx, engine, curLeaf.ShardIDs, &query)
+	results := exec.Execute()
+	// merges the grouped series of all shards, sends the reduced result to parent node
+	merger := newResultMerger(newTopKPruner(&query))
+	if results != nil {
+		span := trace.StartSpan("aggregation")
+		for it := range results {
+			merger.mergeSeries(it)
+		}
+		span.Finish()
+	}
+
+	resp := &pb.TaskResponse{
+		JobID:     req.JobID,
+		TaskID:    req.ParentTaskID,
+		Completed: true,
+		SendNode:  p.currentNodeID,
+	}
+	if err := exec.Error(); err != nil {
+		// the partial result of failed task isn't sent, the shards may be retried on the alternative replicas
+		resp.ErrMsg = err.Error()
+	} else if payload, err := merger.payload(p.compressThreshold); err != nil {
+		resp.ErrMsg = err.Error()
+	} else {
+		resp.Payload = payload
+	}
+	// sends the stats for slow query log, includes the shard level stats if explain query
+	if stats := exec.Statistics(); stats != nil {
+		resp.Stats = encoding.JSONMarshal(stats)
+	}
+	if trace != nil {
+		trace.Root.Finish()
+		resp.TraceID = trace.TraceID
+		resp.Spans = encodeSpans(trace.Root)
+	}
+	return stream.Send(resp)
+}
+
+// reject rejects the task request without processing, reports the failure to the parent node,
+// the parent retries the task on the alternative replica, like the task queue is full
+func (p *leafTask) reject(req *pb.TaskRequest, err error) {
+	physicalPlan := models.PhysicalPlan{}
+	if err := json.Unmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
+		return
+	}
+	for _, leaf := range physicalPlan.Leafs {
+		if leaf.Indicator == p.currentNodeID {
+			p.sendFailure(leaf.Parent, req, err)
+			return
+		}
+	}
+}
+
+// sendFailure sends the failed task response to the parent node
+func (p *leafTask) sendFailure(parentNode string, req *pb.TaskRequest, err error) {
+	stream := p.taskServerFactory.GetStream(parentNode)
+	if stream == nil {
+		return
+	}
 	_ = stream.Send(&pb.TaskResponse{
 		JobID:     req.JobID,
 		TaskID:    req.ParentTaskID,
 		Completed: true,
+		ErrMsg:    err.Error(),
+		SendNode:  p.currentNodeID,
 	})
-
-	//TODO impl query logic and send task result to parent node
-	//exec := p.executorFactory.NewStorageExecutor(engine, curLeaf.ShardIDs, nil)
-	//exec.Execute()
-	return nil
 }
@@ -1,17 +1,23 @@
 package parallel
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
 )
 
 func TestLeafProcessor_Process(t *testing.T) {
@@ -23,7 +29,7 @@ func TestLeafProcessor_Process(t *testing.T) {
 	executorFactory := NewMockExecutorFactory(ctrl)
 
 	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
-	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory)
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, defaultPayloadCompressThreshold)
 	// unmarshal error
 	err := processor.Process(&pb.TaskRequest{PhysicalPlan: nil})
 	assert.Equal(t, errUnmarshalPlan, err)
@@ -39,22 +45,183 @@ func TestLeafProcessor_Process(t *testing.T) {
 		Database: "test_db",
 		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
 	})
-	// db not exist
-	storageService.EXPECT().GetEngine(gomock.Any()).Return(nil)
-	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan})
+	// unmarshal query error
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: []byte{1, 2, 3}})
+	assert.Equal(t, errUnmarshalQuery, err)
+
+	query := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu"})
+	// db not exist, reports the failure to parent node
+	serverStream := pb.NewMockTaskService_HandleServer(ctrl)
+	storageService.EXPECT().GetEngine(gomock.Any()).Return(nil).Times(2)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(nil)
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
+	assert.Equal(t, errNoDatabase, err)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, errNoDatabase.Error(), resp.ErrMsg)
+		assert.True(t, resp.Completed)
+		assert.Equal(t, "1.1.1.3:8000", resp.SendNode)
+		return nil
+	})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
 	assert.Equal(t, errNoDatabase, err)
 
 	engine := tsdb.NewMockEngine(ctrl)
-	storageService.EXPECT().GetEngine(gomock.Any()).Return(engine).MaxTimes(2)
+	storageService.EXPECT().GetEngine(gomock.Any()).Return(engine).AnyTimes()
+	// shard not exist, reports the failure to parent node
+	shardPlan := encoding.JSONMarshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}, ShardIDs: []int32{1}}},
+	})
+	engine.EXPECT().GetShard(int32(1)).Return(nil)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, errNoShard.Error(), resp.ErrMsg)
+		return nil
+	})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: shardPlan, Payload: query})
+	assert.Equal(t, errNoShard, err)
+
 	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(nil)
-	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
 	assert.Equal(t, errNoSendStream, err)
 
-	serverStream := pb.NewMockTaskService_HandleServer(ctrl)
-	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream)
-	serverStream.EXPECT().Send(gomock.Any()).Return(nil)
-	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan})
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream).AnyTimes()
+	exec := NewMockStorageExecutor(ctrl)
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(exec).Times(3)
+
+	// execute fail
+	exec.EXPECT().Execute().Return(nil)
+	exec.EXPECT().Error().Return(fmt.Errorf("err"))
+	exec.EXPECT().Statistics().Return(nil)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, "err", resp.ErrMsg)
+		assert.Equal(t, "1.1.1.3:8000", resp.SendNode)
+		return nil
+	})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sends the merged grouped series of all shards
+	ch := make(chan series.GroupedIterator, 2)
+	ch <- newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{1}},
+		}})
+	ch <- newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{2}},
+		}})
+	close(ch)
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Statistics().Return(nil)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		merger := newResultMerger(nil)
+		assert.NoError(t, merger.mergePayload(resp.Payload))
+		resultSet := merger.resultSet()
+		assert.Len(t, resultSet, 1)
+		assert.Equal(t, map[string]map[int]float64{"f": {1: 3}}, readTestSeries(resultSet[0]))
+		return nil
+	})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
+	assert.NoError(t, err)
+
+	// explain query
+	ch = make(chan series.GroupedIterator)
+	close(ch)
+	stats := models.NewStorageStats()
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Statistics().Return(stats)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, encoding.JSONMarshal(stats), resp.Stats)
+		return nil
+	})
+	query = encoding.JSONMarshal(&stmt.Query{Explain: true, MetricName: "cpu"})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query})
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	// traced query, sends the spans of leaf task
+	ch = make(chan series.GroupedIterator)
+	close(ch)
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, engine tsdb.Engine, shardIDs []int32, query *stmt.Query) StorageExecutor {
+			trace := models.TraceFromContext(ctx)
+			assert.Equal(t, "traceID", trace.ID())
+			trace.StartSpan("shard search").Finish()
+			return exec
+		})
+	exec.EXPECT().Execute().Return(ch)
+	exec.EXPECT().Error().Return(nil)
+	exec.EXPECT().Statistics().Return(nil)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, "traceID", resp.TraceID)
+		spans, err := decodeSpans(resp.Spans)
+		assert.NoError(t, err)
+		assert.Len(t, spans, 1)
+		assert.Equal(t, "leaf task", spans[0].Name)
+		assert.Equal(t, "1.1.1.3:8000", spans[0].Node)
+		assert.Len(t, spans[0].Children, 2)
+		return nil
+	})
+	err = processor.Process(&pb.TaskRequest{PhysicalPlan: plan, Payload: query, TraceID: "traceID"})
+	assert.NoError(t, err)
+}
+
+func TestLeafProcessor_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	storageService := service.NewMockStorageService(ctrl)
+	executorFactory := NewMockExecutorFactory(ctrl)
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, defaultPayloadCompressThreshold)
+
+	plan := encoding.JSONMarshal(&models.PhysicalPlan{
+		Database: "test_db",
+		Leafs:    []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+	})
+	query := encoding.JSONMarshal(&stmt.Query{MetricName: "cpu"})
+	storageService.EXPECT().GetEngine(gomock.Any()).Return(tsdb.NewMockEngine(ctrl))
+	serverStream := pb.NewMockTaskService_HandleServer(ctrl)
+	taskServerFactory.EXPECT().GetStream(gomock.Any()).Return(serverStream)
+	exec := NewMockStorageExecutor(ctrl)
+	started := make(chan struct{})
+	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, engine tsdb.Engine, shardIDs []int32, query *stmt.Query) StorageExecutor {
+			// executes until the task is canceled
+			exec.EXPECT().Execute().DoAndReturn(func() <-chan series.GroupedIterator {
+				close(started)
+				<-ctx.Done()
+				return nil
+			})
+			return exec
+		})
+	exec.EXPECT().Error().Return(fmt.Errorf("query is canceled"))
+	exec.EXPECT().Statistics().Return(models.NewStorageStats())
+	serverStream.EXPECT().Send(gomock.Any()).Return(nil)
+
+	done := make(chan error)
+	go func() {
+		done <- processor.Process(&pb.TaskRequest{JobID: 1, ParentTaskID: "taskID", PhysicalPlan: plan, Payload: query})
+	}()
+	<-started
+	// cancels the running task
+	err := processor.Process(&pb.TaskRequest{JobID: 1, ParentTaskID: "taskID", PhysicalPlan: plan,
+		RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	_, ok := processor.running.Load(leafTaskKey{jobID: 1, parentTaskID: "taskID"})
+	assert.False(t, ok)
+
+	// task not exist
+	err = processor.Process(&pb.TaskRequest{JobID: 2, ParentTaskID: "taskID", PhysicalPlan: plan,
+		RequestType: pb.RequestType_Cancel})
+	assert.NoError(t, err)
 }
@@ -0,0 +1,268 @@
+package parallel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/pkg/bit"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/stream"
+	"github.com/lindb/lindb/tsdb/field"
+)
+
+const (
+	// payloadRaw marks the payload body isn't compressed
+	payloadRaw byte = 0
+	// payloadSnappy marks the payload body is compressed by snappy
+	payloadSnappy byte = 1
+	// defaultPayloadCompressThreshold is the default min size of payload body which is compressed by snappy,
+	// small payload isn't compressed, because the cost of compression is more than the saved bytes.
+	defaultPayloadCompressThreshold = 1024
+	// payloadNoCompression disables compressing the payload body
+	payloadNoCompression = -1
+)
+
+// encodeSeriesPayloads encodes the grouped series into the compact binary payload of task response,
+// payload format: flag(1 byte, raw/snappy) + body(compressed by snappy if flag is snappy)
+// 1) dict: count(uvarint) + [len(uvarint) + string], includes tag keys/values and field names
+// 2) groups: count(uvarint) + [group]
+// 3) group: tag count(uvarint) + [key idx(uvarint) + value idx(uvarint)] + field count(uvarint) + [field]
+// 4) field: id(uint16) + name idx(uvarint) + type(1 byte) + primitive count(uvarint) + [primitive]
+// 5) primitive: id(uint16) + len(uvarint) + tsd data
+// the data points of primitive field are encoded by tsd encoder,
+// time slots are encoded as bit array based on the start slot, values are compressed by xor.
+// The body is compressed by snappy if its size >= compressThreshold, negative threshold means no compression.
+func encodeSeriesPayloads(groups []*seriesPayload, compressThreshold int) ([]byte, error) {
+	dict := newStringDict()
+	body := stream.NewBufferWriter(nil)
+	defer body.ReleaseBuffer()
+
+	body.PutUvarint32(uint32(len(groups)))
+	for _, group := range groups {
+		body.PutUvarint32(uint32(len(group.Tags)))
+		for key, value := range group.Tags {
+			body.PutUvarint32(dict.add(key))
+			body.PutUvarint32(dict.add(value))
+		}
+		body.PutUvarint32(uint32(len(group.Fields)))
+		for _, f := range group.Fields {
+			body.PutUInt16(f.ID)
+			body.PutUvarint32(dict.add(f.Name))
+			body.PutByte(byte(f.Type))
+			body.PutUvarint32(uint32(len(f.Primitives)))
+			for _, primitive := range f.Primitives {
+				body.PutUInt16(primitive.ID)
+				data, err := encodePrimitivePoints(primitive)
+				if err != nil {
+					return nil, err
+				}
+				body.PutUvarint32(uint32(len(data)))
+				body.PutBytes(data)
+			}
+		}
+	}
+	bodyBuf, err := body.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	writer := stream.NewBufferWriter(nil)
+	defer writer.ReleaseBuffer()
+	writer.PutUvarint32(uint32(len(dict.values)))
+	for _, value := range dict.values {
+		writer.PutUvarint32(uint32(len(value)))
+		writer.PutBytes([]byte(value))
+	}
+	writer.PutBytes(bodyBuf)
+	data, err := writer.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	if compressThreshold >= 0 && len(data) >= compressThreshold {
+		compressed := snappy.Encode(nil, data)
+		if len(compressed) < len(data) {
+			result = make([]byte, len(compressed)+1)
+			result[0] = payloadSnappy
+			copy(result[1:], compressed)
+			return result, nil
+		}
+	}
+	// copy the data, because the buffer of writer will be released
+	result = make([]byte, len(data)+1)
+	result[0] = payloadRaw
+	copy(result[1:], data)
+	return result, nil
+}
+
+// encodePrimitivePoints encodes the data points of primitive field by tsd encoder,
+// the time slots of primitive field must be sorted.
+func encodePrimitivePoints(primitive *primitivePayload) ([]byte, error) {
+	if len(primitive.Slots) == 0 {
+		return nil, nil
+	}
+	if len(primitive.Slots) != len(primitive.Values) {
+		return nil, fmt.Errorf("the count of slots/values not match for primitive field: %d", primitive.ID)
+	}
+	startSlot := primitive.Slots[0]
+	if startSlot < 0 {
+		return nil, fmt.Errorf("invalid time slot: %d for primitive field: %d", startSlot, primitive.ID)
+	}
+	encoder := encoding.NewTSDEncoder(startSlot)
+	slot := startSlot
+	for idx, pointSlot := range primitive.Slots {
+		if pointSlot < slot {
+			return nil, fmt.Errorf("time slots not sorted for primitive field: %d", primitive.ID)
+		}
+		for ; slot < pointSlot; slot++ {
+			encoder.AppendTime(bit.Zero)
+		}
+		encoder.AppendTime(bit.One)
+		encoder.AppendValue(math.Float64bits(primitive.Values[idx]))
+		slot++
+	}
+	data, err := encoder.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// copy the data, because the buffer of tsd encoder is put back to buffer pool
+	result := make([]byte, len(data))
+	copy(result, data)
+	return result, nil
+}
+
+// decodeSeriesPayloads decodes the grouped series from the binary payload of task response
+func decodeSeriesPayloads(payload []byte) ([]*seriesPayload, error) {
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("empty payload of task response")
+	}
+	data := payload[1:]
+	switch payload[0] {
+	case payloadRaw:
+	case payloadSnappy:
+		var err error
+		if data, err = snappy.Decode(nil, data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown payload flag: %d", payload[0])
+	}
+
+	reader := stream.NewReader(data)
+	dictCount := int(reader.ReadUvarint32())
+	if reader.Error() != nil {
+		return nil, reader.Error()
+	}
+	if dictCount > len(data) {
+		return nil, fmt.Errorf("invalid dict count: %d of payload", dictCount)
+	}
+	dict := make([]string, dictCount)
+	for idx := range dict {
+		length := int(reader.ReadUvarint32())
+		if length > len(data) {
+			return nil, fmt.Errorf("invalid length: %d of dict value", length)
+		}
+		dict[idx] = string(reader.ReadBytes(length))
+	}
+	lookup := func(idx uint32) (string, error) {
+		if int(idx) >= len(dict) {
+			return "", fmt.Errorf("dict index: %d out of range: %d", idx, len(dict))
+		}
+		return dict[idx], nil
+	}
+
+	groupCount := int(reader.ReadUvarint32())
+	if err := reader.Error(); err != nil {
+		return nil, err
+	}
+	var groups []*seriesPayload
+	for i := 0; i < groupCount; i++ {
+		group := &seriesPayload{Tags: make(map[string]string)}
+		tagCount := int(reader.ReadUvarint32())
+		for j := 0; j < tagCount && reader.Error() == nil; j++ {
+			key, err := lookup(reader.ReadUvarint32())
+			if err != nil {
+				return nil, err
+			}
+			value, err := lookup(reader.ReadUvarint32())
+			if err != nil {
+				return nil, err
+			}
+			group.Tags[key] = value
+		}
+		fieldCount := int(reader.ReadUvarint32())
+		for j := 0; j < fieldCount && reader.Error() == nil; j++ {
+			f := &fieldPayload{ID: reader.ReadUint16()}
+			name, err := lookup(reader.ReadUvarint32())
+			if err != nil {
+				return nil, err
+			}
+			f.Name = name
+			f.Type = field.Type(reader.ReadByte())
+			primitiveCount := int(reader.ReadUvarint32())
+			for k := 0; k < primitiveCount && reader.Error() == nil; k++ {
+				primitive := &primitivePayload{ID: reader.ReadUint16()}
+				length := int(reader.ReadUvarint32())
+				if length > len(data) {
+					return nil, fmt.Errorf("invalid length: %d of primitive field's data", length)
+				}
+				if length > 0 {
+					if err := decodePrimitivePoints(primitive, reader.ReadBytes(length)); err != nil {
+						return nil, err
+					}
+				}
+				f.Primitives = append(f.Primitives, primitive)
+			}
+			group.Fields = append(group.Fields, f)
+		}
+		if err := reader.Error(); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// decodePrimitivePoints decodes the data points of primitive field from tsd data
+func decodePrimitivePoints(primitive *primitivePayload, data []byte) error {
+	decoder := encoding.NewTSDDecoder(data)
+	if err := decoder.Error(); err != nil {
+		return err
+	}
+	// each time slot takes one bit at least
+	if decoder.EndTime()-decoder.StartTime()+1 > len(data)*8 {
+		return fmt.Errorf("invalid time slots of primitive field: %d", primitive.ID)
+	}
+	for slot := decoder.StartTime(); decoder.Next(); slot++ {
+		if decoder.HasValue() {
+			primitive.Slots = append(primitive.Slots, slot)
+			primitive.Values = append(primitive.Values, math.Float64frombits(decoder.Value()))
+		}
+	}
+	return nil
+}
+
+// stringDict represents the dictionary of strings in payload, string => index
+type stringDict struct {
+	indexes map[string]uint32
+	values  []string
+}
+
+// newStringDict creates the string dictionary
+func newStringDict() *stringDict {
+	return &stringDict{indexes: make(map[string]uint32)}
+}
+
+// add adds the string into dictionary if not exist, returns the index of string
+func (d *stringDict) add(value string) uint32 {
+	idx, ok := d.indexes[value]
+	if !ok {
+		idx = uint32(len(d.values))
+		d.indexes[value] = idx
+		d.values = append(d.values, value)
+	}
+	return idx
+}
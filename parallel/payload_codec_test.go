@@ -0,0 +1,116 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/tsdb/field"
+)
+
+func TestPayloadCodec_EncodeDecode(t *testing.T) {
+	groups := []*seriesPayload{
+		{Tags: map[string]string{"host": "1.1.1.1", "ip": "1.1.1.1"}, Fields: []*fieldPayload{
+			{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+				{ID: 1, Slots: []int{1, 2, 10}, Values: []float64{1, 2.5, -3}},
+				{ID: 2},
+			}},
+			{ID: 2, Name: "max", Type: field.MaxField, Primitives: []*primitivePayload{
+				{ID: 1, Slots: []int{0}, Values: []float64{10}},
+			}},
+		}},
+		{Tags: map[string]string{}, Fields: []*fieldPayload{
+			{ID: 1, Name: "f", Type: field.SumField},
+		}},
+	}
+	data, err := encodeSeriesPayloads(groups, defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	assert.Equal(t, payloadRaw, data[0])
+	result, err := decodeSeriesPayloads(data)
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+
+	// empty groups
+	data, err = encodeSeriesPayloads(nil, defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	result, err = decodeSeriesPayloads(data)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestPayloadCodec_Compress(t *testing.T) {
+	var groups []*seriesPayload
+	for i := 0; i < 100; i++ {
+		groups = append(groups, &seriesPayload{
+			Tags: map[string]string{"host": fmt.Sprintf("host-%d", i)},
+			Fields: []*fieldPayload{{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+				{ID: 1, Slots: []int{1, 2, 3}, Values: []float64{1, 1, 1}},
+			}}},
+		})
+	}
+	data, err := encodeSeriesPayloads(groups, defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	assert.Equal(t, payloadSnappy, data[0])
+	result, err := decodeSeriesPayloads(data)
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+
+	// compression disabled, the large payload isn't compressed
+	data, err = encodeSeriesPayloads(groups, payloadNoCompression)
+	assert.NoError(t, err)
+	assert.Equal(t, payloadRaw, data[0])
+	result, err = decodeSeriesPayloads(data)
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+
+	// below the threshold
+	data, err = encodeSeriesPayloads(groups, len(data))
+	assert.NoError(t, err)
+	assert.Equal(t, payloadRaw, data[0])
+	result, err = decodeSeriesPayloads(data)
+	assert.NoError(t, err)
+	assert.Equal(t, groups, result)
+}
+
+func TestPayloadCodec_EncodeFailure(t *testing.T) {
+	cases := []*primitivePayload{
+		{ID: 1, Slots: []int{1, 2}, Values: []float64{1}},
+		{ID: 1, Slots: []int{-1}, Values: []float64{1}},
+		{ID: 1, Slots: []int{2, 1}, Values: []float64{1, 2}},
+	}
+	for _, primitive := range cases {
+		_, err := encodeSeriesPayloads([]*seriesPayload{{Fields: []*fieldPayload{
+			{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{primitive}},
+		}}}, defaultPayloadCompressThreshold)
+		assert.Error(t, err)
+	}
+}
+
+func TestPayloadCodec_DecodeFailure(t *testing.T) {
+	data, err := encodeSeriesPayloads([]*seriesPayload{
+		{Tags: map[string]string{"host": "1.1.1.1"}, Fields: []*fieldPayload{
+			{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+				{ID: 1, Slots: []int{1, 2}, Values: []float64{1, 2}},
+			}},
+		}},
+	}, defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	// truncated payload
+	for i := 1; i < len(data); i++ {
+		_, err = decodeSeriesPayloads(data[:i])
+		assert.Error(t, err)
+	}
+	cases := [][]byte{
+		nil,
+		{10},                        // unknown flag
+		{payloadSnappy, 1},          // invalid snappy data
+		{payloadRaw, 100},           // invalid dict count
+		{payloadRaw, 1, 10},         // invalid dict value length
+		{payloadRaw, 0, 1, 1, 1, 0}, // dict index out of range
+	}
+	for _, data := range cases {
+		_, err = decodeSeriesPayloads(data)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,368 @@
+package parallel
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// resultMerger merges the grouped series of task results by group tags, reduces the data before sending to parent,
+// 1) leaf node merges the grouped series of all shards
+// 2) intermediate node merges the results of leaf nodes, forwards a single reduced payload to root
+// 3) root node merges the results of sub tasks, then emits the merged groups into the result set of job
+// the values of same primitive field and time slot are aggregated by the agg func of primitive field.
+type resultMerger struct {
+	groups map[string]*mergedGroup
+	keys   []string    // keeps the order of groups received
+	pruner *topKPruner // prunes the groups before sending to parent node, nil if disable
+	mutex  sync.Mutex
+}
+
+// newResultMerger creates the result merger, the pruner is optional
+func newResultMerger(pruner *topKPruner) *resultMerger {
+	return &resultMerger{
+		groups: make(map[string]*mergedGroup),
+		pruner: pruner,
+	}
+}
+
+// mergeSeries merges the grouped series into the group with same tags
+func (m *resultMerger) mergeSeries(it series.GroupedIterator) {
+	if it == nil {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := models.TagsAsString(it.Tags())
+	group, ok := m.groups[key]
+	if !ok {
+		group = &mergedGroup{tags: it.Tags(), fields: make(map[string]*mergedField)}
+		m.groups[key] = group
+		m.keys = append(m.keys, key)
+	}
+	for it.HasNext() {
+		group.merge(it.Next())
+	}
+}
+
+// mergePayload decodes the payload of task response, merges the grouped series of it
+func (m *resultMerger) mergePayload(payload []byte) error {
+	groups, err := decodeSeriesPayloads(payload)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		m.mergeSeries(newPayloadSeries(group))
+	}
+	return nil
+}
+
+// payload encodes the merged groups into the binary payload of task response, returns nil if no group,
+// the groups which are not selected by topk/bottomk functions are pruned if the pruner is set,
+// the payload is compressed if exceeds the threshold, negative threshold means no compression.
+func (m *resultMerger) payload(compressThreshold int) ([]byte, error) {
+	groups := m.pruner.prune(m.seriesPayloads())
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	return encodeSeriesPayloads(groups, compressThreshold)
+}
+
+// resultSet returns the merged groups, order by the group received
+func (m *resultMerger) resultSet() []series.GroupedIterator {
+	groups := m.seriesPayloads()
+	resultSet := make([]series.GroupedIterator, len(groups))
+	for idx, group := range groups {
+		resultSet[idx] = newPayloadSeries(group)
+	}
+	return resultSet
+}
+
+// seriesPayloads converts the merged groups to the grouped series of payload
+func (m *resultMerger) seriesPayloads() []*seriesPayload {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	groups := make([]*seriesPayload, 0, len(m.keys))
+	for _, key := range m.keys {
+		groups = append(groups, m.groups[key].payload())
+	}
+	return groups
+}
+
+// mergedGroup represents the merged fields of the group
+type mergedGroup struct {
+	tags   map[string]string
+	fields map[string]*mergedField
+	names  []string // keeps the order of fields received
+}
+
+// merge merges the field's data into the field with same name
+func (g *mergedGroup) merge(it series.FieldIterator) {
+	if it == nil {
+		return
+	}
+	f, ok := g.fields[it.FieldName()]
+	if !ok {
+		f = &mergedField{id: it.FieldID(), fieldType: it.FieldType(), primitives: make(map[uint16]map[int]float64)}
+		g.fields[it.FieldName()] = f
+		g.names = append(g.names, it.FieldName())
+	}
+	var selected map[uint16]map[int]float64
+	for it.HasNext() {
+		primitiveIt := it.Next()
+		if primitiveIt == nil {
+			continue
+		}
+		if !f.isSelected(primitiveIt.FieldID()) {
+			f.merge(primitiveIt)
+			continue
+		}
+		// keeps the values and timestamps until all primitive fields of the field received
+		if selected == nil {
+			selected = make(map[uint16]map[int]float64)
+		}
+		points, ok := selected[primitiveIt.FieldID()]
+		if !ok {
+			points = make(map[int]float64)
+			selected[primitiveIt.FieldID()] = points
+		}
+		for primitiveIt.HasNext() {
+			slot, value := primitiveIt.Next()
+			points[slot] = value
+		}
+	}
+	f.mergeSelected(selected)
+}
+
+// payload converts the merged group to the grouped series of payload
+func (g *mergedGroup) payload() *seriesPayload {
+	group := &seriesPayload{Tags: g.tags}
+	for _, name := range g.names {
+		group.Fields = append(group.Fields, g.fields[name].payload(name))
+	}
+	return group
+}
+
+// mergedField represents the merged primitive fields of the field, primitive field id => time slot => value
+type mergedField struct {
+	id         uint16
+	fieldType  field.Type
+	primitives map[uint16]map[int]float64
+}
+
+// isSelected returns if the values of primitive field are selected by timestamps, like first/last
+func (f *mergedField) isSelected(primitiveID uint16) bool {
+	if _, ok := field.GetTimeFieldID(f.fieldType, primitiveID); ok {
+		return true
+	}
+	return field.IsTimeField(f.fieldType, primitiveID)
+}
+
+// mergeSelected merges the values which are selected by timestamps at same time slot,
+// the value with the first/last timestamp is kept with its timestamp.
+func (f *mergedField) mergeSelected(selected map[uint16]map[int]float64) {
+	for primitiveID, values := range selected {
+		timeFieldID, ok := field.GetTimeFieldID(f.fieldType, primitiveID)
+		if !ok {
+			continue
+		}
+		timestamps, ok := selected[timeFieldID]
+		if !ok {
+			continue
+		}
+		points := f.points(primitiveID)
+		timePoints := f.points(timeFieldID)
+		selectFunc := mergeAggFunc(f.fieldType, timeFieldID)
+		for slot, value := range values {
+			timestamp, ok := timestamps[slot]
+			if !ok {
+				continue
+			}
+			if oldTimestamp, exist := timePoints[slot]; exist &&
+				selectFunc.AggregateFloat(oldTimestamp, timestamp) == oldTimestamp {
+				continue
+			}
+			points[slot] = value
+			timePoints[slot] = timestamp
+		}
+	}
+}
+
+// points returns the data points of primitive field, creates it if not exist
+func (f *mergedField) points(primitiveID uint16) map[int]float64 {
+	points, ok := f.primitives[primitiveID]
+	if !ok {
+		points = make(map[int]float64)
+		f.primitives[primitiveID] = points
+	}
+	return points
+}
+
+// merge aggregates the data points of primitive field by time slot
+func (f *mergedField) merge(it series.PrimitiveIterator) {
+	points := f.points(it.FieldID())
+	aggFunc := mergeAggFunc(f.fieldType, it.FieldID())
+	for it.HasNext() {
+		slot, value := it.Next()
+		if oldValue, exist := points[slot]; exist {
+			points[slot] = aggFunc.AggregateFloat(oldValue, value)
+		} else {
+			points[slot] = value
+		}
+	}
+}
+
+// payload converts the merged field to the field of payload, the data points are sorted by time slot
+func (f *mergedField) payload(name string) *fieldPayload {
+	result := &fieldPayload{ID: f.id, Name: name, Type: f.fieldType}
+	primitiveIDs := make([]int, 0, len(f.primitives))
+	for primitiveID := range f.primitives {
+		primitiveIDs = append(primitiveIDs, int(primitiveID))
+	}
+	sort.Ints(primitiveIDs)
+	for _, primitiveID := range primitiveIDs {
+		points := f.primitives[uint16(primitiveID)]
+		primitive := &primitivePayload{ID: uint16(primitiveID)}
+		for slot := range points {
+			primitive.Slots = append(primitive.Slots, slot)
+		}
+		sort.Ints(primitive.Slots)
+		for _, slot := range primitive.Slots {
+			primitive.Values = append(primitive.Values, points[slot])
+		}
+		result.Primitives = append(result.Primitives, primitive)
+	}
+	return result
+}
+
+// mergeAggFunc returns the agg func for merging the values of primitive field at same time slot,
+// the primitive field is aggregated by the same function as down sampling, like min/max/count of sum field.
+func mergeAggFunc(fieldType field.Type, primitiveID uint16) field.AggFunc {
+	aggType, ok := field.GetAggType(fieldType, primitiveID)
+	if !ok {
+		switch fieldType {
+		case field.MinField:
+			aggType = field.Min
+		case field.MaxField:
+			aggType = field.Max
+		default:
+			aggType = field.Sum
+		}
+	}
+	return field.GetAggFunc(aggType)
+}
+
+// seriesPayload represents the grouped series in the payload of task response
+type seriesPayload struct {
+	Tags   map[string]string `json:"tags"`
+	Fields []*fieldPayload   `json:"fields"`
+}
+
+// fieldPayload represents the field's data of grouped series
+type fieldPayload struct {
+	ID         uint16              `json:"id"`
+	Name       string              `json:"name"`
+	Type       field.Type          `json:"type"`
+	Primitives []*primitivePayload `json:"primitives"`
+}
+
+// primitivePayload represents the data points of primitive field, the slots and values are one-to-one
+type primitivePayload struct {
+	ID     uint16    `json:"id"`
+	Slots  []int     `json:"slots"`
+	Values []float64 `json:"values"`
+}
+
+// payloadSeries implements the grouped iterator over the grouped series of payload
+type payloadSeries struct {
+	series *seriesPayload
+	idx    int
+}
+
+// newPayloadSeries creates the grouped iterator for the grouped series of payload
+func newPayloadSeries(s *seriesPayload) series.GroupedIterator {
+	return &payloadSeries{series: s}
+}
+
+// Tags returns group tags
+func (s *payloadSeries) Tags() map[string]string {
+	return s.series.Tags
+}
+
+// SeriesID returns 0, because the grouped series is merged from multi-series
+func (s *payloadSeries) SeriesID() uint32 {
+	return 0
+}
+
+// HasNext returns if the iteration has more field's iterator
+func (s *payloadSeries) HasNext() bool {
+	return s.idx < len(s.series.Fields)
+}
+
+// Next returns the field's iterator
+func (s *payloadSeries) Next() series.FieldIterator {
+	f := s.series.Fields[s.idx]
+	s.idx++
+	return &payloadFieldIterator{field: f}
+}
+
+// payloadFieldIterator represents the field's iterator of payload
+type payloadFieldIterator struct {
+	field *fieldPayload
+	idx   int
+}
+
+// FieldID returns the field's id
+func (it *payloadFieldIterator) FieldID() uint16 {
+	return it.field.ID
+}
+
+// FieldName returns the field's name
+func (it *payloadFieldIterator) FieldName() string {
+	return it.field.Name
+}
+
+// FieldType returns the field's type
+func (it *payloadFieldIterator) FieldType() field.Type {
+	return it.field.Type
+}
+
+// HasNext returns if the iteration has more primitive fields
+func (it *payloadFieldIterator) HasNext() bool {
+	return it.idx < len(it.field.Primitives)
+}
+
+// Next returns the primitive field's iterator
+func (it *payloadFieldIterator) Next() series.PrimitiveIterator {
+	primitive := it.field.Primitives[it.idx]
+	it.idx++
+	return &payloadPrimitiveIterator{primitive: primitive}
+}
+
+// payloadPrimitiveIterator represents the primitive field's iterator of payload
+type payloadPrimitiveIterator struct {
+	primitive *primitivePayload
+	idx       int
+}
+
+// FieldID returns the primitive field id
+func (it *payloadPrimitiveIterator) FieldID() uint16 {
+	return it.primitive.ID
+}
+
+// HasNext returns if the iteration has more data points
+func (it *payloadPrimitiveIterator) HasNext() bool {
+	return it.idx < len(it.primitive.Slots) && it.idx < len(it.primitive.Values)
+}
+
+// Next returns the data point in the iteration
+func (it *payloadPrimitiveIterator) Next() (timeSlot int, value float64) {
+	timeSlot, value = it.primitive.Slots[it.idx], it.primitive.Values[it.idx]
+	it.idx++
+	return timeSlot, value
+}
@@ -0,0 +1,154 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestResultMerger_Merge(t *testing.T) {
+	merger := newResultMerger(nil)
+	payload, err := merger.payload(defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	assert.Nil(t, payload)
+	assert.Empty(t, merger.resultSet())
+
+	merger.mergeSeries(nil)
+	merger.mergeSeries(newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1, 2}, Values: []float64{1, 2}},
+		}},
+		&fieldPayload{ID: 2, Name: "max", Type: field.MaxField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{10}},
+		}},
+	))
+	merger.mergeSeries(newTestSeries(map[string]string{"host": "1.1.1.2"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{5}},
+		}},
+	))
+	// merges the group with same tags from other node
+	other := newResultMerger(nil)
+	other.mergeSeries(newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 3, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{2, 3}, Values: []float64{3, 4}},
+		}},
+		&fieldPayload{ID: 2, Name: "max", Type: field.MaxField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{8}},
+		}},
+		&fieldPayload{ID: 4, Name: "min", Type: field.MinField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1, 1}, Values: []float64{8, 6}},
+		}},
+	))
+	payload, err = other.payload(defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	assert.NoError(t, merger.mergePayload(payload))
+
+	resultSet := merger.resultSet()
+	assert.Len(t, resultSet, 2)
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, resultSet[0].Tags())
+	assert.Equal(t, uint32(0), resultSet[0].SeriesID())
+	assert.Equal(t, map[string]map[int]float64{
+		"f":   {1: 1, 2: 5, 3: 4},
+		"max": {1: 10},
+		"min": {1: 6},
+	}, readTestSeries(resultSet[0]))
+	assert.Equal(t, map[string]string{"host": "1.1.1.2"}, resultSet[1].Tags())
+	assert.Equal(t, map[string]map[int]float64{"f": {1: 5}}, readTestSeries(resultSet[1]))
+
+	// decode payload failure
+	assert.Error(t, merger.mergePayload([]byte{1, 2, 3}))
+}
+
+func TestResultMerger_MergeAggFunc(t *testing.T) {
+	// primitive fields aggregated from sum field for multi-functions
+	assert.Equal(t, 3.0, mergeAggFunc(field.SumField, 1).AggregateFloat(1, 2))
+	assert.Equal(t, 1.0, mergeAggFunc(field.SumField, 2).AggregateFloat(1, 2))
+	assert.Equal(t, 2.0, mergeAggFunc(field.SumField, 3).AggregateFloat(1, 2))
+	assert.Equal(t, 3.0, mergeAggFunc(field.SumField, 4).AggregateFloat(1, 2))
+	assert.Equal(t, 1.0, mergeAggFunc(field.MinField, 1).AggregateFloat(1, 2))
+	assert.Equal(t, 2.0, mergeAggFunc(field.MaxField, 1).AggregateFloat(1, 2))
+	assert.Equal(t, 3.0, mergeAggFunc(field.HistogramField, 1).AggregateFloat(1, 2))
+}
+
+func TestResultMerger_FirstLast(t *testing.T) {
+	merger := newResultMerger(nil)
+	tags := map[string]string{"host": "1.1.1.1"}
+	merger.mergeSeries(newTestSeries(tags,
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 4, Slots: []int{1, 2}, Values: []float64{2, 1}},
+			{ID: 5, Slots: []int{1, 2}, Values: []float64{10, 20}},
+			{ID: 6, Slots: []int{1, 2}, Values: []float64{1000, 2000}},
+			{ID: 7, Slots: []int{1, 2}, Values: []float64{11, 20}},
+			{ID: 8, Slots: []int{1, 2}, Values: []float64{1500, 2000}},
+		}},
+	))
+	// data of other shard/node, the time primitive fields arrive before value primitive fields
+	merger.mergeSeries(newTestSeries(tags,
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 8, Slots: []int{1, 2, 3}, Values: []float64{1200, 2500, 3000}},
+			{ID: 7, Slots: []int{1, 2, 3}, Values: []float64{12, 25, 30}},
+			{ID: 6, Slots: []int{1, 2, 3}, Values: []float64{900, 2500, 3000}},
+			{ID: 5, Slots: []int{1, 2, 3}, Values: []float64{9, 25, 30}},
+			{ID: 4, Slots: []int{1, 3}, Values: []float64{3, 1}},
+			// value without timestamp
+			{ID: 5, Slots: []int{4}, Values: []float64{40}},
+		}},
+	))
+	groups := merger.seriesPayloads()
+	assert.Len(t, groups, 1)
+	assert.Equal(t, []*primitivePayload{
+		{ID: 4, Slots: []int{1, 2, 3}, Values: []float64{5, 1, 1}},
+		{ID: 5, Slots: []int{1, 2, 3}, Values: []float64{9, 20, 30}},
+		{ID: 6, Slots: []int{1, 2, 3}, Values: []float64{900, 2000, 3000}},
+		{ID: 7, Slots: []int{1, 2, 3}, Values: []float64{11, 25, 30}},
+		{ID: 8, Slots: []int{1, 2, 3}, Values: []float64{1500, 2500, 3000}},
+	}, groups[0].Fields[0].Primitives)
+}
+
+func TestResultMerger_FieldIterator(t *testing.T) {
+	it := newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 2, Slots: []int{1}, Values: []float64{1}},
+		}})
+	assert.True(t, it.HasNext())
+	fieldIt := it.Next()
+	assert.False(t, it.HasNext())
+	assert.Equal(t, uint16(1), fieldIt.FieldID())
+	assert.Equal(t, "f", fieldIt.FieldName())
+	assert.Equal(t, field.SumField, fieldIt.FieldType())
+	assert.True(t, fieldIt.HasNext())
+	primitiveIt := fieldIt.Next()
+	assert.False(t, fieldIt.HasNext())
+	assert.Equal(t, uint16(2), primitiveIt.FieldID())
+	assert.True(t, primitiveIt.HasNext())
+	slot, value := primitiveIt.Next()
+	assert.Equal(t, 1, slot)
+	assert.Equal(t, 1.0, value)
+	assert.False(t, primitiveIt.HasNext())
+}
+
+func newTestSeries(tags map[string]string, fields ...*fieldPayload) series.GroupedIterator {
+	return newPayloadSeries(&seriesPayload{Tags: tags, Fields: fields})
+}
+
+// readTestSeries reads the grouped series, returns field name => time slot => value
+func readTestSeries(it series.GroupedIterator) map[string]map[int]float64 {
+	result := make(map[string]map[int]float64)
+	for it.HasNext() {
+		fieldIt := it.Next()
+		points := make(map[int]float64)
+		for fieldIt.HasNext() {
+			primitiveIt := fieldIt.Next()
+			for primitiveIt.HasNext() {
+				slot, value := primitiveIt.Next()
+				points[slot] = value
+			}
+		}
+		result[fieldIt.FieldName()] = points
+	}
+	return result
+}
@@ -1,6 +1,8 @@
 package parallel
 
 import (
+	"context"
+
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
@@ -21,26 +23,64 @@ type TaskProcessor interface {
 	Process(req *pb.TaskRequest) error
 }
 
-// leafTaskDispatcher represents leaf task dispatcher for storage
+// leafTaskDispatcher represents leaf task dispatcher for storage,
+// the leaf tasks are executed by the bounded worker pool, rejects the task if the queue of pool is full.
 type leafTaskDispatcher struct {
-	processor TaskProcessor
+	processor *leafTask
+	tasks     chan *queuedTask // task queue of worker pool, nil means no limit(a goroutine for each task)
+}
+
+// queuedTask represents the leaf task waiting in the queue, which is registered for canceling when queued
+type queuedTask struct {
+	ctx context.Context
+	req *pb.TaskRequest
 }
 
-// NewLeafTaskDispatcher creates a leaf task dispatcher
+// NewLeafTaskDispatcher creates a leaf task dispatcher with the bounded worker pool,
+// no limit if the num. of workers is 0, the payload of response is compressed by snappy if exceeds the threshold,
+// negative threshold means no compression
 func NewLeafTaskDispatcher(currentNode models.Node,
 	storageService service.StorageService,
-	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory) TaskDispatcher {
-	return &leafTaskDispatcher{
-		processor: newLeafTask(currentNode, storageService, executorFactory, taskServerFactory),
+	executorFactory ExecutorFactory, taskServerFactory rpc.TaskServerFactory,
+	workers, queueSize, compressThreshold int) TaskDispatcher {
+	d := &leafTaskDispatcher{
+		processor: newLeafTask(currentNode, storageService, executorFactory, taskServerFactory, compressThreshold),
 	}
+	if workers > 0 {
+		d.tasks = make(chan *queuedTask, queueSize)
+		for i := 0; i < workers; i++ {
+			go d.work()
+		}
+	}
+	return d
 }
 
 // Dispatch dispatches the request to storage engine query processor
 func (d *leafTaskDispatcher) Dispatch(req *pb.TaskRequest) {
-	//FIXME(stone1100) need remove err
-	go func() {
-		_ = d.processor.Process(req)
-	}()
+	// cancel request isn't queued, cancels the running task as soon as possible
+	if d.tasks == nil || req.RequestType == pb.RequestType_Cancel {
+		//FIXME(stone1100) need remove err
+		go func() {
+			_ = d.processor.Process(req)
+		}()
+		return
+	}
+	// registers the task before queued, so the cancel request of queued task isn't dropped
+	task := &queuedTask{ctx: d.processor.register(req), req: req}
+	select {
+	case d.tasks <- task:
+	default:
+		d.processor.unregister(req)
+		// the parent retries the rejected task on the alternative replica
+		go d.processor.reject(req, errTooManyTasks)
+	}
+}
+
+// work processes the queued leaf tasks, the task canceled when queued is skipped
+func (d *leafTaskDispatcher) work() {
+	for task := range d.tasks {
+		_ = d.processor.process(task.ctx, task.req)
+	}
 }
 
 // intermediateTaskDispatcher represents intermediate task dispatcher for broker
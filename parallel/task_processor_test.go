@@ -1,11 +1,15 @@
 package parallel
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 )
 
@@ -13,8 +17,64 @@ func TestLeafTaskDispatcher_Dispatch(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	leafTaskDispatcher := NewLeafTaskDispatcher(models.Node{IP: "1.1.1.1", Port: 9000}, nil, nil, nil)
+	leafTaskDispatcher := NewLeafTaskDispatcher(models.Node{IP: "1.1.1.1", Port: 9000}, nil, nil, nil, 0, 0, defaultPayloadCompressThreshold)
 	leafTaskDispatcher.Dispatch(&pb.TaskRequest{PhysicalPlan: []byte{1, 1, 1}})
+
+	// bounded worker pool
+	leafTaskDispatcher = NewLeafTaskDispatcher(models.Node{IP: "1.1.1.1", Port: 9000}, nil, nil, nil, 2, 10, defaultPayloadCompressThreshold)
+	leafTaskDispatcher.Dispatch(&pb.TaskRequest{PhysicalPlan: []byte{1, 1, 1}})
+	leafTaskDispatcher.Dispatch(&pb.TaskRequest{PhysicalPlan: []byte{1, 1, 1}, RequestType: pb.RequestType_Cancel})
+}
+
+func TestLeafTaskDispatcher_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	// no worker takes the task, the task is rejected
+	dispatcher := &leafTaskDispatcher{
+		processor: newLeafTask(models.Node{IP: "1.1.1.3", Port: 8000}, nil, nil, taskServerFactory, defaultPayloadCompressThreshold),
+		tasks:     make(chan *queuedTask),
+	}
+	plan := encoding.JSONMarshal(&models.PhysicalPlan{
+		Leafs: []models.Leaf{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000", Parent: "1.1.1.1:9000"}}},
+	})
+	serverStream := pb.NewMockTaskService_HandleServer(ctrl)
+	taskServerFactory.EXPECT().GetStream("1.1.1.1:9000").Return(serverStream)
+	sent := make(chan *pb.TaskResponse)
+	serverStream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		sent <- resp
+		return nil
+	})
+	dispatcher.Dispatch(&pb.TaskRequest{JobID: 1, ParentTaskID: "taskID", PhysicalPlan: plan})
+	resp := <-sent
+	assert.Equal(t, int64(1), resp.JobID)
+	assert.Equal(t, "taskID", resp.TaskID)
+	assert.Equal(t, errTooManyTasks.Error(), resp.ErrMsg)
+	assert.Equal(t, "1.1.1.3:8000", resp.SendNode)
+
+	// invalid plan or task not found, no failure reported
+	dispatcher.processor.reject(&pb.TaskRequest{PhysicalPlan: []byte{1, 1, 1}}, errTooManyTasks)
+	dispatcher.processor.reject(&pb.TaskRequest{PhysicalPlan: encoding.JSONMarshal(&models.PhysicalPlan{})},
+		errTooManyTasks)
+}
+
+func TestLeafTaskDispatcher_CancelQueued(t *testing.T) {
+	// no worker takes the task, the task waits in the queue
+	dispatcher := &leafTaskDispatcher{
+		processor: newLeafTask(models.Node{IP: "1.1.1.3", Port: 8000}, nil, nil, nil, defaultPayloadCompressThreshold),
+		tasks:     make(chan *queuedTask, 1),
+	}
+	dispatcher.Dispatch(&pb.TaskRequest{JobID: 1, ParentTaskID: "taskID"})
+	// cancels the queued task
+	assert.NoError(t, dispatcher.processor.Process(&pb.TaskRequest{JobID: 1, ParentTaskID: "taskID",
+		RequestType: pb.RequestType_Cancel}))
+	task := <-dispatcher.tasks
+	assert.Equal(t, context.Canceled, task.ctx.Err())
+	// the canceled task is skipped when dequeued
+	assert.Equal(t, context.Canceled, dispatcher.processor.process(task.ctx, task.req))
+	_, ok := dispatcher.processor.running.Load(leafTaskKey{jobID: 1, parentTaskID: "taskID"})
+	assert.False(t, ok)
 }
 
 func TestIntermediateTaskDispatcher_Dispatch(t *testing.T) {
@@ -1,6 +1,8 @@
 package parallel
 
 import (
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 )
@@ -24,18 +26,90 @@ func (r *taskReceiver) Receive(resp *pb.TaskResponse) error {
 		return nil
 	}
 	//TODO impl result handler
-	taskCtx.ReceiveResult()
-
-	if taskCtx.Completed() {
-		taskManager.Complete(taskID)
-
-		if taskCtx.TaskType() == RootTask {
-			jobCtx := r.jobManager.GetJob(resp.JobID)
-			if jobCtx != nil {
-				jobCtx.Complete()
+	var jobCtx JobContext
+	if taskCtx.TaskType() == RootTask || taskCtx.TaskType() == RetryTask {
+		// the result of timeout leaf task is ignored, because the leaf task has been retried
+		if resp.Completed && !r.jobManager.ReceiveLeafResult(resp.JobID, taskID, resp.SendNode) {
+			return nil
+		}
+		jobCtx = r.jobManager.GetJob(resp.JobID)
+		if jobCtx != nil {
+			if len(resp.Stats) > 0 {
+				r.receiveStats(jobCtx, resp)
+			}
+			if len(resp.Spans) > 0 {
+				jobCtx.ReceiveSpans(resp.Spans)
+			}
+			if len(resp.Payload) > 0 {
+				if err := jobCtx.ReceivePayload(resp.Payload); err != nil {
+					jobCtx.ReceiveError(err.Error())
+				}
+			}
+			if len(resp.ErrMsg) > 0 {
+				// retries the failed leaf task before marking it completed, so the job keeps waiting for the retry
+				r.jobManager.RetryTask(resp.JobID, taskID, resp.SendNode, resp.ErrMsg)
+			}
+			if resp.Completed {
+				jobCtx.ReceiveResult()
 			}
 		}
-		//TODO need impl finally result build
 	}
+	// the failure of leaf node forwarded by intermediate node isn't the result of sub task
+	if !resp.Completed {
+		return nil
+	}
+	receiveResult(taskManager, taskCtx, jobCtx)
 	return nil
 }
+
+// receiveResult marks a sub task of the task completed, if all sub tasks completed, completes the task,
+// the retry task is the sub task of root task, and the job is completed when the root task completed.
+func receiveResult(taskManager TaskManager, taskCtx TaskContext, jobCtx JobContext) {
+	if !taskCtx.ReceiveResult() {
+		return
+	}
+	taskManager.Complete(taskCtx.TaskID())
+	if taskCtx.TaskType() == RetryTask {
+		if rootTaskCtx := taskManager.Get(taskCtx.ParentTaskID()); rootTaskCtx != nil {
+			receiveResult(taskManager, rootTaskCtx, jobCtx)
+		}
+		return
+	}
+	if jobCtx != nil {
+		jobCtx.Complete()
+	}
+	//TODO need impl finally result build
+}
+
+// receiveStats merges the execution statistics of storage node into job context,
+// the stats forwarded by intermediate node are the stats of its leaf nodes(leaf node => storage stats)
+func (r *taskReceiver) receiveStats(jobCtx JobContext, resp *pb.TaskResponse) {
+	if isIntermediate(jobCtx.Plan(), resp.SendNode) {
+		leafStats := make(map[string]*models.StorageStats)
+		if err := encoding.JSONUnmarshal(resp.Stats, &leafStats); err != nil {
+			return
+		}
+		for leafNode, stats := range leafStats {
+			jobCtx.ReceiveStats(leafNode, stats)
+		}
+		return
+	}
+	stats := &models.StorageStats{}
+	if err := encoding.JSONUnmarshal(resp.Stats, stats); err != nil {
+		return
+	}
+	jobCtx.ReceiveStats(resp.SendNode, stats)
+}
+
+// isIntermediate returns if the node is the intermediate node of physical plan
+func isIntermediate(plan *models.PhysicalPlan, nodeID string) bool {
+	if plan == nil {
+		return false
+	}
+	for _, intermediate := range plan.Intermediates {
+		if intermediate.Indicator == nodeID {
+			return true
+		}
+	}
+	return false
+}
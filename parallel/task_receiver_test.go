@@ -1,12 +1,17 @@
 package parallel
 
 import (
+	"context"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/field"
 	"github.com/lindb/lindb/tsdb/series"
 )
 
@@ -23,12 +28,163 @@ func TestTaskReceiver_Receive(t *testing.T) {
 	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID"})
 	assert.Nil(t, err)
 
+	// ignores the result of timeout leaf task, which has been retried
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1))
+	jobManager.EXPECT().ReceiveLeafResult(int64(0), "taskID", "1.1.1.9:9000").Return(false)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", SendNode: "1.1.1.9:9000", Completed: true})
+	assert.Nil(t, err)
+
+	jobManager.EXPECT().ReceiveLeafResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	taskManager.EXPECT().Complete("taskID")
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1))
+
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(NewJobContext(context.Background(), make(chan series.GroupedIterator), nil, nil, ""))
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true})
+	assert.Nil(t, err)
+
+	// receive stats of explain query
+	jobCtx := NewJobContext(context.Background(), make(chan series.GroupedIterator), nil, &stmt.Query{Explain: true}, "")
+	storageStats := models.NewStorageStats()
+	storageStats.Shards[1] = &models.ShardStats{NumOfPoints: 10}
 	taskManager.EXPECT().Complete("taskID")
 	taskManager.EXPECT().Get("taskID").
 		Return(newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1))
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", SendNode: "1.1.1.1:9000", Completed: true,
+		Stats: encoding.JSONMarshal(storageStats)})
+	assert.Nil(t, err)
+	assert.Equal(t, storageStats, jobCtx.Statistics().StorageNodes["1.1.1.1:9000"])
+	assert.Equal(t, int32(1), jobCtx.NumOfCompleted())
 
-	jobManager.EXPECT().GetJob(gomock.Any()).Return(NewJobContext(make(chan series.GroupedIterator), nil))
-	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID"})
+	// job not exist or stats unmarshal fail
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 3)).Times(2)
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(nil)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Stats: []byte{1, 2, 3}})
 	assert.Nil(t, err)
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Stats: []byte{1, 2, 3}})
+	assert.Nil(t, err)
+
+	// receive failure of leaf node forwarded by intermediate node, the task isn't completed
+	jobCtx = NewJobContext(context.Background(), make(chan series.GroupedIterator), nil, &stmt.Query{}, "")
+	taskManager.EXPECT().Get("taskID").
+		Return(newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1))
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	jobManager.EXPECT().RetryTask(int64(0), "taskID", "1.1.1.1:9000", "err").
+		Do(func(jobID int64, taskID, failedNode, errMsg string) {
+			jobCtx.ReceiveFailure(failedNode, errMsg)
+		})
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", SendNode: "1.1.1.1:9000", ErrMsg: "err"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"1.1.1.1:9000": "err"}, jobCtx.Failures())
+	assert.Equal(t, int32(0), jobCtx.NumOfCompleted())
+	assert.False(t, jobCtx.Completed())
+
+	// the completed retry task completes the root task, then completes the job
+	jobCtx = NewJobContext(context.Background(), make(chan series.GroupedIterator), nil, &stmt.Query{}, "")
+	rootTaskCtx := newTaskContext("rootTaskID", RootTask, "", "", 1)
+	rootTaskCtx.AddSubTask()
+	assert.False(t, rootTaskCtx.ReceiveResult())
+	taskManager.EXPECT().Get("retryTaskID").Return(newTaskContext("retryTaskID", RetryTask, "rootTaskID", "", 1))
+	taskManager.EXPECT().Get("rootTaskID").Return(rootTaskCtx)
+	taskManager.EXPECT().Complete("retryTaskID")
+	taskManager.EXPECT().Complete("rootTaskID")
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "retryTaskID", SendNode: "1.1.1.2:9000", Completed: true})
+	assert.Nil(t, err)
+	assert.True(t, rootTaskCtx.Completed())
+	assert.True(t, jobCtx.Completed())
+}
+
+func TestTaskReceiver_ReceivePayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := NewMockJobManager(ctrl)
+	taskManager := NewMockTaskManager(ctrl)
+	jobManager.EXPECT().GetTaskManager().Return(taskManager).AnyTimes()
+	jobManager.EXPECT().ReceiveLeafResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	receiver := NewTaskReceiver(jobManager)
+
+	resultSet := make(chan series.GroupedIterator)
+	jobCtx := NewJobContext(context.Background(), resultSet, nil, &stmt.Query{}, "")
+	jobManager.EXPECT().GetJob(int64(0)).Return(jobCtx).AnyTimes()
+	taskCtx := newTaskContext("taskID", RootTask, "", "", 3)
+	taskManager.EXPECT().Get("taskID").Return(taskCtx).AnyTimes()
+
+	merger := newResultMerger(nil)
+	merger.mergeSeries(newTestSeries(map[string]string{"host": "1.1.1.1"},
+		&fieldPayload{ID: 1, Name: "f", Type: field.SumField, Primitives: []*primitivePayload{
+			{ID: 1, Slots: []int{1}, Values: []float64{1}},
+		}}))
+	payload, err := merger.payload(defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	assert.NoError(t, receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, Payload: payload,
+		SendNode: "1.1.1.1:9000"}))
+	// no data in leaf node
+	assert.NoError(t, receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, SendNode: "1.1.1.2:9000"}))
+
+	// emits the merged groups when the job completed
+	taskManager.EXPECT().Complete("taskID")
+	go func() {
+		_ = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, Payload: payload,
+			SendNode: "1.1.1.3:9000"})
+	}()
+	var results []series.GroupedIterator
+	for it := range resultSet {
+		results = append(results, it)
+	}
+	assert.Len(t, results, 1)
+	assert.Equal(t, map[string]map[int]float64{"f": {1: 2}}, readTestSeries(results[0]))
+	assert.True(t, jobCtx.Completed())
+	assert.NoError(t, jobCtx.Error())
+
+	// decode payload failure fails the job
+	failedJobCtx := NewJobContext(context.Background(), nil, nil, &stmt.Query{}, "")
+	jobManager.EXPECT().GetJob(int64(2)).Return(failedJobCtx)
+	taskManager.EXPECT().Get("failedTaskID").Return(newTaskContext("failedTaskID", RootTask, "", "", 2))
+	assert.NoError(t, receiver.Receive(&pb.TaskResponse{JobID: 2, TaskID: "failedTaskID", Completed: true,
+		Payload: []byte{1, 2, 3}, SendNode: "1.1.1.2:9000"}))
+	assert.Error(t, failedJobCtx.Error())
+	assert.Empty(t, failedJobCtx.Failures())
+}
+
+func TestTaskReceiver_ReceiveSpans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := NewMockJobManager(ctrl)
+	taskManager := NewMockTaskManager(ctrl)
+	jobManager.EXPECT().GetTaskManager().Return(taskManager).AnyTimes()
+	jobManager.EXPECT().ReceiveLeafResult(gomock.Any(), gomock.Any(), gomock.Any()).Return(true).AnyTimes()
+	receiver := NewTaskReceiver(jobManager)
+
+	trace := models.NewTrace("query")
+	ctx := models.WithTrace(context.Background(), trace)
+	jobCtx := NewJobContext(ctx, make(chan series.GroupedIterator), nil, &stmt.Query{MetricName: "cpu"}, "")
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx).AnyTimes()
+	taskManager.EXPECT().Get("taskID").Return(newTaskContext("taskID", RootTask, "", "", 2)).AnyTimes()
+
+	leafSpan := models.NewSpan("leaf task")
+	leafSpan.Finish()
+	assert.NoError(t, receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		SendNode: "1.1.1.1:9000", Spans: encodeSpans(leafSpan)}))
+	// invalid spans are ignored
+	taskManager.EXPECT().Complete("taskID")
+	assert.NoError(t, receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		SendNode: "1.1.1.2:9000", Spans: []byte{1, 2, 3}}))
 
+	assert.True(t, jobCtx.Completed())
+	assert.Len(t, trace.Root.Children, 1)
+	jobSpan := jobCtx.Span()
+	assert.Equal(t, trace.Root.Children[0], jobSpan)
+	assert.Equal(t, map[string]string{"metric": "cpu"}, jobSpan.Tags)
+	assert.True(t, jobSpan.Duration > 0)
+	// leaf task span + aggregation span
+	assert.Len(t, jobSpan.Children, 2)
+	assert.Equal(t, "leaf task", jobSpan.Children[0].Name)
+	assert.Equal(t, "aggregation", jobSpan.Children[1].Name)
 }
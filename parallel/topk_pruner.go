@@ -0,0 +1,120 @@
+package parallel
+
+import (
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// topKPruner prunes the merged groups by the topk/bottomk functions of query before sending to parent node,
+// only keeps the groups selected by any function, reduces the payload of high cardinality group by query.
+// NOTICE: the values of group may be partial in current node(like the series of group are stored in multi nodes),
+// a group pruned by current node may be kept by other nodes, then merged by broker without the pruned part,
+// so only the functions whose partial value bounds the final value can be pruned(see isPrunable).
+type topKPruner struct {
+	selectItems []stmt.Expr
+	specs       []*aggregation.TopKSpec
+	maxK        int
+}
+
+// newTopKPruner creates the pruner for the query, returns nil(disable) if the query cannot be pruned,
+// only the query whose select items are all topk/bottomk functions can be pruned,
+// because the groups are dropped for all select items.
+func newTopKPruner(query *stmt.Query) *topKPruner {
+	if query == nil || query.SubQuery != nil || query.IsCrossMetric() {
+		return nil
+	}
+	specs := aggregation.NewTopKSpecs(query.SelectItems)
+	if len(specs) == 0 || len(specs) != len(query.SelectItems) {
+		return nil
+	}
+	for _, spec := range specs {
+		if !isPrunable(spec) {
+			return nil
+		}
+	}
+	pruner := &topKPruner{selectItems: query.SelectItems}
+	for _, spec := range specs {
+		candidate := *spec
+		candidate.K = candidateK(spec.K)
+		if candidate.K > pruner.maxK {
+			pruner.maxK = candidate.K
+		}
+		pruner.specs = append(pruner.specs, &candidate)
+	}
+	return pruner
+}
+
+// isPrunable tests if the groups can be pruned by the spec based on the partial values of current node,
+// only topk(k, max(f)) and bottomk(k, min(f)) can be pruned, the rank function must be same as the down sampling,
+// because the partial max(min) is the lower(upper) bound of the final value, which increases(decreases) after merging,
+// if the group is pruned by current node, there are k candidates which are not worse than it finally,
+// so the pruned group cannot be selected whether its value is merged or not.
+// others(like sum/count/avg) cannot be pruned, the group pruned by current node may be kept by other nodes,
+// then the understated value of group is returned.
+func isPrunable(spec *aggregation.TopKSpec) bool {
+	funcType := function.Max
+	if spec.Bottom {
+		funcType = function.Min
+	}
+	callExpr, ok := spec.Expr.(*stmt.CallExpr)
+	if !ok || callExpr.FuncType != funcType || len(callExpr.Params) != 1 {
+		return false
+	}
+	if _, ok := callExpr.Params[0].(*stmt.FieldExpr); !ok {
+		return false
+	}
+	return spec.RankFunc == 0 || spec.RankFunc == funcType
+}
+
+// candidateK returns the number of candidates kept in storage/intermediate node for selecting k series
+func candidateK(k int) int {
+	return k + k/2 + 10
+}
+
+// prune evaluates the select list of each group, returns the groups selected by any topk/bottomk function
+func (p *topKPruner) prune(groups []*seriesPayload) []*seriesPayload {
+	if p == nil || len(groups) <= p.maxK {
+		return groups
+	}
+	pointCount := payloadPointCount(groups)
+	selectors := make([]*aggregation.TopKSelector, len(p.specs))
+	for idx, spec := range p.specs {
+		selectors[idx] = spec.NewSelector()
+	}
+	for groupIdx, group := range groups {
+		expression := aggregation.NewExpression(newPayloadSeries(group), pointCount, p.selectItems)
+		expression.Eval()
+		values := expression.ResultSet()
+		for idx, spec := range p.specs {
+			selectors[idx].Add(groupIdx, values[spec.Name])
+		}
+	}
+	result := make([]*seriesPayload, 0, p.maxK)
+	for groupIdx, group := range groups {
+		for _, selector := range selectors {
+			if selector.IsSelected(groupIdx) {
+				result = append(result, group)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// payloadPointCount returns the number of time slots which covers all data points of the groups
+func payloadPointCount(groups []*seriesPayload) int {
+	pointCount := 0
+	for _, group := range groups {
+		for _, f := range group.Fields {
+			for _, primitive := range f.Primitives {
+				for _, slot := range primitive.Slots {
+					if slot >= pointCount {
+						pointCount = slot + 1
+					}
+				}
+			}
+		}
+	}
+	return pointCount
+}
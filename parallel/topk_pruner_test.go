@@ -0,0 +1,105 @@
+package parallel
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/field"
+)
+
+func TestNewTopKPruner(t *testing.T) {
+	selector := func(funcType, downSampling function.FuncType, rankFunc ...stmt.Expr) stmt.Expr {
+		return &stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: funcType,
+			Params: append([]stmt.Expr{&stmt.NumberLiteral{Val: 2},
+				&stmt.CallExpr{FuncType: downSampling, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}}, rankFunc...)}}
+	}
+	topK := selector(function.TopK, function.Max)
+	assert.Nil(t, newTopKPruner(nil))
+	assert.Nil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}}}}))
+	// the groups cannot be dropped for other select items
+	assert.Nil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{topK, &stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}}}}))
+	assert.Nil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{topK}, SubQuery: &stmt.Query{}}))
+	assert.Nil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{topK}, MetricNames: []string{"a", "b"}}))
+
+	pruner := newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{topK}})
+	assert.NotNil(t, pruner)
+	assert.Equal(t, candidateK(2), pruner.maxK)
+	assert.NotNil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{selector(function.BottomK, function.Min)}}))
+	assert.NotNil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{
+		selector(function.TopK, function.Max, &stmt.CallExpr{FuncType: function.Max})}}))
+	// the partial values cannot bound the final values
+	for _, item := range []stmt.Expr{
+		selector(function.TopK, function.Sum),
+		selector(function.TopK, function.Count),
+		selector(function.TopK, function.Avg),
+		selector(function.TopK, function.Min),
+		selector(function.BottomK, function.Max),
+		selector(function.BottomK, function.Sum),
+		selector(function.TopK, function.Max, &stmt.CallExpr{FuncType: function.Avg}),
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 2}, &stmt.FieldExpr{Name: "f"}}}},
+	} {
+		assert.Nil(t, newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{item}}), item.Rewrite())
+	}
+
+	var disable *topKPruner
+	groups := []*seriesPayload{{}}
+	assert.Equal(t, groups, disable.prune(groups))
+}
+
+func TestTopKPruner_Prune(t *testing.T) {
+	pruner := newTopKPruner(&stmt.Query{SelectItems: []stmt.Expr{
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 1},
+				&stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}}}},
+		&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.BottomK,
+			Params: []stmt.Expr{&stmt.NumberLiteral{Val: 1},
+				&stmt.CallExpr{FuncType: function.Min, Params: []stmt.Expr{&stmt.FieldExpr{Name: "g"}}},
+				&stmt.CallExpr{FuncType: function.Min}}}},
+	}})
+	newGroups := func(count int) []*seriesPayload {
+		var groups []*seriesPayload
+		for i := 0; i < count; i++ {
+			groups = append(groups, &seriesPayload{
+				Tags: map[string]string{"host": strconv.Itoa(i)},
+				Fields: []*fieldPayload{
+					// top 11 at each slot
+					{ID: 1, Name: "f", Type: field.MaxField, Primitives: []*primitivePayload{
+						{ID: 1, Slots: []int{0, 3}, Values: []float64{float64(i), float64(i)}},
+					}},
+					// bottom 11 over the whole time range
+					{ID: 2, Name: "g", Type: field.MinField, Primitives: []*primitivePayload{
+						{ID: 1, Slots: []int{0, 3}, Values: []float64{float64(i), float64(i)}},
+					}},
+				},
+			})
+		}
+		return groups
+	}
+	// nothing to prune
+	groups := newGroups(11)
+	assert.Len(t, pruner.prune(groups), 11)
+
+	groups = newGroups(30)
+	result := pruner.prune(groups)
+	assert.Len(t, result, 22)
+	assert.Equal(t, groups[:11], result[:11])
+	assert.Equal(t, groups[19:], result[11:])
+
+	// prunes the payload sent to parent node
+	merger := newResultMerger(pruner)
+	for _, group := range groups {
+		merger.mergeSeries(newPayloadSeries(group))
+	}
+	payload, err := merger.payload(defaultPayloadCompressThreshold)
+	assert.NoError(t, err)
+	decoded, err := decodeSeriesPayloads(payload)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, 22)
+	// the result set emitted by root node is not pruned
+	assert.Len(t, merger.resultSet(), 30)
+}
@@ -0,0 +1,26 @@
+package parallel
+
+import (
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// encodeSpans encodes the spans of task for propagating to the parent node, returns nil if no span
+func encodeSpans(spans ...*models.Span) []byte {
+	if len(spans) == 0 || spans[0] == nil {
+		return nil
+	}
+	return encoding.JSONMarshal(spans)
+}
+
+// decodeSpans decodes the spans of sub task from the task response
+func decodeSpans(data []byte) ([]*models.Span, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var spans []*models.Span
+	if err := encoding.JSONUnmarshal(data, &spans); err != nil {
+		return nil, err
+	}
+	return spans, nil
+}
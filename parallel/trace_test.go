@@ -0,0 +1,32 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+func TestSpans_EncodeDecode(t *testing.T) {
+	assert.Nil(t, encodeSpans())
+	assert.Nil(t, encodeSpans(nil))
+	spans, err := decodeSpans(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, spans)
+
+	span := models.NewSpan("leaf task")
+	span.SetNode("1.1.1.1:9000")
+	span.StartChild("shard search").SetTag("shard", "1")
+	span.Finish()
+	spans, err = decodeSpans(encodeSpans(span))
+	assert.NoError(t, err)
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "leaf task", spans[0].Name)
+	assert.Equal(t, "1.1.1.1:9000", spans[0].Node)
+	assert.Equal(t, span.Duration, spans[0].Duration)
+	assert.Equal(t, map[string]string{"shard": "1"}, spans[0].Children[0].Tags)
+
+	_, err = decodeSpans([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
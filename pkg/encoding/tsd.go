@@ -73,7 +73,12 @@ func (e *TSDEncoder) Bytes() ([]byte, error) {
 	writer.PutUvarint32(uint32(len(valueBuf)))
 	writer.PutBytes(valueBuf)
 
-	return writer.Bytes()
+	data, err := writer.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// copies the data, because the buffer of writer is put back to the pool for reusing
+	return append([]byte(nil), data...), nil
 }
 
 // TSDDecoder decodes time series compress data
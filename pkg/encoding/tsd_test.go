@@ -45,6 +45,18 @@ func TestCodec(t *testing.T) {
 	assert.Equal(t, uint64(50), decoder.Value())
 
 	assert.False(t, decoder.Next())
+
+	// the encoded data isn't overwritten by other encoder which reuses the buffer
+	encoder = NewTSDEncoder(20)
+	encoder.AppendTime(bit.One)
+	encoder.AppendValue(uint64(30))
+	_, err = encoder.Bytes()
+	assert.Nil(t, err)
+	decoder = NewTSDDecoder(data)
+	assert.Equal(t, 10, decoder.StartTime())
+	assert.True(t, decoder.Next())
+	assert.True(t, decoder.HasValue())
+	assert.Equal(t, uint64(10), decoder.Value())
 }
 
 func TestHasValueWithSlot(t *testing.T) {
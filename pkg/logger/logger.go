@@ -56,6 +56,7 @@ type Logger struct {
 	module string
 	role   string
 	logger *zap.Logger
+	store  *atomic.Value // stores the initialized zap logger, default is the global logger
 }
 
 // getInitializedOrDefaultLogger try get initialized zap logger,
@@ -64,7 +65,11 @@ func (l *Logger) getInitializedOrDefaultLogger() *zap.Logger {
 	if l.logger != nil {
 		return l.logger
 	}
-	item := logger.Load()
+	store := &logger
+	if l.store != nil {
+		store = l.store
+	}
+	item := store.Load()
 	if item == nil {
 		return defaultLogger
 	}
@@ -130,6 +135,11 @@ func Uint32(key string, val uint32) zap.Field {
 	return zap.Field{Key: key, Type: zapcore.Uint32Type, Integer: int64(val)}
 }
 
+// Uint64 constructs a field with the given key and value.
+func Uint64(key string, val uint64) zap.Field {
+	return zap.Field{Key: key, Type: zapcore.Uint64Type, Integer: int64(val)}
+}
+
 // Stack constructs a field that stores a stacktrace of the current goroutine
 // under provided key. Keep in mind that taking a stacktrace is eager and
 // expensive (relatively speaking); this function both makes an allocation and
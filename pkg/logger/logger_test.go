@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/lindb/lindb/config"
@@ -20,7 +21,7 @@ func Test_Logger(t *testing.T) {
 	logger1.Warn("warn for test", String("count", "1"), Reflect("v1", map[string]string{"a": "1"}))
 	logger1.Info("info for test", Uint16("value", 1), Int32("v1", 2),
 		Int64("v2", 2), Any("v3", 3))
-	logger1.Debug("debug for test", Uint32("value", 2))
+	logger1.Debug("debug for test", Uint32("value", 2), Uint64("v4", 3))
 	logger1.Error("error for test", Error(fmt.Errorf("error")))
 
 	assert.NotNil(t, defaultLogger)
@@ -64,3 +65,17 @@ func Test_InitLogger(t *testing.T) {
 	cfg4 := config.Logging{Level: "debug"}
 	assert.Nil(t, InitLogger(cfg4))
 }
+
+func Test_SlowQueryLogger(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "slow_query_log_test")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	cfg := config.NewDefaultLoggingCfg()
+	cfg.Dir = dir
+	assert.Nil(t, InitLogger(cfg))
+	slowQueryLogger1 := GetSlowQueryLogger()
+	assert.Equal(t, slowQueryLogger.Load(), slowQueryLogger1.getInitializedOrDefaultLogger())
+	assert.NotEqual(t, logger.Load(), slowQueryLogger1.getInitializedOrDefaultLogger())
+	slowQueryLogger1.Warn("slow query for test", String("sql", "select f from cpu"))
+}
@@ -17,6 +17,8 @@ var (
 	// max length of all modules
 	maxModuleNameLen uint32
 	logger           atomic.Value
+	// logger for slow query log, writes into a dedicated log file
+	slowQueryLogger atomic.Value
 	// uninitialized logger for default usage
 	defaultLogger = newDefaultLogger()
 	// RunningAtomicLevel supports changing level on the fly
@@ -24,7 +26,8 @@ var (
 )
 
 const (
-	lindLogFilename = "lind.log"
+	lindLogFilename      = "lind.log"
+	slowQueryLogFilename = "slow_query.log"
 )
 
 // GetLogger return logger with module name
@@ -45,6 +48,13 @@ func GetLogger(module, role string) *Logger {
 	}
 }
 
+// GetSlowQueryLogger returns the logger which writes the slow query log into a dedicated log file
+func GetSlowQueryLogger() *Logger {
+	l := GetLogger("query", "SlowQuery")
+	l.store = &slowQueryLogger
+	return l
+}
+
 // newDefaultLogger creates a default logger for uninitialized usage
 func newDefaultLogger() *zap.Logger {
 	encoderConfig := zap.NewProductionEncoderConfig()
@@ -59,8 +69,19 @@ func newDefaultLogger() *zap.Logger {
 
 // InitLogger initializes a zap logger from user config
 func InitLogger(cfg config.Logging) error {
+	// parse logging level
+	if err := RunningAtomicLevel.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return err
+	}
+	logger.Store(newFileLogger(cfg, lindLogFilename))
+	slowQueryLogger.Store(newFileLogger(cfg, slowQueryLogFilename))
+	return nil
+}
+
+// newFileLogger creates a zap logger which writes into the log file under the log dir
+func newFileLogger(cfg config.Logging, filename string) *zap.Logger {
 	w := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   filepath.Join(cfg.Dir, lindLogFilename),
+		Filename:   filepath.Join(cfg.Dir, filename),
 		MaxSize:    int(cfg.MaxSize),
 		MaxBackups: int(cfg.MaxBackups),
 		MaxAge:     int(cfg.MaxAge),
@@ -69,10 +90,6 @@ func InitLogger(cfg config.Logging) error {
 	if isTerminal {
 		w = os.Stdout
 	}
-	// parse logging level
-	if err := RunningAtomicLevel.UnmarshalText([]byte(cfg.Level)); err != nil {
-		return err
-	}
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = SimpleTimeEncoder
 	encoderConfig.EncodeLevel = SimpleLevelEncoder
@@ -81,6 +98,5 @@ func InitLogger(cfg config.Logging) error {
 		zapcore.NewConsoleEncoder(encoderConfig),
 		w,
 		RunningAtomicLevel)
-	logger.Store(zap.New(core))
-	return nil
+	return zap.New(core)
 }
@@ -0,0 +1,60 @@
+package option
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// QueryLimit represents the resource limits of query, zero value means no limit,
+// the global limit is set in the config of broker/storage, which can be overridden by database's engine option.
+type QueryLimit struct {
+	MaxSeries    uint64 `toml:"maxSeries" json:"maxSeries,omitempty"`       // max num. of series matched by tag filter
+	MaxPoints    int64  `toml:"maxPoints" json:"maxPoints,omitempty"`       // max num. of data points decoded/aggregated
+	MaxTimeRange string `toml:"maxTimeRange" json:"maxTimeRange,omitempty"` // max time range span(like 30d)
+	Timeout      string `toml:"timeout" json:"timeout,omitempty"`           // wall-clock timeout(like 1m)
+}
+
+// Validation validates query limit if valid
+func (l QueryLimit) Validation() error {
+	if err := ValidateInterval(l.MaxTimeRange, false); err != nil {
+		return fmt.Errorf("max time range of query limit is invalid, err:%s", err)
+	}
+	if err := ValidateInterval(l.Timeout, false); err != nil {
+		return fmt.Errorf("timeout of query limit is invalid, err:%s", err)
+	}
+	if l.MaxPoints < 0 {
+		return fmt.Errorf("max points of query limit cannot be negative")
+	}
+	return nil
+}
+
+// Override returns a new query limit which the limits are overridden by the non-zero limits of other
+func (l QueryLimit) Override(other QueryLimit) QueryLimit {
+	if other.MaxSeries > 0 {
+		l.MaxSeries = other.MaxSeries
+	}
+	if other.MaxPoints > 0 {
+		l.MaxPoints = other.MaxPoints
+	}
+	if other.MaxTimeRange != "" {
+		l.MaxTimeRange = other.MaxTimeRange
+	}
+	if other.Timeout != "" {
+		l.Timeout = other.Timeout
+	}
+	return l
+}
+
+// GetMaxTimeRange returns the max time range span in milliseconds, returns 0 if no limit or invalid(see Validation)
+func (l QueryLimit) GetMaxTimeRange() int64 {
+	maxTimeRange, _ := timeutil.ParseInterval(l.MaxTimeRange)
+	return maxTimeRange
+}
+
+// GetTimeout returns the wall-clock timeout of query, returns 0 if no limit or invalid(see Validation)
+func (l QueryLimit) GetTimeout() time.Duration {
+	timeout, _ := timeutil.ParseInterval(l.Timeout)
+	return time.Duration(timeout) * time.Millisecond
+}
@@ -0,0 +1,39 @@
+package option
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestQueryLimit_Validation(t *testing.T) {
+	assert.NoError(t, QueryLimit{}.Validation())
+	assert.NoError(t, QueryLimit{MaxSeries: 10, MaxPoints: 100, MaxTimeRange: "30d", Timeout: "1m"}.Validation())
+	assert.Error(t, QueryLimit{MaxTimeRange: "aa"}.Validation())
+	assert.Error(t, QueryLimit{Timeout: "-1s"}.Validation())
+	assert.Error(t, QueryLimit{MaxPoints: -1}.Validation())
+
+	engine := EngineOption{Interval: "10s", Query: QueryLimit{Timeout: "aa"}}
+	assert.Error(t, engine.Validation())
+}
+
+func TestQueryLimit_Override(t *testing.T) {
+	global := QueryLimit{MaxSeries: 10, MaxPoints: 100, MaxTimeRange: "30d", Timeout: "1m"}
+	assert.Equal(t, global, global.Override(QueryLimit{}))
+	assert.Equal(t, QueryLimit{MaxSeries: 20, MaxPoints: 200, MaxTimeRange: "1d", Timeout: "10s"},
+		global.Override(QueryLimit{MaxSeries: 20, MaxPoints: 200, MaxTimeRange: "1d", Timeout: "10s"}))
+	assert.Equal(t, QueryLimit{MaxSeries: 20, MaxPoints: 100, MaxTimeRange: "30d", Timeout: "1m"},
+		global.Override(QueryLimit{MaxSeries: 20}))
+}
+
+func TestQueryLimit_Get(t *testing.T) {
+	limit := QueryLimit{}
+	assert.Equal(t, int64(0), limit.GetMaxTimeRange())
+	assert.Equal(t, time.Duration(0), limit.GetTimeout())
+	limit = QueryLimit{MaxTimeRange: "30d", Timeout: "1m"}
+	assert.Equal(t, 30*timeutil.OneDay, limit.GetMaxTimeRange())
+	assert.Equal(t, time.Minute, limit.GetTimeout())
+}
@@ -21,6 +21,8 @@ type EngineOption struct {
 
 	Index FlusherOption `toml:"index" json:"index,omitempty"` // index flusher option
 	Data  FlusherOption `toml:"data" json:"data,omitempty"`   // data flusher data
+
+	Query QueryLimit `toml:"query" json:"query,omitempty"` // query limit of database, overrides global limit
 }
 
 // FlusherOption represents a flusher configuration for index and memory db
@@ -31,18 +33,21 @@ type FlusherOption struct {
 
 // Validation validates engine option if valid
 func (e EngineOption) Validation() error {
-	if err := validateInterval(e.Interval, true); err != nil {
+	if err := ValidateInterval(e.Interval, true); err != nil {
 		return err
 	}
 	for _, interval := range e.Rollup {
-		if err := validateInterval(interval, true); err != nil {
+		if err := ValidateInterval(interval, true); err != nil {
 			return err
 		}
 	}
-	if err := validateInterval(e.Ahead, false); err != nil {
+	if err := ValidateInterval(e.Ahead, false); err != nil {
+		return err
+	}
+	if err := ValidateInterval(e.Behind, false); err != nil {
 		return err
 	}
-	if err := validateInterval(e.Behind, false); err != nil {
+	if err := e.Query.Validation(); err != nil {
 		return err
 	}
 	interval, _ := timeutil.ParseInterval(e.Interval)
@@ -55,8 +60,8 @@ func (e EngineOption) Validation() error {
 	return nil
 }
 
-// validateInterval checks interval string if valid
-func validateInterval(intervalStr string, require bool) error {
+// ValidateInterval checks interval string if valid, the empty interval is valid if not required
+func ValidateInterval(intervalStr string, require bool) error {
 	if !require && intervalStr == "" {
 		return nil
 	}
@@ -0,0 +1,130 @@
+package timeutil
+
+import (
+	"sort"
+	"time"
+)
+
+// IntervalUnit represents the unit of group by time interval,
+// the interval of calendar unit has no fixed length, like 28~31 days for month,
+// or 23/25 hours for day when daylight saving time changes, so the buckets are calculated by calendar.
+type IntervalUnit int
+
+const (
+	// FixedUnit is the unit of interval with fixed length, like second/minute/hour, or day/week without time zone
+	FixedUnit IntervalUnit = iota
+	// DayUnit is the unit of calendar day in time zone
+	DayUnit
+	// WeekUnit is the unit of calendar week in time zone, the week starts on monday
+	WeekUnit
+	// MonthUnit is the unit of calendar month in time zone
+	MonthUnit
+	// YearUnit is the unit of calendar year in time zone
+	YearUnit
+)
+
+// Length returns the nominal length of the unit(ms), like 31 days for month,
+// which is only used for estimating, like choosing the interval type of storage, not for calculating the buckets.
+func (u IntervalUnit) Length() int64 {
+	switch u {
+	case DayUnit:
+		return OneDay
+	case WeekUnit:
+		return OneWeek
+	case MonthUnit:
+		return OneMonth
+	case YearUnit:
+		return OneYear
+	default:
+		return 0
+	}
+}
+
+// CalendarInterval represents the interval of calendar unit in time zone, like 1 month in Asia/Shanghai,
+// every bucket starts at local midnight, the start time of next bucket is calculated by time.Time.AddDate in location.
+type CalendarInterval struct {
+	Unit     IntervalUnit
+	Count    int
+	Location *time.Location
+}
+
+// NewCalendarInterval creates the calendar interval by the nominal interval(see IntervalUnit.Length),
+// returns false if the unit isn't calendar unit.
+func NewCalendarInterval(unit IntervalUnit, interval int64, loc *time.Location) (CalendarInterval, bool) {
+	length := unit.Length()
+	if length <= 0 || interval < length {
+		return CalendarInterval{}, false
+	}
+	return CalendarInterval{Unit: unit, Count: int(interval / length), Location: loc}, true
+}
+
+// Truncate returns the start time of the bucket which timestamp belongs to,
+// day/week bucket aligns to local midnight(week starts on monday), month/year bucket aligns to the first day,
+// the num. of days/weeks/months/years since 1970-01-01 is a multiple of count.
+func (c CalendarInterval) Truncate(timestamp int64) int64 {
+	tm := time.Unix(timestamp/1000, (timestamp%1000)*1000000).In(c.Location)
+	var start time.Time
+	switch c.Unit {
+	case DayUnit, WeekUnit:
+		// the num. of days since 1970-01-01 by local date
+		days := int(time.Date(tm.Year(), tm.Month(), tm.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+		if c.Unit == DayUnit {
+			days -= days % c.Count
+		} else {
+			// 1970-01-01 is thursday, shift 3 days for monday aligned
+			days += 3
+			days -= days % (7 * c.Count)
+			days -= 3
+		}
+		start = time.Date(1970, 1, 1+days, 0, 0, 0, 0, c.Location)
+	case MonthUnit:
+		months := (tm.Year()-1970)*12 + int(tm.Month()) - 1
+		months -= months % c.Count
+		start = time.Date(1970, time.Month(months+1), 1, 0, 0, 0, 0, c.Location)
+	case YearUnit:
+		years := tm.Year() - 1970
+		years -= years % c.Count
+		start = time.Date(1970+years, 1, 1, 0, 0, 0, 0, c.Location)
+	default:
+		return timestamp
+	}
+	return start.UnixNano() / 1000000
+}
+
+// Next returns the start time of next bucket, adds the interval to the start time of bucket in location,
+// so the length of bucket follows the calendar, like 29 days for 2020-02, 23 hours for the day of DST starting.
+func (c CalendarInterval) Next(bucketStart int64) int64 {
+	tm := time.Unix(bucketStart/1000, (bucketStart%1000)*1000000).In(c.Location)
+	switch c.Unit {
+	case DayUnit:
+		tm = tm.AddDate(0, 0, c.Count)
+	case WeekUnit:
+		tm = tm.AddDate(0, 0, 7*c.Count)
+	case MonthUnit:
+		tm = tm.AddDate(0, c.Count, 0)
+	case YearUnit:
+		tm = tm.AddDate(c.Count, 0, 0)
+	default:
+		return bucketStart
+	}
+	return tm.UnixNano() / 1000000
+}
+
+// Buckets returns the start time of the buckets which overlap with the time range [start, end),
+// returns at least one bucket, same as CalPointCount.
+func (c CalendarInterval) Buckets(start, end int64) []int64 {
+	bucketStart := c.Truncate(start)
+	buckets := []int64{bucketStart}
+	for bucketStart = c.Next(bucketStart); bucketStart < end; bucketStart = c.Next(bucketStart) {
+		buckets = append(buckets, bucketStart)
+	}
+	return buckets
+}
+
+// BucketIndex returns the index of the bucket which timestamp belongs to, the buckets are order by start time,
+// returns -1 if timestamp is before the first bucket, the last bucket if after it.
+func BucketIndex(buckets []int64, timestamp int64) int {
+	return sort.Search(len(buckets), func(i int) bool {
+		return buckets[i] > timestamp
+	}) - 1
+}
@@ -0,0 +1,103 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toMillis(tm time.Time) int64 {
+	return tm.UnixNano() / 1000000
+}
+
+func TestNewCalendarInterval(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	_, ok := NewCalendarInterval(FixedUnit, OneDay, loc)
+	assert.False(t, ok)
+	_, ok = NewCalendarInterval(MonthUnit, OneDay, loc)
+	assert.False(t, ok)
+	calendar, ok := NewCalendarInterval(MonthUnit, 3*OneMonth, loc)
+	assert.True(t, ok)
+	assert.Equal(t, CalendarInterval{Unit: MonthUnit, Count: 3, Location: loc}, calendar)
+	assert.Equal(t, int64(0), FixedUnit.Length())
+}
+
+func TestCalendarInterval_Truncate(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	// 2019-07-31 is wednesday
+	timestamp := toMillis(time.Date(2019, 7, 31, 5, 23, 10, 0, loc))
+
+	assert.Equal(t, toMillis(time.Date(2019, 7, 31, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: DayUnit, Count: 1, Location: loc}.Truncate(timestamp))
+	assert.Equal(t, toMillis(time.Date(2019, 7, 29, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: WeekUnit, Count: 1, Location: loc}.Truncate(timestamp))
+	assert.Equal(t, toMillis(time.Date(2019, 7, 1, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: MonthUnit, Count: 1, Location: loc}.Truncate(timestamp))
+	assert.Equal(t, toMillis(time.Date(2019, 7, 1, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: MonthUnit, Count: 3, Location: loc}.Truncate(timestamp))
+	assert.Equal(t, toMillis(time.Date(2019, 1, 1, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: YearUnit, Count: 1, Location: loc}.Truncate(timestamp))
+	// the local date is different from utc date
+	assert.Equal(t, toMillis(time.Date(2019, 8, 1, 0, 0, 0, 0, loc)),
+		CalendarInterval{Unit: DayUnit, Count: 1, Location: loc}.Truncate(toMillis(time.Date(2019, 7, 31, 20, 0, 0, 0, time.UTC))))
+	assert.Equal(t, timestamp, CalendarInterval{Location: loc}.Truncate(timestamp))
+	assert.Equal(t, timestamp, CalendarInterval{Location: loc}.Next(timestamp))
+}
+
+func TestCalendarInterval_MonthBoundary(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	month := CalendarInterval{Unit: MonthUnit, Count: 1, Location: loc}
+	buckets := month.Buckets(toMillis(time.Date(2020, 1, 31, 10, 0, 0, 0, loc)),
+		toMillis(time.Date(2020, 4, 1, 0, 0, 0, 0, loc)))
+	assert.Equal(t, []int64{
+		toMillis(time.Date(2020, 1, 1, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2020, 2, 1, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2020, 3, 1, 0, 0, 0, 0, loc)),
+	}, buckets)
+	// 31 days for january, 29 days for february of leap year
+	assert.Equal(t, 31*OneDay, buckets[1]-buckets[0])
+	assert.Equal(t, 29*OneDay, buckets[2]-buckets[1])
+	// the first point of march belongs to the bucket of march, the last point of february belongs to february
+	assert.Equal(t, 2, BucketIndex(buckets, toMillis(time.Date(2020, 3, 1, 0, 0, 0, 0, loc))))
+	assert.Equal(t, 1, BucketIndex(buckets, toMillis(time.Date(2020, 2, 29, 23, 59, 59, 0, loc))))
+	assert.Equal(t, -1, BucketIndex(buckets, buckets[0]-1))
+	assert.Equal(t, 2, BucketIndex(buckets, toMillis(time.Date(2020, 5, 1, 0, 0, 0, 0, loc))))
+
+	year := CalendarInterval{Unit: YearUnit, Count: 1, Location: loc}
+	buckets = year.Buckets(toMillis(time.Date(2019, 6, 1, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2020, 6, 1, 0, 0, 0, 0, loc)))
+	assert.Equal(t, []int64{
+		toMillis(time.Date(2019, 1, 1, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2020, 1, 1, 0, 0, 0, 0, loc)),
+	}, buckets)
+	assert.Equal(t, 365*OneDay, buckets[1]-buckets[0])
+	assert.Equal(t, 366*OneDay, year.Next(buckets[1])-buckets[1])
+
+	// at least one bucket
+	assert.Len(t, month.Buckets(buckets[0], buckets[0]), 1)
+}
+
+func TestCalendarInterval_DaylightSavingTime(t *testing.T) {
+	loc, _ := time.LoadLocation("America/New_York")
+	day := CalendarInterval{Unit: DayUnit, Count: 1, Location: loc}
+	// 2019-03-10 is the day of daylight saving time starting, 2019-11-03 is the day of ending
+	buckets := day.Buckets(toMillis(time.Date(2019, 3, 9, 12, 0, 0, 0, loc)),
+		toMillis(time.Date(2019, 3, 12, 0, 0, 0, 0, loc)))
+	assert.Equal(t, []int64{
+		toMillis(time.Date(2019, 3, 9, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2019, 3, 10, 0, 0, 0, 0, loc)),
+		toMillis(time.Date(2019, 3, 11, 0, 0, 0, 0, loc)),
+	}, buckets)
+	// every bucket starts at local midnight, the day of DST starting has 23 hours
+	assert.Equal(t, 24*OneHour, buckets[1]-buckets[0])
+	assert.Equal(t, 23*OneHour, buckets[2]-buckets[1])
+	start := toMillis(time.Date(2019, 11, 3, 0, 0, 0, 0, loc))
+	assert.Equal(t, start, day.Truncate(start+24*OneHour))
+	assert.Equal(t, 25*OneHour, day.Next(start)-start)
+
+	week := CalendarInterval{Unit: WeekUnit, Count: 1, Location: loc}
+	start = week.Truncate(toMillis(time.Date(2019, 3, 10, 12, 0, 0, 0, loc)))
+	assert.Equal(t, toMillis(time.Date(2019, 3, 4, 0, 0, 0, 0, loc)), start)
+	assert.Equal(t, toMillis(time.Date(2019, 3, 11, 0, 0, 0, 0, loc)), week.Next(start))
+}
@@ -18,14 +18,16 @@ const (
 	OneDay = 24 * OneHour
 	// OneWeek is the number of millisecond for a week
 	OneWeek = 7 * OneDay
-	// OneMonth is the number of millisecond for a month
+	// OneMonth is the nominal number of millisecond for a month, the calendar month is calculated by CalendarInterval
 	OneMonth = 31 * OneDay
-	// OneYear is the number of millisecond for a year
+	// OneYear is the nominal number of millisecond for a year, the calendar year is calculated by CalendarInterval
 	OneYear = 365 * OneDay
 	//TODO ????
 	dataTimeFormat1 = "20060102 15:04:05"
 	dataTimeFormat2 = "2006-01-02 15:04:05"
 	dataTimeFormat3 = "2006/01/02 15:04:05"
+	// isoTimeFormat is the ISO-8601 format without zone offset
+	isoTimeFormat = "2006-01-02T15:04:05"
 )
 
 // FormatTimestamp returns timestamp format based on layout
@@ -36,26 +38,64 @@ func FormatTimestamp(timestamp int64, layout string) string {
 
 // ParseTimestamp parses timestamp str value based on layout using local zone
 func ParseTimestamp(timestampStr string, layout ...string) (int64, error) {
-	var format string
 	if len(layout) > 0 {
-		format = layout[0]
-	} else {
-		switch {
-		case strings.Index(timestampStr, "-") > 0:
-			format = dataTimeFormat2
-		case strings.Index(timestampStr, "/") > 0:
-			format = dataTimeFormat3
-		default:
-			format = dataTimeFormat1
+		tm, err := time.ParseInLocation(layout[0], timestampStr, time.Local)
+		if err != nil {
+			return 0, err
+		}
+		return tm.UnixNano() / 1000000, nil
+	}
+	return ParseTimestampInLocation(timestampStr, time.Local)
+}
+
+// ParseTimestampInLocation parses timestamp str value using given location,
+// supports RFC3339/ISO-8601 format(like 2019-07-29T11:00:00+08:00 or 2019-07-29T11:00:00),
+// the location is used when the str value doesn't include zone offset
+func ParseTimestampInLocation(timestampStr string, loc *time.Location) (int64, error) {
+	var tm time.Time
+	var err error
+	switch {
+	case strings.Contains(timestampStr, "T"):
+		tm, err = time.Parse(time.RFC3339Nano, timestampStr)
+		if err != nil {
+			tm, err = time.ParseInLocation(isoTimeFormat, timestampStr, loc)
 		}
+	case strings.Index(timestampStr, "-") > 0:
+		tm, err = time.ParseInLocation(dataTimeFormat2, timestampStr, loc)
+	case strings.Index(timestampStr, "/") > 0:
+		tm, err = time.ParseInLocation(dataTimeFormat3, timestampStr, loc)
+	default:
+		tm, err = time.ParseInLocation(dataTimeFormat1, timestampStr, loc)
 	}
-	tm, err := time.ParseInLocation(format, timestampStr, time.Local)
 	if err != nil {
 		return 0, err
 	}
 	return tm.UnixNano() / 1000000, nil
 }
 
+// Truncate returns the start time of the bucket with fixed length which timestamp belongs to in given location,
+// the interval of multiple days/weeks aligns to local midnight(week starts on monday), other interval aligns to
+// local wall clock. The interval of calendar unit(like day/month in time zone) is aligned by CalendarInterval.
+func Truncate(timestamp, interval int64, loc *time.Location) int64 {
+	if interval <= 0 {
+		return timestamp
+	}
+	tm := time.Unix(timestamp/1000, (timestamp%1000)*1000000).In(loc)
+	_, offset := tm.Zone()
+	local := timestamp + int64(offset)*1000
+	if interval%OneWeek == 0 {
+		// 1970-01-01 is thursday, shift 3 days for monday aligned
+		local += 3 * OneDay
+		local -= local % interval
+		local -= 3 * OneDay
+	} else {
+		local -= local % interval
+	}
+	// uses the offset of bucket start time, because the offset maybe changed by daylight saving time
+	_, offset = time.Unix(local/1000, 0).In(loc).Zone()
+	return local - int64(offset)*1000
+}
+
 // Now returns t as a Unix time, the number of millisecond elapsed
 // since January 1, 1970 UTC. The result does not depend on the
 // location associated with t.
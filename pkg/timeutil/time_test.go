@@ -3,6 +3,7 @@ package timeutil
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +21,53 @@ func Test_ParseTimestamp(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestParseTimestampInLocation(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	expect := time.Date(2019, 7, 29, 11, 0, 0, 0, loc).UnixNano() / 1000000
+
+	timestamp, err := ParseTimestampInLocation("2019-07-29T11:00:00+08:00", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+	timestamp, err = ParseTimestampInLocation("2019-07-29T03:00:00Z", loc)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+	timestamp, err = ParseTimestampInLocation("2019-07-29T11:00:00", loc)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+	timestamp, err = ParseTimestampInLocation("2019-07-29 11:00:00", loc)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+	timestamp, err = ParseTimestampInLocation("2019/07/29 11:00:00", loc)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+	timestamp, err = ParseTimestampInLocation("20190729 11:00:00", loc)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, timestamp)
+
+	_, err = ParseTimestampInLocation("2019-07-29T11:00", loc)
+	assert.Error(t, err)
+	_, err = ParseTimestamp("2019-07-29", "2006-01-02 15")
+	assert.Error(t, err)
+	_, err = ParseTimestamp("2019-07-29", "2006-01-02")
+	assert.NoError(t, err)
+}
+
+func TestTruncate(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	// 2019-07-31 is wednesday
+	timestamp := time.Date(2019, 7, 31, 5, 23, 10, 0, loc).UnixNano() / 1000000
+
+	assert.Equal(t, timestamp, Truncate(timestamp, 0, loc))
+	assert.Equal(t, time.Date(2019, 7, 31, 5, 20, 0, 0, loc).UnixNano()/1000000,
+		Truncate(timestamp, 5*OneMinute, loc))
+	assert.Equal(t, time.Date(2019, 7, 31, 0, 0, 0, 0, loc).UnixNano()/1000000,
+		Truncate(timestamp, OneDay, loc))
+	assert.Equal(t, time.Date(2019, 7, 29, 0, 0, 0, 0, loc).UnixNano()/1000000,
+		Truncate(timestamp, OneWeek, loc))
+	assert.Equal(t, time.Date(2019, 7, 31, 0, 0, 0, 0, time.UTC).UnixNano()/1000000,
+		Truncate(timestamp+OneDay, OneDay, time.UTC))
+}
+
 func TestCalPointCount(t *testing.T) {
 	time, _ := ParseTimestamp(date)
 	assert.Equal(t, 1, CalPointCount(time, time, 10*OneSecond))
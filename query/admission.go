@@ -0,0 +1,256 @@
+package query
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/models"
+)
+
+// AdmissionController controls the concurrent queries in broker side by global/per-user/per-database limits,
+// the queries exceeding the limits wait in the queue by priority until admitted or timeout,
+// bursts of dashboard loads are queued instead of spawning unbounded jobs.
+// NOTICE: the alerting query preempts(cancels) the latest admitted ad-hoc query if the global limit is reached.
+type AdmissionController struct {
+	maxConcurrency            int                 // 0 means no limit
+	maxConcurrencyPerUser     int                 // 0 means no limit
+	maxConcurrencyPerDatabase int                 // 0 means no limit
+	maxQueueSize              int                 // rejects the query if the queue is full
+	queueTimeout              time.Duration       // 0 means waiting until the query is canceled or timeout
+	alertingUsers             map[string]struct{} // users allowed to run alerting queries, empty means any authenticated user
+
+	running    []*admissionTicket // running queries, order by admitted time
+	ofUser     map[string]int     // user => num. of running queries
+	ofDatabase map[string]int     // database => num. of running queries
+	waiting    []*admissionTicket // waiting queries, order by priority, then arrival time
+	mutex      sync.Mutex
+}
+
+// admissionTicket represents the admission of a query, which is released when the query completed
+type admissionTicket struct {
+	user      string
+	database  string
+	priority  models.QueryPriority
+	cancel    context.CancelFunc // cancels the query if preempted
+	admitted  chan struct{}      // closed when the query is admitted
+	preempted bool
+}
+
+// NewAdmissionController creates the admission controller of query, returns nil(disable) if no limit is set,
+// the alerting priority is only accepted from the authenticated users(limited by alertingUsers if not empty).
+func NewAdmissionController(maxConcurrency, maxConcurrencyPerUser, maxConcurrencyPerDatabase, maxQueueSize int,
+	queueTimeout time.Duration, alertingUsers []string) *AdmissionController {
+	if maxConcurrency <= 0 && maxConcurrencyPerUser <= 0 && maxConcurrencyPerDatabase <= 0 {
+		return nil
+	}
+	users := make(map[string]struct{})
+	for _, user := range alertingUsers {
+		users[user] = struct{}{}
+	}
+	return &AdmissionController{
+		alertingUsers:             users,
+		maxConcurrency:            maxConcurrency,
+		maxConcurrencyPerUser:     maxConcurrencyPerUser,
+		maxConcurrencyPerDatabase: maxConcurrencyPerDatabase,
+		maxQueueSize:              maxQueueSize,
+		queueTimeout:              queueTimeout,
+		ofUser:                    make(map[string]int),
+		ofDatabase:                make(map[string]int),
+	}
+}
+
+// admit admits the query of database, waits in the queue if exceeds the limits,
+// returns the ticket which must be released when the query completed,
+// returns the error if the queue is full or waiting timeout or the context is done.
+func (c *AdmissionController) admit(ctx context.Context, cancel context.CancelFunc,
+	database string) (*admissionTicket, error) {
+	if c == nil {
+		return nil, nil
+	}
+	ticket := &admissionTicket{
+		user:     models.UserFromContext(ctx),
+		database: database,
+		priority: models.QueryPriorityFromContext(ctx),
+		cancel:   cancel,
+		admitted: make(chan struct{}),
+	}
+	// the priority param can be set by anyone, only the trusted user can preempt the ad-hoc queries
+	if ticket.priority == models.AlertingPriority && !c.alertingAllowed(ticket.user) {
+		ticket.priority = models.AdHocPriority
+	}
+	c.mutex.Lock()
+	if !c.acquirable(ticket, false) && len(c.waiting) >= c.maxQueueSize {
+		c.mutex.Unlock()
+		return nil, errTooManyQueries
+	}
+	c.enqueue(ticket)
+	c.schedule()
+	if ticket.priority == models.AlertingPriority {
+		c.preempt(ticket)
+	}
+	c.mutex.Unlock()
+
+	var timeout <-chan time.Time
+	if c.queueTimeout > 0 {
+		timer := time.NewTimer(c.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var err error
+	select {
+	case <-ticket.admitted:
+		return ticket, nil
+	case <-timeout:
+		err = errAdmissionTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.dequeue(ticket) {
+		return nil, err
+	}
+	// admitted before dequeue
+	return ticket, nil
+}
+
+// release releases the ticket of completed query, then admits the waiting queries
+func (c *AdmissionController) release(ticket *admissionTicket) {
+	if c == nil || ticket == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for idx, running := range c.running {
+		if running == ticket {
+			c.running = append(c.running[:idx], c.running[idx+1:]...)
+			c.decrease(c.ofUser, ticket.user)
+			c.decrease(c.ofDatabase, ticket.database)
+			c.schedule()
+			return
+		}
+	}
+}
+
+// preempted returns if the query of ticket is preempted by alerting query
+func (c *AdmissionController) preempted(ticket *admissionTicket) bool {
+	if c == nil || ticket == nil {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return ticket.preempted
+}
+
+// alertingAllowed checks if the user is allowed to run the alerting priority query
+func (c *AdmissionController) alertingAllowed(user string) bool {
+	if user == "" {
+		return false
+	}
+	if len(c.alertingUsers) == 0 {
+		return true
+	}
+	_, ok := c.alertingUsers[user]
+	return ok
+}
+
+// acquirable checks if the query of ticket can run under the limits, ignores the global limit if ignoreGlobal
+func (c *AdmissionController) acquirable(ticket *admissionTicket, ignoreGlobal bool) bool {
+	if !ignoreGlobal && c.maxConcurrency > 0 && len(c.running) >= c.maxConcurrency {
+		return false
+	}
+	// the anonymous query(no user in context) isn't limited by the per-user limit, else it acts as a global limit,
+	// see config.Admission.MaxConcurrencyPerUser
+	if c.maxConcurrencyPerUser > 0 && ticket.user != "" && c.ofUser[ticket.user] >= c.maxConcurrencyPerUser {
+		return false
+	}
+	if c.maxConcurrencyPerDatabase > 0 && c.ofDatabase[ticket.database] >= c.maxConcurrencyPerDatabase {
+		return false
+	}
+	return true
+}
+
+// schedule admits the waiting queries by priority, skips the query if exceeds the per-user/per-database limit
+func (c *AdmissionController) schedule() {
+	waiting := c.waiting[:0]
+	for _, ticket := range c.waiting {
+		if c.acquirable(ticket, false) {
+			c.running = append(c.running, ticket)
+			c.ofUser[ticket.user]++
+			c.ofDatabase[ticket.database]++
+			close(ticket.admitted)
+			continue
+		}
+		waiting = append(waiting, ticket)
+	}
+	c.waiting = waiting
+}
+
+// preempt cancels the latest admitted ad-hoc query if the waiting alerting query is blocked by the global limit,
+// the alerting query is admitted after the preempted query released.
+func (c *AdmissionController) preempt(ticket *admissionTicket) {
+	select {
+	case <-ticket.admitted:
+		return
+	default:
+	}
+	if c.maxConcurrency <= 0 || !c.acquirable(ticket, true) {
+		// blocked by the per-user/per-database limit, preemption doesn't help
+		return
+	}
+	preempting := 0
+	for _, running := range c.running {
+		if running.preempted {
+			preempting++
+		}
+	}
+	if len(c.running)-preempting < c.maxConcurrency {
+		// the preempted queries will release enough capacity
+		return
+	}
+	for idx := len(c.running) - 1; idx >= 0; idx-- {
+		running := c.running[idx]
+		if running.priority == models.AdHocPriority && !running.preempted {
+			running.preempted = true
+			running.cancel()
+			return
+		}
+	}
+}
+
+// enqueue puts the ticket into the waiting queue, after the tickets with same or higher priority
+func (c *AdmissionController) enqueue(ticket *admissionTicket) {
+	pos := len(c.waiting)
+	for idx, waiting := range c.waiting {
+		if waiting.priority < ticket.priority {
+			pos = idx
+			break
+		}
+	}
+	c.waiting = append(c.waiting, nil)
+	copy(c.waiting[pos+1:], c.waiting[pos:])
+	c.waiting[pos] = ticket
+}
+
+// dequeue removes the ticket from the waiting queue, returns false if the ticket isn't waiting
+func (c *AdmissionController) dequeue(ticket *admissionTicket) bool {
+	for idx, waiting := range c.waiting {
+		if waiting == ticket {
+			c.waiting = append(c.waiting[:idx], c.waiting[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// decrease decreases the num. of running queries of key
+func (c *AdmissionController) decrease(counts map[string]int, key string) {
+	if counts[key] <= 1 {
+		delete(counts, key)
+		return
+	}
+	counts[key]--
+}
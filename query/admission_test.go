@@ -0,0 +1,215 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+type admitResult struct {
+	ticket *admissionTicket
+	err    error
+}
+
+// admitAsync admits the query in background, returns the channel of admit result
+func admitAsync(c *AdmissionController, ctx context.Context, cancel context.CancelFunc,
+	database string) <-chan admitResult {
+	result := make(chan admitResult, 1)
+	go func() {
+		ticket, err := c.admit(ctx, cancel, database)
+		result <- admitResult{ticket: ticket, err: err}
+	}()
+	return result
+}
+
+// waitQueued waits until the num. of waiting queries is expected
+func waitQueued(t *testing.T, c *AdmissionController, expected int) {
+	for i := 0; i < 1000; i++ {
+		c.mutex.Lock()
+		queued := len(c.waiting)
+		c.mutex.Unlock()
+		if queued == expected {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("wait queued queries timeout, expected: %d", expected)
+}
+
+func noopCancel() {}
+
+func TestNewAdmissionController(t *testing.T) {
+	c := NewAdmissionController(0, 0, 0, 10, time.Second, nil)
+	assert.Nil(t, c)
+	ticket, err := c.admit(context.TODO(), noopCancel, "db")
+	assert.NoError(t, err)
+	assert.Nil(t, ticket)
+	c.release(ticket)
+	assert.False(t, c.preempted(ticket))
+
+	assert.NotNil(t, NewAdmissionController(1, 0, 0, 10, time.Second, nil))
+	assert.NotNil(t, NewAdmissionController(0, 1, 0, 10, time.Second, nil))
+	assert.NotNil(t, NewAdmissionController(0, 0, 1, 10, time.Second, nil))
+}
+
+func TestAdmissionController_GlobalLimit(t *testing.T) {
+	c := NewAdmissionController(1, 0, 0, 1, 0, nil)
+	t1, err := c.admit(context.TODO(), noopCancel, "db")
+	assert.NoError(t, err)
+	assert.NotNil(t, t1)
+
+	result := admitAsync(c, context.TODO(), noopCancel, "db")
+	waitQueued(t, c, 1)
+	// queue is full
+	_, err = c.admit(context.TODO(), noopCancel, "db")
+	assert.Equal(t, errTooManyQueries, err)
+
+	c.release(t1)
+	t2 := <-result
+	assert.NoError(t, t2.err)
+	c.release(t2.ticket)
+	// release twice
+	c.release(t2.ticket)
+	assert.Empty(t, c.running)
+	assert.Empty(t, c.ofUser)
+	assert.Empty(t, c.ofDatabase)
+}
+
+func TestAdmissionController_WaitFailure(t *testing.T) {
+	c := NewAdmissionController(1, 0, 0, 10, 10*time.Millisecond, nil)
+	t1, err := c.admit(context.TODO(), noopCancel, "db")
+	assert.NoError(t, err)
+	// queue timeout
+	_, err = c.admit(context.TODO(), noopCancel, "db")
+	assert.Equal(t, errAdmissionTimeout, err)
+	c.release(t1)
+
+	// query canceled
+	c = NewAdmissionController(1, 0, 0, 10, 0, nil)
+	_, err = c.admit(context.TODO(), noopCancel, "db")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.TODO())
+	result := admitAsync(c, ctx, cancel, "db")
+	waitQueued(t, c, 1)
+	cancel()
+	r := <-result
+	assert.Equal(t, context.Canceled, r.err)
+	assert.Nil(t, r.ticket)
+	assert.Empty(t, c.waiting)
+}
+
+func TestAdmissionController_UserAndDatabaseLimit(t *testing.T) {
+	c := NewAdmissionController(0, 1, 2, 10, 0, nil)
+	userA := models.WithUser(context.TODO(), "a")
+	t1, err := c.admit(userA, noopCancel, "db")
+	assert.NoError(t, err)
+	// exceeds the limit of user a
+	resultA := admitAsync(c, userA, noopCancel, "db")
+	waitQueued(t, c, 1)
+	// other user isn't blocked
+	t2, err := c.admit(models.WithUser(context.TODO(), "b"), noopCancel, "db")
+	assert.NoError(t, err)
+	// exceeds the limit of database
+	resultC := admitAsync(c, models.WithUser(context.TODO(), "c"), noopCancel, "db")
+	waitQueued(t, c, 2)
+	_, err = c.admit(models.WithUser(context.TODO(), "d"), noopCancel, "other")
+	assert.NoError(t, err)
+	// anonymous queries aren't limited by the per-user limit
+	_, err = c.admit(context.TODO(), noopCancel, "other")
+	assert.NoError(t, err)
+
+	c.release(t1)
+	r := <-resultA
+	assert.NoError(t, r.err)
+	c.release(t2)
+	r = <-resultC
+	assert.NoError(t, r.err)
+}
+
+func TestAdmissionController_Priority(t *testing.T) {
+	c := NewAdmissionController(1, 0, 0, 10, 0, nil)
+	alerting := models.WithQueryPriority(models.WithUser(context.TODO(), "alert"), models.AlertingPriority)
+	t1, err := c.admit(alerting, noopCancel, "db")
+	assert.NoError(t, err)
+	adHoc := admitAsync(c, context.TODO(), noopCancel, "db")
+	waitQueued(t, c, 1)
+	// the running alerting query isn't preempted
+	alertingResult := admitAsync(c, alerting, noopCancel, "db")
+	waitQueued(t, c, 2)
+	assert.False(t, c.preempted(t1))
+
+	// the alerting query is admitted before ad-hoc query
+	c.release(t1)
+	r := <-alertingResult
+	assert.NoError(t, r.err)
+	waitQueued(t, c, 1)
+	c.release(r.ticket)
+	r = <-adHoc
+	assert.NoError(t, r.err)
+}
+
+func TestAdmissionController_AlertingUsers(t *testing.T) {
+	c := NewAdmissionController(1, 0, 0, 10, 0, nil)
+	// the alerting priority of anonymous query falls back to ad-hoc
+	ticket, err := c.admit(models.WithQueryPriority(context.TODO(), models.AlertingPriority), noopCancel, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AdHocPriority, ticket.priority)
+	c.release(ticket)
+	// any authenticated user if alerting users not set
+	ticket, err = c.admit(models.WithQueryPriority(models.WithUser(context.TODO(), "a"), models.AlertingPriority),
+		noopCancel, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AlertingPriority, ticket.priority)
+	c.release(ticket)
+
+	c = NewAdmissionController(1, 0, 0, 10, 0, []string{"alert"})
+	ticket, err = c.admit(models.WithQueryPriority(models.WithUser(context.TODO(), "a"), models.AlertingPriority),
+		noopCancel, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AdHocPriority, ticket.priority)
+	c.release(ticket)
+	ticket, err = c.admit(models.WithQueryPriority(models.WithUser(context.TODO(), "alert"), models.AlertingPriority),
+		noopCancel, "db")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AlertingPriority, ticket.priority)
+	c.release(ticket)
+}
+
+func TestAdmissionController_Preempt(t *testing.T) {
+	c := NewAdmissionController(2, 0, 0, 10, 0, nil)
+	canceled := make(map[string]bool)
+	t1, err := c.admit(context.TODO(), func() { canceled["t1"] = true }, "db")
+	assert.NoError(t, err)
+	t2, err := c.admit(context.TODO(), func() { canceled["t2"] = true }, "db")
+	assert.NoError(t, err)
+
+	alerting := models.WithQueryPriority(models.WithUser(context.TODO(), "alert"), models.AlertingPriority)
+	result := admitAsync(c, alerting, noopCancel, "db")
+	waitQueued(t, c, 1)
+	// preempts the latest admitted ad-hoc query
+	c.mutex.Lock()
+	assert.Equal(t, map[string]bool{"t2": true}, canceled)
+	c.mutex.Unlock()
+	assert.True(t, c.preempted(t2))
+	assert.False(t, c.preempted(t1))
+
+	c.release(t2)
+	r := <-result
+	assert.NoError(t, r.err)
+	assert.False(t, c.preempted(r.ticket))
+	c.release(t1)
+	c.release(r.ticket)
+
+	// blocked by the per-user limit, no preemption
+	c = NewAdmissionController(1, 1, 0, 10, 10*time.Millisecond, nil)
+	t1, err = c.admit(models.WithUser(context.TODO(), "a"), func() { canceled["t3"] = true }, "db")
+	assert.NoError(t, err)
+	_, err = c.admit(models.WithQueryPriority(models.WithUser(context.TODO(), "a"), models.AlertingPriority),
+		noopCancel, "db")
+	assert.Equal(t, errAdmissionTimeout, err)
+	assert.False(t, c.preempted(t1))
+}
@@ -1,12 +1,26 @@
 package query
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb/series"
 )
 
+var log = logger.GetLogger("query", "BrokerExecutor")
+
 // brokerExecutor represents the broker query executor,
 // 1) chooses the storage nodes that the data is relatively complete
 // 2) chooses broker nodes for root and intermediate computing from all available broker nodes
@@ -20,30 +34,86 @@ import (
 //    maybe some expectant results are lost in data in offline shard, WHY can query not completely data,
 //    because of for the system availability.
 type brokerExecutor struct {
+	ctx    context.Context // done if the client cancels the query or timeout
+	cancel context.CancelFunc
+
 	database string
 	sql      string
+	query    *stmt.Query // query statement, parsed from sql when creating if the sql is set
 
 	replicaStateMachine replica.StatusStateMachine
 	nodeStateMachine    broker.NodeStateMachine
+	databaseService     service.DatabaseService // for checking the unavailable shards, nil means skip
 
 	resultSet chan series.GroupedIterator
 
-	jobManager parallel.JobManager
+	jobManager  parallel.JobManager
+	jobContexts []parallel.JobContext // a job for each metric if cross-metric query
+
+	limit              option.QueryLimit // global query limit, overridden by the query limit of database
+	limiter            *queryLimiter
+	slowQueryThreshold time.Duration // records the query into slow query log if exceeds, 0 means disable
+
+	resultCache *ResultCache // nil if disable
+	cacheLookup *cacheLookup // cached buckets of query, nil if the query isn't cacheable
+
+	admission *AdmissionController // nil if disable
+	ticket    *admissionTicket     // admission of the running query, released when the query completed
+
+	failOnIncomplete bool    // fails the query if the result is incomplete, else returns partial result with warnings
+	missingShards    []int32 // shards of database without queryable replica
+
+	trace  *models.Trace // span tree of query, recorded if requested by client or slow query log enabled
+	traced bool          // returns the trace to client if requested
+
+	startTime time.Time
+
+	err     error      // failure before executing, like plan or admission error, set before Execute returns
+	mutex   sync.Mutex // guards the failure when executing
+	execErr error      // failure when executing, like timeout or killed, set by the forwarding goroutine
+}
+
+// newBrokerExecutor creates the execution which executes the job of parallel query under the query limit,
+// the job is canceled if the context is done, like client disconnect,
+// the sql is parsed when creating, the parse error is returned when executing.
+func newBrokerExecutor(ctx context.Context, database string, sqlText string,
+	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+	jobManager parallel.JobManager, option ExecutorOption) parallel.BrokerExecutor {
+	query, err := sql.Parse(sqlText)
+	exec := newExecutor(ctx, database, sqlText, query, replicaStateMachine, nodeStateMachine, jobManager, option)
+	exec.err = err
+	return exec
+}
 
-	err error
+// newBrokerQueryExecutor creates the execution which executes the job of the parsed query statement
+func newBrokerQueryExecutor(ctx context.Context, database string, query *stmt.Query,
+	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+	jobManager parallel.JobManager, option ExecutorOption) parallel.BrokerExecutor {
+	return newExecutor(ctx, database, "", query, replicaStateMachine, nodeStateMachine, jobManager, option)
 }
 
-// newBrokerExecutor creates the execution which executes the job of parallel query
-func newBrokerExecutor(database string, sql string,
+// newExecutor creates the broker executor of query statement, the sql is empty if the query isn't parsed from sql
+func newExecutor(ctx context.Context, database string, sql string, query *stmt.Query,
 	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
-	jobManager parallel.JobManager) parallel.Executor {
+	jobManager parallel.JobManager, option ExecutorOption) *brokerExecutor {
 	exec := &brokerExecutor{
+		ctx:                 ctx,
 		sql:                 sql,
+		query:               query,
 		database:            database,
 		replicaStateMachine: replicaStateMachine,
 		nodeStateMachine:    nodeStateMachine,
+		databaseService:     option.DatabaseService,
 		jobManager:          jobManager,
+		failOnIncomplete:    models.FailOnIncompleteFromContext(ctx),
+		limit:               option.Limit,
+		limiter:             newQueryLimiter(option.Limit, time.Now()),
+		slowQueryThreshold:  option.SlowQueryThreshold,
+		resultCache:         option.ResultCache,
+		admission:           option.Admission,
+		trace:               models.TraceFromContext(ctx),
 	}
+	exec.traced = exec.trace != nil
 	return exec
 }
 
@@ -52,31 +122,427 @@ func newBrokerExecutor(database string, sql string,
 // 2) build execute plan
 // 3) run distribution query job
 func (e *brokerExecutor) Execute() <-chan series.GroupedIterator {
+	e.startTime = time.Now()
+	if e.err != nil {
+		// parse sql error
+		return nil
+	}
+	if e.trace == nil && e.slowQueryThreshold > 0 {
+		// records the trace for the slow query log, which isn't returned to client
+		e.trace = models.NewTrace("query")
+		e.ctx = models.WithTrace(e.ctx, e.trace)
+	}
+	if e.trace != nil {
+		e.trace.Root.SetTag("database", e.database)
+	}
 	//FIXME need using storage's replica state ???
 	storageNodes := e.replicaStateMachine.GetQueryableReplicas(e.database)
 	if len(storageNodes) == 0 {
 		e.err = errNoAvailableStorageNode
 		return nil
 	}
+	database := e.getDatabase()
+	e.missingShards = e.findMissingShards(database, storageNodes)
+	if err := e.checkIncomplete(); err != nil {
+		e.err = err
+		return nil
+	}
+	// the query limit of database overrides the global limit, same as storage nodes,
+	// fails fast if time range exceeds the limit before planning
+	if database != nil {
+		e.limiter = newQueryLimiter(e.limit.Override(database.Engine.Query), e.startTime)
+	}
+	if err := e.limiter.checkTimeRange(e.query.LeafQuery().TimeRange); err != nil {
+		e.err = err
+		return nil
+	}
 
 	brokerNodes := e.nodeStateMachine.GetActiveNodes()
-	plan := newBrokerPlan(e.sql, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
-	if err := plan.Plan(); err != nil {
+	plan := newBrokerQueryPlan(e.query, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
+	planSpan := e.trace.StartSpan("plan")
+	err := plan.Plan()
+	planSpan.Finish()
+	if err != nil {
 		e.err = err
 		return nil
 	}
 	brokerPlan := plan.(*brokerPlan)
 	brokerPlan.physicalPlan.Database = e.database
-	e.resultSet = make(chan series.GroupedIterator)
-	if err := e.jobManager.SubmitJob(parallel.NewJobContext(e.resultSet, brokerPlan.physicalPlan)); err != nil {
-		e.err = err
-		close(e.resultSet)
-		return nil
+	e.query = brokerPlan.query
+	// the jobs of query are canceled if the client cancels the query or timeout
+	if e.limiter.deadline.IsZero() {
+		e.ctx, e.cancel = context.WithCancel(e.ctx)
+	} else {
+		e.ctx, e.cancel = context.WithDeadline(e.ctx, e.limiter.deadline)
+	}
+	// aligns the time range by the interval before cache lookup, then reuses the cached buckets of query,
+	// only queries the uncached time range which starts from the bucket boundary
+	e.query = e.resultCache.align(e.query)
+	if e.cacheLookup = e.resultCache.lookup(e.database, e.query); e.cacheLookup != nil {
+		query := *e.query
+		query.TimeRange.Start = e.cacheLookup.start
+		e.query = &query
 	}
+	var resultSet chan series.GroupedIterator
+	if e.cacheLookup != nil && e.cacheLookup.hitAll() {
+		// all buckets are cached, no job need to submit
+		resultSet = make(chan series.GroupedIterator)
+		close(resultSet)
+	} else {
+		// waits in the admission queue if exceeds the concurrent limits
+		var err error
+		if e.ticket, err = e.admission.admit(e.ctx, e.cancel, e.database); err != nil {
+			e.cancel()
+			e.err = e.admissionError(err)
+			return nil
+		}
+		if resultSet, err = e.executeQuery(brokerPlan.physicalPlan, e.query); err != nil {
+			e.cancel()
+			e.admission.release(e.ticket)
+			e.err = err
+			return nil
+		}
+	}
+	e.resultSet = e.forward(resultSet)
 	return e.resultSet
 }
 
-// Error returns the execution error
+// forward forwards the results of execution until the context is done,
+// fails the query if it's canceled or timeout, then drains the remaining results for completing the job.
+func (e *brokerExecutor) forward(results chan series.GroupedIterator) chan series.GroupedIterator {
+	forwarded := make(chan series.GroupedIterator)
+	go func() {
+		// the error is set before closing the forwarded channel, so it's visible after draining the results
+		defer close(forwarded)
+		defer e.admission.release(e.ticket)
+		defer e.cancel()
+		defer e.logSlowQuery()
+		defer e.finishTrace()
+		for {
+			select {
+			case it, ok := <-results:
+				if !ok {
+					err := e.checkKilled()
+					if err == nil {
+						err = e.checkJobError()
+					}
+					if err == nil {
+						err = e.checkIncomplete()
+					}
+					e.setExecError(err)
+					return
+				}
+				select {
+				case forwarded <- it:
+				case <-e.ctx.Done():
+					e.abort(results)
+					return
+				}
+			case <-e.ctx.Done():
+				e.abort(results)
+				return
+			}
+		}
+	}()
+	return forwarded
+}
+
+// finishTrace finishes the root span of the trace when the query completed
+func (e *brokerExecutor) finishTrace() {
+	if e.trace != nil {
+		e.trace.Root.Finish()
+	}
+}
+
+// checkKilled returns the error if any job of query is killed
+func (e *brokerExecutor) checkKilled() error {
+	for _, jobCtx := range e.jobContexts {
+		if jobCtx.Killed() {
+			return errQueryKilled
+		}
+	}
+	return nil
+}
+
+// checkJobError returns the failure of query reported by the sub tasks of any job,
+// like exceeding the limit or timeout in storage nodes, which fails the query instead of partial result
+func (e *brokerExecutor) checkJobError() error {
+	for _, jobCtx := range e.jobContexts {
+		if err := jobCtx.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDatabase returns the config of database, returns nil if the database service isn't set or gets config failure
+func (e *brokerExecutor) getDatabase() *models.Database {
+	if e.databaseService == nil {
+		return nil
+	}
+	database, err := e.databaseService.Get(e.database)
+	if err != nil {
+		log.Warn("get database config error, skip checking unavailable shards and query limit of database",
+			logger.String("database", e.database), logger.Error(err))
+		return nil
+	}
+	return database
+}
+
+// findMissingShards returns the shards of database which have no queryable replica,
+// the data of missing shards isn't included in the result of query, returns nil if database config not found
+func (e *brokerExecutor) findMissingShards(database *models.Database, storageNodes map[string][]int32) []int32 {
+	if database == nil {
+		return nil
+	}
+	queryable := make(map[int32]struct{})
+	for _, shardIDs := range storageNodes {
+		for _, shardID := range shardIDs {
+			queryable[shardID] = struct{}{}
+		}
+	}
+	var missingShards []int32
+	for shardID := int32(0); shardID < int32(database.NumOfShard); shardID++ {
+		if _, ok := queryable[shardID]; !ok {
+			missingShards = append(missingShards, shardID)
+		}
+	}
+	return missingShards
+}
+
+// checkIncomplete returns the error if the result is incomplete and the query fails on incomplete
+func (e *brokerExecutor) checkIncomplete() error {
+	if !e.failOnIncomplete {
+		return nil
+	}
+	if partial := e.Partial(); partial != nil {
+		return fmt.Errorf("query result is incomplete: %s", strings.Join(partial.Warnings, "; "))
+	}
+	return nil
+}
+
+// abort fails the query because of canceled or timeout, drains the remaining results in background,
+// the jobs are canceled by job manager when the context is done.
+func (e *brokerExecutor) abort(results <-chan series.GroupedIterator) {
+	switch {
+	case e.admission.preempted(e.ticket):
+		e.setExecError(errQueryPreempted)
+	case e.ctx.Err() == context.DeadlineExceeded:
+		e.setExecError(e.limiter.timeoutError())
+	default:
+		e.setExecError(errQueryCanceled)
+	}
+	go func() {
+		for range results {
+		}
+	}()
+}
+
+// admissionError returns the error of query rejected by admission controller,
+// the context error means the query is canceled or timeout when waiting in the queue
+func (e *brokerExecutor) admissionError(err error) error {
+	switch err {
+	case context.DeadlineExceeded:
+		return e.limiter.timeoutError()
+	case context.Canceled:
+		return errQueryCanceled
+	default:
+		return err
+	}
+}
+
+// executeQuery executes the query based on physical plan, returns the result set
+// 1) sub query, executes the sub query, then aggregates the results of sub query for outer query
+// 2) cross-metric query, submits a job for each metric, then joins the results by group tags
+// 3) time shift query, executes a sub query for each shift, then joins the results by group tags
+// 4) submits the job of query
+func (e *brokerExecutor) executeQuery(physicalPlan *models.PhysicalPlan,
+	query *stmt.Query) (chan series.GroupedIterator, error) {
+	resultSet := make(chan series.GroupedIterator)
+	switch {
+	case query.SubQuery != nil:
+		results, err := e.executeQuery(physicalPlan, query.SubQuery)
+		if err != nil {
+			return nil, err
+		}
+		go newSubQueryAggregator(query).aggregate(results, resultSet)
+	case query.IsTimeShift():
+		subQueries, shifts := splitTimeShiftQuery(query)
+		results := make([]<-chan series.GroupedIterator, len(subQueries))
+		for idx, subQuery := range subQueries {
+			shiftResultSet, err := e.executeQuery(physicalPlan, subQuery)
+			if err != nil {
+				return nil, err
+			}
+			results[idx] = shiftResultSet
+		}
+		go newTimeShiftJoiner(shifts).join(results, resultSet)
+	case query.IsCrossMetric():
+		subQueries := splitCrossMetricQuery(query)
+		results := make([]<-chan series.GroupedIterator, len(subQueries))
+		for idx, subQuery := range subQueries {
+			metricResultSet := make(chan series.GroupedIterator)
+			if err := e.submitJob(metricResultSet, physicalPlan, subQuery); err != nil {
+				return nil, err
+			}
+			results[idx] = metricResultSet
+		}
+		go newCrossMetricJoiner(query.MetricNames).join(results, resultSet)
+	default:
+		if err := e.submitJob(resultSet, physicalPlan, query); err != nil {
+			return nil, err
+		}
+	}
+	return resultSet, nil
+}
+
+// submitJob submits the distribution query job of the query
+func (e *brokerExecutor) submitJob(resultSet chan series.GroupedIterator,
+	physicalPlan *models.PhysicalPlan, query *stmt.Query) error {
+	jobCtx := parallel.NewJobContext(e.ctx, resultSet, physicalPlan, query, e.sql)
+	if err := e.jobManager.SubmitJob(jobCtx); err != nil {
+		return err
+	}
+	e.jobContexts = append(e.jobContexts, jobCtx)
+	// fails fast if some nodes fail to submit, the job is canceled with the context
+	return e.checkIncomplete()
+}
+
+// Error returns the execution error, the failure when executing is set after the results are drained
 func (e *brokerExecutor) Error() error {
-	return e.err
+	if e.err != nil {
+		return e.err
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.execErr
+}
+
+// setExecError sets the failure when executing
+func (e *brokerExecutor) setExecError(err error) {
+	e.mutex.Lock()
+	e.execErr = err
+	e.mutex.Unlock()
+}
+
+// Partial returns the incomplete part of the query result, like the unavailable shards and the failed nodes,
+// returns nil if the result is complete
+func (e *brokerExecutor) Partial() *models.PartialResult {
+	failedNodes := make(map[string]string)
+	for _, jobCtx := range e.jobContexts {
+		for nodeID, errMsg := range jobCtx.Failures() {
+			failedNodes[nodeID] = errMsg
+		}
+	}
+	if len(failedNodes) == 0 {
+		failedNodes = nil
+	}
+	return models.NewPartialResult(e.missingShards, failedNodes)
+}
+
+// Statistics returns the execution statistics of the query, includes physical plan and storage nodes' stats,
+// returns nil if not explain query
+func (e *brokerExecutor) Statistics() *models.QueryStats {
+	if len(e.jobContexts) == 0 || !e.jobContexts[0].Query().Explain {
+		return nil
+	}
+	return e.collectStats()
+}
+
+// collectStats collects the execution statistics of all jobs, returns nil if no job
+func (e *brokerExecutor) collectStats() *models.QueryStats {
+	if len(e.jobContexts) == 0 {
+		return nil
+	}
+	stats := e.jobContexts[0].Statistics()
+	if stats == nil {
+		return nil
+	}
+	if len(e.jobContexts) > 1 {
+		// merges all metric's job stats for cross-metric query
+		merged := models.NewQueryStats(stats.PhysicalPlan)
+		for _, jobCtx := range e.jobContexts {
+			for nodeID, storageStats := range jobCtx.Statistics().StorageNodes {
+				merged.MergeStorageStats(nodeID, storageStats)
+			}
+		}
+		stats = merged
+	}
+	stats.TotalCost = time.Since(e.startTime).Nanoseconds()
+	return stats
+}
+
+// ResultSet drains the results of execution, returns the result set which evaluates the select list
+// of each group, returns nil if execute failure
+func (e *brokerExecutor) ResultSet() *models.ResultSet {
+	if e.resultSet == nil {
+		return nil
+	}
+	resultSet := newResultSetBuilder(e.query).build(e.resultSet)
+	if e.Error() != nil {
+		// failure when draining the results, like timeout
+		return nil
+	}
+	resultSet.Partial = e.Partial()
+	if e.cacheLookup != nil {
+		// the partial result isn't cached, because the missing data would be reused
+		if resultSet.Partial == nil {
+			e.cacheLookup.store(resultSet)
+		}
+		resultSet = e.cacheLookup.stitch(resultSet)
+	}
+	resultSet.Trace = e.Trace()
+	return resultSet
+}
+
+// Trace returns the span tree of the query if requested by client, returns nil if not requested
+func (e *brokerExecutor) Trace() *models.Trace {
+	if !e.traced {
+		return nil
+	}
+	return e.trace
+}
+
+// StreamResultSet streams the results of execution to the writer, writes each group as soon as it's emitted,
+// keeps draining the results if writer fails, returns the error of writer or the failure when draining
+func (e *brokerExecutor) StreamResultSet(writer parallel.ResultSetWriter) error {
+	if e.resultSet == nil {
+		return nil
+	}
+	builder := newResultSetBuilder(e.query)
+	if e.cacheLookup != nil || len(builder.specs) > 0 {
+		return e.streamBufferedResultSet(writer)
+	}
+	err := writer.WriteMeta(builder.newResultSet())
+	for it := range e.resultSet {
+		if err != nil {
+			// drains the results for completing the job
+			continue
+		}
+		if s := builder.buildSeries(it); s != nil {
+			err = writer.WriteSeries(s)
+		}
+	}
+	if err == nil {
+		err = e.Error()
+	}
+	return err
+}
+
+// streamBufferedResultSet streams the result set after all results are drained, the series of group can't be written
+// until the results are merged with the cached buckets, or the series are selected by topk/bottomk functions.
+func (e *brokerExecutor) streamBufferedResultSet(writer parallel.ResultSetWriter) error {
+	resultSet := e.ResultSet()
+	if err := e.Error(); err != nil {
+		return err
+	}
+	if err := writer.WriteMeta(resultSet); err != nil {
+		return err
+	}
+	for _, s := range resultSet.Series {
+		if err := writer.WriteSeries(s); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,8 +1,10 @@
 package query
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +13,13 @@ import (
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/series"
 )
 
 func TestBrokerExecutor_Execute(t *testing.T) {
@@ -23,11 +32,14 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
 	jobManager := parallel.NewMockJobManager(ctrl)
 
-	exec := newBrokerExecutor("test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, jobManager)
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(nil)
 	_ = exec.Execute()
 	assert.Equal(t, errNoAvailableStorageNode, exec.Error())
+	assert.Nil(t, exec.ResultSet())
+	assert.NoError(t, exec.StreamResultSet(nil))
+	assert.Nil(t, exec.Statistics())
 
 	storageNodes := map[string][]int32{
 		"1.1.1.1:9000": {1, 2, 4},
@@ -42,27 +54,545 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 		currentNode,
 		generateBrokerActiveNode("1.1.1.4", 8000),
 	}
-	exec = newBrokerExecutor("test_db", "select f fro",
-		replicaStateMachine, nodeStateMachine, jobManager)
+	// parse sql error, fails before planning
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	assert.Nil(t, exec.Execute())
+	assert.NotNil(t, exec.Error())
+
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		jobCtx.Complete()
+		return nil
+	})
 	_ = exec.Execute()
-	assert.NotNil(t, exec.Error())
+	assert.Nil(t, exec.Error())
+	resultSet := exec.ResultSet()
+	assert.Equal(t, "cpu", resultSet.MetricName)
+	assert.Empty(t, resultSet.Series)
+	assert.Nil(t, exec.Statistics())
 
-	exec = newBrokerExecutor("test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, jobManager)
+	// explain query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "explain select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any())
 	_ = exec.Execute()
 	assert.Nil(t, exec.Error())
+	stats := exec.Statistics()
+	assert.NotNil(t, stats)
+	assert.NotNil(t, stats.PhysicalPlan)
+
+	// cross-metric query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "explain select a.f/b.f from a, b group by host",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	var jobs []parallel.JobContext
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		jobs = append(jobs, jobCtx)
+		return nil
+	}).Times(2)
+	results := exec.Execute()
+	assert.Nil(t, exec.Error())
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, "a", jobs[0].Query().MetricName)
+	assert.Equal(t, "b", jobs[1].Query().MetricName)
+	storageStats := models.NewStorageStats()
+	storageStats.TotalCost = 10
+	jobs[0].ReceiveStats("1.1.1.1:9000", storageStats)
+	jobs[1].ReceiveStats("1.1.1.1:9000", storageStats)
+	for _, job := range jobs {
+		job.Complete()
+	}
+	for range results {
+	}
+	stats = exec.Statistics()
+	assert.Equal(t, int64(20), stats.StorageNodes["1.1.1.1:9000"].TotalCost)
+
+	// submit job error for cross-metric query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select a.f/b.f from a, b",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
+	_ = exec.Execute()
+	assert.NotNil(t, exec.Error())
+
+	// sub query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select max(v) from (select sum(f) as v from cpu group by host)",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobs = nil
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		jobs = append(jobs, jobCtx)
+		return nil
+	})
+	results = exec.Execute()
+	assert.Nil(t, exec.Error())
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "cpu", jobs[0].Query().MetricName)
+	jobs[0].Complete()
+	for range results {
+	}
+
+	// submit job error for sub query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select max(v) from (select sum(f) as v from cpu group by host)",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
+	_ = exec.Execute()
+	assert.NotNil(t, exec.Error())
+
+	// time shift query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select sum(f)/time_shift(sum(f), 1d) from cpu group by host, time(1m)",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobs = nil
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		jobs = append(jobs, jobCtx)
+		return nil
+	}).Times(2)
+	results = exec.Execute()
+	assert.Nil(t, exec.Error())
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, jobs[0].Query().TimeRange.Start-timeutil.OneDay, jobs[1].Query().TimeRange.Start)
+	for _, job := range jobs {
+		job.Complete()
+	}
+	for range results {
+	}
+
+	// submit job error for time shift query
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select sum(f)/time_shift(sum(f), 1d) from cpu group by host, time(1m)",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
+	_ = exec.Execute()
+	assert.NotNil(t, exec.Error())
+
+	// parsed query statement
+	query := &stmt.Query{MetricName: "cpu", SelectItems: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}
+	exec = newBrokerQueryExecutor(context.TODO(), "test_db", query,
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		assert.Equal(t, query, jobCtx.Query())
+		return nil
+	})
+	_ = exec.Execute()
+	assert.Nil(t, exec.Error())
 
 	// submit job error
-	exec = newBrokerExecutor("test_db", "select f from cpu",
-		replicaStateMachine, nodeStateMachine, jobManager)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
 	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
 	_ = exec.Execute()
 	assert.NotNil(t, exec.Error())
 }
+
+func TestBrokerExecutor_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+
+	// time range exceeds limit
+	exec := newBrokerExecutor(context.TODO(), "test_db",
+		"select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Limit: option.QueryLimit{MaxTimeRange: "10m"}})
+	assert.Nil(t, exec.Execute())
+	assert.EqualError(t, exec.Error(), "time range span of query[1h0m0s] exceeds the limit[10m]")
+
+	// the query limit of database overrides the global limit
+	databaseService := service.NewMockDatabaseService(ctrl)
+	databaseService.EXPECT().Get("test_db").Return(&models.Database{NumOfShard: 3,
+		Engine: option.EngineOption{Query: option.QueryLimit{MaxTimeRange: "30m"}}}, nil).AnyTimes()
+	exec = newBrokerExecutor(context.TODO(), "test_db",
+		"select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'",
+		replicaStateMachine, nodeStateMachine, jobManager,
+		ExecutorOption{DatabaseService: databaseService, Limit: option.QueryLimit{MaxTimeRange: "2h"}})
+	assert.Nil(t, exec.Execute())
+	assert.EqualError(t, exec.Error(), "time range span of query[1h0m0s] exceeds the limit[30m]")
+	exec = newBrokerExecutor(context.TODO(), "test_db",
+		"select f from cpu where time>'20190729 11:00:00' and time<'20190729 11:20:00'",
+		replicaStateMachine, nodeStateMachine, jobManager,
+		ExecutorOption{DatabaseService: databaseService, Limit: option.QueryLimit{MaxTimeRange: "10m"}})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		ctx.Complete()
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	assert.NoError(t, exec.Error())
+
+	// timeout
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Limit: option.QueryLimit{Timeout: "1s"}})
+	exec.(*brokerExecutor).limiter.deadline = time.Now()
+	var jobCtx parallel.JobContext
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	assert.Nil(t, exec.ResultSet())
+	assert.EqualError(t, exec.Error(), "query is running over the timeout[1s]")
+	// completes the job after timeout
+	jobCtx.Complete()
+
+	// completes before timeout
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Limit: option.QueryLimit{Timeout: "1m"}})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		ctx.Complete()
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	assert.NotNil(t, exec.ResultSet())
+	assert.NoError(t, exec.Error())
+}
+
+func TestBrokerExecutor_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+
+	// client cancels the query
+	ctx, cancel := context.WithCancel(context.Background())
+	exec := newBrokerExecutor(ctx, "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	var jobCtx parallel.JobContext
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	cancel()
+	assert.Nil(t, exec.ResultSet())
+	assert.Equal(t, errQueryCanceled, exec.Error())
+	// the context of job is done after canceled
+	<-jobCtx.Context().Done()
+	jobCtx.Complete()
+
+	// operator kills the job
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	jobCtx.Kill()
+	// job manager completes the job after killed
+	jobCtx.Complete()
+	assert.Nil(t, exec.ResultSet())
+	assert.Equal(t, errQueryKilled, exec.Error())
+}
+
+func TestBrokerExecutor_Admission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+	admission := NewAdmissionController(1, 0, 0, 1, 10*time.Millisecond, nil)
+
+	// running ad-hoc query
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	var jobCtx parallel.JobContext
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+
+	// waiting timeout
+	exec2 := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	assert.Nil(t, exec2.Execute())
+	assert.Equal(t, errAdmissionTimeout, exec2.Error())
+
+	// alerting query preempts the running ad-hoc query
+	exec3 := newBrokerExecutor(models.WithQueryPriority(models.WithUser(context.TODO(), "alert"), models.AlertingPriority), "test_db",
+		"select f from cpu", replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		ctx.Complete()
+		return nil
+	})
+	assert.NotNil(t, exec3.Execute())
+	assert.NotNil(t, exec3.ResultSet())
+	assert.NoError(t, exec3.Error())
+	assert.Nil(t, exec.ResultSet())
+	assert.Equal(t, errQueryPreempted, exec.Error())
+	jobCtx.Complete()
+
+	// canceled when waiting
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	exec2 = newBrokerExecutor(ctx, "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	assert.Nil(t, exec2.Execute())
+	assert.Equal(t, errQueryCanceled, exec2.Error())
+	jobCtx.Complete()
+	assert.NotNil(t, exec.ResultSet())
+	assert.NoError(t, exec.Error())
+
+	// releases the admission if submit job failure
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{Admission: admission})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("err"))
+	assert.Nil(t, exec.Execute())
+	assert.Empty(t, admission.running)
+}
+
+func TestBrokerExecutor_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {0, 1}, "1.1.1.2:9000": {3}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+	databaseService := service.NewMockDatabaseService(ctrl)
+
+	// get database config failure, skip checking unavailable shards
+	databaseService.EXPECT().Get("test_db").Return(nil, errors.New("get database error"))
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{DatabaseService: databaseService})
+	var jobCtx parallel.JobContext
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	jobCtx.Complete()
+	resultSet := exec.ResultSet()
+	assert.NoError(t, exec.Error())
+	assert.Nil(t, resultSet.Partial)
+	assert.Nil(t, exec.Partial())
+
+	// shard 2 is unavailable and node 1.1.1.2 failed
+	databaseService.EXPECT().Get("test_db").Return(&models.Database{NumOfShard: 4}, nil).AnyTimes()
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{DatabaseService: databaseService})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	jobCtx.ReceiveFailure("1.1.1.2:9000", "leaf error")
+	jobCtx.Complete()
+	resultSet = exec.ResultSet()
+	assert.NoError(t, exec.Error())
+	assert.Equal(t, &models.PartialResult{
+		MissingShards: []int32{2},
+		FailedNodes:   map[string]string{"1.1.1.2:9000": "leaf error"},
+		Warnings:      []string{"shards [2] are unavailable", "node 1.1.1.2:9000 failed: leaf error"},
+	}, resultSet.Partial)
+
+	// failure of query reported by leaf node fails the query instead of partial result
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{DatabaseService: databaseService})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(ctx parallel.JobContext) error {
+		jobCtx = ctx
+		return nil
+	})
+	assert.NotNil(t, exec.Execute())
+	jobCtx.ReceiveError("num. of series matched by query exceeds the limit")
+	jobCtx.Complete()
+	assert.Nil(t, exec.ResultSet())
+	assert.EqualError(t, exec.Error(), "num. of series matched by query exceeds the limit")
+
+	// fails fast on unavailable shards
+	exec = newBrokerExecutor(models.WithFailOnIncomplete(context.TODO()), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{DatabaseService: databaseService})
+	assert.Nil(t, exec.Execute())
+	assert.Nil(t, exec.ResultSet())
+	assert.EqualError(t, exec.Error(), "query result is incomplete: shards [2] are unavailable")
+}
+
+func TestBrokerExecutor_ResultCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+	cache := NewResultCache(time.Hour, 5*time.Minute, 1024*1024)
+
+	start := timeutil.Now()/timeutil.OneHour*timeutil.OneHour - 3*timeutil.OneHour
+	newQuery := func() *stmt.Query {
+		query, _ := sql.Parse("select f from cpu group by host, time(1m)")
+		// the time range isn't aligned by the interval
+		query.TimeRange = timeutil.TimeRange{Start: start + 10*timeutil.OneMinute + 30*timeutil.OneSecond,
+			End: start + 2*timeutil.OneHour - 30*timeutil.OneSecond}
+		return query
+	}
+	// queries from the start of first bucket
+	exec := newBrokerQueryExecutor(context.TODO(), "test_db", newQuery(),
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{ResultCache: cache})
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		assert.Equal(t, start, jobCtx.Query().TimeRange.Start)
+		jobCtx.Complete()
+		return nil
+	})
+	_ = exec.Execute()
+	resultSet := exec.ResultSet()
+	assert.NoError(t, exec.Error())
+	// the time range is aligned by the interval
+	assert.Equal(t, start+10*timeutil.OneMinute, resultSet.StartTime)
+	assert.Equal(t, start+2*timeutil.OneHour, resultSet.EndTime)
+
+	// all buckets are cached, no job submitted
+	exec = newBrokerQueryExecutor(context.TODO(), "test_db", newQuery(),
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{ResultCache: cache})
+	_ = exec.Execute()
+	writer := parallel.NewMockResultSetWriter(ctrl)
+	writer.EXPECT().WriteMeta(gomock.Any()).Return(nil)
+	assert.NoError(t, exec.StreamResultSet(writer))
+}
+
+func TestBrokerExecutor_StreamResultSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query, _ := sql.Parse("select f from cpu group by host")
+	newExecutor := func() (*brokerExecutor, chan series.GroupedIterator) {
+		resultSet := make(chan series.GroupedIterator)
+		go func() {
+			resultSet <- newResultSeries(map[string]string{"host": "1.1.1.1"},
+				map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{1: 1})})
+			resultSet <- newResultSeries(map[string]string{"host": "1.1.1.2"},
+				map[string]collections.FloatArray{"g": mockFloatArray(map[int]float64{1: 1})})
+			resultSet <- newResultSeries(map[string]string{"host": "1.1.1.3"},
+				map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{1: 1})})
+			close(resultSet)
+		}()
+		return &brokerExecutor{query: query, resultSet: resultSet}, resultSet
+	}
+
+	exec, _ := newExecutor()
+	writer := parallel.NewMockResultSetWriter(ctrl)
+	gomock.InOrder(
+		writer.EXPECT().WriteMeta(gomock.Any()).DoAndReturn(func(resultSet *models.ResultSet) error {
+			assert.Equal(t, "cpu", resultSet.MetricName)
+			return nil
+		}),
+		writer.EXPECT().WriteSeries(gomock.Any()).DoAndReturn(func(s *models.Series) error {
+			assert.Equal(t, map[string]string{"host": "1.1.1.1"}, s.Tags)
+			return nil
+		}),
+		writer.EXPECT().WriteSeries(gomock.Any()).DoAndReturn(func(s *models.Series) error {
+			assert.Equal(t, map[string]string{"host": "1.1.1.3"}, s.Tags)
+			return nil
+		}),
+	)
+	assert.NoError(t, exec.StreamResultSet(writer))
+
+	// keeps draining the results after writer fails
+	exec, resultSet := newExecutor()
+	writer.EXPECT().WriteMeta(gomock.Any()).Return(nil)
+	writer.EXPECT().WriteSeries(gomock.Any()).Return(errors.New("write error"))
+	assert.Error(t, exec.StreamResultSet(writer))
+	_, ok := <-resultSet
+	assert.False(t, ok)
+}
+
+func TestBrokerExecutor_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	nodeStateMachine.EXPECT().GetCurrentNode().Return(currentNode.Node).AnyTimes()
+	nodeStateMachine.EXPECT().GetActiveNodes().Return([]models.ActiveNode{currentNode}).AnyTimes()
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").
+		Return(map[string][]int32{"1.1.1.1:9000": {1}}).AnyTimes()
+	jobManager := parallel.NewMockJobManager(ctrl)
+	jobManager.EXPECT().SubmitJob(gomock.Any()).DoAndReturn(func(jobCtx parallel.JobContext) error {
+		jobCtx.Complete()
+		return nil
+	}).AnyTimes()
+
+	// trace requested by client
+	trace := models.NewTrace("query")
+	exec := newBrokerExecutor(models.WithTrace(context.TODO(), trace), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	_ = exec.Execute()
+	resultSet := exec.ResultSet()
+	assert.NoError(t, exec.Error())
+	assert.Equal(t, trace, exec.Trace())
+	assert.Equal(t, trace, resultSet.Trace)
+	assert.Equal(t, map[string]string{"database": "test_db"}, trace.Root.Tags)
+	assert.True(t, trace.Root.Duration > 0)
+	assert.Len(t, trace.Root.Children, 2)
+	assert.Equal(t, "plan", trace.Root.Children[0].Name)
+	assert.Equal(t, "job", trace.Root.Children[1].Name)
+
+	// records the trace for slow query log, which isn't returned to client
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{SlowQueryThreshold: time.Hour})
+	_ = exec.Execute()
+	resultSet = exec.ResultSet()
+	assert.NoError(t, exec.Error())
+	assert.Nil(t, exec.Trace())
+	assert.Nil(t, resultSet.Trace)
+	assert.NotNil(t, exec.(*brokerExecutor).trace)
+
+	// not traced
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, jobManager, ExecutorOption{})
+	_ = exec.Execute()
+	_ = exec.ResultSet()
+	assert.Nil(t, exec.(*brokerExecutor).trace)
+}
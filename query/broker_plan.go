@@ -29,6 +29,17 @@ func newBrokerPlan(sql string, storageNodes map[string][]int32,
 	}
 }
 
+// newBrokerQueryPlan creates broker execute plan based on the parsed query statement
+func newBrokerQueryPlan(query *stmt.Query, storageNodes map[string][]int32,
+	currentBrokerNode models.Node, brokerNodes []models.ActiveNode) Plan {
+	return &brokerPlan{
+		query:             query,
+		storageNodes:      storageNodes,
+		currentBrokerNode: currentBrokerNode,
+		brokerNodes:       brokerNodes,
+	}
+}
+
 // Plan plans broker level query execute plan, there are some scenarios as below:
 // 1) parse sql => stmt
 // 2) build parallel exec tree
@@ -42,12 +53,14 @@ func (p *brokerPlan) Plan() error {
 		return errNoAvailableStorageNode
 	}
 
-	query, err := sql.Parse(p.sql)
-	if err != nil {
-		return err
+	if p.query == nil {
+		query, err := sql.Parse(p.sql)
+		if err != nil {
+			return err
+		}
+		// set query statement
+		p.query = query
 	}
-	// set query statement
-	p.query = query
 
 	root := p.currentBrokerNode
 
@@ -76,7 +89,8 @@ func (p *brokerPlan) Plan() error {
 
 // buildIntermediateNodes builds intermediate nodes if need
 func (p *brokerPlan) buildIntermediateNodes() {
-	if len(p.query.GroupBy) == 0 {
+	// storage nodes execute the innermost sub query if query from sub query
+	if len(p.query.LeafQuery().GroupBy) == 0 {
 		return
 	}
 	if len(p.brokerNodes) == 0 {
@@ -116,7 +130,7 @@ func (p *brokerPlan) buildIntermediates() {
 
 	var pos, end, idx = 0, 0, 0
 	for {
-		if pos > lenOfStorageNodes {
+		if pos > lenOfStorageNodes || idx >= lenOfIntermediateNodes {
 			break
 		}
 		end += parallel
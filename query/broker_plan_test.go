@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestBrokerPlan_Wrong_Case(t *testing.T) {
@@ -55,6 +56,18 @@ func TestBrokerPlan_No_GroupBy(t *testing.T) {
 	assert.Equal(t, 0, len(p.physicalPlan.Intermediates))
 }
 
+func TestBrokerPlan_Query(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	query := &stmt.Query{MetricName: "cpu", SelectItems: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}
+	plan := newBrokerQueryPlan(query, storageNodes, currentNode.Node, nil)
+	err := plan.Plan()
+	assert.NoError(t, err)
+	p := plan.(*brokerPlan)
+	assert.Equal(t, query, p.query)
+	assert.Equal(t, 1, len(p.physicalPlan.Leafs))
+}
+
 func TestBrokerPlan_GroupBy(t *testing.T) {
 	storageNodes := map[string][]int32{
 		"1.1.1.1:9000": {1, 2, 4},
@@ -96,6 +109,27 @@ func TestBrokerPlan_GroupBy(t *testing.T) {
 	assert.Equal(t, storageNodes, storageNodes2)
 }
 
+func TestBrokerPlan_SubQuery_GroupBy(t *testing.T) {
+	storageNodes := map[string][]int32{
+		"1.1.1.1:9000": {1, 2, 4},
+		"1.1.1.2:9000": {3, 6, 9},
+	}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	// outer query without group by, but sub query need intermediate nodes
+	plan := newBrokerPlan(
+		"select max(v) from (select sum(f) as v from cpu group by host)",
+		storageNodes,
+		currentNode.Node,
+		[]models.ActiveNode{
+			generateBrokerActiveNode("1.1.1.1", 8000),
+			currentNode,
+		})
+	err := plan.Plan()
+	assert.NoError(t, err)
+	p := plan.(*brokerPlan)
+	assert.Equal(t, 1, len(p.intermediateNodes))
+}
+
 func TestBrokerPlan_GroupBy_Less_StorageNodes(t *testing.T) {
 	storageNodes := map[string][]int32{
 		"1.1.1.1:9000": {1, 2, 4},
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"sync"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// splitCrossMetricQuery splits the cross-metric query into a sub query for each metric,
+// the select list of sub query only includes the unqualified fields(with the down sampling function) of the metric,
+// other parts(condition/time range/group by etc.) are the same as the cross-metric query.
+func splitCrossMetricQuery(query *stmt.Query) []*stmt.Query {
+	subQueries := make([]*stmt.Query, len(query.MetricNames))
+	metrics := make(map[string]*stmt.Query)
+	selectItems := make(map[string]struct{})
+	for idx, metricName := range query.MetricNames {
+		subQuery := *query
+		subQuery.MetricName = metricName
+		subQuery.MetricNames = nil
+		subQuery.SelectItems = nil
+		subQueries[idx] = &subQuery
+		metrics[metricName] = &subQuery
+	}
+
+	var collect func(parentFunc *stmt.CallExpr, expr stmt.Expr)
+	collect = func(parentFunc *stmt.CallExpr, expr stmt.Expr) {
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			collect(nil, e.Expr)
+		case *stmt.CallExpr:
+			parent := e
+			if !function.IsAggFunc(e.FuncType) {
+				parent = nil
+			}
+			for _, param := range e.Params {
+				collect(parent, param)
+			}
+		case *stmt.ParenExpr:
+			collect(nil, e.Expr)
+		case *stmt.BinaryExpr:
+			collect(nil, e.Left)
+			collect(nil, e.Right)
+		case *stmt.FieldExpr:
+			metricName, fieldName, ok := query.FieldMetric(e.Name)
+			if !ok {
+				return
+			}
+			var item stmt.Expr = &stmt.FieldExpr{Name: fieldName}
+			if parentFunc != nil {
+				item = &stmt.CallExpr{FuncType: parentFunc.FuncType, Params: []stmt.Expr{item}}
+			}
+			key := metricName + "/" + item.Rewrite()
+			if _, exist := selectItems[key]; exist {
+				return
+			}
+			selectItems[key] = struct{}{}
+			subQuery := metrics[metricName]
+			subQuery.SelectItems = append(subQuery.SelectItems, &stmt.SelectItem{Expr: item})
+		}
+	}
+	for _, selectItem := range query.SelectItems {
+		collect(nil, selectItem)
+	}
+	return subQueries
+}
+
+// crossMetricJoiner joins the grouped time series of each metric's sub query by group tags(inner join),
+// the field name of joined series is qualified by metric name(like a.f) for expression evaluation.
+type crossMetricJoiner struct {
+	metricNames []string
+}
+
+// newCrossMetricJoiner creates the cross-metric joiner for given metric names(same order as sub query's results)
+func newCrossMetricJoiner(metricNames []string) *crossMetricJoiner {
+	return &crossMetricJoiner{metricNames: metricNames}
+}
+
+// join drains all sub query's results, then sends the joined series to the result set and closes it,
+// the series group which not exists in all metrics will be dropped.
+func (j *crossMetricJoiner) join(results []<-chan series.GroupedIterator, resultSet chan<- series.GroupedIterator) {
+	defer close(resultSet)
+
+	groups := make([]map[string][]series.GroupedIterator, len(results))
+	var keys []string
+	var wait sync.WaitGroup
+	for idx := range results {
+		groups[idx] = make(map[string][]series.GroupedIterator)
+		wait.Add(1)
+		go func(idx int) {
+			defer wait.Done()
+			for it := range results[idx] {
+				if it == nil {
+					continue
+				}
+				key := models.TagsAsString(it.Tags())
+				if idx == 0 {
+					if _, ok := groups[idx][key]; !ok {
+						keys = append(keys, key)
+					}
+				}
+				groups[idx][key] = append(groups[idx][key], it)
+			}
+		}(idx)
+	}
+	wait.Wait()
+
+	for _, key := range keys {
+		joined := &joinedGroupedIterator{}
+		for idx, group := range groups {
+			its, ok := group[key]
+			if !ok {
+				joined = nil
+				break
+			}
+			metricName := j.metricNames[idx]
+			for _, it := range its {
+				joined.qualifiers = append(joined.qualifiers, func(fieldName string) string {
+					return metricName + "." + fieldName
+				})
+				joined.its = append(joined.its, it)
+			}
+		}
+		if joined != nil {
+			resultSet <- joined
+		}
+	}
+}
+
+// joinedGroupedIterator represents the joined series of multi-query(like multi-metric) with the same group tags,
+// the field name of each query's series is qualified for distinguishing the fields with the same name.
+type joinedGroupedIterator struct {
+	qualifiers []func(fieldName string) string
+	its        []series.GroupedIterator
+	idx        int
+}
+
+// Tags returns group tags
+func (it *joinedGroupedIterator) Tags() map[string]string {
+	return it.its[0].Tags()
+}
+
+// SeriesID returns 0, because joined series doesn't belong to one query
+func (it *joinedGroupedIterator) SeriesID() uint32 {
+	return 0
+}
+
+// HasNext returns if the iteration has more field's iterator of all queries
+func (it *joinedGroupedIterator) HasNext() bool {
+	for it.idx < len(it.its) {
+		if it.its[it.idx].HasNext() {
+			return true
+		}
+		it.idx++
+	}
+	return false
+}
+
+// Next returns the field's iterator which field name is qualified, like a.f
+func (it *joinedGroupedIterator) Next() series.FieldIterator {
+	return &qualifiedFieldIterator{
+		FieldIterator: it.its[it.idx].Next(),
+		qualify:       it.qualifiers[it.idx],
+	}
+}
+
+// qualifiedFieldIterator represents the field's iterator which field name is qualified
+type qualifiedFieldIterator struct {
+	series.FieldIterator
+	qualify func(fieldName string) string
+}
+
+// FieldName return the qualified field's name, like a.f
+func (it *qualifiedFieldIterator) FieldName() string {
+	return it.qualify(it.FieldIterator.FieldName())
+}
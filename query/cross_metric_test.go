@@ -0,0 +1,93 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestSplitCrossMetricQuery(t *testing.T) {
+	query, _ := sql.Parse("select sum(a.f)/sum(b.f), max(a.f), a.f+b.f, sum(a.f) from a, b" +
+		" where host='1.1.1.1' group by host")
+	subQueries := splitCrossMetricQuery(query)
+	assert.Len(t, subQueries, 2)
+
+	assert.Equal(t, "a", subQueries[0].MetricName)
+	assert.Empty(t, subQueries[0].MetricNames)
+	assert.Len(t, subQueries[0].SelectItems, 3)
+	assert.Equal(t, "sum(f)", subQueries[0].SelectItems[0].Rewrite())
+	assert.Equal(t, "max(f)", subQueries[0].SelectItems[1].Rewrite())
+	assert.Equal(t, "f", subQueries[0].SelectItems[2].Rewrite())
+	assert.Equal(t, query.Condition, subQueries[0].Condition)
+	assert.Equal(t, query.GroupBy, subQueries[0].GroupBy)
+
+	assert.Equal(t, "b", subQueries[1].MetricName)
+	assert.Len(t, subQueries[1].SelectItems, 2)
+	assert.Equal(t, "sum(f)", subQueries[1].SelectItems[0].Rewrite())
+	assert.Equal(t, "f", subQueries[1].SelectItems[1].Rewrite())
+	// not modify the cross-metric query
+	assert.Equal(t, "a", query.MetricName)
+	assert.Len(t, query.SelectItems, 4)
+
+	// the field under topk uses the default down sampling func
+	query, _ = sql.Parse("select topk(2, a.f/b.f) from a, b group by host")
+	subQueries = splitCrossMetricQuery(query)
+	assert.Len(t, subQueries, 2)
+	assert.Equal(t, "f", subQueries[0].SelectItems[0].Rewrite())
+	assert.Equal(t, "f", subQueries[1].SelectItems[0].Rewrite())
+}
+
+func TestCrossMetricJoiner_Join(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeries := func(tags map[string]string, fieldName string) series.GroupedIterator {
+		fieldIt := series.NewMockFieldIterator(ctrl)
+		fieldIt.EXPECT().FieldName().Return(fieldName).AnyTimes()
+		it := series.NewMockGroupedIterator(ctrl)
+		it.EXPECT().Tags().Return(tags).AnyTimes()
+		gomock.InOrder(
+			it.EXPECT().HasNext().Return(true),
+			it.EXPECT().Next().Return(fieldIt),
+			it.EXPECT().HasNext().Return(false),
+		)
+		return it
+	}
+	ch1 := make(chan series.GroupedIterator)
+	ch2 := make(chan series.GroupedIterator)
+	go func() {
+		ch1 <- mockSeries(map[string]string{"host": "1.1.1.1"}, "errors")
+		// dropped series
+		it := series.NewMockGroupedIterator(ctrl)
+		it.EXPECT().Tags().Return(map[string]string{"host": "1.1.1.2"})
+		ch1 <- it
+		ch1 <- nil
+		close(ch1)
+	}()
+	go func() {
+		ch2 <- mockSeries(map[string]string{"host": "1.1.1.1"}, "requests")
+		close(ch2)
+	}()
+
+	resultSet := make(chan series.GroupedIterator)
+	go newCrossMetricJoiner([]string{"a", "b"}).join([]<-chan series.GroupedIterator{ch1, ch2}, resultSet)
+
+	var results []series.GroupedIterator
+	for result := range resultSet {
+		results = append(results, result)
+	}
+	// host=1.1.1.2 not exist in metric b
+	assert.Len(t, results, 1)
+	joined := results[0]
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, joined.Tags())
+	assert.Equal(t, uint32(0), joined.SeriesID())
+	var fieldNames []string
+	for joined.HasNext() {
+		fieldNames = append(fieldNames, joined.Next().FieldName())
+	}
+	assert.Equal(t, []string{"a.errors", "b.requests"}, fieldNames)
+}
@@ -4,4 +4,11 @@ import (
 	"errors"
 )
 
-var errNoAvailableStorageNode = errors.New("no available storage node for server")
+var (
+	errNoAvailableStorageNode = errors.New("no available storage node for server")
+	errQueryCanceled          = errors.New("query is canceled")
+	errQueryKilled            = errors.New("query is killed")
+	errTooManyQueries         = errors.New("too many queries, the admission queue is full")
+	errAdmissionTimeout       = errors.New("query is timeout in the admission queue")
+	errQueryPreempted         = errors.New("query is preempted by alerting query")
+)
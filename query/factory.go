@@ -1,26 +1,52 @@
 package query
 
 import (
+	"context"
+	"time"
+
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/service"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
 
+// ExecutorOption represents the options of query executors, the options of broker are ignored by storage and vice versa
+type ExecutorOption struct {
+	DatabaseService    service.DatabaseService // for the unavailable shards and query limit of database in broker, nil means skip
+	Limit              option.QueryLimit       // global query limit, overridden by the query limit of database
+	SlowQueryThreshold time.Duration           // threshold of slow query log in broker, 0 means disable
+	ResultCache        *ResultCache            // result cache of query in broker, nil means disable
+	Admission          *AdmissionController    // admission control of query in broker, nil means disable
+	ScanPool           *ScanPool               // scans the shards and data families in storage, nil means sequentially
+}
+
 type executorFactory struct {
+	option ExecutorOption
 }
 
-func NewExecutorFactory() parallel.ExecutorFactory {
-	return &executorFactory{}
+// NewExecutorFactory creates the factory of query executors with the options
+func NewExecutorFactory(option ExecutorOption) parallel.ExecutorFactory {
+	return &executorFactory{
+		option: option,
+	}
 }
 
-func (*executorFactory) NewStorageExecutor(engine tsdb.Engine, shardIDs []int32, query *stmt.Query) parallel.Executor {
-	return newStorageExecutor(engine, shardIDs, query)
+func (f *executorFactory) NewStorageExecutor(ctx context.Context, engine tsdb.Engine, shardIDs []int32, query *stmt.Query) parallel.StorageExecutor {
+	// the query limit of database overrides the global limit
+	return newStorageExecutor(ctx, engine, shardIDs, query, f.option.Limit.Override(engine.GetOption().Query), f.option.ScanPool)
+}
+
+func (f *executorFactory) NewBrokerExecutor(ctx context.Context, database string, sql string,
+	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+	jobManager parallel.JobManager) parallel.BrokerExecutor {
+	return newBrokerExecutor(ctx, database, sql, replicaStateMachine, nodeStateMachine, jobManager, f.option)
 }
 
-func (*executorFactory) NewBrokerExecutor(database string, sql string,
+func (f *executorFactory) NewBrokerQueryExecutor(ctx context.Context, database string, query *stmt.Query,
 	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
-	jobManager parallel.JobManager) parallel.Executor {
-	return newBrokerExecutor(database, sql, replicaStateMachine, nodeStateMachine, jobManager)
+	jobManager parallel.JobManager) parallel.BrokerExecutor {
+	return newBrokerQueryExecutor(ctx, database, query, replicaStateMachine, nodeStateMachine, jobManager, f.option)
 }
@@ -1,32 +1,50 @@
 package query
 
 import (
+	"context"
 	"sort"
+	"testing"
 
+	"github.com/lindb/lindb/pkg/interval"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
 	"github.com/lindb/lindb/tsdb"
 	"github.com/lindb/lindb/tsdb/diskdb"
 	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/series"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 )
 
 ///////////////////////////////////////////////////
 //                mock interface				 //
 ///////////////////////////////////////////////////
 
+// mockFamilyTime is the base time of mock data family, which is the start time of test queries
+var mockFamilyTime, _ = timeutil.ParseTimestamp("20190729 11:00:00")
+
+// MockTSDBEngine returns mock engine, the data family of each shard is scanned by the scanner in order,
+// the base time of data family is mockFamilyTime
 func MockTSDBEngine(ctrl *gomock.Controller, scanners ...series.DataFamilyScanner) tsdb.Engine {
 	segment := tsdb.NewMockSegment(ctrl)
 	if len(scanners) > 0 {
 		for _, f := range scanners {
-			segment.EXPECT().GetDataFamilyScanners(gomock.Any()).Return([]series.DataFamilyScanner{f})
+			family := tsdb.NewMockDataFamily(ctrl)
+			family.EXPECT().BaseTime().Return(mockFamilyTime).AnyTimes()
+			family.EXPECT().Scan(gomock.Any()).DoAndReturn(f.Scan).AnyTimes()
+			segment.EXPECT().GetDataFamilies(gomock.Any()).Return([]tsdb.DataFamily{family})
 		}
 	} else {
-		segment.EXPECT().GetDataFamilyScanners(gomock.Any()).Return(nil).AnyTimes()
+		segment.EXPECT().GetDataFamilies(gomock.Any()).Return(nil).AnyTimes()
 	}
 
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().GetSegments(gomock.Any(), gomock.Any()).Return([]tsdb.Segment{segment}).AnyTimes()
+	shard.EXPECT().GetMemoryDatabase().Return(nil).AnyTimes()
+	shard.EXPECT().GetSeriesIDsFilter().Return(nil).AnyTimes()
 
 	metadataIndex := diskdb.NewMockIDGetter(ctrl)
 	metadataIndex.EXPECT().GetMetricID(gomock.Any()).Return(uint32(10), nil).AnyTimes()
@@ -36,6 +54,7 @@ func MockTSDBEngine(ctrl *gomock.Controller, scanners ...series.DataFamilyScanne
 	engine.EXPECT().GetShard(gomock.Any()).Return(shard).AnyTimes()
 	engine.EXPECT().GetIDGetter().Return(metadataIndex).AnyTimes()
 	engine.EXPECT().NumOfShards().Return(3).AnyTimes()
+	engine.EXPECT().GetOption().Return(option.EngineOption{Interval: "10s"}).AnyTimes()
 	return engine
 }
 
@@ -76,9 +95,56 @@ func MockSumFieldSeries(ctrl *gomock.Controller, fieldID uint16, primitiveFieldI
 	it.EXPECT().HasNext().Return(false).AnyTimes()
 
 	timeSeries := series.NewMockIterator(ctrl)
+	timeSeries.EXPECT().SeriesID().Return(uint32(1)).AnyTimes()
 	timeSeries.EXPECT().HasNext().Return(true)
 	timeSeries.EXPECT().Next().Return(it)
 	timeSeries.EXPECT().HasNext().Return(false)
 	//return it
 	return timeSeries
 }
+
+// MockMemoryDatabaseEngine returns mock engine with one shard, the data of shard is written into the memory database,
+// the points of metric cpu(field f) are in the family of mockFamilyTime except the first one,
+// returns the cancel func for closing the memory database
+func MockMemoryDatabaseEngine(t *testing.T, ctrl *gomock.Controller) (tsdb.Engine, context.CancelFunc) {
+	generator := diskdb.NewMockIDGenerator(ctrl)
+	generator.EXPECT().GenMetricID(gomock.Any()).Return(uint32(10)).AnyTimes()
+	generator.EXPECT().GenFieldID(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint16(10), nil).AnyTimes()
+	generator.EXPECT().GenTagID(gomock.Any(), gomock.Any()).Return(uint32(1)).AnyTimes()
+	ctx, cancel := context.WithCancel(context.Background())
+	memDB, err := memdb.NewMemoryDatabase(ctx, memdb.MemoryDatabaseCfg{
+		TimeWindow:    32,
+		IntervalValue: 10 * timeutil.OneSecond,
+		IntervalType:  interval.Day,
+		Generator:     generator,
+	})
+	assert.NoError(t, err)
+	write := func(timestamp int64, host, zone string, value float64) {
+		assert.NoError(t, memDB.Write(&pb.Metric{
+			Name:      "cpu",
+			Timestamp: timestamp,
+			Tags:      map[string]string{"host": host, "zone": zone},
+			Fields:    []*pb.Field{{Name: "f", Field: &pb.Field_Sum{Sum: &pb.Sum{Value: value}}}},
+		}))
+	}
+	write(mockFamilyTime-10*timeutil.OneSecond, "1.1.1.1", "sh", 100)
+	write(mockFamilyTime+50*timeutil.OneSecond, "1.1.1.1", "sh", 1)
+	write(mockFamilyTime+50*timeutil.OneSecond, "1.1.1.2", "sh", 2)
+	write(mockFamilyTime+70*timeutil.OneSecond, "1.1.1.3", "bj", 3)
+	write(mockFamilyTime+80*timeutil.OneSecond, "1.1.1.3", "bj", 4)
+
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().GetSegments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	shard.EXPECT().GetSeriesIDsFilter().Return(nil).AnyTimes()
+	shard.EXPECT().GetMemoryDatabase().Return(memDB).AnyTimes()
+	idGetter := diskdb.NewMockIDGetter(ctrl)
+	idGetter.EXPECT().GetMetricID(gomock.Any()).Return(uint32(10), nil).AnyTimes()
+	idGetter.EXPECT().GetFieldID(gomock.Any(), gomock.Any()).Return(uint16(10), field.SumField, nil).AnyTimes()
+	engine := tsdb.NewMockEngine(ctrl)
+	engine.EXPECT().Name().Return("mock_tsdb").AnyTimes()
+	engine.EXPECT().NumOfShards().Return(1).AnyTimes()
+	engine.EXPECT().GetShard(gomock.Any()).Return(shard).AnyTimes()
+	engine.EXPECT().GetIDGetter().Return(idGetter).AnyTimes()
+	engine.EXPECT().GetOption().Return(option.EngineOption{Interval: "10s"}).AnyTimes()
+	return engine, cancel
+}
@@ -0,0 +1,150 @@
+package query
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// queryLimiter checks the resource usage of query against the query limit, fails fast if exceeds the limit
+type queryLimiter struct {
+	limit        option.QueryLimit
+	maxTimeRange int64
+	deadline     time.Time // zero if no timeout
+
+	numOfSeries    uint64
+	numOfPoints    int64
+	numOfAggPoints int64 // num. of time slots allocated in memory for aggregating
+}
+
+// newQueryLimiter creates the query limiter, the deadline is calculated from the start time of query
+func newQueryLimiter(limit option.QueryLimit, startTime time.Time) *queryLimiter {
+	l := &queryLimiter{
+		limit:        limit,
+		maxTimeRange: limit.GetMaxTimeRange(),
+	}
+	if timeout := limit.GetTimeout(); timeout > 0 {
+		l.deadline = startTime.Add(timeout)
+	}
+	return l
+}
+
+// checkTimeRange checks if the time range span of query exceeds the limit
+func (l *queryLimiter) checkTimeRange(timeRange timeutil.TimeRange) error {
+	span := timeRange.End - timeRange.Start
+	if l.maxTimeRange > 0 && span > l.maxTimeRange {
+		return fmt.Errorf("time range span of query[%s] exceeds the limit[%s]",
+			time.Duration(span)*time.Millisecond, l.limit.MaxTimeRange)
+	}
+	return nil
+}
+
+// addSeries adds the num. of series matched, checks if the total num. of series exceeds the limit
+func (l *queryLimiter) addSeries(numOfSeries uint64) error {
+	total := atomic.AddUint64(&l.numOfSeries, numOfSeries)
+	if l.limit.MaxSeries > 0 && total > l.limit.MaxSeries {
+		return fmt.Errorf("num. of series matched by query[%d] exceeds the limit[%d], "+
+			"please narrow the tag filter or group by", total, l.limit.MaxSeries)
+	}
+	return nil
+}
+
+// getNumOfSeries returns the total num. of series matched
+func (l *queryLimiter) getNumOfSeries() uint64 {
+	return atomic.LoadUint64(&l.numOfSeries)
+}
+
+// addPoint increases the num. of points decoded, returns false if the total num. of points exceeds the limit
+func (l *queryLimiter) addPoint() bool {
+	total := atomic.AddInt64(&l.numOfPoints, 1)
+	return l.limit.MaxPoints <= 0 || total <= l.limit.MaxPoints
+}
+
+// checkPoints checks if the total num. of points decoded exceeds the limit
+func (l *queryLimiter) checkPoints() error {
+	total := atomic.LoadInt64(&l.numOfPoints)
+	if l.limit.MaxPoints > 0 && total > l.limit.MaxPoints {
+		return fmt.Errorf("num. of points decoded by query exceeds the limit[%d], "+
+			"please narrow the time range or tag filter", l.limit.MaxPoints)
+	}
+	return nil
+}
+
+// addAggPoints adds the num. of time slots allocated for aggregating,
+// checks if the total num. of time slots in memory exceeds the max points of limit
+func (l *queryLimiter) addAggPoints(numOfPoints int) error {
+	total := atomic.AddInt64(&l.numOfAggPoints, int64(numOfPoints))
+	if l.limit.MaxPoints > 0 && total > l.limit.MaxPoints {
+		return fmt.Errorf("num. of points aggregated by query exceeds the limit[%d], "+
+			"please narrow the time range or group by", l.limit.MaxPoints)
+	}
+	return nil
+}
+
+// releaseAggPoints releases the num. of time slots which are not used for aggregating
+func (l *queryLimiter) releaseAggPoints(numOfPoints int) {
+	atomic.AddInt64(&l.numOfAggPoints, -int64(numOfPoints))
+}
+
+// checkTimeout checks if the query is running over the timeout
+func (l *queryLimiter) checkTimeout() error {
+	if !l.deadline.IsZero() && time.Now().After(l.deadline) {
+		return l.timeoutError()
+	}
+	return nil
+}
+
+// timeoutError returns the error of query timeout
+func (l *queryLimiter) timeoutError() error {
+	return fmt.Errorf("query is running over the timeout[%s]", l.limit.Timeout)
+}
+
+// check checks if the points or timeout exceeds the limit during scanning
+func (l *queryLimiter) check() error {
+	if err := l.checkPoints(); err != nil {
+		return err
+	}
+	return l.checkTimeout()
+}
+
+// limitFieldIterator wraps the field iterator, counts the num. of data points decoded for query limit
+type limitFieldIterator struct {
+	series.FieldIterator
+	limiter *queryLimiter
+}
+
+// Next returns the primitive field iterator which counts the data points
+func (it *limitFieldIterator) Next() series.PrimitiveIterator {
+	primitiveIt := it.FieldIterator.Next()
+	if primitiveIt == nil {
+		return nil
+	}
+	return &limitPrimitiveIterator{PrimitiveIterator: primitiveIt, limiter: it.limiter}
+}
+
+// limitPrimitiveIterator wraps the primitive iterator, stops the iteration if the num. of points exceeds the limit
+type limitPrimitiveIterator struct {
+	series.PrimitiveIterator
+	limiter *queryLimiter
+	reached bool
+}
+
+// HasNext returns false if the num. of points exceeds the limit, else returns if the iteration has more points
+func (it *limitPrimitiveIterator) HasNext() bool {
+	if it.reached {
+		return false
+	}
+	return it.PrimitiveIterator.HasNext()
+}
+
+// Next returns the data point in the iteration, and increases the num. of points
+func (it *limitPrimitiveIterator) Next() (timeSlot int, value float64) {
+	if !it.limiter.addPoint() {
+		it.reached = true
+	}
+	return it.PrimitiveIterator.Next()
+}
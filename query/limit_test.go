@@ -0,0 +1,74 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestQueryLimiter_NoLimit(t *testing.T) {
+	limiter := newQueryLimiter(option.QueryLimit{}, time.Now())
+	assert.NoError(t, limiter.checkTimeRange(timeutil.TimeRange{Start: 0, End: 100 * timeutil.OneYear}))
+	assert.NoError(t, limiter.addSeries(1000000))
+	for i := 0; i < 100; i++ {
+		assert.True(t, limiter.addPoint())
+	}
+	assert.NoError(t, limiter.check())
+}
+
+func TestQueryLimiter_Limit(t *testing.T) {
+	limiter := newQueryLimiter(option.QueryLimit{
+		MaxSeries:    10,
+		MaxPoints:    2,
+		MaxTimeRange: "1h",
+		Timeout:      "1s",
+	}, time.Now())
+	assert.NoError(t, limiter.checkTimeRange(timeutil.TimeRange{Start: 0, End: timeutil.OneHour}))
+	err := limiter.checkTimeRange(timeutil.TimeRange{Start: 0, End: timeutil.OneDay})
+	assert.EqualError(t, err, "time range span of query[24h0m0s] exceeds the limit[1h]")
+
+	assert.NoError(t, limiter.addSeries(10))
+	err = limiter.addSeries(1)
+	assert.EqualError(t, err, "num. of series matched by query[11] exceeds the limit[10], "+
+		"please narrow the tag filter or group by")
+
+	assert.True(t, limiter.addPoint())
+	assert.True(t, limiter.addPoint())
+	assert.NoError(t, limiter.check())
+	assert.False(t, limiter.addPoint())
+	assert.Error(t, limiter.check())
+
+	// timeout
+	limiter = newQueryLimiter(option.QueryLimit{Timeout: "1s"}, time.Now().Add(-2*time.Second))
+	assert.EqualError(t, limiter.check(), "query is running over the timeout[1s]")
+}
+
+func TestLimitFieldIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limiter := newQueryLimiter(option.QueryLimit{MaxPoints: 1}, time.Now())
+	fieldIt := series.NewMockFieldIterator(ctrl)
+	fieldIt.EXPECT().Next().Return(nil)
+	it := &limitFieldIterator{FieldIterator: fieldIt, limiter: limiter}
+	assert.Nil(t, it.Next())
+
+	primitiveIt := series.NewMockPrimitiveIterator(ctrl)
+	fieldIt.EXPECT().Next().Return(primitiveIt)
+	primitiveIt.EXPECT().HasNext().Return(true).Times(2)
+	primitiveIt.EXPECT().Next().Return(1, 1.0).Times(2)
+	pIt := it.Next()
+	assert.True(t, pIt.HasNext())
+	pIt.Next()
+	assert.True(t, pIt.HasNext())
+	pIt.Next()
+	// stops iteration if exceeds the limit
+	assert.False(t, pIt.HasNext())
+	assert.Error(t, limiter.check())
+}
@@ -0,0 +1,276 @@
+package query
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// ResultCache caches the result set of down-sampled query in time aligned buckets in broker side,
+// the repeated queries(like dashboard refresh) only query the newest uncached time range,
+// then stitch the results with the cached buckets.
+// NOTICE: the buckets within the write window are never cached, because the data is still being written.
+type ResultCache struct {
+	bucketSize    int64 // time range of bucket(ms), the bucket is aligned by bucket size
+	writeWindow   int64 // the buckets whose end time is within the write window(ms) are bypassed
+	maxMemorySize int   // evicts the least recently used buckets if the memory size of buckets exceeds
+
+	memorySize int // estimated memory size of cached buckets
+	buckets    map[string]*list.Element
+	lru        *list.List
+	mutex      sync.Mutex
+}
+
+// cachedPointSize is the estimated memory size of a cached point, timestamp(int64)+value(float64)+overhead of map entry
+const cachedPointSize = 40
+
+// cachedBucket represents the series of query within the time range of bucket
+type cachedBucket struct {
+	key    string
+	series []*models.Series
+	size   int // estimated memory size of bucket
+}
+
+// newCachedBucket creates the cached bucket, estimates the memory size by the key, tags and points of series
+func newCachedBucket(key string, series []*models.Series) *cachedBucket {
+	size := len(key)
+	for _, s := range series {
+		for tagKey, tagValue := range s.Tags {
+			size += len(tagKey) + len(tagValue)
+		}
+		for fieldName, points := range s.Fields {
+			size += len(fieldName) + len(points)*cachedPointSize
+		}
+	}
+	return &cachedBucket{key: key, series: series, size: size}
+}
+
+// NewResultCache creates the result cache of query, returns nil(disable) if bucket size or max memory size is not set
+func NewResultCache(bucketSize, writeWindow time.Duration, maxMemorySize int) *ResultCache {
+	if bucketSize <= 0 || maxMemorySize <= 0 {
+		return nil
+	}
+	return &ResultCache{
+		bucketSize:    int64(bucketSize / time.Millisecond),
+		writeWindow:   int64(writeWindow / time.Millisecond),
+		maxMemorySize: maxMemorySize,
+		buckets:       make(map[string]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+// align returns the query whose time range is aligned by the interval if the query can be cached,
+// the start time is rounded down and the end time is rounded up, so the points of the query with the rolling time range
+// (like dashboard refresh) are at the same timestamps, and the buckets queried before can be reused.
+// The aligned points cover the whole time range of the original query.
+func (c *ResultCache) align(query *stmt.Query) *stmt.Query {
+	if !c.cacheable(query) {
+		return query
+	}
+	aligned := *query
+	aligned.TimeRange.Start -= aligned.TimeRange.Start % query.Interval
+	if remainder := aligned.TimeRange.End % query.Interval; remainder > 0 {
+		aligned.TimeRange.End += query.Interval - remainder
+	}
+	return &aligned
+}
+
+// cacheable checks if the result of query can be cached,
+// only the down-sampled query whose interval is aligned by the bucket can be cached,
+// the query with calendar interval cannot be cached, because its points have no fixed length.
+func (c *ResultCache) cacheable(query *stmt.Query) bool {
+	if c == nil || query.Explain || query.SubQuery != nil || query.Interval <= 0 || query.IntervalUnit != timeutil.FixedUnit {
+		return false
+	}
+	for _, spec := range aggregation.NewTopKSpecs(query.SelectItems) {
+		// the series ranked over the whole time range cannot be stitched with the cached buckets
+		if spec.RankFunc > 0 {
+			return false
+		}
+	}
+	return c.bucketSize%query.Interval == 0 && query.TimeRange.End > query.TimeRange.Start
+}
+
+// lookup returns the cached buckets from the start of query and the uncached time range,
+// returns nil if the query isn't cacheable or the time range isn't aligned by the interval(see align).
+// The uncached time range starts from the first uncached bucket, even if the start time of query is within it,
+// so the bucket is complete for caching, like the rolling time range of dashboard.
+func (c *ResultCache) lookup(database string, query *stmt.Query) *cacheLookup {
+	if !c.cacheable(query) || query.TimeRange.Start%query.Interval != 0 {
+		return nil
+	}
+	l := &cacheLookup{
+		cache:  c,
+		prefix: cacheKeyPrefix(database, query),
+		query:  query,
+		start:  c.alignBucket(query.TimeRange.Start),
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// only reuses the continuous cached buckets, queries the time range from the first uncached bucket
+	for bucketStart := l.start; bucketStart < query.TimeRange.End; bucketStart += c.bucketSize {
+		bucket, ok := c.get(l.key(bucketStart))
+		if !ok {
+			break
+		}
+		l.buckets = append(l.buckets, bucket)
+		l.start = bucketStart + c.bucketSize
+	}
+	return l
+}
+
+// alignBucket returns the start time of bucket which the timestamp belongs to
+func (c *ResultCache) alignBucket(timestamp int64) int64 {
+	return timestamp - timestamp%c.bucketSize
+}
+
+// get returns the cached bucket by key, marks it as recently used
+func (c *ResultCache) get(key string) (*cachedBucket, bool) {
+	elem, ok := c.buckets[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cachedBucket), true
+}
+
+// put caches the bucket, evicts the least recently used buckets if the memory size exceeds the max memory size,
+// the bucket larger than the max memory size isn't cached
+func (c *ResultCache) put(bucket *cachedBucket) {
+	if bucket.size > c.maxMemorySize {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.buckets[bucket.key]; ok {
+		c.memorySize -= elem.Value.(*cachedBucket).size
+		elem.Value = bucket
+		c.lru.MoveToFront(elem)
+	} else {
+		c.buckets[bucket.key] = c.lru.PushFront(bucket)
+	}
+	c.memorySize += bucket.size
+	for c.memorySize > c.maxMemorySize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		evicted := oldest.Value.(*cachedBucket)
+		delete(c.buckets, evicted.key)
+		c.memorySize -= evicted.size
+	}
+}
+
+// cacheKeyPrefix returns the key prefix of query's buckets, normalizes the query without time range
+func cacheKeyPrefix(database string, query *stmt.Query) string {
+	normalized := *query
+	normalized.TimeRange = timeutil.TimeRange{}
+	return database + "/" + string(encoding.JSONMarshal(&normalized)) + "/"
+}
+
+// cacheLookup represents the cached buckets of query, and the uncached time range which needs to query
+type cacheLookup struct {
+	cache   *ResultCache
+	prefix  string
+	query   *stmt.Query     // original query
+	buckets []*cachedBucket // continuous cached buckets from the start of query
+	start   int64           // start time of uncached time range(aligned by bucket), >= end time of query if all cached
+}
+
+// key returns the cache key of the bucket
+func (l *cacheLookup) key(bucketStart int64) string {
+	return l.prefix + strconv.FormatInt(bucketStart, 10)
+}
+
+// hitAll returns if all buckets of query are cached
+func (l *cacheLookup) hitAll() bool {
+	return l.start >= l.query.TimeRange.End
+}
+
+// store caches the complete buckets of the queried results,
+// skips the last partial bucket and the buckets within the write window.
+func (l *cacheLookup) store(resultSet *models.ResultSet) {
+	c := l.cache
+	end := l.query.TimeRange.End
+	if writable := timeutil.Now() - c.writeWindow; writable < end {
+		end = writable
+	}
+	for bucketStart := l.start; bucketStart+c.bucketSize <= end; bucketStart += c.bucketSize {
+		var bucketSeries []*models.Series
+		for _, s := range resultSet.Series {
+			if sliced := sliceSeries(s, bucketStart, bucketStart+c.bucketSize); sliced != nil {
+				bucketSeries = append(bucketSeries, sliced)
+			}
+		}
+		c.put(newCachedBucket(l.key(bucketStart), bucketSeries))
+	}
+}
+
+// stitch returns the result set of the original query,
+// merges the points of cached buckets and the queried results by group tags.
+func (l *cacheLookup) stitch(queried *models.ResultSet) *models.ResultSet {
+	resultSet := newResultSetBuilder(l.query).newResultSet()
+	resultSet.Partial = queried.Partial
+	groups := make(map[string]*models.Series)
+	merge := func(s *models.Series) {
+		if s == nil {
+			return
+		}
+		group := models.TagsAsString(s.Tags)
+		target, ok := groups[group]
+		if !ok {
+			target = models.NewSeries(s.Tags)
+			groups[group] = target
+			resultSet.AddSeries(target)
+		}
+		for fieldName, points := range s.Fields {
+			targetPoints, ok := target.Fields[fieldName]
+			if !ok {
+				targetPoints = make(map[int64]float64)
+				target.AddField(fieldName, targetPoints)
+			}
+			for timestamp, value := range points {
+				targetPoints[timestamp] = value
+			}
+		}
+	}
+	// the first/last bucket may be out of the time range of query
+	start, end := l.query.TimeRange.Start, l.query.TimeRange.End
+	for _, bucket := range l.buckets {
+		for _, s := range bucket.series {
+			merge(sliceSeries(s, start, end))
+		}
+	}
+	for _, s := range queried.Series {
+		merge(sliceSeries(s, start, end))
+	}
+	return resultSet
+}
+
+// sliceSeries returns the series which only includes the points within [start, end), returns nil if no point
+func sliceSeries(s *models.Series, start, end int64) *models.Series {
+	var sliced *models.Series
+	for fieldName, points := range s.Fields {
+		slicedPoints := make(map[int64]float64)
+		for timestamp, value := range points {
+			if timestamp >= start && timestamp < end {
+				slicedPoints[timestamp] = value
+			}
+		}
+		if len(slicedPoints) == 0 {
+			continue
+		}
+		if sliced == nil {
+			sliced = models.NewSeries(s.Tags)
+		}
+		sliced.AddField(fieldName, slicedPoints)
+	}
+	return sliced
+}
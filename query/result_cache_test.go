@@ -0,0 +1,178 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func newCacheQuery(t *testing.T, start, end int64) *stmt.Query {
+	query, err := sql.Parse("select f from cpu group by host, time(1m)")
+	assert.NoError(t, err)
+	query.TimeRange = timeutil.TimeRange{Start: start, End: end}
+	return query
+}
+
+func newCacheResultSet(start, end int64) *models.ResultSet {
+	resultSet := models.NewResultSet()
+	points := make(map[int64]float64)
+	for timestamp := start; timestamp < end; timestamp += timeutil.OneMinute {
+		points[timestamp] = 1
+	}
+	s := models.NewSeries(map[string]string{"host": "1.1.1.1"})
+	s.AddField("f", points)
+	resultSet.AddSeries(s)
+	return resultSet
+}
+
+func TestNewResultCache(t *testing.T) {
+	assert.Nil(t, NewResultCache(0, time.Minute, 10))
+	assert.Nil(t, NewResultCache(time.Hour, time.Minute, 0))
+	assert.NotNil(t, NewResultCache(time.Hour, time.Minute, 1024*1024))
+}
+
+func TestResultCache_cacheable(t *testing.T) {
+	var disable *ResultCache
+	start := timeutil.Now() / timeutil.OneHour * timeutil.OneHour
+	query := newCacheQuery(t, start-timeutil.OneHour, start)
+	assert.Nil(t, disable.lookup("db", query))
+
+	cache := NewResultCache(time.Hour, time.Minute, 1024*1024)
+	assert.True(t, cache.cacheable(query))
+
+	q := *query
+	q.Explain = true
+	assert.False(t, cache.cacheable(&q))
+	q = *query
+	q.Interval = 0
+	assert.False(t, cache.cacheable(&q))
+	q = *query
+	q.Interval = 7 * timeutil.OneMinute
+	assert.False(t, cache.cacheable(&q))
+	q = *query
+	q.TimeRange.End = q.TimeRange.Start
+	assert.False(t, cache.cacheable(&q))
+	q = *query
+	q.SubQuery = query
+	assert.False(t, cache.cacheable(&q))
+	// the points of calendar interval have no fixed length
+	q = *query
+	q.IntervalUnit = timeutil.DayUnit
+	assert.False(t, cache.cacheable(&q))
+	// topk per time slot can be stitched, but not ranked over the whole time range
+	q = *query
+	q.SelectItems = []stmt.Expr{&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK,
+		Params: []stmt.Expr{&stmt.NumberLiteral{Val: 2}, &stmt.FieldExpr{Name: "f"}}}}}
+	assert.True(t, cache.cacheable(&q))
+	q.SelectItems = []stmt.Expr{&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK,
+		Params: []stmt.Expr{&stmt.NumberLiteral{Val: 2}, &stmt.FieldExpr{Name: "f"}, &stmt.CallExpr{FuncType: function.Avg}}}}}
+	assert.False(t, cache.cacheable(&q))
+}
+
+func TestResultCache_lookup(t *testing.T) {
+	cache := NewResultCache(time.Hour, 0, 1024*1024)
+	now := timeutil.Now()
+	start := now/timeutil.OneHour*timeutil.OneHour - 3*timeutil.OneHour
+	query := newCacheQuery(t, start+30*timeutil.OneMinute, now)
+
+	// nothing cached, queries from the start of first bucket
+	l := cache.lookup("db", query)
+	assert.Equal(t, start, l.start)
+	assert.False(t, l.hitAll())
+	// the newest bucket isn't complete
+	l.store(newCacheResultSet(l.start, now))
+	assert.Equal(t, 3, cache.lru.Len())
+
+	// dashboard refresh, only queries from the first uncached bucket
+	query = newCacheQuery(t, start+40*timeutil.OneMinute, now)
+	l = cache.lookup("db", query)
+	assert.Equal(t, start+3*timeutil.OneHour, l.start)
+	assert.Len(t, l.buckets, 3)
+	resultSet := l.stitch(newCacheResultSet(l.start, now))
+	assert.Equal(t, query.TimeRange.Start, resultSet.StartTime)
+	assert.Len(t, resultSet.Series, 1)
+	assert.Len(t, resultSet.Series[0].Fields["f"],
+		timeutil.CalPointCount(query.TimeRange.Start, now, timeutil.OneMinute))
+
+	// other database or query isn't hit
+	l = cache.lookup("db2", query)
+	assert.Empty(t, l.buckets)
+
+	// historical query hits all buckets
+	historical := newCacheQuery(t, start+timeutil.OneHour, start+2*timeutil.OneHour+10*timeutil.OneMinute)
+	l = cache.lookup("db", historical)
+	assert.True(t, l.hitAll())
+	resultSet = l.stitch(models.NewResultSet())
+	assert.Len(t, resultSet.Series[0].Fields["f"], 70)
+}
+
+func TestResultCache_align(t *testing.T) {
+	cache := NewResultCache(time.Hour, 0, 1024*1024)
+	start := timeutil.Now() / timeutil.OneHour * timeutil.OneHour
+	query := newCacheQuery(t, start-timeutil.OneHour+10*timeutil.OneSecond, start-50*timeutil.OneSecond)
+	// the query isn't aligned
+	assert.Nil(t, cache.lookup("db", query))
+
+	aligned := cache.align(query)
+	assert.Equal(t, timeutil.TimeRange{Start: start - timeutil.OneHour, End: start}, aligned.TimeRange)
+	// the original query isn't changed
+	assert.Equal(t, start-timeutil.OneHour+10*timeutil.OneSecond, query.TimeRange.Start)
+	l := cache.lookup("db", aligned)
+	assert.Equal(t, start-timeutil.OneHour, l.start)
+	l.store(newCacheResultSet(l.start, start))
+
+	// dashboard refresh with the rolling time range hits the aligned bucket
+	l = cache.lookup("db", cache.align(newCacheQuery(t, start-timeutil.OneHour+20*timeutil.OneSecond, start)))
+	assert.True(t, l.hitAll())
+	assert.Len(t, l.buckets, 1)
+
+	// not cacheable query isn't aligned
+	var disable *ResultCache
+	assert.Equal(t, query, disable.align(query))
+}
+
+func TestResultCache_writeWindow(t *testing.T) {
+	cache := NewResultCache(time.Hour, 24*time.Hour, 1024*1024)
+	now := timeutil.Now()
+	start := now/timeutil.OneHour*timeutil.OneHour - 3*timeutil.OneHour
+	l := cache.lookup("db", newCacheQuery(t, start, now))
+	l.store(newCacheResultSet(start, now))
+	// all buckets are within the write window
+	assert.Equal(t, 0, cache.lru.Len())
+}
+
+func TestResultCache_evict(t *testing.T) {
+	cache := NewResultCache(time.Hour, 5*time.Minute, 1)
+	start := timeutil.Now()/timeutil.OneHour*timeutil.OneHour - 5*timeutil.OneHour
+	query := newCacheQuery(t, start, start+3*timeutil.OneHour)
+	l := cache.lookup("db", query)
+	// the bucket larger than max memory size isn't cached
+	l.store(newCacheResultSet(start, start+3*timeutil.OneHour))
+	assert.Equal(t, 0, cache.lru.Len())
+
+	// the memory size of cache is enough for 2 buckets
+	bucket := newCachedBucket(l.key(start), newCacheResultSet(start, start+timeutil.OneHour).Series)
+	assert.Equal(t, len(l.key(start))+len("host")+len("1.1.1.1")+len("f")+60*cachedPointSize, bucket.size)
+	cache.maxMemorySize = 2 * bucket.size
+	l.store(newCacheResultSet(start, start+3*timeutil.OneHour))
+	assert.Equal(t, 2, cache.lru.Len())
+	assert.Len(t, cache.buckets, 2)
+	assert.Equal(t, 2*bucket.size, cache.memorySize)
+
+	// the oldest bucket is evicted, so no bucket is reused from the start of query
+	l = cache.lookup("db", query)
+	assert.Empty(t, l.buckets)
+	// replaces the cached bucket
+	l.store(models.NewResultSet())
+	l = cache.lookup("db", newCacheQuery(t, start+2*timeutil.OneHour, start+3*timeutil.OneHour))
+	assert.True(t, l.hitAll())
+	assert.Equal(t, 3*len(l.key(start)), cache.memorySize)
+	assert.Empty(t, l.buckets[0].series)
+}
@@ -0,0 +1,164 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/fields"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// resultSetBuilder builds the result set of query in broker side,
+// evaluates the select list for each group, then converts the time slot of values to timestamp.
+type resultSetBuilder struct {
+	query      *stmt.Query
+	interval   int64
+	pointCount int
+	buckets    []int64                 // start time of calendar buckets, nil if the interval has fixed length
+	specs      []*aggregation.TopKSpec // series selector functions of select list
+}
+
+// newResultSetBuilder creates the result set builder for the query
+func newResultSetBuilder(query *stmt.Query) *resultSetBuilder {
+	interval := query.Interval
+	if interval <= 0 {
+		//TODO use storage interval
+		interval = 10 * timeutil.OneSecond
+	}
+	b := &resultSetBuilder{
+		query:      query,
+		interval:   interval,
+		pointCount: timeutil.CalPointCount(query.TimeRange.Start, query.TimeRange.End, interval),
+		specs:      aggregation.NewTopKSpecs(query.SelectItems),
+	}
+	if calendar, ok := query.CalendarInterval(); ok {
+		b.buckets = calendar.Buckets(query.TimeRange.Start, query.TimeRange.End)
+		b.pointCount = len(b.buckets)
+	}
+	return b
+}
+
+// build drains the results, returns the result set includes the metadata of query and the series of groups
+func (b *resultSetBuilder) build(results <-chan series.GroupedIterator) *models.ResultSet {
+	resultSet := b.newResultSet()
+	if len(b.specs) > 0 {
+		b.buildSelected(results, resultSet)
+		return resultSet
+	}
+	for it := range results {
+		if s := b.buildSeries(it); s != nil {
+			resultSet.AddSeries(s)
+		}
+	}
+	return resultSet
+}
+
+// buildSelected builds the series selected by topk/bottomk functions,
+// all groups are evaluated before selecting, the values of the group at unselected time slots are dropped.
+func (b *resultSetBuilder) buildSelected(results <-chan series.GroupedIterator, resultSet *models.ResultSet) {
+	selectors := make([]*aggregation.TopKSelector, len(b.specs))
+	for idx, spec := range b.specs {
+		selectors[idx] = spec.NewSelector()
+	}
+	var tags []map[string]string
+	var groups []map[string]collections.FloatArray
+	for it := range results {
+		if it == nil {
+			continue
+		}
+		values := b.evalSeries(it)
+		if len(values) == 0 {
+			continue
+		}
+		for idx, spec := range b.specs {
+			selectors[idx].Add(len(groups), values[spec.Name])
+		}
+		tags = append(tags, it.Tags())
+		groups = append(groups, values)
+	}
+	for groupIdx, values := range groups {
+		for idx, spec := range b.specs {
+			if selected := selectors[idx].Filter(groupIdx, values[spec.Name]); selected != nil {
+				values[spec.Name] = selected
+			} else {
+				delete(values, spec.Name)
+			}
+		}
+		if s := b.toSeries(tags[groupIdx], values); s != nil {
+			resultSet.AddSeries(s)
+		}
+	}
+}
+
+// newResultSet creates the result set with the metadata of query
+func (b *resultSetBuilder) newResultSet() *models.ResultSet {
+	resultSet := models.NewResultSet()
+	resultSet.MetricName = b.query.LeafQuery().MetricName
+	if b.query.IsCrossMetric() {
+		resultSet.MetricName = strings.Join(b.query.MetricNames, ",")
+	}
+	resultSet.GroupBy = b.query.GroupBy
+	resultSet.Fields = b.query.ResultNames()
+	resultSet.StartTime = b.query.TimeRange.Start
+	resultSet.EndTime = b.query.TimeRange.End
+	resultSet.Interval = b.interval
+	resultSet.TimeZone = b.query.TimeZone
+	return resultSet
+}
+
+// buildSeries evaluates the select list of the group, returns nil if the group has no value
+func (b *resultSetBuilder) buildSeries(it series.GroupedIterator) *models.Series {
+	if it == nil {
+		return nil
+	}
+	return b.toSeries(it.Tags(), b.evalSeries(it))
+}
+
+// evalSeries evaluates the select list of the group, returns the values of select items,
+// if query from sub query, the group is already evaluated by the aggregator of sub query.
+func (b *resultSetBuilder) evalSeries(it series.GroupedIterator) map[string]collections.FloatArray {
+	if b.query.SubQuery == nil {
+		expression := aggregation.NewExpression(it, b.pointCount, b.query.SelectItems)
+		expression.Eval()
+		return expression.ResultSet()
+	}
+	values := make(map[string]collections.FloatArray)
+	for it.HasNext() {
+		fieldIt := it.Next()
+		f := fields.NewSingleField(b.pointCount, fieldIt)
+		if f != nil {
+			values[fieldIt.FieldName()] = f.GetDefaultValues()[0]
+		}
+	}
+	return values
+}
+
+// toSeries converts the time slot of values to timestamp, returns nil if the group has no value
+func (b *resultSetBuilder) toSeries(tags map[string]string, values map[string]collections.FloatArray) *models.Series {
+	if len(values) == 0 {
+		return nil
+	}
+	s := models.NewSeries(tags)
+	for fieldName, array := range values {
+		points := make(map[int64]float64)
+		valueIt := array.Iterator()
+		for valueIt.HasNext() {
+			slot, value := valueIt.Next()
+			points[b.timestamp(slot)] = value
+		}
+		s.AddField(fieldName, points)
+	}
+	return s
+}
+
+// timestamp returns the timestamp of time slot, the start time of bucket if calendar interval
+func (b *resultSetBuilder) timestamp(slot int) int64 {
+	if b.buckets != nil {
+		return b.buckets[slot]
+	}
+	return b.query.TimeRange.Start + int64(slot)*b.interval
+}
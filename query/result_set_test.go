@@ -0,0 +1,185 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/rpc"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestResultSetBuilder_Build(t *testing.T) {
+	query, err := sql.Parse("select f as v, f+f from cpu where time>'20190729 11:00:00' and time<'20190729 11:01:00'" +
+		" group by host, time(10s) tz('UTC')")
+	assert.NoError(t, err)
+	results := make(chan series.GroupedIterator)
+	go func() {
+		results <- nil
+		results <- newResultSeries(map[string]string{"host": "1.1.1.1"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 1, 2: 3})})
+		// no value
+		results <- newResultSeries(map[string]string{"host": "1.1.1.2"},
+			map[string]collections.FloatArray{"g": mockFloatArray(map[int]float64{0: 1})})
+		close(results)
+	}()
+	resultSet := newResultSetBuilder(query).build(results)
+	assert.Equal(t, "cpu", resultSet.MetricName)
+	assert.Equal(t, []string{"host"}, resultSet.GroupBy)
+	assert.Equal(t, []string{"v", "f+f"}, resultSet.Fields)
+	assert.Equal(t, query.TimeRange.Start, resultSet.StartTime)
+	assert.Equal(t, query.TimeRange.End, resultSet.EndTime)
+	assert.Equal(t, 10*timeutil.OneSecond, resultSet.Interval)
+	assert.Equal(t, "UTC", resultSet.TimeZone)
+	assert.Len(t, resultSet.Series, 1)
+	start := query.TimeRange.Start
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, resultSet.Series[0].Tags)
+	assert.Equal(t, map[int64]float64{start: 1, start + 20*timeutil.OneSecond: 3}, resultSet.Series[0].Fields["v"])
+	assert.Equal(t, map[int64]float64{start: 2, start + 20*timeutil.OneSecond: 6}, resultSet.Series[0].Fields["f+f"])
+}
+
+func TestResultSetBuilder_CalendarInterval(t *testing.T) {
+	query, err := sql.Parse("select f from cpu where time>'20200115 00:00:00' and time<'20200310 00:00:00'" +
+		" group by host, time(1M) tz('Asia/Shanghai')")
+	assert.NoError(t, err)
+	results := make(chan series.GroupedIterator)
+	go func() {
+		results <- newResultSeries(map[string]string{"host": "1.1.1.1"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 1, 1: 2, 2: 3})})
+		close(results)
+	}()
+	resultSet := newResultSetBuilder(query).build(results)
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	monthStart := func(month time.Month) int64 {
+		return time.Date(2020, month, 1, 0, 0, 0, 0, loc).UnixNano() / 1000000
+	}
+	// the points are at the start of calendar months, 31 days for january, 29 days for february
+	assert.Equal(t, monthStart(time.January), resultSet.StartTime)
+	assert.Len(t, resultSet.Series, 1)
+	assert.Equal(t, map[int64]float64{
+		monthStart(time.January):  1,
+		monthStart(time.February): 2,
+		monthStart(time.March):    3,
+	}, resultSet.Series[0].Fields["f"])
+}
+
+func TestResultSetBuilder_TopK(t *testing.T) {
+	query, err := sql.Parse("select topk(1, f) as t, bottomk(1, f, sum()) from cpu" +
+		" where time>'20190729 11:00:00' and time<'20190729 11:01:00' group by host, time(10s)")
+	assert.NoError(t, err)
+	results := make(chan series.GroupedIterator)
+	go func() {
+		results <- nil
+		results <- newResultSeries(map[string]string{"host": "1.1.1.1"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 1, 1: 5})})
+		results <- newResultSeries(map[string]string{"host": "1.1.1.2"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 3, 1: 4})})
+		results <- newResultSeries(map[string]string{"host": "1.1.1.3"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 2, 1: 2})})
+		results <- newResultSeries(map[string]string{"host": "1.1.1.4"},
+			map[string]collections.FloatArray{"f": mockFloatArray(map[int]float64{0: 2.5, 1: 3})})
+		close(results)
+	}()
+	resultSet := newResultSetBuilder(query).build(results)
+	assert.Equal(t, []string{"t", "bottomk(1,f,sum())"}, resultSet.Fields)
+	// the series which isn't selected by any function is dropped
+	assert.Len(t, resultSet.Series, 3)
+	assert.Equal(t, map[string]string{"host": "1.1.1.3"}, resultSet.Series[2].Tags)
+	start := query.TimeRange.Start
+	assert.Equal(t, map[int64]float64{start + 10*timeutil.OneSecond: 5}, resultSet.Series[0].Fields["t"])
+	assert.Nil(t, resultSet.Series[0].Fields["bottomk(1,f,sum())"])
+	assert.Equal(t, map[int64]float64{start: 3}, resultSet.Series[1].Fields["t"])
+	assert.Nil(t, resultSet.Series[1].Fields["bottomk(1,f,sum())"])
+	assert.Nil(t, resultSet.Series[2].Fields["t"])
+	assert.Equal(t, map[int64]float64{start: 2, start + 10*timeutil.OneSecond: 2}, resultSet.Series[2].Fields["bottomk(1,f,sum())"])
+}
+
+func TestResultSetBuilder_CrossMetric(t *testing.T) {
+	query, err := sql.Parse("select a.f/b.f from a, b")
+	assert.NoError(t, err)
+	builder := newResultSetBuilder(query)
+	// default interval
+	assert.Equal(t, 10*timeutil.OneSecond, builder.interval)
+	resultSet := builder.newResultSet()
+	assert.Equal(t, "a,b", resultSet.MetricName)
+	assert.Equal(t, []string{"a.f/b.f"}, resultSet.Fields)
+}
+
+func TestResultSetBuilder_SubQuery(t *testing.T) {
+	query, err := sql.Parse("select max(v) from (select sum(f) as v from cpu group by host, time(1m))")
+	assert.NoError(t, err)
+	builder := newResultSetBuilder(query)
+	assert.Equal(t, "cpu", builder.newResultSet().MetricName)
+	// results of sub query are evaluated
+	s := builder.buildSeries(newResultSeries(map[string]string{},
+		map[string]collections.FloatArray{"max(v)": mockFloatArray(map[int]float64{1: 10})}))
+	assert.Equal(t, map[int64]float64{query.TimeRange.Start + timeutil.OneMinute: 10}, s.Fields["max(v)"])
+}
+
+func TestResultSetBuilder_StorageToRoot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine, cancel := MockMemoryDatabaseEngine(t, ctrl)
+	defer cancel()
+	storageService := service.NewMockStorageService(ctrl)
+	storageService.EXPECT().GetEngine("test_db").Return(engine)
+	responses := make(chan *pb.TaskResponse, 1)
+	stream := pb.NewMockTaskService_HandleServer(ctrl)
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		responses <- resp
+		return nil
+	})
+	taskServerFactory := rpc.NewMockTaskServerFactory(ctrl)
+	taskServerFactory.EXPECT().GetStream("root").Return(stream)
+
+	// the storage executor of leaf task scans the memory database, sends the merged payload to root
+	query, err := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'" +
+		" group by zone, time(1m)")
+	assert.NoError(t, err)
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	plan := &models.PhysicalPlan{
+		Database: "test_db",
+		Leafs: []models.Leaf{{BaseNode: models.BaseNode{Parent: "root", Indicator: (&currentNode).Indicator()},
+			ShardIDs: []int32{1}}},
+	}
+	dispatcher := parallel.NewLeafTaskDispatcher(currentNode, storageService,
+		NewExecutorFactory(ExecutorOption{}), taskServerFactory, 0, 0, 0)
+	dispatcher.Dispatch(&pb.TaskRequest{
+		JobID:        1,
+		ParentTaskID: "root-task",
+		PhysicalPlan: encoding.JSONMarshal(plan),
+		Payload:      encoding.JSONMarshal(query),
+	})
+	resp := <-responses
+	assert.Empty(t, resp.ErrMsg)
+	assert.True(t, resp.Completed)
+	assert.NotEmpty(t, resp.Payload)
+
+	// the root job merges the payload of leaf, then builds the result set
+	results := make(chan series.GroupedIterator, 10)
+	jobCtx := parallel.NewJobContext(context.TODO(), results, plan, query, "")
+	assert.NoError(t, jobCtx.ReceivePayload(resp.Payload))
+	jobCtx.Complete()
+	resultSet := newResultSetBuilder(query).build(results)
+	assert.Len(t, resultSet.Series, 2)
+	values := make(map[string]map[int64]float64)
+	for _, s := range resultSet.Series {
+		values[s.Tags["zone"]] = s.Fields["f"]
+	}
+	assert.Equal(t, map[string]map[int64]float64{
+		"sh": {mockFamilyTime: 3},
+		"bj": {mockFamilyTime + timeutil.OneMinute: 7},
+	}, values)
+}
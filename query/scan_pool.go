@@ -0,0 +1,104 @@
+package query
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// ScanPool represents the bounded worker pool shared by all queries in storage side,
+// which filters the series of shards and scans the data families concurrently.
+// The task is run by the caller itself if no idle worker, so the query never waits for the workers
+// which are busy with other queries, and the num. of concurrent scanning is bounded by workers + queries.
+type ScanPool struct {
+	tasks chan func() // unbuffered, only accepted by the idle worker
+}
+
+// NewScanPool creates the scan pool with the num. of workers, returns nil(scans sequentially) if workers is 0
+func NewScanPool(workers int) *ScanPool {
+	if workers <= 0 {
+		return nil
+	}
+	p := &ScanPool{tasks: make(chan func())}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// execute executes the tasks by the idle workers, runs the task in the caller goroutine if no idle worker,
+// then waits until all tasks completed, executes the tasks sequentially if the pool is nil
+func (p *ScanPool) execute(tasks []func()) {
+	if p == nil {
+		for _, task := range tasks {
+			task()
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(len(tasks))
+	for _, task := range tasks {
+		task := task
+		run := func() {
+			defer wg.Done()
+			task()
+		}
+		select {
+		case p.tasks <- run:
+		default:
+			run()
+		}
+	}
+	wg.Wait()
+}
+
+// work runs the tasks submitted to the pool
+func (p *ScanPool) work() {
+	for task := range p.tasks {
+		task()
+	}
+}
+
+// scanTasks represents the scan tasks of a query, the remaining tasks are skipped after any task fails
+// or the query is canceled, the error of each task is kept in order of tasks.
+type scanTasks struct {
+	ctx    context.Context
+	tasks  []func()
+	errs   []error
+	failed int32
+}
+
+// newScanTasks creates the scan tasks of the query
+func newScanTasks(ctx context.Context) *scanTasks {
+	return &scanTasks{ctx: ctx}
+}
+
+// add adds the scan task
+func (t *scanTasks) add(scan func() error) {
+	idx := len(t.tasks)
+	t.errs = append(t.errs, nil)
+	t.tasks = append(t.tasks, func() {
+		if atomic.LoadInt32(&t.failed) == 1 {
+			return
+		}
+		err := errQueryCanceled
+		if t.ctx.Err() == nil {
+			err = scan()
+		}
+		if err != nil {
+			t.errs[idx] = err
+			atomic.StoreInt32(&t.failed, 1)
+		}
+	})
+}
+
+// execute executes the tasks by the scan pool, returns the first error in order of tasks
+func (t *scanTasks) execute(pool *ScanPool) error {
+	pool.execute(t.tasks)
+	for _, err := range t.errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScanPool(t *testing.T) {
+	assert.Nil(t, NewScanPool(0))
+	assert.NotNil(t, NewScanPool(1))
+
+	// executes the tasks sequentially if the pool is nil
+	var pool *ScanPool
+	var order []int
+	var tasks []func()
+	for i := 0; i < 3; i++ {
+		i := i
+		tasks = append(tasks, func() {
+			order = append(order, i)
+		})
+	}
+	pool.execute(tasks)
+	assert.Equal(t, []int{0, 1, 2}, order)
+}
+
+func TestScanPool_Execute(t *testing.T) {
+	pool := NewScanPool(2)
+	var count int32
+	var tasks []func()
+	for i := 0; i < 100; i++ {
+		tasks = append(tasks, func() {
+			atomic.AddInt32(&count, 1)
+		})
+	}
+	pool.execute(tasks)
+	assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+
+	// runs the tasks in caller goroutine if all workers are busy
+	blocked := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		pool.tasks <- func() { <-blocked }
+	}
+	executed := false
+	pool.execute([]func(){func() { executed = true }})
+	assert.True(t, executed)
+	close(blocked)
+}
+
+func TestScanTasks_Execute(t *testing.T) {
+	pool := NewScanPool(2)
+	tasks := newScanTasks(context.TODO())
+	var count int32
+	for i := 0; i < 10; i++ {
+		tasks.add(func() error {
+			atomic.AddInt32(&count, 1)
+			return nil
+		})
+	}
+	assert.NoError(t, tasks.execute(pool))
+	assert.Equal(t, int32(10), count)
+
+	// returns the first error in order, the remaining tasks are skipped after failure
+	tasks = newScanTasks(context.TODO())
+	count = 0
+	tasks.add(func() error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	tasks.add(func() error {
+		return errors.New("err1")
+	})
+	tasks.add(func() error {
+		return errors.New("err2")
+	})
+	tasks.add(func() error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	assert.EqualError(t, tasks.execute(nil), "err1")
+	assert.Equal(t, int32(1), count)
+
+	// query canceled
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	tasks = newScanTasks(ctx)
+	tasks.add(func() error {
+		t.Fatal("task of canceled query is executed")
+		return nil
+	})
+	assert.Equal(t, errQueryCanceled, tasks.execute(pool))
+}
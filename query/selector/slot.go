@@ -1,5 +1,7 @@
 package selector
 
+import "github.com/lindb/lindb/pkg/timeutil"
+
 // SlotSelector represents a slot selector for aggregator value's index
 type SlotSelector interface {
 	// IndexOf returns the index of the specified element in aggregator values
@@ -28,3 +30,33 @@ func (s *indexSlotSelector) IndexOf(timeSlot int) int {
 	}
 	return (timeSlot - s.startSlot) / s.intervalRatio
 }
+
+// calendarSlotSelector represents a slot selector based on the buckets of calendar interval,
+// the buckets have no fixed length, like month/year, so the index is searched by the time of slot
+type calendarSlotSelector struct {
+	baseTime    int64
+	interval    int64
+	startBucket int
+	buckets     []int64
+}
+
+// NewCalendarSlotSelector creates a slot selector using the buckets of calendar interval,
+// the time of slot is baseTime+timeSlot*interval, the index is relative to the start bucket
+func NewCalendarSlotSelector(baseTime, interval int64, startBucket int, buckets []int64) SlotSelector {
+	return &calendarSlotSelector{
+		baseTime:    baseTime,
+		interval:    interval,
+		startBucket: startBucket,
+		buckets:     buckets,
+	}
+}
+
+// IndexOf returns the index of the specified element in aggregator values,
+// index = bucket index of the time of slot - start bucket, if the bucket < start bucket return -1
+func (s *calendarSlotSelector) IndexOf(timeSlot int) int {
+	bucket := timeutil.BucketIndex(s.buckets, s.baseTime+int64(timeSlot)*s.interval)
+	if bucket < s.startBucket {
+		return -1
+	}
+	return bucket - s.startBucket
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestIndexSlotSelector_IndexOf(t *testing.T) {
@@ -18,3 +20,14 @@ func TestIndexSlotSelector_IndexOf(t *testing.T) {
 	assert.Equal(t, 1, selector.IndexOf(13))
 	assert.Equal(t, 40, selector.IndexOf(130))
 }
+
+func TestCalendarSlotSelector_IndexOf(t *testing.T) {
+	// buckets of 3 days, 2 days, 4 days
+	buckets := []int64{0, 3 * timeutil.OneDay, 5 * timeutil.OneDay, 9 * timeutil.OneDay}
+	selector := NewCalendarSlotSelector(0, timeutil.OneHour, 1, buckets)
+	assert.Equal(t, -1, selector.IndexOf(71))
+	assert.Equal(t, 0, selector.IndexOf(72))
+	assert.Equal(t, 0, selector.IndexOf(119))
+	assert.Equal(t, 1, selector.IndexOf(120))
+	assert.Equal(t, 2, selector.IndexOf(300))
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"time"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// slowQueryLog writes the slow query into a dedicated log file
+var slowQueryLog = logger.GetSlowQueryLogger()
+
+// slowQuery represents the entry of slow query log
+type slowQuery struct {
+	sql         string
+	database    string
+	user        string
+	duration    time.Duration
+	numOfSeries uint64                   // num. of series scanned by all storage nodes
+	nodes       map[string]time.Duration // storage node's indicator => cost of storage node
+	trace       *models.Trace            // span tree of query, nil if not recorded
+	err         error
+}
+
+// newSlowQuery creates the slow query entry, collects the stats from the task responses of storage nodes
+func newSlowQuery(e *brokerExecutor, duration time.Duration) *slowQuery {
+	entry := &slowQuery{
+		sql:      e.sql,
+		database: e.database,
+		user:     models.UserFromContext(e.ctx),
+		duration: duration,
+		nodes:    make(map[string]time.Duration),
+		trace:    e.trace,
+		err:      e.Error(),
+	}
+	if stats := e.collectStats(); stats != nil {
+		for nodeID, storageStats := range stats.StorageNodes {
+			entry.numOfSeries += storageStats.NumOfSeries
+			entry.nodes[nodeID] = time.Duration(storageStats.TotalCost)
+		}
+	}
+	return entry
+}
+
+// log writes the slow query entry into slow query log
+func (q *slowQuery) log() {
+	nodes := make(map[string]string, len(q.nodes))
+	for nodeID, cost := range q.nodes {
+		nodes[nodeID] = cost.String()
+	}
+	// the error field is skipped if no error
+	slowQueryLog.Warn("slow query",
+		logger.String("sql", q.sql),
+		logger.String("database", q.database),
+		logger.String("user", q.user),
+		logger.String("duration", q.duration.String()),
+		logger.Uint64("numOfSeries", q.numOfSeries),
+		logger.Any("nodes", nodes),
+		logger.Any("trace", q.trace),
+		logger.Error(q.err))
+}
+
+// logSlowQuery records the query into slow query log if the execution exceeds the threshold
+func (e *brokerExecutor) logSlowQuery() {
+	if e.slowQueryThreshold <= 0 {
+		return
+	}
+	duration := time.Since(e.startTime)
+	if duration < e.slowQueryThreshold {
+		return
+	}
+	newSlowQuery(e, duration).log()
+}
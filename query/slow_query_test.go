@@ -0,0 +1,94 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/encoding"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSlowQuery(t *testing.T) {
+	jobCtx := parallel.NewJobContext(context.TODO(), nil, models.NewPhysicalPlan(models.Root{}),
+		&stmt.Query{MetricName: "cpu"}, "select f from cpu")
+	jobCtx.ReceiveStats("1.1.1.1:9000", &models.StorageStats{TotalCost: int64(time.Second), NumOfSeries: 10})
+	jobCtx.ReceiveStats("1.1.1.2:9000", &models.StorageStats{TotalCost: int64(2 * time.Second), NumOfSeries: 20})
+	exec := &brokerExecutor{
+		ctx:         models.WithUser(context.TODO(), "admin"),
+		database:    "test_db",
+		sql:         "select f from cpu",
+		jobContexts: []parallel.JobContext{jobCtx},
+		startTime:   time.Now().Add(-3 * time.Second),
+		trace:       models.NewTrace("query"),
+		err:         fmt.Errorf("err"),
+	}
+
+	entry := newSlowQuery(exec, 3*time.Second)
+	assert.Equal(t, "select f from cpu", entry.sql)
+	assert.Equal(t, "test_db", entry.database)
+	assert.Equal(t, "admin", entry.user)
+	assert.Equal(t, 3*time.Second, entry.duration)
+	assert.Equal(t, uint64(30), entry.numOfSeries)
+	assert.Equal(t, map[string]time.Duration{"1.1.1.1:9000": time.Second, "1.1.1.2:9000": 2 * time.Second}, entry.nodes)
+	assert.Equal(t, exec.trace, entry.trace)
+	assert.Error(t, entry.err)
+	entry.log()
+
+	// disable slow query log
+	exec.logSlowQuery()
+	// not exceeds the threshold
+	exec.slowQueryThreshold = time.Minute
+	exec.logSlowQuery()
+	// exceeds the threshold
+	exec.slowQueryThreshold = time.Second
+	exec.logSlowQuery()
+
+	// no job submitted
+	entry = newSlowQuery(&brokerExecutor{ctx: context.TODO()}, time.Second)
+	assert.Empty(t, entry.nodes)
+	assert.Empty(t, entry.user)
+	assert.Nil(t, entry.trace)
+}
+
+func TestSlowQuery_Intermediates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	plan.AddIntermediate(models.Intermediate{BaseNode: models.BaseNode{Parent: "1.1.1.3:8000",
+		Indicator: "1.1.1.4:8000"}, NumOfTask: 2})
+	jobCtx := parallel.NewJobContext(context.TODO(), nil, plan, &stmt.Query{MetricName: "cpu"}, "select f from cpu")
+
+	// the intermediate node forwards the stats of its leaf nodes
+	taskCtx := parallel.NewMockTaskContext(ctrl)
+	taskCtx.EXPECT().TaskType().Return(parallel.RootTask).AnyTimes()
+	taskManager := parallel.NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Get("taskID").Return(taskCtx)
+	jobManager := parallel.NewMockJobManager(ctrl)
+	jobManager.EXPECT().GetTaskManager().Return(taskManager)
+	jobManager.EXPECT().GetJob(int64(1)).Return(jobCtx)
+	err := parallel.NewTaskReceiver(jobManager).Receive(&pb.TaskResponse{JobID: 1, TaskID: "taskID",
+		SendNode: "1.1.1.4:8000",
+		Stats: encoding.JSONMarshal(map[string]*models.StorageStats{
+			"1.1.1.1:9000": {TotalCost: int64(time.Second), NumOfSeries: 10},
+			"1.1.1.2:9000": {TotalCost: int64(2 * time.Second), NumOfSeries: 20},
+		})})
+	assert.NoError(t, err)
+
+	exec := &brokerExecutor{
+		ctx:         context.TODO(),
+		jobContexts: []parallel.JobContext{jobCtx},
+		startTime:   time.Now().Add(-3 * time.Second),
+	}
+	entry := newSlowQuery(exec, 3*time.Second)
+	assert.Equal(t, uint64(30), entry.numOfSeries)
+	assert.Equal(t, map[string]time.Duration{"1.1.1.1:9000": time.Second, "1.1.1.2:9000": 2 * time.Second}, entry.nodes)
+}
@@ -1,10 +1,20 @@
 package query
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
 
 	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/interval"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
@@ -12,13 +22,16 @@ import (
 )
 
 // storageExecutor represents execution search logic in storage level,
-// does query task async, then merge result, such as map-reduce job.
+// does query task async, then merge result, such as map-reduce job,
+// the shards and data families are searched concurrently by the scan pool,
+// the aggregated results of families are merged by shard in time order.
 // 1) Filtering
 // 2) Scanning
 // 3) Grouping if need
 // 4) down sampling
 // 5) Sample aggregation
 type storageExecutor struct {
+	ctx      context.Context // canceled if the query is canceled by parent node
 	engine   tsdb.Engine
 	query    *stmt.Query
 	shardIDs []int32
@@ -27,29 +40,50 @@ type storageExecutor struct {
 
 	metricID uint32
 
-	fieldIDs      []uint16
-	aggregations  map[uint16]*aggregation.AggregatorSpec
-	intervalRatio int
-	interval      int64
+	fieldIDs        []uint16
+	aggregations    map[uint16]*aggregation.AggregatorSpec
+	intervalRatio   int
+	interval        int64   // interval of query, the time slot of result is based on it
+	storageInterval int64   // interval of storage engine, the time slot of data family is based on it
+	slotInterval    int64   // interval of aggregation slot, min(interval, storage interval)
+	buckets         []int64 // start time of calendar buckets, nil if the interval has fixed length
+	calc            interval.Calculator
 
 	resultCh chan series.GroupedIterator
 
+	scanPool *ScanPool // nil if scans sequentially
+
+	limiter       *queryLimiter
+	scannedSeries *roaring.Bitmap // series ids scanned if query without tag filter, for limiting num. of series
+	mutex         sync.Mutex      // guards the scanned series when scanning concurrently
+
+	stats *models.StorageStats
+	trace *models.Trace // records the spans of execution, nil if the query isn't traced
+
 	err error
 }
 
-// newStorageExecutor creates the execution which queries the data of storage engine
-func newStorageExecutor(engine tsdb.Engine, shardIDs []int32, query *stmt.Query) parallel.Executor {
-	interval := query.Interval
-	if interval <= 0 {
-		//TODO use storage interval
-		interval = 10 * timeutil.OneSecond
+// newStorageExecutor creates the execution which queries the data of storage engine under the query limit,
+// stops searching if the context is canceled, scans sequentially if the scan pool is nil
+func newStorageExecutor(ctx context.Context, engine tsdb.Engine, shardIDs []int32, query *stmt.Query,
+	limit option.QueryLimit, scanPool *ScanPool) parallel.StorageExecutor {
+	queryInterval := query.Interval
+	if queryInterval <= 0 {
+		// same as the default interval of broker, the time slots of result are based on it
+		queryInterval = 10 * timeutil.OneSecond
 	}
-	return &storageExecutor{
+	exec := &storageExecutor{
+		ctx:      ctx,
 		engine:   engine,
 		shardIDs: shardIDs,
 		query:    query,
-		interval: interval,
+		interval: queryInterval,
+		scanPool: scanPool,
+		limiter:  newQueryLimiter(limit, time.Now()),
+		stats:    models.NewStorageStats(),
+		trace:    models.TraceFromContext(ctx),
 	}
+	return exec
 }
 
 // Execute executes search logic in storage level,
@@ -58,11 +92,17 @@ func newStorageExecutor(engine tsdb.Engine, shardIDs []int32, query *stmt.Query)
 // 3) build execute pipeline
 // 4) run pipeline
 func (e *storageExecutor) Execute() <-chan series.GroupedIterator {
+	startTime := time.Now()
 	// do query validation
 	if err := e.validation(); err != nil {
 		e.err = err
 		return nil
 	}
+	// fail fast if time range exceeds the limit
+	if err := e.limiter.checkTimeRange(e.query.TimeRange); err != nil {
+		e.err = err
+		return nil
+	}
 
 	// get shard by given query shard id list
 	for _, shardID := range e.shardIDs {
@@ -78,30 +118,48 @@ func (e *storageExecutor) Execute() <-chan series.GroupedIterator {
 		e.err = err
 		return nil
 	}
+	if err := e.initInterval(); err != nil {
+		e.err = err
+		return nil
+	}
 
-	//TODO need modify
-	e.intervalRatio = timeutil.CalIntervalRatio(100, 100)
-
+	planStartTime := time.Now()
+	planSpan := e.trace.StartSpan("plan")
 	plan := newStorageExecutePlan(e.engine.GetIDGetter(), e.query)
-	if err := plan.Plan(); err != nil {
+	err := plan.Plan()
+	planSpan.Finish()
+	if err != nil {
 		e.err = err
 		return nil
 	}
+	e.stats.PlanCost = time.Since(planStartTime).Nanoseconds()
 	storageExecutePlan, ok := plan.(*storageExecutePlan)
 	if !ok {
 		e.err = fmt.Errorf("cannot get storage execute plan")
 		return nil
 	}
 
-	//TODO set size
-	e.resultCh = make(chan series.GroupedIterator, 10)
-
+	e.metricID = storageExecutePlan.metricID
 	e.fieldIDs = storageExecutePlan.getFieldIDs()
 	e.aggregations = storageExecutePlan.fields
 
-	for _, shard := range e.shards {
-		e.shardLevelSearch(shard)
+	searches := make([]*shardSearch, len(e.shards))
+	for idx, shard := range e.shards {
+		searches[idx] = e.newShardSearch(e.shardIDs[idx], shard)
+	}
+	results, err := e.shardLevelSearch(searches)
+	e.stats.TotalCost = time.Since(startTime).Nanoseconds()
+	e.stats.NumOfSeries = e.limiter.getNumOfSeries()
+	if err != nil {
+		e.err = err
+		return nil
 	}
+	// the search is done, so the result channel is sized to hold all the results
+	e.resultCh = make(chan series.GroupedIterator, len(results))
+	for _, result := range results {
+		e.resultCh <- result
+	}
+	close(e.resultCh)
 	return e.resultCh
 }
 
@@ -110,61 +168,372 @@ func (e *storageExecutor) Error() error {
 	return e.err
 }
 
-// shardLevelSearch searches data from shard
-func (e *storageExecutor) shardLevelSearch(shard tsdb.Shard) {
-	condition := e.query.Condition
-	metricID := e.metricID
-	var seriesIDSet *series.MultiVerSeriesIDSet
-	if condition != nil {
-		seriesSearch := newSeriesSearch(metricID, shard.GetSeriesIDsFilter(), e.query)
-		idSet, err := seriesSearch.Search()
-		if err != nil {
-			//TODO
-			return
+// Statistics returns the execution statistics of storage node, the shard level stats only for explain query
+func (e *storageExecutor) Statistics() *models.StorageStats {
+	return e.stats
+}
+
+// initInterval initializes the intervals of query and storage, the storage interval is from the engine option
+func (e *storageExecutor) initInterval() error {
+	storageInterval, err := timeutil.ParseInterval(e.engine.GetOption().Interval)
+	if err != nil {
+		return fmt.Errorf("parse interval of tsdb engine[%s] error:%s", e.engine.Name(), err)
+	}
+	calc, err := interval.GetCalculator(interval.CalcIntervalType(storageInterval))
+	if err != nil {
+		return err
+	}
+	e.storageInterval = storageInterval
+	e.calc = calc
+	e.intervalRatio = timeutil.CalIntervalRatio(e.interval, storageInterval)
+	e.slotInterval = e.interval
+	if storageInterval < e.interval {
+		e.slotInterval = storageInterval
+	}
+	// the slots of storage interval are aggregated into the calendar buckets which have no fixed length
+	if calendar, ok := e.query.CalendarInterval(); ok {
+		e.buckets = calendar.Buckets(e.query.TimeRange.Start, e.query.TimeRange.End)
+		e.slotInterval = storageInterval
+		e.intervalRatio = 1
+	}
+	return nil
+}
+
+// shardSearch represents the search of a shard, filters the series of shard, then scans the data families
+type shardSearch struct {
+	shardID  int32
+	shard    tsdb.Shard
+	span     *models.Span       // nil if the query isn't traced
+	stats    *models.ShardStats // nil if not explain query
+	families []*familyScan      // order by family time
+}
+
+// familyScan represents the scan of a data family, the stats of family are merged into the shard stats
+type familyScan struct {
+	scanner     series.DataFamilyScanner
+	familyTime  int64
+	seriesIDSet *series.MultiVerSeriesIDSet // nil if query without tag filter
+	stats       *models.ShardStats          // nil if not explain query
+	result      *familyResult               // nil if no data scanned
+}
+
+// newShardSearch creates the search of shard, creates the shard stats if explain query
+func (e *storageExecutor) newShardSearch(shardID int32, shard tsdb.Shard) *shardSearch {
+	search := &shardSearch{
+		shardID: shardID,
+		shard:   shard,
+		span:    e.trace.StartSpan("shard search"),
+	}
+	search.span.SetTag("shard", strconv.Itoa(int(shardID)))
+	if e.query.Explain {
+		search.stats = &models.ShardStats{}
+		e.stats.Shards[shardID] = search.stats
+	}
+	return search
+}
+
+// shardLevelSearch searches data from shards by the scan pool, stops if the query is canceled or any task fails,
+// 1) filters the series of each shard concurrently
+// 2) scans the data families of all shards concurrently
+// 3) merges the stats of data families in order, returns the first error in order of shards and families
+// 4) merges the results of data families by shard in time order, returns the merged series of shards
+func (e *storageExecutor) shardLevelSearch(searches []*shardSearch) ([]series.GroupedIterator, error) {
+	defer func() {
+		for _, search := range searches {
+			search.span.Finish()
+		}
+	}()
+	filterTasks := newScanTasks(e.ctx)
+	for _, search := range searches {
+		search := search
+		filterTasks.add(func() error {
+			return e.filterSeries(search)
+		})
+	}
+	if err := filterTasks.execute(e.scanPool); err != nil {
+		return nil, err
+	}
+
+	scanTasks := newScanTasks(e.ctx)
+	for _, search := range searches {
+		search := search
+		for _, family := range search.families {
+			family := family
+			scanTasks.add(func() error {
+				return e.familyLevelSearch(search.span, family)
+			})
 		}
-		if idSet == nil || idSet.IsEmpty() {
-			return
+	}
+	err := scanTasks.execute(e.scanPool)
+	for _, search := range searches {
+		search.mergeFamilyStats()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []series.GroupedIterator
+	for _, search := range searches {
+		its, err := e.mergeFamilyResults(search)
+		if err != nil {
+			return nil, err
 		}
-		seriesIDSet = idSet
+		results = append(results, its...)
 	}
-	//TODO need group by
+	return results, nil
+}
+
+// filterSeries filters the series ids of shard by tag filter, collects the data families of shard to scan,
+// the data families on disk are filtered by the series index of shard,
+// the data families in memory are filtered by the memory database which has its own series ids,
+// returns error if exceeds the query limit
+func (e *storageExecutor) filterSeries(search *shardSearch) error {
 	timeRange := e.query.TimeRange
-	segments := shard.GetSegments(e.query.IntervalType, timeRange)
-	for _, segment := range segments {
-		families := segment.GetDataFamilyScanners(timeRange)
-		for _, family := range families {
-			e.familyLevelSearch(family, seriesIDSet)
+	idSet, matched, err := e.searchSeries(search, search.shard.GetSeriesIDsFilter())
+	if err != nil {
+		return err
+	}
+	if matched {
+		segments := search.shard.GetSegments(e.query.IntervalType, timeRange)
+		for _, segment := range segments {
+			for _, family := range segment.GetDataFamilies(timeRange) {
+				e.addFamilyScan(search, family, family.BaseTime(), idSet)
+			}
+		}
+	}
+	if memDB := search.shard.GetMemoryDatabase(); memDB != nil {
+		idSet, matched, err := e.searchSeries(search, memDB)
+		if err != nil {
+			return err
+		}
+		if matched {
+			for _, familyTime := range memDB.Families() {
+				familyTimeRange := e.familyTimeRange(familyTime)
+				if familyTimeRange.Overlap(&timeRange) {
+					e.addFamilyScan(search, memDB, familyTime, idSet)
+				}
+			}
+		}
+	}
+	// merges the results of families in time order
+	sort.SliceStable(search.families, func(i, j int) bool {
+		return search.families[i].familyTime < search.families[j].familyTime
+	})
+	return nil
+}
+
+// searchSeries searches the series ids by tag filter, returns nil series ids if query without tag filter,
+// returns false if no series matched, returns error if exceeds the query limit
+func (e *storageExecutor) searchSeries(search *shardSearch,
+	filter series.Filter) (idSet *series.MultiVerSeriesIDSet, matched bool, err error) {
+	if e.query.Condition == nil {
+		return nil, true, nil
+	}
+	shardStats := search.stats
+	filterStartTime := time.Now()
+	seriesSearch := newSeriesSearch(e.metricID, filter, e.query)
+	idSet, err = seriesSearch.Search()
+	if shardStats != nil {
+		shardStats.SeriesFilterCost += time.Since(filterStartTime).Nanoseconds()
+	}
+	if err != nil {
+		//TODO
+		return nil, false, nil
+	}
+	if idSet == nil || idSet.IsEmpty() {
+		return nil, false, nil
+	}
+	numOfSeries := idSet.Cardinality()
+	if shardStats != nil {
+		shardStats.NumOfSeries += numOfSeries
+	}
+	if err := e.limiter.addSeries(numOfSeries); err != nil {
+		return nil, false, err
+	}
+	return idSet, true, nil
+}
+
+// addFamilyScan adds the scan of data family into the shard search
+func (e *storageExecutor) addFamilyScan(search *shardSearch, scanner series.DataFamilyScanner, familyTime int64,
+	seriesIDSet *series.MultiVerSeriesIDSet) {
+	family := &familyScan{scanner: scanner, familyTime: familyTime, seriesIDSet: seriesIDSet}
+	if search.stats != nil {
+		family.stats = &models.ShardStats{}
+	}
+	search.families = append(search.families, family)
+}
+
+// familyTimeRange returns the time range of data family, the end time is the start time of next family - 1
+func (e *storageExecutor) familyTimeRange(familyTime int64) timeutil.TimeRange {
+	segmentTime := e.calc.CalcSegmentTime(familyTime)
+	nextFamilyTime := e.calc.CalcFamilyStartTime(segmentTime, e.calc.CalcFamily(familyTime, segmentTime)+1)
+	return timeutil.TimeRange{Start: familyTime, End: nextFamilyTime - 1}
+}
+
+// mergeFamilyStats merges the stats of data families into the shard stats in order of families,
+// the scan cost of shard is the sum of families' scan cost
+func (s *shardSearch) mergeFamilyStats() {
+	if s.stats == nil {
+		return
+	}
+	for _, family := range s.families {
+		s.stats.NumOfFamilies += family.stats.NumOfFamilies
+		s.stats.NumOfPoints += family.stats.NumOfPoints
+		s.stats.ScanCost += family.stats.ScanCost
+	}
+}
+
+// mergeFamilyResults merges the aggregated results of data families under the shard in time order,
+// the results of families are released after merging, returns error if exceeds the query limit
+func (e *storageExecutor) mergeFamilyResults(search *shardSearch) ([]series.GroupedIterator, error) {
+	pointCount := int((e.query.TimeRange.End-e.query.TimeRange.Start)/e.interval) + 1
+	if e.buckets != nil {
+		pointCount = len(e.buckets)
+	}
+	result := newShardResult(pointCount)
+	for _, family := range search.families {
+		if family.result == nil {
+			continue
+		}
+		allocated := result.merge(family.result)
+		e.limiter.releaseAggPoints(family.result.numOfPoints)
+		family.result = nil
+		if err := e.limiter.addAggPoints(allocated); err != nil {
+			return nil, err
 		}
 	}
+	return result.iterators(), nil
 }
 
-// familyLevelSearch searches data from data family, do down sampling and aggregation
-func (e *storageExecutor) familyLevelSearch(scanner series.DataFamilyScanner, seriesIDSet *series.MultiVerSeriesIDSet) {
-	scanItr := scanner.Scan(
+// familyLevelSearch searches data from data family, do down sampling and aggregation by group,
+// collects the scan statistics of family if stats not nil, records the span under the shard span if traced,
+// the aggregators only cover the time range of family which overlaps with the query,
+// returns error if exceeds the query limit.
+func (e *storageExecutor) familyLevelSearch(shardSpan *models.Span, family *familyScan) error {
+	span := shardSpan.StartChild("family scan")
+	defer span.Finish()
+
+	shardStats := family.stats
+	if shardStats != nil {
+		shardStats.NumOfFamilies++
+		scanStartTime := time.Now()
+		defer func() {
+			shardStats.ScanCost += time.Since(scanStartTime).Nanoseconds()
+		}()
+	}
+	queryTimeRange := e.query.TimeRange
+	familyTimeRange := e.familyTimeRange(family.familyTime)
+	if !familyTimeRange.Overlap(&queryTimeRange) {
+		return nil
+	}
+	timeRange := *familyTimeRange.Intersect(&queryTimeRange)
+	// the start slot is aligned with the interval ratio, so the slots of a query interval are aggregated together
+	startSlot := int((timeRange.Start - queryTimeRange.Start) / e.slotInterval)
+	startSlot -= startSlot % e.intervalRatio
+	endSlot := int((timeRange.End - queryTimeRange.Start) / e.slotInterval)
+	converter := slotConverter{
+		familyTime:      family.familyTime,
+		storageInterval: e.storageInterval,
+		queryStart:      queryTimeRange.Start,
+		queryEnd:        queryTimeRange.End,
+		slotInterval:    e.slotInterval,
+	}
+
+	scanItr := family.scanner.Scan(
 		series.ScanContext{
 			MetricID:    e.metricID,
 			FieldIDs:    e.fieldIDs,
-			TimeRange:   e.query.TimeRange,
-			SeriesIDSet: seriesIDSet,
+			TimeRange:   timeRange,
+			SeriesIDSet: family.seriesIDSet,
+			GroupBy:     e.query.GroupBy,
+			FamilyTime:  family.familyTime,
 		})
 
 	if scanItr == nil {
-		return
+		return nil
 	}
 	defer scanItr.Close()
+	// the slots of a query interval are aggregated into one point by the interval ratio,
+	// so the aggregator only allocates the points of query interval, or the calendar buckets overlap with family
+	startPoint := startSlot / e.intervalRatio
+	numOfPoints := (endSlot-startSlot)/e.intervalRatio + 1
+	if e.buckets != nil {
+		startPoint = timeutil.BucketIndex(e.buckets, timeRange.Start)
+		numOfPoints = timeutil.BucketIndex(e.buckets, timeRange.End) - startPoint + 1
+	}
+	result := newFamilyResult(startPoint)
+	family.result = result
 	for scanItr.HasNext() {
 		timeSeries := scanItr.Next()
 		if timeSeries == nil {
 			break
 		}
+		// counts the series when scanning if query without tag filter
+		if family.seriesIDSet == nil {
+			if err := e.addScannedSeries(timeSeries.SeriesID()); err != nil {
+				return err
+			}
+		}
+		var tags map[string]string
+		if groupedSeries, ok := timeSeries.(series.GroupedIterator); ok {
+			tags = groupedSeries.Tags()
+		} else if len(e.query.GroupBy) > 0 {
+			return fmt.Errorf("group by isn't supported by data family")
+		}
+		group := result.getGroup(tags)
 		for timeSeries.HasNext() {
-			it := timeSeries.Next()
-			//TODO use family time range
-			agg := aggregation.NewFieldAggregator(1, e.interval, e.query.TimeRange.Start,
-				e.query.TimeRange.End, e.intervalRatio, e.aggregations[it.FieldID()])
-			agg.Aggregate(it)
+			fieldIt := timeSeries.Next()
+			if fieldIt == nil {
+				continue
+			}
+			fieldID := fieldIt.FieldID()
+			aggSpec, ok := e.aggregations[fieldID]
+			if !ok {
+				continue
+			}
+			agg, ok := group.aggregators[fieldID]
+			if !ok {
+				if e.buckets != nil {
+					agg = aggregation.NewCalendarFieldAggregator(queryTimeRange.Start, e.slotInterval,
+						startPoint, numOfPoints, e.buckets, aggSpec)
+				} else {
+					agg = aggregation.NewFieldAggregator(queryTimeRange.Start, e.slotInterval,
+						int64(startSlot), int64(startSlot+numOfPoints), e.intervalRatio, aggSpec)
+				}
+				result.numOfPoints += numOfPoints
+				if err := e.limiter.addAggPoints(numOfPoints); err != nil {
+					return err
+				}
+				group.aggregators[fieldID] = agg
+				group.fieldIDs = append(group.fieldIDs, fieldID)
+			}
+			var it series.FieldIterator = &limitFieldIterator{FieldIterator: fieldIt, limiter: e.limiter}
+			if shardStats != nil {
+				it = &statsFieldIterator{FieldIterator: it, shardStats: shardStats}
+			}
+			agg.Aggregate(&slotFieldIterator{FieldIterator: it, converter: converter})
+		}
+		if err := e.limiter.check(); err != nil {
+			return err
+		}
+		if e.ctx.Err() != nil {
+			return errQueryCanceled
 		}
 	}
+	return nil
+}
+
+// addScannedSeries adds the scanned series id, checks the num. of distinct series against the query limit
+func (e *storageExecutor) addScannedSeries(seriesID uint32) error {
+	e.mutex.Lock()
+	if e.scannedSeries == nil {
+		e.scannedSeries = roaring.New()
+	}
+	added := e.scannedSeries.CheckedAdd(seriesID)
+	e.mutex.Unlock()
+	if !added {
+		return nil
+	}
+	return e.limiter.addSeries(1)
 }
 
 // validation validates query input params are valid
@@ -196,3 +565,30 @@ func (e *storageExecutor) checkShards() error {
 	}
 	return nil
 }
+
+// statsFieldIterator wraps the field iterator, counts the num. of data points decoded for explain query
+type statsFieldIterator struct {
+	series.FieldIterator
+	shardStats *models.ShardStats
+}
+
+// Next returns the primitive field iterator which counts the data points
+func (it *statsFieldIterator) Next() series.PrimitiveIterator {
+	primitiveIt := it.FieldIterator.Next()
+	if primitiveIt == nil {
+		return nil
+	}
+	return &statsPrimitiveIterator{PrimitiveIterator: primitiveIt, shardStats: it.shardStats}
+}
+
+// statsPrimitiveIterator wraps the primitive iterator, counts the num. of data points decoded
+type statsPrimitiveIterator struct {
+	series.PrimitiveIterator
+	shardStats *models.ShardStats
+}
+
+// Next returns the data point in the iteration, and increases the num. of points
+func (it *statsPrimitiveIterator) Next() (timeSlot int, value float64) {
+	it.shardStats.NumOfPoints++
+	return it.PrimitiveIterator.Next()
+}
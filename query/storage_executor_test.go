@@ -1,11 +1,15 @@
 package query
 
 import (
+	"context"
+	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
@@ -22,32 +26,32 @@ func TestStorageExecute_validation(t *testing.T) {
 	query := &stmt.Query{Interval: timeutil.OneSecond}
 
 	// query shards is empty
-	exec := newStorageExecutor(engine, nil, query)
+	exec := newStorageExecutor(context.TODO(), engine, nil, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.NotNil(t, exec.Error())
 
 	// shards of engine is empty
 	engine.EXPECT().NumOfShards().Return(0)
-	exec = newStorageExecutor(engine, []int32{1, 2, 3}, query)
+	exec = newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.NotNil(t, exec.Error())
 
 	// num. of shard not match
 	engine.EXPECT().NumOfShards().Return(2)
-	exec = newStorageExecutor(engine, []int32{1, 2, 3}, query)
+	exec = newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.NotNil(t, exec.Error())
 
 	engine.EXPECT().NumOfShards().Return(3).AnyTimes()
 	engine.EXPECT().GetShard(gomock.Any()).Return(nil).MaxTimes(3)
-	exec = newStorageExecutor(engine, []int32{1, 2, 3}, query)
+	exec = newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.NotNil(t, exec.Error())
 
 	// normal case
 	query, _ = sql.Parse("select f from cpu")
 	engine1 := MockTSDBEngine(ctrl)
-	exec = newStorageExecutor(engine1, []int32{1, 2, 3}, query)
+	exec = newStorageExecutor(context.TODO(), engine1, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.Nil(t, exec.Error())
 }
@@ -83,15 +87,19 @@ func TestStorageExecute_Simple(t *testing.T) {
 
 	// normal case
 	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
-	exec := newStorageExecutor(engine, []int32{1, 2, 3}, query)
+	exec := newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
 	_ = exec.Execute()
 	assert.Nil(t, exec.Error())
+	// collects the summary stats, no shard level stats if not explain query
+	stats := exec.Statistics()
+	assert.Equal(t, uint64(1), stats.NumOfSeries)
+	assert.Empty(t, stats.Shards)
 
 	execImpl := exec.(*storageExecutor)
 	// mock scanner return nil
 	mockScanner1 := series.NewMockDataFamilyScanner(ctrl)
 	mockScanner1.EXPECT().Scan(gomock.Any()).Return(nil).Times(1)
-	execImpl.familyLevelSearch(mockScanner1, nil)
+	assert.NoError(t, execImpl.familyLevelSearch(nil, &familyScan{scanner: mockScanner1, familyTime: mockFamilyTime}))
 	// mock scanner return iterator with nil ts
 	mockScanner2 := series.NewMockDataFamilyScanner(ctrl)
 	mockItr := series.NewMockVersionIterator(ctrl)
@@ -99,8 +107,291 @@ func TestStorageExecute_Simple(t *testing.T) {
 	mockItr.EXPECT().HasNext().Return(true)
 	mockItr.EXPECT().Next().Return(nil)
 	mockScanner2.EXPECT().Scan(gomock.Any()).Return(mockItr)
-	execImpl.familyLevelSearch(mockScanner2, nil)
+	assert.NoError(t, execImpl.familyLevelSearch(nil, &familyScan{scanner: mockScanner2, familyTime: mockFamilyTime}))
 	// check shards error
 	execImpl.shardIDs = nil
 	assert.NotNil(t, execImpl.checkShards())
 }
+
+func TestStorageExecute_Explain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var scanners []series.DataFamilyScanner
+	for i := 0; i < 3; i++ {
+		seriesData := MockSumFieldSeries(ctrl, 10, 1, map[int]interface{}{
+			5:  5.5,
+			15: 5.5,
+		})
+		itr := series.NewMockVersionIterator(ctrl)
+		itr.EXPECT().Close()
+		itr.EXPECT().HasNext().Return(true)
+		itr.EXPECT().Next().Return(seriesData)
+		itr.EXPECT().HasNext().Return(false)
+
+		scanner := series.NewMockDataFamilyScanner(ctrl)
+		scanner.EXPECT().Scan(gomock.Any()).Return(itr)
+		scanners = append(scanners, scanner)
+	}
+
+	engine := MockTSDBEngine(ctrl, scanners...)
+	query, _ := sql.Parse("explain select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+	exec := newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
+	results := exec.Execute()
+	for range results {
+	}
+	assert.Nil(t, exec.Error())
+	stats := exec.Statistics()
+	assert.NotNil(t, stats)
+	assert.Len(t, stats.Shards, 3)
+	for _, shardStats := range stats.Shards {
+		assert.Equal(t, 1, shardStats.NumOfFamilies)
+		assert.Equal(t, 2, shardStats.NumOfPoints)
+	}
+}
+
+func TestStorageExecute_ScanPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var scanners []series.DataFamilyScanner
+	for i := 0; i < 3; i++ {
+		seriesData := MockSumFieldSeries(ctrl, 10, 1, map[int]interface{}{
+			5:  5.5,
+			15: 5.5,
+		})
+		itr := series.NewMockVersionIterator(ctrl)
+		itr.EXPECT().Close()
+		itr.EXPECT().HasNext().Return(true)
+		itr.EXPECT().Next().Return(&seriesWithID{Iterator: seriesData, seriesID: uint32(i)})
+		itr.EXPECT().HasNext().Return(false)
+
+		scanner := series.NewMockDataFamilyScanner(ctrl)
+		scanner.EXPECT().Scan(gomock.Any()).Return(itr)
+		scanners = append(scanners, scanner)
+	}
+
+	// scans the shards and data families concurrently, merges the stats of families
+	engine := MockTSDBEngine(ctrl, scanners...)
+	query, _ := sql.Parse("explain select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+	exec := newStorageExecutor(context.TODO(), engine, []int32{1, 2, 3}, query, option.QueryLimit{},
+		NewScanPool(2))
+	results := exec.Execute()
+	for range results {
+	}
+	assert.Nil(t, exec.Error())
+	stats := exec.Statistics()
+	assert.Equal(t, uint64(3), stats.NumOfSeries)
+	assert.Len(t, stats.Shards, 3)
+	for _, shardStats := range stats.Shards {
+		assert.Equal(t, 1, shardStats.NumOfFamilies)
+		assert.Equal(t, 2, shardStats.NumOfPoints)
+		assert.True(t, shardStats.ScanCost > 0)
+	}
+}
+
+func TestStorageExecute_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var scanners []series.DataFamilyScanner
+	for i := 0; i < 3; i++ {
+		seriesData := MockSumFieldSeries(ctrl, 10, 1, map[int]interface{}{
+			5: 5.5,
+		})
+		itr := series.NewMockVersionIterator(ctrl)
+		itr.EXPECT().Close()
+		itr.EXPECT().HasNext().Return(true)
+		itr.EXPECT().Next().Return(seriesData)
+		itr.EXPECT().HasNext().Return(false)
+
+		scanner := series.NewMockDataFamilyScanner(ctrl)
+		scanner.EXPECT().Scan(gomock.Any()).Return(itr)
+		scanners = append(scanners, scanner)
+	}
+
+	engine := MockTSDBEngine(ctrl, scanners...)
+	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+	trace := models.NewTrace("leaf task")
+	exec := newStorageExecutor(models.WithTrace(context.TODO(), trace), engine, []int32{1, 2, 3}, query,
+		option.QueryLimit{}, nil)
+	results := exec.Execute()
+	for range results {
+	}
+	assert.Nil(t, exec.Error())
+	// plan span + a span for each shard
+	spans := trace.Root.Children
+	assert.Len(t, spans, 4)
+	assert.Equal(t, "plan", spans[0].Name)
+	for idx, shardSpan := range spans[1:] {
+		assert.Equal(t, "shard search", shardSpan.Name)
+		assert.Equal(t, map[string]string{"shard": strconv.Itoa(idx + 1)}, shardSpan.Tags)
+		assert.True(t, shardSpan.Duration > 0)
+		assert.Len(t, shardSpan.Children, 1)
+		assert.Equal(t, "family scan", shardSpan.Children[0].Name)
+	}
+}
+
+func TestStorageExecute_Limit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScanner := func(seriesID uint32, timeSeries series.Iterator) series.DataFamilyScanner {
+		itr := series.NewMockVersionIterator(ctrl)
+		itr.EXPECT().Close()
+		itr.EXPECT().HasNext().Return(true)
+		itr.EXPECT().Next().Return(&seriesWithID{Iterator: timeSeries, seriesID: seriesID})
+		itr.EXPECT().HasNext().Return(false).MaxTimes(1)
+		scanner := series.NewMockDataFamilyScanner(ctrl)
+		scanner.EXPECT().Scan(gomock.Any()).Return(itr)
+		return scanner
+	}
+	mockSeries := func() series.Iterator {
+		return MockSumFieldSeries(ctrl, 10, 1, map[int]interface{}{
+			5:  5.5,
+			15: 5.5,
+			17: 5.5,
+			16: 5.5,
+			56: 5.5,
+		})
+	}
+	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+
+	// time range exceeds limit
+	exec := newStorageExecutor(context.TODO(), MockTSDBEngine(ctrl), []int32{1, 2, 3}, query,
+		option.QueryLimit{MaxTimeRange: "10m"}, nil)
+	assert.Nil(t, exec.Execute())
+	assert.Error(t, exec.Error())
+
+	// num. of series exceeds limit, the series of last shard exceeds,
+	// the series of last shard is not scanned
+	exec = newStorageExecutor(context.TODO(), MockTSDBEngine(ctrl, mockScanner(1, mockSeries()), mockScanner(2, mockSeries()),
+		mockScanner(3, series.NewMockIterator(ctrl))), []int32{1, 2, 3}, query,
+		option.QueryLimit{MaxSeries: 2}, nil)
+	assert.Nil(t, exec.Execute())
+	assert.EqualError(t, exec.Error(), "num. of series matched by query[3] exceeds the limit[2], "+
+		"please narrow the tag filter or group by")
+
+	// num. of points exceeds limit, the points of last shard exceeds
+	hourQuery, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00' group by time(1h)")
+	exec = newStorageExecutor(context.TODO(), MockTSDBEngine(ctrl, mockScanner(1, mockSeries()), mockScanner(2, mockSeries()),
+		mockScanner(3, mockSeries())), []int32{1, 2, 3}, hourQuery,
+		option.QueryLimit{MaxPoints: 14}, nil)
+	assert.Nil(t, exec.Execute())
+	assert.EqualError(t, exec.Error(), "num. of points decoded by query exceeds the limit[14], "+
+		"please narrow the time range or tag filter")
+
+	// num. of points aggregated in memory exceeds limit, fails before scanning the data points
+	scanner := series.NewMockDataFamilyScanner(ctrl)
+	itr := series.NewMockVersionIterator(ctrl)
+	scanner.EXPECT().Scan(gomock.Any()).Return(itr)
+	itr.EXPECT().Close()
+	itr.EXPECT().HasNext().Return(true)
+	timeSeries := series.NewMockIterator(ctrl)
+	itr.EXPECT().Next().Return(timeSeries)
+	timeSeries.EXPECT().SeriesID().Return(uint32(1))
+	timeSeries.EXPECT().HasNext().Return(true)
+	fieldIt := series.NewMockFieldIterator(ctrl)
+	timeSeries.EXPECT().Next().Return(fieldIt)
+	fieldIt.EXPECT().FieldID().Return(uint16(10))
+	emptyScanner := series.NewMockDataFamilyScanner(ctrl)
+	emptyScanner.EXPECT().Scan(gomock.Any()).Return(nil).AnyTimes()
+	exec = newStorageExecutor(context.TODO(), MockTSDBEngine(ctrl, scanner, emptyScanner, emptyScanner), []int32{1, 2, 3}, query,
+		option.QueryLimit{MaxPoints: 100}, nil)
+	assert.Nil(t, exec.Execute())
+	assert.EqualError(t, exec.Error(), "num. of points aggregated by query exceeds the limit[100], "+
+		"please narrow the time range or group by")
+}
+
+// seriesWithID wraps the series iterator with the given series id
+type seriesWithID struct {
+	series.Iterator
+	seriesID uint32
+}
+
+func (s *seriesWithID) SeriesID() uint32 {
+	return s.seriesID
+}
+
+func TestStorageExecute_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00'")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exec := newStorageExecutor(ctx, MockTSDBEngine(ctrl), []int32{1, 2, 3}, query, option.QueryLimit{}, nil)
+	assert.Nil(t, exec.Execute())
+	assert.Equal(t, errQueryCanceled, exec.Error())
+}
+
+func TestStorageExecute_MemoryDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine, cancel := MockMemoryDatabaseEngine(t, ctrl)
+	defer cancel()
+
+	// scans the family in memory, aggregates the series by group and query interval
+	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00' " +
+		"group by zone, time(1m)")
+	exec := newStorageExecutor(context.TODO(), engine, []int32{1}, query, option.QueryLimit{}, nil)
+	results := exec.Execute()
+	assert.NoError(t, exec.Error())
+	values := make(map[string]map[int]float64)
+	for result := range results {
+		points := make(map[int]float64)
+		for result.HasNext() {
+			fieldIt := result.Next()
+			assert.Equal(t, uint16(10), fieldIt.FieldID())
+			assert.Equal(t, "f", fieldIt.FieldName())
+			for fieldIt.HasNext() {
+				primitiveIt := fieldIt.Next()
+				for primitiveIt.HasNext() {
+					slot, value := primitiveIt.Next()
+					points[slot] = value
+				}
+			}
+		}
+		values[result.Tags()["zone"]] = points
+	}
+	assert.Equal(t, map[string]map[int]float64{
+		"sh": {0: 3},
+		"bj": {1: 7},
+	}, values)
+	assert.Equal(t, uint64(3), exec.Statistics().NumOfSeries)
+}
+
+func TestStorageExecute_CalendarInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	engine, cancel := MockMemoryDatabaseEngine(t, ctrl)
+	defer cancel()
+
+	// the start time is truncated to the midnight of UTC, the slots of storage are aggregated into the day bucket
+	query, _ := sql.Parse("select f from cpu where time>'20190729 11:00:00' and time<'20190729 12:00:00' " +
+		"group by zone, time(1d) tz('UTC')")
+	exec := newStorageExecutor(context.TODO(), engine, []int32{1}, query, option.QueryLimit{}, nil)
+	results := exec.Execute()
+	assert.NoError(t, exec.Error())
+	values := make(map[string]map[int]float64)
+	for result := range results {
+		points := make(map[int]float64)
+		for result.HasNext() {
+			fieldIt := result.Next()
+			for fieldIt.HasNext() {
+				primitiveIt := fieldIt.Next()
+				for primitiveIt.HasNext() {
+					slot, value := primitiveIt.Next()
+					points[slot] = value
+				}
+			}
+		}
+		values[result.Tags()["zone"]] = points
+	}
+	assert.Equal(t, map[string]map[int]float64{
+		"sh": {0: 103},
+		"bj": {0: 7},
+	}, values)
+}
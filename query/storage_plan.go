@@ -93,8 +93,13 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 	case *stmt.SelectItem:
 		p.field(nil, e.Expr)
 	case *stmt.CallExpr:
+		// the field under non-aggregate function(like topk) uses the default down sampling func
+		parent := e
+		if !function.IsAggFunc(e.FuncType) {
+			parent = nil
+		}
 		for _, param := range e.Params {
-			p.field(e, param)
+			p.field(parent, param)
 		}
 	case *stmt.ParenExpr:
 		p.field(nil, e.Expr)
@@ -123,4 +123,16 @@ func TestStoragePlan_SelectList(t *testing.T) {
 	}
 	assert.Equal(t, expect, storagePlan.fields)
 	assert.Equal(t, []uint16{uint16(11), uint16(13), uint16(14)}, storagePlan.getFieldIDs())
+
+	// the field under topk uses the default down sampling func
+	query, _ = sql.Parse("select topk(5, f) from cpu")
+	plan = newStorageExecutePlan(metadataIndex, query)
+	err = plan.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storagePlan = plan.(*storageExecutePlan)
+	downSampling = aggregation.NewAggregatorSpec(uint16(10), "f", field.SumField)
+	downSampling.AddFunctionType(function.Sum)
+	assert.Equal(t, map[uint16]*aggregation.AggregatorSpec{uint16(10): downSampling}, storagePlan.fields)
 }
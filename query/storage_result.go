@@ -0,0 +1,312 @@
+package query
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// familyResult represents the aggregated result of a data family, the series of family are aggregated by group tags,
+// the aggregators only cover the time slots of family, the slot of aggregator is offset by the start slot of family.
+type familyResult struct {
+	startSlot   int // the query slot of the first aggregated value
+	numOfPoints int // num. of time slots allocated by the aggregators
+	groups      map[string]*familyGroup
+	keys        []string // keeps the order of groups scanned
+}
+
+// familyGroup represents the field aggregators of a group under data family
+type familyGroup struct {
+	tags        map[string]string
+	aggregators map[uint16]aggregation.FieldAggregator
+	fieldIDs    []uint16 // keeps the order of fields scanned
+}
+
+// newFamilyResult creates the aggregated result of a data family
+func newFamilyResult(startSlot int) *familyResult {
+	return &familyResult{
+		startSlot: startSlot,
+		groups:    make(map[string]*familyGroup),
+	}
+}
+
+// getGroup returns the group by tags, creates it if not exist
+func (r *familyResult) getGroup(tags map[string]string) *familyGroup {
+	key := models.TagsAsString(tags)
+	group, ok := r.groups[key]
+	if !ok {
+		group = &familyGroup{tags: tags, aggregators: make(map[uint16]aggregation.FieldAggregator)}
+		r.groups[key] = group
+		r.keys = append(r.keys, key)
+	}
+	return group
+}
+
+// shardResult merges the aggregated results of data families under a shard in time order,
+// the values of same group, field, primitive field and time slot are aggregated by the agg func of primitive field,
+// like sum/min/max/count, the first value is kept and the last value is replaced because of the time order.
+type shardResult struct {
+	pointCount int // num. of time slots of query
+	groups     map[string]*mergedSeries
+	keys       []string // keeps the order of groups merged
+}
+
+// newShardResult creates the merged result of shard with the num. of time slots of query
+func newShardResult(pointCount int) *shardResult {
+	return &shardResult{
+		pointCount: pointCount,
+		groups:     make(map[string]*mergedSeries),
+	}
+}
+
+// merge merges the aggregated result of data family, the families must be merged in time order,
+// returns the num. of time slots allocated for the primitive fields which are not merged before.
+func (r *shardResult) merge(result *familyResult) (allocated int) {
+	for _, key := range result.keys {
+		group := result.groups[key]
+		merged, ok := r.groups[key]
+		if !ok {
+			merged = &mergedSeries{tags: group.tags, fields: make(map[uint16]*mergedFieldSeries)}
+			r.groups[key] = merged
+			r.keys = append(r.keys, key)
+		}
+		for _, fieldID := range group.fieldIDs {
+			it := group.aggregators[fieldID].Iterator()
+			f, ok := merged.fields[fieldID]
+			if !ok {
+				f = &mergedFieldSeries{id: fieldID, name: it.FieldName(), fieldType: it.FieldType(),
+					primitives: make(map[uint16]collections.FloatArray)}
+				merged.fields[fieldID] = f
+				merged.fieldIDs = append(merged.fieldIDs, fieldID)
+			}
+			allocated += f.merge(it, result.startSlot, r.pointCount)
+		}
+	}
+	return allocated
+}
+
+// iterators returns the merged series of groups, order by the group merged
+func (r *shardResult) iterators() []series.GroupedIterator {
+	its := make([]series.GroupedIterator, len(r.keys))
+	for idx, key := range r.keys {
+		its[idx] = r.groups[key]
+	}
+	return its
+}
+
+// mergedSeries implements series.GroupedIterator over the merged fields of group
+type mergedSeries struct {
+	tags     map[string]string
+	fields   map[uint16]*mergedFieldSeries
+	fieldIDs []uint16 // keeps the order of fields merged
+	idx      int
+}
+
+// Tags returns group tags
+func (s *mergedSeries) Tags() map[string]string {
+	return s.tags
+}
+
+// SeriesID returns 0, because the group is merged from multi-series
+func (s *mergedSeries) SeriesID() uint32 {
+	return 0
+}
+
+// HasNext returns if the iteration has more field's iterator
+func (s *mergedSeries) HasNext() bool {
+	return s.idx < len(s.fieldIDs)
+}
+
+// Next returns the field's iterator
+func (s *mergedSeries) Next() series.FieldIterator {
+	if s.idx >= len(s.fieldIDs) {
+		return nil
+	}
+	f := s.fields[s.fieldIDs[s.idx]]
+	s.idx++
+	return f
+}
+
+// mergedFieldSeries implements series.FieldIterator over the merged primitive fields of field,
+// the values are stored by the time slot of query.
+type mergedFieldSeries struct {
+	id           uint16
+	name         string
+	fieldType    field.Type
+	primitives   map[uint16]collections.FloatArray
+	primitiveIDs []uint16 // order by id
+	idx          int
+}
+
+// merge merges the primitive fields of family aggregator into the values of query slots,
+// returns the num. of time slots allocated for the new primitive fields.
+func (f *mergedFieldSeries) merge(it series.FieldIterator, startSlot, pointCount int) (allocated int) {
+	for it.HasNext() {
+		primitiveIt := it.Next()
+		if primitiveIt == nil {
+			continue
+		}
+		primitiveID := primitiveIt.FieldID()
+		aggFunc := mergeAggFunc(f.fieldType, primitiveID)
+		var values collections.FloatArray
+		for primitiveIt.HasNext() {
+			idx, value := primitiveIt.Next()
+			slot := startSlot + idx
+			if slot < 0 || slot >= pointCount {
+				continue
+			}
+			if values == nil {
+				values = f.primitives[primitiveID]
+				if values == nil {
+					values = collections.NewFloatArray(pointCount)
+					allocated += pointCount
+					f.addPrimitive(primitiveID, values)
+				}
+			}
+			if values.HasValue(slot) {
+				values.SetValue(slot, aggFunc.AggregateFloat(values.GetValue(slot), value))
+			} else {
+				values.SetValue(slot, value)
+			}
+		}
+	}
+	return allocated
+}
+
+// addPrimitive adds the values of primitive field, keeps the primitive fields order by id
+func (f *mergedFieldSeries) addPrimitive(primitiveID uint16, values collections.FloatArray) {
+	f.primitives[primitiveID] = values
+	f.primitiveIDs = append(f.primitiveIDs, primitiveID)
+	sort.Slice(f.primitiveIDs, func(i, j int) bool {
+		return f.primitiveIDs[i] < f.primitiveIDs[j]
+	})
+}
+
+// FieldID returns the field's id
+func (f *mergedFieldSeries) FieldID() uint16 {
+	return f.id
+}
+
+// FieldName returns the field's name
+func (f *mergedFieldSeries) FieldName() string {
+	return f.name
+}
+
+// FieldType returns the field's type
+func (f *mergedFieldSeries) FieldType() field.Type {
+	return f.fieldType
+}
+
+// HasNext returns if the iteration has more primitive fields
+func (f *mergedFieldSeries) HasNext() bool {
+	return f.idx < len(f.primitiveIDs)
+}
+
+// Next returns the primitive field iterator
+func (f *mergedFieldSeries) Next() series.PrimitiveIterator {
+	if f.idx >= len(f.primitiveIDs) {
+		return nil
+	}
+	primitiveID := f.primitiveIDs[f.idx]
+	f.idx++
+	return &arrayPrimitiveIterator{id: primitiveID, it: f.primitives[primitiveID].Iterator()}
+}
+
+// arrayPrimitiveIterator implements series.PrimitiveIterator over the values of query slots
+type arrayPrimitiveIterator struct {
+	id uint16
+	it collections.FloatArrayIterator
+}
+
+// FieldID returns the primitive field id
+func (it *arrayPrimitiveIterator) FieldID() uint16 {
+	return it.id
+}
+
+// HasNext returns if the iteration has more data points
+func (it *arrayPrimitiveIterator) HasNext() bool {
+	return it.it.HasNext()
+}
+
+// Next returns the time slot of query and the value
+func (it *arrayPrimitiveIterator) Next() (timeSlot int, value float64) {
+	return it.it.Next()
+}
+
+// mergeAggFunc returns the agg func for merging the values of primitive field at same time slot,
+// aggregates by sum if the agg type of primitive field not found.
+func mergeAggFunc(fieldType field.Type, primitiveID uint16) field.AggFunc {
+	aggType, ok := field.GetAggType(fieldType, primitiveID)
+	if !ok {
+		aggType = field.Sum
+	}
+	return field.GetAggFunc(aggType)
+}
+
+// slotFieldIterator wraps the field iterator of data family,
+// converts the time slots of family into the aggregation slots of query.
+type slotFieldIterator struct {
+	series.FieldIterator
+	converter slotConverter
+}
+
+// Next returns the primitive field iterator which converts the time slots
+func (it *slotFieldIterator) Next() series.PrimitiveIterator {
+	primitiveIt := it.FieldIterator.Next()
+	if primitiveIt == nil {
+		return nil
+	}
+	return &slotPrimitiveIterator{PrimitiveIterator: primitiveIt, converter: it.converter}
+}
+
+// slotPrimitiveIterator wraps the primitive iterator of data family,
+// skips the data points out of the time range of query.
+type slotPrimitiveIterator struct {
+	series.PrimitiveIterator
+	converter slotConverter
+	timeSlot  int
+	value     float64
+}
+
+// HasNext returns if the iteration has more data points in the time range of query
+func (it *slotPrimitiveIterator) HasNext() bool {
+	for it.PrimitiveIterator.HasNext() {
+		timeSlot, value := it.PrimitiveIterator.Next()
+		slot, ok := it.converter.convert(timeSlot)
+		if !ok {
+			continue
+		}
+		it.timeSlot = slot
+		it.value = value
+		return true
+	}
+	return false
+}
+
+// Next returns the aggregation slot of query and the value
+func (it *slotPrimitiveIterator) Next() (timeSlot int, value float64) {
+	return it.timeSlot, it.value
+}
+
+// slotConverter converts the time slot of data family into the aggregation slot of query,
+// the aggregation slot is relative to the start time of query by the slot interval.
+type slotConverter struct {
+	familyTime      int64
+	storageInterval int64
+	queryStart      int64
+	queryEnd        int64
+	slotInterval    int64
+}
+
+// convert converts the time slot of family, returns false if the time of slot is out of the time range of query
+func (c slotConverter) convert(timeSlot int) (int, bool) {
+	timestamp := c.familyTime + int64(timeSlot)*c.storageInterval
+	if timestamp < c.queryStart || timestamp > c.queryEnd {
+		return 0, false
+	}
+	return int((timestamp - c.queryStart) / c.slotInterval), true
+}
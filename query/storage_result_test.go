@@ -0,0 +1,114 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestShardResult_merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spec := aggregation.NewAggregatorSpec(10, "f", field.SumField)
+	spec.AddFunctionType(function.Sum)
+	newFamilyResult := func(startSlot int, tags map[string]string, points map[int]interface{}) *familyResult {
+		result := newFamilyResult(startSlot)
+		group := result.getGroup(tags)
+		agg := aggregation.NewFieldAggregator(0, 10, 0, 3, 1, spec)
+		timeSeries := MockSumFieldSeries(ctrl, 10, 1, points)
+		assert.True(t, timeSeries.HasNext())
+		fieldIt := timeSeries.Next()
+		assert.Equal(t, uint16(10), fieldIt.FieldID())
+		agg.Aggregate(fieldIt)
+		assert.False(t, timeSeries.HasNext())
+		group.aggregators[10] = agg
+		group.fieldIDs = append(group.fieldIDs, 10)
+		result.numOfPoints += 3
+		return result
+	}
+	result := newShardResult(5)
+	// the families overlap at query slot 2, the values out of query slots are dropped
+	assert.Equal(t, 5, result.merge(newFamilyResult(0, map[string]string{"host": "a"}, map[int]interface{}{0: 1.0, 2: 2.0})))
+	assert.Equal(t, 0, result.merge(newFamilyResult(2, map[string]string{"host": "a"}, map[int]interface{}{0: 3.0, 2: 4.0})))
+	assert.Equal(t, 5, result.merge(newFamilyResult(4, map[string]string{"host": "b"}, map[int]interface{}{0: 5.0, 1: 6.0})))
+
+	its := result.iterators()
+	assert.Len(t, its, 2)
+	expects := []struct {
+		tags   map[string]string
+		points map[int]float64
+	}{
+		{tags: map[string]string{"host": "a"}, points: map[int]float64{0: 1, 2: 5, 4: 4}},
+		{tags: map[string]string{"host": "b"}, points: map[int]float64{4: 5}},
+	}
+	for idx, it := range its {
+		assert.Equal(t, expects[idx].tags, it.Tags())
+		assert.Equal(t, uint32(0), it.SeriesID())
+		assert.True(t, it.HasNext())
+		fieldIt := it.Next()
+		assert.False(t, it.HasNext())
+		assert.Nil(t, it.Next())
+		assert.Equal(t, uint16(10), fieldIt.FieldID())
+		assert.Equal(t, "f", fieldIt.FieldName())
+		assert.Equal(t, field.SumField, fieldIt.FieldType())
+		assert.True(t, fieldIt.HasNext())
+		primitiveIt := fieldIt.Next()
+		assert.False(t, fieldIt.HasNext())
+		assert.Nil(t, fieldIt.Next())
+		assert.Equal(t, uint16(1), primitiveIt.FieldID())
+		points := make(map[int]float64)
+		for primitiveIt.HasNext() {
+			slot, value := primitiveIt.Next()
+			points[slot] = value
+		}
+		assert.Equal(t, expects[idx].points, points)
+	}
+}
+
+func TestSlotConverter_convert(t *testing.T) {
+	converter := slotConverter{familyTime: 1000, storageInterval: 10, queryStart: 1020, queryEnd: 1100, slotInterval: 10}
+	_, ok := converter.convert(1)
+	assert.False(t, ok)
+	slot, ok := converter.convert(2)
+	assert.True(t, ok)
+	assert.Equal(t, 0, slot)
+	slot, ok = converter.convert(10)
+	assert.True(t, ok)
+	assert.Equal(t, 8, slot)
+	_, ok = converter.convert(11)
+	assert.False(t, ok)
+}
+
+func TestSlotFieldIterator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fieldIt := series.NewMockFieldIterator(ctrl)
+	fieldIt.EXPECT().Next().Return(nil)
+	it := &slotFieldIterator{FieldIterator: fieldIt,
+		converter: slotConverter{familyTime: 1000, storageInterval: 10, queryStart: 1020, queryEnd: 1100, slotInterval: 10}}
+	assert.Nil(t, it.Next())
+
+	primitiveIt := series.NewMockPrimitiveIterator(ctrl)
+	fieldIt.EXPECT().Next().Return(primitiveIt)
+	gomock.InOrder(
+		primitiveIt.EXPECT().HasNext().Return(true),
+		primitiveIt.EXPECT().Next().Return(1, 1.0),
+		primitiveIt.EXPECT().HasNext().Return(true),
+		primitiveIt.EXPECT().Next().Return(3, 3.0),
+		primitiveIt.EXPECT().HasNext().Return(false),
+	)
+	slotIt := it.Next()
+	assert.True(t, slotIt.HasNext())
+	slot, value := slotIt.Next()
+	assert.Equal(t, 1, slot)
+	assert.Equal(t, 3.0, value)
+	assert.False(t, slotIt.HasNext())
+}
@@ -0,0 +1,296 @@
+package query
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/fields"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// subQueryAggregator aggregates the results of sub query in broker side for the outer query,
+// 1) evaluates the select list of sub query for each series of sub query
+// 2) groups the series by the group by tags of outer query
+// 3) aggregates the values of same time slot in the group
+// 4) evaluates the select list of outer query based on the aggregated values
+type subQueryAggregator struct {
+	query      *stmt.Query
+	pointCount int
+}
+
+// newSubQueryAggregator creates the sub query aggregator for the outer query
+func newSubQueryAggregator(query *stmt.Query) *subQueryAggregator {
+	subQuery := query.SubQuery
+	interval := subQuery.Interval
+	if interval <= 0 {
+		//TODO use storage interval
+		interval = 10 * timeutil.OneSecond
+	}
+	pointCount := timeutil.CalPointCount(subQuery.TimeRange.Start, subQuery.TimeRange.End, interval)
+	if calendar, ok := subQuery.CalendarInterval(); ok {
+		pointCount = len(calendar.Buckets(subQuery.TimeRange.Start, subQuery.TimeRange.End))
+	}
+	return &subQueryAggregator{
+		query:      query,
+		pointCount: pointCount,
+	}
+}
+
+// aggregate drains the results of sub query, then sends the aggregated series to the result set and closes it,
+// the field name of aggregated series is the result name of outer query's select list.
+func (a *subQueryAggregator) aggregate(results <-chan series.GroupedIterator, resultSet chan<- series.GroupedIterator) {
+	defer close(resultSet)
+
+	groups := make(map[string]*seriesGroup)
+	var keys []string
+	for it := range results {
+		if it == nil {
+			continue
+		}
+		tags := make(map[string]string)
+		for _, tagKey := range a.query.GroupBy {
+			tags[tagKey] = it.Tags()[tagKey]
+		}
+		key := models.TagsAsString(tags)
+		group, ok := groups[key]
+		if !ok {
+			group = &seriesGroup{tags: tags, fields: make(map[string]*aggregatedField)}
+			groups[key] = group
+			keys = append(keys, key)
+		}
+		for name, values := range a.evalSubQuery(it) {
+			group.add(name, values, a.pointCount)
+		}
+	}
+
+	for _, key := range keys {
+		group := groups[key]
+		expression := aggregation.NewFieldsExpression(group.fieldStore(), a.query.SelectItems)
+		expression.Eval()
+		resultSet <- newResultSeries(group.tags, expression.ResultSet())
+	}
+}
+
+// evalSubQuery evaluates the select list of sub query for the series, returns the result name => values,
+// if sub query also from sub query, the series is already evaluated by the aggregator of sub query.
+func (a *subQueryAggregator) evalSubQuery(it series.GroupedIterator) map[string]collections.FloatArray {
+	if a.query.SubQuery.SubQuery == nil {
+		expression := aggregation.NewExpression(it, a.pointCount, a.query.SubQuery.SelectItems)
+		expression.Eval()
+		return expression.ResultSet()
+	}
+	result := make(map[string]collections.FloatArray)
+	for it.HasNext() {
+		fieldIt := it.Next()
+		f := fields.NewSingleField(a.pointCount, fieldIt)
+		if f != nil {
+			result[fieldIt.FieldName()] = f.GetDefaultValues()[0]
+		}
+	}
+	return result
+}
+
+// seriesGroup represents the series group of sub query's results with the same group by tags of outer query
+type seriesGroup struct {
+	tags   map[string]string
+	fields map[string]*aggregatedField
+}
+
+// add aggregates the values of the series into the field of the group
+func (g *seriesGroup) add(name string, values collections.FloatArray, pointCount int) {
+	f, ok := g.fields[name]
+	if !ok {
+		f = newAggregatedField(pointCount)
+		g.fields[name] = f
+	}
+	f.aggregate(values)
+}
+
+// fieldStore returns the aggregated fields for expression evaluation
+func (g *seriesGroup) fieldStore() map[string]fields.Field {
+	fieldStore := make(map[string]fields.Field, len(g.fields))
+	for name, f := range g.fields {
+		fieldStore[name] = f
+	}
+	return fieldStore
+}
+
+// aggregatedField represents the field which values are aggregated from multi-series by time slot,
+// supports sum/min/max/avg/count function, the default values are sum values.
+type aggregatedField struct {
+	sum   collections.FloatArray
+	min   collections.FloatArray
+	max   collections.FloatArray
+	count []int
+}
+
+// newAggregatedField creates the aggregated field
+func newAggregatedField(pointCount int) *aggregatedField {
+	return &aggregatedField{
+		sum:   collections.NewFloatArray(pointCount),
+		min:   collections.NewFloatArray(pointCount),
+		max:   collections.NewFloatArray(pointCount),
+		count: make([]int, pointCount),
+	}
+}
+
+// aggregate aggregates the values of series by time slot
+func (f *aggregatedField) aggregate(values collections.FloatArray) {
+	it := values.Iterator()
+	for it.HasNext() {
+		slot, value := it.Next()
+		if slot < 0 || slot >= len(f.count) {
+			continue
+		}
+		if f.count[slot] == 0 {
+			f.sum.SetValue(slot, value)
+			f.min.SetValue(slot, value)
+			f.max.SetValue(slot, value)
+		} else {
+			f.sum.SetValue(slot, f.sum.GetValue(slot)+value)
+			f.min.SetValue(slot, math.Min(f.min.GetValue(slot), value))
+			f.max.SetValue(slot, math.Max(f.max.GetValue(slot), value))
+		}
+		f.count[slot]++
+	}
+}
+
+// GetValues returns the aggregated values by function type
+func (f *aggregatedField) GetValues(funcType function.FuncType) []collections.FloatArray {
+	switch funcType {
+	case function.Sum:
+		return []collections.FloatArray{f.sum}
+	case function.Min:
+		return []collections.FloatArray{f.min}
+	case function.Max:
+		return []collections.FloatArray{f.max}
+	case function.Avg:
+		avg := collections.NewFloatArray(len(f.count))
+		for slot, count := range f.count {
+			if count > 0 {
+				avg.SetValue(slot, f.sum.GetValue(slot)/float64(count))
+			}
+		}
+		return []collections.FloatArray{avg}
+	case function.Count:
+		count := collections.NewFloatArray(len(f.count))
+		for slot, c := range f.count {
+			if c > 0 {
+				count.SetValue(slot, float64(c))
+			}
+		}
+		return []collections.FloatArray{count}
+	default:
+		return nil
+	}
+}
+
+// GetDefaultValues returns the sum values
+func (f *aggregatedField) GetDefaultValues() []collections.FloatArray {
+	return []collections.FloatArray{f.sum}
+}
+
+// resultSeries represents the grouped series which values are computed in broker side,
+// each field has one primitive field which iterates the values of float array.
+type resultSeries struct {
+	tags   map[string]string
+	names  []string
+	values map[string]collections.FloatArray
+	idx    int
+}
+
+// newResultSeries creates the result series, iterates the fields order by field name
+func newResultSeries(tags map[string]string, values map[string]collections.FloatArray) series.GroupedIterator {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &resultSeries{
+		tags:   tags,
+		names:  names,
+		values: values,
+	}
+}
+
+// Tags returns group tags
+func (s *resultSeries) Tags() map[string]string {
+	return s.tags
+}
+
+// SeriesID returns 0, because result series is computed in broker side
+func (s *resultSeries) SeriesID() uint32 {
+	return 0
+}
+
+// HasNext returns if the iteration has more field's iterator
+func (s *resultSeries) HasNext() bool {
+	return s.idx < len(s.names)
+}
+
+// Next returns the field's iterator
+func (s *resultSeries) Next() series.FieldIterator {
+	name := s.names[s.idx]
+	s.idx++
+	return &resultFieldIterator{name: name, values: s.values[name]}
+}
+
+// resultFieldIterator represents the field's iterator of result series
+type resultFieldIterator struct {
+	name     string
+	values   collections.FloatArray
+	consumed bool
+}
+
+// FieldID returns 0, because result field is computed in broker side
+func (it *resultFieldIterator) FieldID() uint16 {
+	return 0
+}
+
+// FieldName returns the field's name
+func (it *resultFieldIterator) FieldName() string {
+	return it.name
+}
+
+// FieldType returns sum field type, because the values are computed
+func (it *resultFieldIterator) FieldType() field.Type {
+	return field.SumField
+}
+
+// HasNext returns if the iteration has the primitive field
+func (it *resultFieldIterator) HasNext() bool {
+	return !it.consumed
+}
+
+// Next returns the primitive field's iterator
+func (it *resultFieldIterator) Next() series.PrimitiveIterator {
+	it.consumed = true
+	return &resultPrimitiveIterator{it: it.values.Iterator()}
+}
+
+// resultPrimitiveIterator represents the primitive field's iterator which iterates the values of float array
+type resultPrimitiveIterator struct {
+	it collections.FloatArrayIterator
+}
+
+// FieldID returns the stored primitive field id of sum field, because the values are computed as sum field
+func (it *resultPrimitiveIterator) FieldID() uint16 {
+	return field.GetSourceFieldID(field.SumField, 0)
+}
+
+// HasNext returns if the iteration has more data points
+func (it *resultPrimitiveIterator) HasNext() bool {
+	return it.it.HasNext()
+}
+
+// Next returns the data point in the iteration
+func (it *resultPrimitiveIterator) Next() (timeSlot int, value float64) {
+	return it.it.Next()
+}
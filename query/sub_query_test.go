@@ -0,0 +1,141 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/tsdb/field"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestSubQueryAggregator_Aggregate(t *testing.T) {
+	query, err := sql.Parse("select max(v) as max_v, min(v), avg(v), sum(v), v from" +
+		" (select sum(requests) as v from http group by host, region, time(1m)) group by region")
+	assert.NoError(t, err)
+
+	results := make(chan series.GroupedIterator)
+	go func() {
+		results <- newResultSeries(map[string]string{"host": "1.1.1.1", "region": "sh"},
+			map[string]collections.FloatArray{"requests": mockFloatArray(map[int]float64{1: 10, 2: 20})})
+		results <- newResultSeries(map[string]string{"host": "1.1.1.2", "region": "sh"},
+			map[string]collections.FloatArray{"requests": mockFloatArray(map[int]float64{1: 30, 3: 5})})
+		results <- nil
+		results <- newResultSeries(map[string]string{"host": "1.1.1.3", "region": "bj"},
+			map[string]collections.FloatArray{"requests": mockFloatArray(map[int]float64{1: 1})})
+		close(results)
+	}()
+	resultSet := make(chan series.GroupedIterator)
+	go newSubQueryAggregator(query).aggregate(results, resultSet)
+
+	var groups []map[string]collections.FloatArray
+	var tags []map[string]string
+	for result := range resultSet {
+		tags = append(tags, result.Tags())
+		groups = append(groups, readResultSeries(result))
+	}
+	assert.Equal(t, []map[string]string{{"region": "sh"}, {"region": "bj"}}, tags)
+	sh := groups[0]
+	assert.Len(t, sh, 5)
+	assert.Equal(t, 30.0, sh["max_v"].GetValue(1))
+	assert.Equal(t, 20.0, sh["max_v"].GetValue(2))
+	assert.Equal(t, 5.0, sh["max_v"].GetValue(3))
+	assert.Equal(t, 10.0, sh["min(v)"].GetValue(1))
+	assert.Equal(t, 20.0, sh["avg(v)"].GetValue(1))
+	assert.Equal(t, 40.0, sh["sum(v)"].GetValue(1))
+	assert.Equal(t, 40.0, sh["v"].GetValue(1))
+	assert.Equal(t, 1.0, groups[1]["max_v"].GetValue(1))
+}
+
+func TestSubQueryAggregator_Nested(t *testing.T) {
+	query, err := sql.Parse("select max(v) from (select sum(v) as v from (select sum(f) as v from cpu" +
+		" group by host, ip) group by host)")
+	assert.NoError(t, err)
+
+	results := make(chan series.GroupedIterator)
+	go func() {
+		// results of sub query are evaluated
+		results <- newResultSeries(map[string]string{"host": "1.1.1.1"},
+			map[string]collections.FloatArray{"v": mockFloatArray(map[int]float64{1: 10})})
+		results <- newResultSeries(map[string]string{"host": "1.1.1.2"},
+			map[string]collections.FloatArray{"v": mockFloatArray(map[int]float64{1: 30})})
+		close(results)
+	}()
+	resultSet := make(chan series.GroupedIterator)
+	go newSubQueryAggregator(query).aggregate(results, resultSet)
+
+	var groups []map[string]collections.FloatArray
+	for result := range resultSet {
+		assert.Empty(t, result.Tags())
+		groups = append(groups, readResultSeries(result))
+	}
+	assert.Len(t, groups, 1)
+	assert.Equal(t, 30.0, groups[0]["max(v)"].GetValue(1))
+}
+
+func TestAggregatedField(t *testing.T) {
+	f := newAggregatedField(10)
+	// out of range
+	f.aggregate(mockFloatArray(map[int]float64{1: 10, 20: 10}))
+	assert.Equal(t, 1, f.GetDefaultValues()[0].Size())
+	assert.Nil(t, f.GetValues(function.Histogram))
+	f.aggregate(mockFloatArray(map[int]float64{1: 5, 2: 10}))
+	count := f.GetValues(function.Count)[0]
+	assert.Equal(t, 2.0, count.GetValue(1))
+	assert.Equal(t, 1.0, count.GetValue(2))
+	assert.False(t, count.HasValue(3))
+}
+
+func TestResultSeries(t *testing.T) {
+	it := newResultSeries(map[string]string{"host": "1.1.1.1"},
+		map[string]collections.FloatArray{
+			"b": mockFloatArray(map[int]float64{1: 10}),
+			"a": mockFloatArray(map[int]float64{2: 20}),
+		})
+	assert.Equal(t, uint32(0), it.SeriesID())
+	assert.True(t, it.HasNext())
+	fieldIt := it.Next()
+	assert.Equal(t, "a", fieldIt.FieldName())
+	assert.Equal(t, uint16(0), fieldIt.FieldID())
+	assert.Equal(t, field.SumField, fieldIt.FieldType())
+	assert.True(t, fieldIt.HasNext())
+	primitiveIt := fieldIt.Next()
+	assert.False(t, fieldIt.HasNext())
+	assert.Equal(t, uint16(1), primitiveIt.FieldID())
+	assert.True(t, primitiveIt.HasNext())
+	slot, value := primitiveIt.Next()
+	assert.Equal(t, 2, slot)
+	assert.Equal(t, 20.0, value)
+	assert.False(t, primitiveIt.HasNext())
+	assert.True(t, it.HasNext())
+	assert.Equal(t, "b", it.Next().FieldName())
+	assert.False(t, it.HasNext())
+}
+
+func mockFloatArray(values map[int]float64) collections.FloatArray {
+	array := collections.NewFloatArray(10)
+	for slot, value := range values {
+		array.SetValue(slot, value)
+	}
+	return array
+}
+
+func readResultSeries(it series.GroupedIterator) map[string]collections.FloatArray {
+	result := make(map[string]collections.FloatArray)
+	for it.HasNext() {
+		fieldIt := it.Next()
+		values := collections.NewFloatArray(10)
+		for fieldIt.HasNext() {
+			primitiveIt := fieldIt.Next()
+			for primitiveIt.HasNext() {
+				slot, value := primitiveIt.Next()
+				values.SetValue(slot, value)
+			}
+		}
+		result[fieldIt.FieldName()] = values
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package query
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+// splitTimeShiftQuery splits the query with time shift functions into a sub query for each shift(0 means not shifted),
+// the select list of sub query only includes the fields(with the down sampling function) under the shift,
+// the time range of sub query is shifted to the past, other parts(condition/group by etc.) are the same as the query.
+func splitTimeShiftQuery(query *stmt.Query) (subQueries []*stmt.Query, shifts []int64) {
+	queries := make(map[int64]*stmt.Query)
+	selectItems := make(map[string]struct{})
+
+	var collect func(shift int64, parentFunc *stmt.CallExpr, expr stmt.Expr)
+	collect = func(shift int64, parentFunc *stmt.CallExpr, expr stmt.Expr) {
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			collect(shift, nil, e.Expr)
+		case *stmt.CallExpr:
+			if e.FuncType == function.TimeShift {
+				if len(e.Params) != 2 {
+					return
+				}
+				if duration, ok := e.Params[1].(*stmt.DurationLiteral); ok {
+					collect(duration.Val, nil, e.Params[0])
+				}
+				return
+			}
+			parent := e
+			if !function.IsAggFunc(e.FuncType) {
+				parent = nil
+			}
+			for _, param := range e.Params {
+				collect(shift, parent, param)
+			}
+		case *stmt.ParenExpr:
+			collect(shift, nil, e.Expr)
+		case *stmt.BinaryExpr:
+			collect(shift, nil, e.Left)
+			collect(shift, nil, e.Right)
+		case *stmt.FieldExpr:
+			var item stmt.Expr = &stmt.FieldExpr{Name: e.Name}
+			if parentFunc != nil {
+				item = &stmt.CallExpr{FuncType: parentFunc.FuncType, Params: []stmt.Expr{item}}
+			}
+			key := strconv.FormatInt(shift, 10) + "/" + item.Rewrite()
+			if _, exist := selectItems[key]; exist {
+				return
+			}
+			selectItems[key] = struct{}{}
+			subQuery, ok := queries[shift]
+			if !ok {
+				shifted := *query
+				shifted.SelectItems = nil
+				shifted.TimeRange = timeutil.TimeRange{
+					Start: query.TimeRange.Start - shift,
+					End:   query.TimeRange.End - shift,
+				}
+				subQuery = &shifted
+				queries[shift] = subQuery
+				subQueries = append(subQueries, subQuery)
+				shifts = append(shifts, shift)
+			}
+			subQuery.SelectItems = append(subQuery.SelectItems, &stmt.SelectItem{Expr: item})
+		}
+	}
+	for _, selectItem := range query.SelectItems {
+		collect(0, nil, selectItem)
+	}
+	return subQueries, shifts
+}
+
+// timeShiftJoiner joins the grouped time series of each shift's sub query by group tags(full outer join),
+// the field name of shifted series is qualified by the shift(like f@604800000) for expression evaluation,
+// the time slots of shifted series are aligned with the query, because the shift is a multiple of interval.
+type timeShiftJoiner struct {
+	shifts []int64
+}
+
+// newTimeShiftJoiner creates the time shift joiner for given shifts(same order as sub query's results)
+func newTimeShiftJoiner(shifts []int64) *timeShiftJoiner {
+	return &timeShiftJoiner{shifts: shifts}
+}
+
+// join drains all sub query's results, then sends the joined series to the result set and closes it,
+// the series group which only exists in some sub queries is kept, like the new series within last week.
+func (j *timeShiftJoiner) join(results []<-chan series.GroupedIterator, resultSet chan<- series.GroupedIterator) {
+	defer close(resultSet)
+
+	groups := make([]map[string][]series.GroupedIterator, len(results))
+	keys := make([][]string, len(results))
+	var wait sync.WaitGroup
+	for idx := range results {
+		groups[idx] = make(map[string][]series.GroupedIterator)
+		wait.Add(1)
+		go func(idx int) {
+			defer wait.Done()
+			for it := range results[idx] {
+				if it == nil {
+					continue
+				}
+				key := models.TagsAsString(it.Tags())
+				if _, ok := groups[idx][key]; !ok {
+					keys[idx] = append(keys[idx], key)
+				}
+				groups[idx][key] = append(groups[idx][key], it)
+			}
+		}(idx)
+	}
+	wait.Wait()
+
+	// keeps the order of groups received, the groups of not shifted query first
+	joinedKeys := make(map[string]struct{})
+	for _, groupKeys := range keys {
+		for _, key := range groupKeys {
+			if _, ok := joinedKeys[key]; ok {
+				continue
+			}
+			joinedKeys[key] = struct{}{}
+			joined := &joinedGroupedIterator{}
+			for idx, group := range groups {
+				shift := j.shifts[idx]
+				for _, it := range group[key] {
+					joined.qualifiers = append(joined.qualifiers, func(fieldName string) string {
+						return aggregation.ShiftedFieldName(fieldName, shift)
+					})
+					joined.its = append(joined.its, it)
+				}
+			}
+			resultSet <- joined
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/tsdb/series"
+)
+
+func TestSplitTimeShiftQuery(t *testing.T) {
+	query, err := sql.Parse("select sum(f)/time_shift(sum(f), 1d), time_shift(max(f), 1w), time_shift(sum(f), 1d)" +
+		" from cpu where host='1.1.1.1' and time>now()-1h group by host, time(1m)")
+	assert.NoError(t, err)
+	subQueries, shifts := splitTimeShiftQuery(query)
+	assert.Equal(t, []int64{0, timeutil.OneDay, timeutil.OneWeek}, shifts)
+	assert.Len(t, subQueries, 3)
+
+	assert.Len(t, subQueries[0].SelectItems, 1)
+	assert.Equal(t, "sum(f)", subQueries[0].SelectItems[0].Rewrite())
+	assert.Equal(t, query.TimeRange, subQueries[0].TimeRange)
+	// same shift and field is selected once
+	assert.Len(t, subQueries[1].SelectItems, 1)
+	assert.Equal(t, "sum(f)", subQueries[1].SelectItems[0].Rewrite())
+	assert.Equal(t, query.TimeRange.Start-timeutil.OneDay, subQueries[1].TimeRange.Start)
+	assert.Equal(t, query.TimeRange.End-timeutil.OneDay, subQueries[1].TimeRange.End)
+	assert.Equal(t, "max(f)", subQueries[2].SelectItems[0].Rewrite())
+	assert.Equal(t, query.TimeRange.Start-7*timeutil.OneDay, subQueries[2].TimeRange.Start)
+
+	assert.Equal(t, query.Condition, subQueries[1].Condition)
+	assert.Equal(t, query.GroupBy, subQueries[1].GroupBy)
+	// not modify the time shift query
+	assert.Len(t, query.SelectItems, 3)
+}
+
+func TestTimeShiftJoiner_Join(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeries := func(tags map[string]string, fieldName string) series.GroupedIterator {
+		fieldIt := series.NewMockFieldIterator(ctrl)
+		fieldIt.EXPECT().FieldName().Return(fieldName).AnyTimes()
+		it := series.NewMockGroupedIterator(ctrl)
+		it.EXPECT().Tags().Return(tags).AnyTimes()
+		gomock.InOrder(
+			it.EXPECT().HasNext().Return(true),
+			it.EXPECT().Next().Return(fieldIt),
+			it.EXPECT().HasNext().Return(false),
+		)
+		return it
+	}
+	ch1 := make(chan series.GroupedIterator)
+	ch2 := make(chan series.GroupedIterator)
+	go func() {
+		ch1 <- mockSeries(map[string]string{"host": "1.1.1.1"}, "f")
+		ch1 <- nil
+		// new series not exist in the shifted time range
+		ch1 <- mockSeries(map[string]string{"host": "1.1.1.2"}, "f")
+		close(ch1)
+	}()
+	go func() {
+		ch2 <- mockSeries(map[string]string{"host": "1.1.1.3"}, "f")
+		ch2 <- mockSeries(map[string]string{"host": "1.1.1.1"}, "f")
+		close(ch2)
+	}()
+
+	resultSet := make(chan series.GroupedIterator)
+	go newTimeShiftJoiner([]int64{0, timeutil.OneDay}).join([]<-chan series.GroupedIterator{ch1, ch2}, resultSet)
+
+	var results []series.GroupedIterator
+	for result := range resultSet {
+		results = append(results, result)
+	}
+	// full outer join, keeps the series only exist in some time ranges
+	assert.Len(t, results, 3)
+	fieldNames := func(it series.GroupedIterator) []string {
+		var names []string
+		for it.HasNext() {
+			names = append(names, it.Next().FieldName())
+		}
+		return names
+	}
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, results[0].Tags())
+	assert.Equal(t, []string{"f", "f@86400000"}, fieldNames(results[0]))
+	assert.Equal(t, map[string]string{"host": "1.1.1.2"}, results[1].Tags())
+	assert.Equal(t, []string{"f"}, fieldNames(results[1]))
+	assert.Equal(t, map[string]string{"host": "1.1.1.3"}, results[2].Tags())
+	assert.Equal(t, []string{"f@86400000"}, fieldNames(results[2]))
+}
@@ -54,15 +54,42 @@ func (TaskType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_555bd8c177793206, []int{0}
 }
 
+type RequestType int32
+
+const (
+	RequestType_Query  RequestType = 0
+	RequestType_Cancel RequestType = 1
+)
+
+var RequestType_name = map[int32]string{
+	0: "Query",
+	1: "Cancel",
+}
+
+var RequestType_value = map[string]int32{
+	"Query":  0,
+	"Cancel": 1,
+}
+
+func (x RequestType) String() string {
+	return proto.EnumName(RequestType_name, int32(x))
+}
+
+func (RequestType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{1}
+}
+
 type TaskRequest struct {
-	JobID                int64    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
-	ParentTaskID         string   `protobuf:"bytes,2,opt,name=parentTaskID,proto3" json:"parentTaskID,omitempty"`
-	Type                 int32    `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
-	PhysicalPlan         []byte   `protobuf:"bytes,4,opt,name=physicalPlan,proto3" json:"physicalPlan,omitempty"`
-	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	JobID                int64       `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
+	ParentTaskID         string      `protobuf:"bytes,2,opt,name=parentTaskID,proto3" json:"parentTaskID,omitempty"`
+	Type                 int32       `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	PhysicalPlan         []byte      `protobuf:"bytes,4,opt,name=physicalPlan,proto3" json:"physicalPlan,omitempty"`
+	Payload              []byte      `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	RequestType          RequestType `protobuf:"varint,6,opt,name=requestType,proto3,enum=common.RequestType" json:"requestType,omitempty"`
+	TraceID              string      `protobuf:"bytes,7,opt,name=traceID,proto3" json:"traceID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *TaskRequest) Reset()         { *m = TaskRequest{} }
@@ -133,12 +160,30 @@ func (m *TaskRequest) GetPayload() []byte {
 	return nil
 }
 
+func (m *TaskRequest) GetRequestType() RequestType {
+	if m != nil {
+		return m.RequestType
+	}
+	return RequestType_Query
+}
+
+func (m *TaskRequest) GetTraceID() string {
+	if m != nil {
+		return m.TraceID
+	}
+	return ""
+}
+
 type TaskResponse struct {
 	JobID                int64    `protobuf:"varint,1,opt,name=jobID,proto3" json:"jobID,omitempty"`
 	TaskID               string   `protobuf:"bytes,2,opt,name=TaskID,proto3" json:"TaskID,omitempty"`
 	Completed            bool     `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
 	ErrMsg               string   `protobuf:"bytes,4,opt,name=errMsg,proto3" json:"errMsg,omitempty"`
 	Payload              []byte   `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	Stats                []byte   `protobuf:"bytes,6,opt,name=stats,proto3" json:"stats,omitempty"`
+	SendNode             string   `protobuf:"bytes,7,opt,name=sendNode,proto3" json:"sendNode,omitempty"`
+	TraceID              string   `protobuf:"bytes,8,opt,name=traceID,proto3" json:"traceID,omitempty"`
+	Spans                []byte   `protobuf:"bytes,9,opt,name=spans,proto3" json:"spans,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -212,8 +257,37 @@ func (m *TaskResponse) GetPayload() []byte {
 	return nil
 }
 
+func (m *TaskResponse) GetStats() []byte {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *TaskResponse) GetSendNode() string {
+	if m != nil {
+		return m.SendNode
+	}
+	return ""
+}
+
+func (m *TaskResponse) GetTraceID() string {
+	if m != nil {
+		return m.TraceID
+	}
+	return ""
+}
+
+func (m *TaskResponse) GetSpans() []byte {
+	if m != nil {
+		return m.Spans
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("common.TaskType", TaskType_name, TaskType_value)
+	proto.RegisterEnum("common.RequestType", RequestType_name, RequestType_value)
 	proto.RegisterType((*TaskRequest)(nil), "common.TaskRequest")
 	proto.RegisterType((*TaskResponse)(nil), "common.TaskResponse")
 }
@@ -221,27 +295,33 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xcf, 0x4a, 0xc3, 0x40,
-	0x10, 0x87, 0x3b, 0xfd, 0x93, 0xb6, 0xd3, 0x1c, 0xc2, 0x5a, 0x24, 0x88, 0x84, 0x90, 0x53, 0xf0,
-	0x50, 0xc4, 0x9e, 0xbc, 0x4a, 0x0f, 0x2d, 0x6a, 0x95, 0xb5, 0xe2, 0x79, 0x9b, 0x8c, 0x5a, 0x4d,
-	0xb2, 0x31, 0x59, 0x85, 0xbe, 0x83, 0x0f, 0xa0, 0x6f, 0xe4, 0xd1, 0x47, 0x90, 0xfa, 0x22, 0x92,
-	0x4d, 0x44, 0x73, 0xe8, 0x6d, 0xbf, 0xdf, 0xee, 0x2c, 0xdf, 0xcc, 0xa0, 0x19, 0xc8, 0x38, 0x96,
-	0xc9, 0x28, 0xcd, 0xa4, 0x92, 0xcc, 0x28, 0xc9, 0x7b, 0x07, 0x1c, 0x2c, 0x44, 0xfe, 0xc8, 0xe9,
-	0xe9, 0x99, 0x72, 0xc5, 0x86, 0xd8, 0x79, 0x90, 0xcb, 0xd9, 0xc4, 0x06, 0x17, 0xfc, 0x16, 0x2f,
-	0x81, 0x79, 0x68, 0xa6, 0x22, 0xa3, 0x44, 0x15, 0x4f, 0x67, 0x13, 0xbb, 0xe9, 0x82, 0xdf, 0xe7,
-	0xb5, 0x8c, 0x31, 0x6c, 0xab, 0x75, 0x4a, 0x76, 0xcb, 0x05, 0xbf, 0xc3, 0xf5, 0x59, 0xd7, 0xdd,
-	0xaf, 0xf3, 0x55, 0x20, 0xa2, 0xcb, 0x48, 0x24, 0x76, 0xdb, 0x05, 0xdf, 0xe4, 0xb5, 0x8c, 0xd9,
-	0xd8, 0x4d, 0xc5, 0x3a, 0x92, 0x22, 0xb4, 0x3b, 0xfa, 0xfa, 0x17, 0xbd, 0x57, 0x40, 0xb3, 0x74,
-	0xcb, 0x53, 0x99, 0xe4, 0xb4, 0x45, 0x6e, 0x17, 0x8d, 0x9a, 0x56, 0x45, 0x6c, 0x1f, 0xfb, 0x81,
-	0x8c, 0xd3, 0x88, 0x14, 0x85, 0xda, 0xaa, 0xc7, 0xff, 0x82, 0xa2, 0x8a, 0xb2, 0xec, 0x3c, 0xbf,
-	0xd3, 0x52, 0x7d, 0x5e, 0xd1, 0x76, 0x9d, 0x83, 0x31, 0xf6, 0x8a, 0x9f, 0x17, 0x45, 0x63, 0x03,
-	0xec, 0x5e, 0xcf, 0x4f, 0xe7, 0x17, 0x37, 0x73, 0xab, 0xc1, 0x2c, 0x34, 0x67, 0x89, 0xa2, 0x2c,
-	0xa6, 0x70, 0x25, 0x14, 0x59, 0xc0, 0x7a, 0xd8, 0x3e, 0x23, 0x71, 0x6b, 0x35, 0x8f, 0xa6, 0xe5,
-	0x78, 0xaf, 0x28, 0x7b, 0x59, 0x05, 0xc4, 0x8e, 0xd1, 0x98, 0x8a, 0x24, 0x8c, 0x88, 0xed, 0x8c,
-	0xaa, 0x7d, 0xfc, 0x9b, 0xfe, 0xde, 0xb0, 0x1e, 0x96, 0x6d, 0x7b, 0x0d, 0x1f, 0x0e, 0xe1, 0xc4,
-	0xfa, 0xd8, 0x38, 0xf0, 0xb9, 0x71, 0xe0, 0x6b, 0xe3, 0xc0, 0xdb, 0xb7, 0xd3, 0x58, 0x1a, 0x7a,
-	0x95, 0xe3, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x93, 0x76, 0xd8, 0xda, 0x01, 0x00, 0x00,
+	// 414 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x6e, 0xd4, 0x30,
+	0x10, 0x86, 0xd7, 0xed, 0x26, 0x9b, 0xcc, 0x46, 0x28, 0x32, 0x2b, 0x64, 0x55, 0x68, 0x15, 0xad,
+	0x38, 0x44, 0x3d, 0x54, 0xa8, 0x15, 0x07, 0xae, 0xb0, 0x87, 0x46, 0x40, 0x00, 0x53, 0xc4, 0xd9,
+	0x4d, 0x06, 0x58, 0x48, 0xec, 0x60, 0xbb, 0x48, 0x79, 0x13, 0x1e, 0x89, 0x23, 0x8f, 0x80, 0x96,
+	0x1b, 0x07, 0x9e, 0x01, 0xc5, 0x49, 0xdb, 0xe4, 0x00, 0xb7, 0x7c, 0x63, 0x7b, 0xf4, 0x7f, 0x93,
+	0x81, 0xa8, 0x50, 0x75, 0xad, 0xe4, 0x49, 0xa3, 0x95, 0x55, 0xd4, 0xef, 0x69, 0xf3, 0x9b, 0xc0,
+	0xf2, 0x42, 0x98, 0xcf, 0x1c, 0xbf, 0x5c, 0xa1, 0xb1, 0x74, 0x05, 0xde, 0x27, 0x75, 0x99, 0x6d,
+	0x19, 0x49, 0x48, 0x7a, 0xc8, 0x7b, 0xa0, 0x1b, 0x88, 0x1a, 0xa1, 0x51, 0xda, 0xee, 0x6a, 0xb6,
+	0x65, 0x07, 0x09, 0x49, 0x43, 0x3e, 0xa9, 0x51, 0x0a, 0x73, 0xdb, 0x36, 0xc8, 0x0e, 0x13, 0x92,
+	0x7a, 0xdc, 0x7d, 0xbb, 0x77, 0x1f, 0x5b, 0xb3, 0x2b, 0x44, 0xf5, 0xaa, 0x12, 0x92, 0xcd, 0x13,
+	0x92, 0x46, 0x7c, 0x52, 0xa3, 0x0c, 0x16, 0x8d, 0x68, 0x2b, 0x25, 0x4a, 0xe6, 0xb9, 0xe3, 0x6b,
+	0xa4, 0x8f, 0x60, 0xa9, 0xfb, 0x58, 0x17, 0x5d, 0x63, 0x3f, 0x21, 0xe9, 0x9d, 0xd3, 0xbb, 0x27,
+	0x83, 0x07, 0xbf, 0x3d, 0xe2, 0xe3, 0x7b, 0x5d, 0x43, 0xab, 0x45, 0x81, 0xd9, 0x96, 0x2d, 0x5c,
+	0xce, 0x6b, 0xdc, 0xfc, 0x21, 0x10, 0xf5, 0xb2, 0xa6, 0x51, 0xd2, 0xe0, 0x3f, 0x6c, 0xef, 0x81,
+	0x3f, 0xf1, 0x1c, 0x88, 0xde, 0x87, 0xb0, 0x50, 0x75, 0x53, 0xa1, 0xc5, 0xd2, 0x69, 0x06, 0xfc,
+	0xb6, 0xd0, 0xbd, 0x42, 0xad, 0x5f, 0x98, 0x0f, 0xce, 0x32, 0xe4, 0x03, 0xfd, 0xc7, 0x6f, 0x05,
+	0x9e, 0xb1, 0xc2, 0x1a, 0x67, 0x16, 0xf1, 0x1e, 0xe8, 0x11, 0x04, 0x06, 0x65, 0x99, 0xab, 0x12,
+	0x87, 0xfc, 0x37, 0x3c, 0x56, 0x0b, 0x26, 0x6a, 0xae, 0x57, 0x23, 0xa4, 0x61, 0xe1, 0xd0, 0xab,
+	0x83, 0xe3, 0x33, 0x08, 0xba, 0xec, 0x6e, 0x2c, 0x4b, 0x58, 0xbc, 0xcd, 0x9f, 0xe5, 0x2f, 0xdf,
+	0xe5, 0xf1, 0x8c, 0xc6, 0x10, 0x65, 0xd2, 0xa2, 0xae, 0xb1, 0xdc, 0x09, 0x8b, 0x31, 0xa1, 0x01,
+	0xcc, 0x9f, 0xa3, 0x78, 0x1f, 0x1f, 0x1c, 0x3f, 0x80, 0xe5, 0x68, 0xb6, 0x34, 0x04, 0xef, 0xf5,
+	0x15, 0xea, 0x36, 0x9e, 0x51, 0x00, 0xff, 0xa9, 0x90, 0x05, 0x56, 0x31, 0x39, 0x3d, 0xef, 0xf7,
+	0xe6, 0x0d, 0xea, 0xaf, 0xbb, 0x02, 0xe9, 0x63, 0xf0, 0xcf, 0x85, 0x2c, 0x2b, 0xa4, 0x37, 0x3f,
+	0x68, 0xb4, 0x56, 0x47, 0xab, 0x69, 0xb1, 0x1f, 0xff, 0x66, 0x96, 0x92, 0x87, 0xe4, 0x49, 0xfc,
+	0x7d, 0xbf, 0x26, 0x3f, 0xf6, 0x6b, 0xf2, 0x73, 0xbf, 0x26, 0xdf, 0x7e, 0xad, 0x67, 0x97, 0xbe,
+	0xdb, 0xd1, 0xb3, 0xbf, 0x03, 0x00, 0xee, 0x74, 0x25, 0xd1, 0xb3, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -380,6 +460,18 @@ func (m *TaskRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.TraceID) > 0 {
+		i -= len(m.TraceID)
+		copy(dAtA[i:], m.TraceID)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.TraceID)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.RequestType != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.RequestType))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.Payload) > 0 {
 		i -= len(m.Payload)
 		copy(dAtA[i:], m.Payload)
@@ -438,6 +530,34 @@ func (m *TaskResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Spans) > 0 {
+		i -= len(m.Spans)
+		copy(dAtA[i:], m.Spans)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Spans)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.TraceID) > 0 {
+		i -= len(m.TraceID)
+		copy(dAtA[i:], m.TraceID)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.TraceID)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.SendNode) > 0 {
+		i -= len(m.SendNode)
+		copy(dAtA[i:], m.SendNode)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.SendNode)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Stats) > 0 {
+		i -= len(m.Stats)
+		copy(dAtA[i:], m.Stats)
+		i = encodeVarintCommon(dAtA, i, uint64(len(m.Stats)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.Payload) > 0 {
 		i -= len(m.Payload)
 		copy(dAtA[i:], m.Payload)
@@ -512,6 +632,13 @@ func (m *TaskRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	if m.RequestType != 0 {
+		n += 1 + sovCommon(uint64(m.RequestType))
+	}
+	l = len(m.TraceID)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -542,6 +669,22 @@ func (m *TaskResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	l = len(m.Stats)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.SendNode)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.TraceID)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
+	l = len(m.Spans)
+	if l > 0 {
+		n += 1 + l + sovCommon(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -721,6 +864,57 @@ func (m *TaskRequest) Unmarshal(dAtA []byte) error {
 				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestType", wireType)
+			}
+			m.RequestType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestType |= RequestType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -912,6 +1106,138 @@ func (m *TaskResponse) Unmarshal(dAtA []byte) error {
 				m.Payload = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stats = append(m.Stats[:0], dAtA[iNdEx:postIndex]...)
+			if m.Stats == nil {
+				m.Stats = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendNode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SendNode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommon
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCommon
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Spans = append(m.Spans[:0], dAtA[iNdEx:postIndex]...)
+			if m.Spans == nil {
+				m.Spans = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -5,6 +5,9 @@ import (
 	"io"
 	"sync"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/rpc/proto/common"
@@ -32,7 +35,9 @@ type taskClientFactory struct {
 	taskReceiver TaskReceiver
 	// target node ID => client stream
 	taskStreams map[string]common.TaskService_HandleClient
-	mutex       sync.RWMutex
+	// target node ID => cancel function of client stream's context
+	cancels map[string]context.CancelFunc
+	mutex   sync.RWMutex
 
 	connFct ClientConnFactory
 }
@@ -43,6 +48,7 @@ func NewTaskClientFactory(currentNode models.Node) TaskClientFactory {
 		currentNode: currentNode,
 		connFct:     GetClientConnFactory(),
 		taskStreams: make(map[string]common.TaskService_HandleClient),
+		cancels:     make(map[string]context.CancelFunc),
 	}
 }
 
@@ -74,10 +80,12 @@ func (f *taskClientFactory) CreateTaskClient(target models.Node) error {
 		return err
 	}
 
-	//TODO handle context?????
-	ctx := createOutgoingContextWithPairs(context.TODO(), metaKeyLogicNode, (&f.currentNode).Indicator())
+	// the context of stream is canceled when closing the task client
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = createOutgoingContextWithPairs(ctx, metaKeyLogicNode, (&f.currentNode).Indicator())
 	cli, err := common.NewTaskServiceClient(conn).Handle(ctx)
 	if err != nil {
+		cancel()
 		return err
 	}
 
@@ -85,6 +93,7 @@ func (f *taskClientFactory) CreateTaskClient(target models.Node) error {
 
 	// cache task client stream
 	f.taskStreams[targetNodeID] = cli
+	f.cancels[targetNodeID] = cancel
 	return nil
 }
 
@@ -98,15 +107,19 @@ func (f *taskClientFactory) CloseTaskClient(targetNodeID string) {
 			log.Error("close task client stream", logger.String("target", targetNodeID), logger.Error(err))
 		}
 		delete(f.taskStreams, targetNodeID)
+		if cancel, ok := f.cancels[targetNodeID]; ok {
+			cancel()
+			delete(f.cancels, targetNodeID)
+		}
 		log.Info("close task client stream", logger.String("target", targetNodeID))
 	}
 }
 
-// handleTaskResponse handles task response loop, if stream closed exist loop
+// handleTaskResponse handles task response loop, if stream closed or canceled exist loop
 func (f *taskClientFactory) handleTaskResponse(cli common.TaskService_HandleClient) {
 	for {
 		resp, err := cli.Recv()
-		if err == io.EOF {
+		if err == io.EOF || status.Code(err) == codes.Canceled {
 			return
 		}
 		if err != nil {
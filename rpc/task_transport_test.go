@@ -9,6 +9,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/rpc/proto/common"
@@ -118,3 +120,13 @@ func TestTaskClientFactory_handler(t *testing.T) {
 	)
 	factory.handleTaskResponse(cli)
 }
+
+func TestTaskClientFactory_handler_canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fct := NewTaskClientFactory(models.Node{IP: "127.0.0.1", Port: 123})
+	cli := common.NewMockTaskService_HandleClient(ctrl)
+	cli.EXPECT().Recv().Return(nil, status.Error(codes.Canceled, "context canceled"))
+	fct.(*taskClientFactory).handleTaskResponse(cli)
+}
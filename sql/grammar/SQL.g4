@@ -6,7 +6,7 @@ statement                : statementList EOF;
 statementList           : queryStmt;
 
 //data query plan
-queryStmt               : T_EXPLAIN? selectExpr fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
+queryStmt               : T_EXPLAIN? selectExpr fromClause whereClause? groupByClause? orderByClause? limitClause? timeZoneClause? T_WITH_VALUE?;
 selectExpr              : T_SELECT fields;
 //select fields
 fields                   : field ( T_COMMA field )* ;
@@ -14,7 +14,8 @@ field                    : fieldExpr alias? ;
 alias                    : T_AS ident ;
 
 //from clause
-fromClause              : T_FROM metricName ;
+fromClause              : T_FROM ( metricName ( T_COMMA metricName )* | subQuery ) ;
+subQuery                : T_OPEN_P queryStmt T_CLOSE_P ;
 
 //where clause
 whereClause             : T_WHERE conditionExpr;
@@ -30,7 +31,7 @@ tagFilterExpr          :
 
 tagValueList           : tagValue (T_COMMA tagValue)*;
 timeRangeExpr          : timeExpr (T_AND timeExpr)? ;
-timeExpr               : T_TIME binaryOperator (nowExpr | ident) ;
+timeExpr               : T_TIME binaryOperator (nowExpr | ident | L_INT) ;
 
 nowExpr                 : nowFunc  durationLit? ;
 
@@ -71,6 +72,13 @@ fieldExpr                :
                          | fieldExpr T_DIV fieldExpr
                          | fieldExpr T_ADD fieldExpr
                          | fieldExpr T_SUB fieldExpr
+                         | fieldExpr T_EQUAL fieldExpr
+                         | fieldExpr T_NOTEQUAL fieldExpr
+                         | fieldExpr T_NOTEQUAL2 fieldExpr
+                         | fieldExpr T_LESS fieldExpr
+                         | fieldExpr T_LESSEQUAL fieldExpr
+                         | fieldExpr T_GREATER fieldExpr
+                         | fieldExpr T_GREATEREQUAL fieldExpr
                          | T_OPEN_P fieldExpr T_CLOSE_P
                          | exprFunc
                          | exprAtom
@@ -88,7 +96,8 @@ intervalItem            :
                          | T_YEAR
                          ;
 exprFunc                : funcName T_OPEN_P exprFuncParams? T_CLOSE_P ;
-funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_STDDEV | T_HISTOGRAM;
+funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_STDDEV | T_HISTOGRAM | T_COUNT | T_FIRST | T_LAST | T_TOPK | T_BOTTOMK | T_TIME_SHIFT
+                        | T_ABS | T_CEIL | T_FLOOR | T_ROUND | T_LOG | T_LOG10 | T_SQRT | T_CLAMP_MIN | T_CLAMP_MAX ;
 exprFuncParams          : funcParam (T_COMMA funcParam)* ;
 funcParam               :
                            fieldExpr
@@ -156,6 +165,7 @@ nonReservedWords      :
                         | T_ON
                         | T_STATS
                         | T_TIME
+                        | T_TZ
                         | T_FOR
                         | T_SECOND
                         | T_MINUTE
@@ -170,8 +180,26 @@ nonReservedWords      :
                         | T_AVG
                         | T_STDDEV
                         | T_HISTOGRAM
+                        | T_COUNT
+                        | T_FIRST
+                        | T_LAST
+                        | T_TOPK
+                        | T_BOTTOMK
+                        | T_TIME_SHIFT
+                        | T_ABS
+                        | T_CEIL
+                        | T_FLOOR
+                        | T_ROUND
+                        | T_LOG
+                        | T_LOG10
+                        | T_SQRT
+                        | T_CLAMP_MIN
+                        | T_CLAMP_MAX
                         ;
 
+//time zone clause
+timeZoneClause          : T_TZ T_OPEN_P ident T_CLOSE_P ;
+
 // Lexer rules
 T_CREATE             : C R E A T E                      ;
 T_UPDATE             : U P D A T E                      ;
@@ -229,6 +257,7 @@ T_FOR                : F O R                            ;
 T_STATS              : S T A T S                        ;
 T_TIME               : T I M E                          ;
 T_NOW                : N O W                            ;
+T_TZ                 : T Z                              ;
 T_IN                 : I N                              ;
 
 T_LOG                : L O G                            ;
@@ -240,6 +269,20 @@ T_MAX                : M A X                            ;
 T_AVG                : A V G                            ;
 T_STDDEV             : S T D D E V                      ;
 T_HISTOGRAM          : H I S T O G R A M                ;
+T_COUNT              : C O U N T                        ;
+T_FIRST              : F I R S T                        ;
+T_LAST               : L A S T                          ;
+T_TOPK               : T O P K                          ;
+T_BOTTOMK            : B O T T O M K                    ;
+T_TIME_SHIFT         : T I M E '_' S H I F T            ;
+T_ABS                : A B S                            ;
+T_CEIL               : C E I L                          ;
+T_FLOOR              : F L O O R                        ;
+T_ROUND              : R O U N D                        ;
+T_LOG10              : L O G '1' '0'                    ;
+T_SQRT               : S Q R T                          ;
+T_CLAMP_MIN          : C L A M P '_' M I N              ;
+T_CLAMP_MAX          : C L A M P '_' M A X              ;
 
 //time unit
 T_SECOND             : S                                ;
@@ -295,3 +295,15 @@ func (s *BaseSQLListener) EnterNonReservedWords(ctx *NonReservedWordsContext) {}
 
 // ExitNonReservedWords is called when production nonReservedWords is exited.
 func (s *BaseSQLListener) ExitNonReservedWords(ctx *NonReservedWordsContext) {}
+
+// EnterTimeZoneClause is called when production timeZoneClause is entered.
+func (s *BaseSQLListener) EnterTimeZoneClause(ctx *TimeZoneClauseContext) {}
+
+// ExitTimeZoneClause is called when production timeZoneClause is exited.
+func (s *BaseSQLListener) ExitTimeZoneClause(ctx *TimeZoneClauseContext) {}
+
+// EnterSubQuery is called when production subQuery is entered.
+func (s *BaseSQLListener) EnterSubQuery(ctx *SubQueryContext) {}
+
+// ExitSubQuery is called when production subQuery is exited.
+func (s *BaseSQLListener) ExitSubQuery(ctx *SubQueryContext) {}
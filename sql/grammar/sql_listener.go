@@ -145,6 +145,12 @@ type SQLListener interface {
 	// EnterNonReservedWords is called when entering the nonReservedWords production.
 	EnterNonReservedWords(c *NonReservedWordsContext)
 
+	// EnterTimeZoneClause is called when entering the timeZoneClause production.
+	EnterTimeZoneClause(c *TimeZoneClauseContext)
+
+	// EnterSubQuery is called when entering the subQuery production.
+	EnterSubQuery(c *SubQueryContext)
+
 	// ExitStatement is called when exiting the statement production.
 	ExitStatement(c *StatementContext)
 
@@ -282,4 +288,10 @@ type SQLListener interface {
 
 	// ExitNonReservedWords is called when exiting the nonReservedWords production.
 	ExitNonReservedWords(c *NonReservedWordsContext)
+
+	// ExitTimeZoneClause is called when exiting the timeZoneClause production.
+	ExitTimeZoneClause(c *TimeZoneClauseContext)
+
+	// ExitSubQuery is called when exiting the subQuery production.
+	ExitSubQuery(c *SubQueryContext)
 }
@@ -16,7 +16,7 @@ var _ = strconv.Itoa
 
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 101, 412, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 116, 461, 
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 
@@ -60,145 +60,169 @@ var parserATN = []uint16{
 	3, 41, 3, 41, 3, 42, 3, 42, 3, 42, 3, 43, 3, 43, 3, 44, 3, 44, 3, 45, 3, 
 	45, 3, 46, 3, 46, 5, 46, 398, 10, 46, 3, 46, 3, 46, 3, 46, 5, 46, 403, 
 	10, 46, 7, 46, 405, 10, 46, 12, 46, 14, 46, 408, 11, 46, 3, 47, 3, 47, 
-	3, 47, 2, 5, 22, 50, 60, 48, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 
-	26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 
-	62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 2, 10, 
-	3, 2, 40, 41, 4, 2, 43, 44, 99, 100, 3, 2, 46, 47, 4, 2, 48, 48, 84, 84, 
-	3, 2, 68, 74, 3, 2, 62, 67, 3, 2, 93, 94, 11, 2, 3, 3, 7, 7, 9, 11, 15, 
-	24, 26, 29, 31, 35, 38, 52, 54, 57, 61, 74, 2, 424, 2, 94, 3, 2, 2, 2, 
-	4, 97, 3, 2, 2, 2, 6, 100, 3, 2, 2, 2, 8, 119, 3, 2, 2, 2, 10, 122, 3, 
-	2, 2, 2, 12, 130, 3, 2, 2, 2, 14, 134, 3, 2, 2, 2, 16, 137, 3, 2, 2, 2, 
-	18, 140, 3, 2, 2, 2, 20, 153, 3, 2, 2, 2, 22, 183, 3, 2, 2, 2, 24, 193, 
-	3, 2, 2, 2, 26, 201, 3, 2, 2, 2, 28, 206, 3, 2, 2, 2, 30, 212, 3, 2, 2, 
-	2, 32, 216, 3, 2, 2, 2, 34, 223, 3, 2, 2, 2, 36, 236, 3, 2, 2, 2, 38, 250, 
-	3, 2, 2, 2, 40, 252, 3, 2, 2, 2, 42, 254, 3, 2, 2, 2, 44, 258, 3, 2, 2, 
-	2, 46, 265, 3, 2, 2, 2, 48, 273, 3, 2, 2, 2, 50, 282, 3, 2, 2, 2, 52, 293, 
-	3, 2, 2, 2, 54, 295, 3, 2, 2, 2, 56, 297, 3, 2, 2, 2, 58, 309, 3, 2, 2, 
-	2, 60, 319, 3, 2, 2, 2, 62, 338, 3, 2, 2, 2, 64, 341, 3, 2, 2, 2, 66, 343, 
-	3, 2, 2, 2, 68, 350, 3, 2, 2, 2, 70, 352, 3, 2, 2, 2, 72, 362, 3, 2, 2, 
-	2, 74, 370, 3, 2, 2, 2, 76, 372, 3, 2, 2, 2, 78, 377, 3, 2, 2, 2, 80, 382, 
-	3, 2, 2, 2, 82, 386, 3, 2, 2, 2, 84, 389, 3, 2, 2, 2, 86, 391, 3, 2, 2, 
-	2, 88, 393, 3, 2, 2, 2, 90, 397, 3, 2, 2, 2, 92, 409, 3, 2, 2, 2, 94, 95, 
-	5, 4, 3, 2, 95, 96, 7, 2, 2, 3, 96, 3, 3, 2, 2, 2, 97, 98, 5, 6, 4, 2, 
-	98, 5, 3, 2, 2, 2, 99, 101, 7, 36, 2, 2, 100, 99, 3, 2, 2, 2, 100, 101, 
-	3, 2, 2, 2, 101, 102, 3, 2, 2, 2, 102, 103, 5, 8, 5, 2, 103, 105, 5, 16, 
-	9, 2, 104, 106, 5, 18, 10, 2, 105, 104, 3, 2, 2, 2, 105, 106, 3, 2, 2, 
-	2, 106, 108, 3, 2, 2, 2, 107, 109, 5, 34, 18, 2, 108, 107, 3, 2, 2, 2, 
-	108, 109, 3, 2, 2, 2, 109, 111, 3, 2, 2, 2, 110, 112, 5, 42, 22, 2, 111, 
-	110, 3, 2, 2, 2, 111, 112, 3, 2, 2, 2, 112, 114, 3, 2, 2, 2, 113, 115, 
-	5, 82, 42, 2, 114, 113, 3, 2, 2, 2, 114, 115, 3, 2, 2, 2, 115, 117, 3, 
-	2, 2, 2, 116, 118, 7, 37, 2, 2, 117, 116, 3, 2, 2, 2, 117, 118, 3, 2, 2, 
-	2, 118, 7, 3, 2, 2, 2, 119, 120, 7, 38, 2, 2, 120, 121, 5, 10, 6, 2, 121, 
-	9, 3, 2, 2, 2, 122, 127, 5, 12, 7, 2, 123, 124, 7, 86, 2, 2, 124, 126, 
-	5, 12, 7, 2, 125, 123, 3, 2, 2, 2, 126, 129, 3, 2, 2, 2, 127, 125, 3, 2, 
-	2, 2, 127, 128, 3, 2, 2, 2, 128, 11, 3, 2, 2, 2, 129, 127, 3, 2, 2, 2, 
-	130, 132, 5, 60, 31, 2, 131, 133, 5, 14, 8, 2, 132, 131, 3, 2, 2, 2, 132, 
-	133, 3, 2, 2, 2, 133, 13, 3, 2, 2, 2, 134, 135, 7, 39, 2, 2, 135, 136, 
-	5, 90, 46, 2, 136, 15, 3, 2, 2, 2, 137, 138, 7, 31, 2, 2, 138, 139, 5, 
-	84, 43, 2, 139, 17, 3, 2, 2, 2, 140, 141, 7, 32, 2, 2, 141, 142, 5, 20, 
-	11, 2, 142, 19, 3, 2, 2, 2, 143, 154, 5, 22, 12, 2, 144, 145, 5, 22, 12, 
-	2, 145, 146, 7, 40, 2, 2, 146, 147, 5, 26, 14, 2, 147, 154, 3, 2, 2, 2, 
-	148, 151, 5, 26, 14, 2, 149, 150, 7, 40, 2, 2, 150, 152, 5, 22, 12, 2, 
-	151, 149, 3, 2, 2, 2, 151, 152, 3, 2, 2, 2, 152, 154, 3, 2, 2, 2, 153, 
-	143, 3, 2, 2, 2, 153, 144, 3, 2, 2, 2, 153, 148, 3, 2, 2, 2, 154, 21, 3, 
-	2, 2, 2, 155, 156, 8, 12, 1, 2, 156, 157, 7, 91, 2, 2, 157, 158, 5, 22, 
-	12, 2, 158, 159, 7, 92, 2, 2, 159, 184, 3, 2, 2, 2, 160, 169, 5, 86, 44, 
-	2, 161, 170, 7, 77, 2, 2, 162, 170, 7, 48, 2, 2, 163, 164, 7, 49, 2, 2, 
-	164, 170, 7, 48, 2, 2, 165, 170, 7, 84, 2, 2, 166, 170, 7, 85, 2, 2, 167, 
-	170, 7, 78, 2, 2, 168, 170, 7, 79, 2, 2, 169, 161, 3, 2, 2, 2, 169, 162, 
-	3, 2, 2, 2, 169, 163, 3, 2, 2, 2, 169, 165, 3, 2, 2, 2, 169, 166, 3, 2, 
-	2, 2, 169, 167, 3, 2, 2, 2, 169, 168, 3, 2, 2, 2, 170, 171, 3, 2, 2, 2, 
-	171, 172, 5, 88, 45, 2, 172, 184, 3, 2, 2, 2, 173, 177, 5, 86, 44, 2, 174, 
-	178, 7, 59, 2, 2, 175, 176, 7, 49, 2, 2, 176, 178, 7, 59, 2, 2, 177, 174, 
-	3, 2, 2, 2, 177, 175, 3, 2, 2, 2, 178, 179, 3, 2, 2, 2, 179, 180, 7, 91, 
-	2, 2, 180, 181, 5, 24, 13, 2, 181, 182, 7, 92, 2, 2, 182, 184, 3, 2, 2, 
-	2, 183, 155, 3, 2, 2, 2, 183, 160, 3, 2, 2, 2, 183, 173, 3, 2, 2, 2, 184, 
-	190, 3, 2, 2, 2, 185, 186, 12, 3, 2, 2, 186, 187, 9, 2, 2, 2, 187, 189, 
-	5, 22, 12, 4, 188, 185, 3, 2, 2, 2, 189, 192, 3, 2, 2, 2, 190, 188, 3, 
-	2, 2, 2, 190, 191, 3, 2, 2, 2, 191, 23, 3, 2, 2, 2, 192, 190, 3, 2, 2, 
-	2, 193, 198, 5, 88, 45, 2, 194, 195, 7, 86, 2, 2, 195, 197, 5, 88, 45, 
-	2, 196, 194, 3, 2, 2, 2, 197, 200, 3, 2, 2, 2, 198, 196, 3, 2, 2, 2, 198, 
-	199, 3, 2, 2, 2, 199, 25, 3, 2, 2, 2, 200, 198, 3, 2, 2, 2, 201, 204, 5, 
-	28, 15, 2, 202, 203, 7, 40, 2, 2, 203, 205, 5, 28, 15, 2, 204, 202, 3, 
-	2, 2, 2, 204, 205, 3, 2, 2, 2, 205, 27, 3, 2, 2, 2, 206, 207, 7, 57, 2, 
-	2, 207, 210, 5, 58, 30, 2, 208, 211, 5, 30, 16, 2, 209, 211, 5, 90, 46, 
-	2, 210, 208, 3, 2, 2, 2, 210, 209, 3, 2, 2, 2, 211, 29, 3, 2, 2, 2, 212, 
-	214, 5, 32, 17, 2, 213, 215, 5, 62, 32, 2, 214, 213, 3, 2, 2, 2, 214, 215, 
-	3, 2, 2, 2, 215, 31, 3, 2, 2, 2, 216, 217, 7, 58, 2, 2, 217, 219, 7, 91, 
-	2, 2, 218, 220, 5, 70, 36, 2, 219, 218, 3, 2, 2, 2, 219, 220, 3, 2, 2, 
-	2, 220, 221, 3, 2, 2, 2, 221, 222, 7, 92, 2, 2, 222, 33, 3, 2, 2, 2, 223, 
-	224, 7, 52, 2, 2, 224, 225, 7, 54, 2, 2, 225, 231, 5, 36, 19, 2, 226, 227, 
-	7, 42, 2, 2, 227, 228, 7, 91, 2, 2, 228, 229, 5, 40, 21, 2, 229, 230, 7, 
-	92, 2, 2, 230, 232, 3, 2, 2, 2, 231, 226, 3, 2, 2, 2, 231, 232, 3, 2, 2, 
-	2, 232, 234, 3, 2, 2, 2, 233, 235, 5, 48, 25, 2, 234, 233, 3, 2, 2, 2, 
-	234, 235, 3, 2, 2, 2, 235, 35, 3, 2, 2, 2, 236, 241, 5, 38, 20, 2, 237, 
-	238, 7, 86, 2, 2, 238, 240, 5, 38, 20, 2, 239, 237, 3, 2, 2, 2, 240, 243, 
-	3, 2, 2, 2, 241, 239, 3, 2, 2, 2, 241, 242, 3, 2, 2, 2, 242, 37, 3, 2, 
-	2, 2, 243, 241, 3, 2, 2, 2, 244, 251, 5, 90, 46, 2, 245, 246, 7, 57, 2, 
-	2, 246, 247, 7, 91, 2, 2, 247, 248, 5, 62, 32, 2, 248, 249, 7, 92, 2, 2, 
-	249, 251, 3, 2, 2, 2, 250, 244, 3, 2, 2, 2, 250, 245, 3, 2, 2, 2, 251, 
-	39, 3, 2, 2, 2, 252, 253, 9, 3, 2, 2, 253, 41, 3, 2, 2, 2, 254, 255, 7, 
-	45, 2, 2, 255, 256, 7, 54, 2, 2, 256, 257, 5, 46, 24, 2, 257, 43, 3, 2, 
-	2, 2, 258, 262, 5, 60, 31, 2, 259, 261, 9, 4, 2, 2, 260, 259, 3, 2, 2, 
-	2, 261, 264, 3, 2, 2, 2, 262, 260, 3, 2, 2, 2, 262, 263, 3, 2, 2, 2, 263, 
-	45, 3, 2, 2, 2, 264, 262, 3, 2, 2, 2, 265, 270, 5, 44, 23, 2, 266, 267, 
-	7, 86, 2, 2, 267, 269, 5, 44, 23, 2, 268, 266, 3, 2, 2, 2, 269, 272, 3, 
-	2, 2, 2, 270, 268, 3, 2, 2, 2, 270, 271, 3, 2, 2, 2, 271, 47, 3, 2, 2, 
-	2, 272, 270, 3, 2, 2, 2, 273, 274, 7, 53, 2, 2, 274, 275, 5, 50, 26, 2, 
-	275, 49, 3, 2, 2, 2, 276, 277, 8, 26, 1, 2, 277, 278, 7, 91, 2, 2, 278, 
-	279, 5, 50, 26, 2, 279, 280, 7, 92, 2, 2, 280, 283, 3, 2, 2, 2, 281, 283, 
-	5, 54, 28, 2, 282, 276, 3, 2, 2, 2, 282, 281, 3, 2, 2, 2, 283, 290, 3, 
-	2, 2, 2, 284, 285, 12, 4, 2, 2, 285, 286, 5, 52, 27, 2, 286, 287, 5, 50, 
-	26, 5, 287, 289, 3, 2, 2, 2, 288, 284, 3, 2, 2, 2, 289, 292, 3, 2, 2, 2, 
-	290, 288, 3, 2, 2, 2, 290, 291, 3, 2, 2, 2, 291, 51, 3, 2, 2, 2, 292, 290, 
-	3, 2, 2, 2, 293, 294, 9, 2, 2, 2, 294, 53, 3, 2, 2, 2, 295, 296, 5, 56, 
-	29, 2, 296, 55, 3, 2, 2, 2, 297, 298, 5, 60, 31, 2, 298, 299, 5, 58, 30, 
-	2, 299, 300, 5, 60, 31, 2, 300, 57, 3, 2, 2, 2, 301, 310, 7, 77, 2, 2, 
-	302, 310, 7, 78, 2, 2, 303, 310, 7, 79, 2, 2, 304, 310, 7, 82, 2, 2, 305, 
-	310, 7, 83, 2, 2, 306, 310, 7, 80, 2, 2, 307, 310, 7, 81, 2, 2, 308, 310, 
-	9, 5, 2, 2, 309, 301, 3, 2, 2, 2, 309, 302, 3, 2, 2, 2, 309, 303, 3, 2, 
-	2, 2, 309, 304, 3, 2, 2, 2, 309, 305, 3, 2, 2, 2, 309, 306, 3, 2, 2, 2, 
-	309, 307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 59, 3, 2, 2, 2, 311, 312, 
-	8, 31, 1, 2, 312, 313, 7, 91, 2, 2, 313, 314, 5, 60, 31, 2, 314, 315, 7, 
-	92, 2, 2, 315, 320, 3, 2, 2, 2, 316, 320, 5, 66, 34, 2, 317, 320, 5, 74, 
+	3, 47, 3, 15, 4, 48, 9, 48, 3, 48, 3, 48, 3, 48, 3, 48, 3, 48, 10, 4, 5, 
+	4, 420, 3, 4, 12, 9, 10, 9, 7, 9, 424, 11, 9, 14, 9, 426, 3, 9, 3, 9, 4, 
+	49, 9, 49, 3, 49, 3, 49, 3, 49, 3, 49, 10, 9, 5, 9, 436, 3, 9, 3, 9, 3, 
+	31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 
+	3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 2, 
+	5, 22, 50, 60, 50, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 
+	30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 58, 60, 62, 64, 
+	66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 413, 430, 2, 10, 
+	3, 2, 40, 41, 4, 2, 43, 44, 114, 115, 3, 2, 46, 47, 4, 2, 48, 48, 99, 99, 
+	3, 2, 83, 89, 4, 2, 61, 61, 63, 82, 3, 2, 108, 109, 12, 2, 3, 3, 7, 7, 
+	9, 11, 15, 24, 26, 29, 31, 35, 38, 52, 54, 57, 59, 59, 61, 89, 2, 482, 
+	2, 94, 3, 2, 2, 2, 4, 97, 3, 2, 2, 2, 6, 100, 3, 2, 2, 2, 8, 119, 3, 2, 
+	2, 2, 10, 122, 3, 2, 2, 2, 12, 130, 3, 2, 2, 2, 14, 134, 3, 2, 2, 2, 16, 
+	137, 3, 2, 2, 2, 18, 140, 3, 2, 2, 2, 20, 153, 3, 2, 2, 2, 22, 183, 3, 
+	2, 2, 2, 24, 193, 3, 2, 2, 2, 26, 201, 3, 2, 2, 2, 28, 206, 3, 2, 2, 2, 
+	30, 212, 3, 2, 2, 2, 32, 216, 3, 2, 2, 2, 34, 223, 3, 2, 2, 2, 36, 236, 
+	3, 2, 2, 2, 38, 250, 3, 2, 2, 2, 40, 252, 3, 2, 2, 2, 42, 254, 3, 2, 2, 
+	2, 44, 258, 3, 2, 2, 2, 46, 265, 3, 2, 2, 2, 48, 273, 3, 2, 2, 2, 50, 282, 
+	3, 2, 2, 2, 52, 293, 3, 2, 2, 2, 54, 295, 3, 2, 2, 2, 56, 297, 3, 2, 2, 
+	2, 58, 309, 3, 2, 2, 2, 60, 319, 3, 2, 2, 2, 62, 338, 3, 2, 2, 2, 64, 341, 
+	3, 2, 2, 2, 66, 343, 3, 2, 2, 2, 68, 350, 3, 2, 2, 2, 70, 352, 3, 2, 2, 
+	2, 72, 362, 3, 2, 2, 2, 74, 370, 3, 2, 2, 2, 76, 372, 3, 2, 2, 2, 78, 377, 
+	3, 2, 2, 2, 80, 382, 3, 2, 2, 2, 82, 386, 3, 2, 2, 2, 84, 389, 3, 2, 2, 
+	2, 86, 391, 3, 2, 2, 2, 88, 393, 3, 2, 2, 2, 90, 397, 3, 2, 2, 2, 92, 409, 
+	3, 2, 2, 2, 94, 95, 5, 4, 3, 2, 95, 96, 7, 2, 2, 3, 96, 3, 3, 2, 2, 2, 
+	97, 98, 5, 6, 4, 2, 98, 5, 3, 2, 2, 2, 99, 101, 7, 36, 2, 2, 100, 99, 3, 
+	2, 2, 2, 100, 101, 3, 2, 2, 2, 101, 102, 3, 2, 2, 2, 102, 103, 5, 8, 5, 
+	2, 103, 105, 5, 16, 9, 2, 104, 106, 5, 18, 10, 2, 105, 104, 3, 2, 2, 2, 
+	105, 106, 3, 2, 2, 2, 106, 108, 3, 2, 2, 2, 107, 109, 5, 34, 18, 2, 108, 
+	107, 3, 2, 2, 2, 108, 109, 3, 2, 2, 2, 109, 111, 3, 2, 2, 2, 110, 112, 
+	5, 42, 22, 2, 111, 110, 3, 2, 2, 2, 111, 112, 3, 2, 2, 2, 112, 114, 3, 
+	2, 2, 2, 113, 115, 5, 82, 42, 2, 114, 113, 3, 2, 2, 2, 114, 115, 3, 2, 
+	2, 2, 115, 421, 3, 2, 2, 2, 116, 118, 7, 37, 2, 2, 117, 116, 3, 2, 2, 2, 
+	117, 118, 3, 2, 2, 2, 118, 7, 3, 2, 2, 2, 119, 120, 7, 38, 2, 2, 120, 121, 
+	5, 10, 6, 2, 121, 9, 3, 2, 2, 2, 122, 127, 5, 12, 7, 2, 123, 124, 7, 101, 
+	2, 2, 124, 126, 5, 12, 7, 2, 125, 123, 3, 2, 2, 2, 126, 129, 3, 2, 2, 2, 
+	127, 125, 3, 2, 2, 2, 127, 128, 3, 2, 2, 2, 128, 11, 3, 2, 2, 2, 129, 127, 
+	3, 2, 2, 2, 130, 132, 5, 60, 31, 2, 131, 133, 5, 14, 8, 2, 132, 131, 3, 
+	2, 2, 2, 132, 133, 3, 2, 2, 2, 133, 13, 3, 2, 2, 2, 134, 135, 7, 39, 2, 
+	2, 135, 136, 5, 90, 46, 2, 136, 15, 3, 2, 2, 2, 137, 437, 7, 31, 2, 2, 
+	138, 423, 5, 84, 43, 2, 139, 17, 3, 2, 2, 2, 140, 141, 7, 32, 2, 2, 141, 
+	142, 5, 20, 11, 2, 142, 19, 3, 2, 2, 2, 143, 154, 5, 22, 12, 2, 144, 145, 
+	5, 22, 12, 2, 145, 146, 7, 40, 2, 2, 146, 147, 5, 26, 14, 2, 147, 154, 
+	3, 2, 2, 2, 148, 151, 5, 26, 14, 2, 149, 150, 7, 40, 2, 2, 150, 152, 5, 
+	22, 12, 2, 151, 149, 3, 2, 2, 2, 151, 152, 3, 2, 2, 2, 152, 154, 3, 2, 
+	2, 2, 153, 143, 3, 2, 2, 2, 153, 144, 3, 2, 2, 2, 153, 148, 3, 2, 2, 2, 
+	154, 21, 3, 2, 2, 2, 155, 156, 8, 12, 1, 2, 156, 157, 7, 106, 2, 2, 157, 
+	158, 5, 22, 12, 2, 158, 159, 7, 107, 2, 2, 159, 184, 3, 2, 2, 2, 160, 169, 
+	5, 86, 44, 2, 161, 170, 7, 92, 2, 2, 162, 170, 7, 48, 2, 2, 163, 164, 7, 
+	49, 2, 2, 164, 170, 7, 48, 2, 2, 165, 170, 7, 99, 2, 2, 166, 170, 7, 100, 
+	2, 2, 167, 170, 7, 93, 2, 2, 168, 170, 7, 94, 2, 2, 169, 161, 3, 2, 2, 
+	2, 169, 162, 3, 2, 2, 2, 169, 163, 3, 2, 2, 2, 169, 165, 3, 2, 2, 2, 169, 
+	166, 3, 2, 2, 2, 169, 167, 3, 2, 2, 2, 169, 168, 3, 2, 2, 2, 170, 171, 
+	3, 2, 2, 2, 171, 172, 5, 88, 45, 2, 172, 184, 3, 2, 2, 2, 173, 177, 5, 
+	86, 44, 2, 174, 178, 7, 60, 2, 2, 175, 176, 7, 49, 2, 2, 176, 178, 7, 60, 
+	2, 2, 177, 174, 3, 2, 2, 2, 177, 175, 3, 2, 2, 2, 178, 179, 3, 2, 2, 2, 
+	179, 180, 7, 106, 2, 2, 180, 181, 5, 24, 13, 2, 181, 182, 7, 107, 2, 2, 
+	182, 184, 3, 2, 2, 2, 183, 155, 3, 2, 2, 2, 183, 160, 3, 2, 2, 2, 183, 
+	173, 3, 2, 2, 2, 184, 190, 3, 2, 2, 2, 185, 186, 12, 3, 2, 2, 186, 187, 
+	9, 2, 2, 2, 187, 189, 5, 22, 12, 4, 188, 185, 3, 2, 2, 2, 189, 192, 3, 
+	2, 2, 2, 190, 188, 3, 2, 2, 2, 190, 191, 3, 2, 2, 2, 191, 23, 3, 2, 2, 
+	2, 192, 190, 3, 2, 2, 2, 193, 198, 5, 88, 45, 2, 194, 195, 7, 101, 2, 2, 
+	195, 197, 5, 88, 45, 2, 196, 194, 3, 2, 2, 2, 197, 200, 3, 2, 2, 2, 198, 
+	196, 3, 2, 2, 2, 198, 199, 3, 2, 2, 2, 199, 25, 3, 2, 2, 2, 200, 198, 3, 
+	2, 2, 2, 201, 204, 5, 28, 15, 2, 202, 203, 7, 40, 2, 2, 203, 205, 5, 28, 
+	15, 2, 204, 202, 3, 2, 2, 2, 204, 205, 3, 2, 2, 2, 205, 27, 3, 2, 2, 2, 
+	206, 207, 7, 57, 2, 2, 207, 210, 5, 58, 30, 2, 208, 211, 5, 30, 16, 2, 
+	209, 211, 5, 90, 46, 2, 210, 208, 3, 2, 2, 2, 210, 209, 3, 2, 2, 2, 210, 
+	412, 3, 2, 2, 2, 211, 29, 3, 2, 2, 2, 212, 214, 5, 32, 17, 2, 213, 215, 
+	5, 62, 32, 2, 214, 213, 3, 2, 2, 2, 214, 215, 3, 2, 2, 2, 215, 31, 3, 2, 
+	2, 2, 216, 217, 7, 58, 2, 2, 217, 219, 7, 106, 2, 2, 218, 220, 5, 70, 36, 
+	2, 219, 218, 3, 2, 2, 2, 219, 220, 3, 2, 2, 2, 220, 221, 3, 2, 2, 2, 221, 
+	222, 7, 107, 2, 2, 222, 33, 3, 2, 2, 2, 223, 224, 7, 52, 2, 2, 224, 225, 
+	7, 54, 2, 2, 225, 231, 5, 36, 19, 2, 226, 227, 7, 42, 2, 2, 227, 228, 7, 
+	106, 2, 2, 228, 229, 5, 40, 21, 2, 229, 230, 7, 107, 2, 2, 230, 232, 3, 
+	2, 2, 2, 231, 226, 3, 2, 2, 2, 231, 232, 3, 2, 2, 2, 232, 234, 3, 2, 2, 
+	2, 233, 235, 5, 48, 25, 2, 234, 233, 3, 2, 2, 2, 234, 235, 3, 2, 2, 2, 
+	235, 35, 3, 2, 2, 2, 236, 241, 5, 38, 20, 2, 237, 238, 7, 101, 2, 2, 238, 
+	240, 5, 38, 20, 2, 239, 237, 3, 2, 2, 2, 240, 243, 3, 2, 2, 2, 241, 239, 
+	3, 2, 2, 2, 241, 242, 3, 2, 2, 2, 242, 37, 3, 2, 2, 2, 243, 241, 3, 2, 
+	2, 2, 244, 251, 5, 90, 46, 2, 245, 246, 7, 57, 2, 2, 246, 247, 7, 106, 
+	2, 2, 247, 248, 5, 62, 32, 2, 248, 249, 7, 107, 2, 2, 249, 251, 3, 2, 2, 
+	2, 250, 244, 3, 2, 2, 2, 250, 245, 3, 2, 2, 2, 251, 39, 3, 2, 2, 2, 252, 
+	253, 9, 3, 2, 2, 253, 41, 3, 2, 2, 2, 254, 255, 7, 45, 2, 2, 255, 256, 
+	7, 54, 2, 2, 256, 257, 5, 46, 24, 2, 257, 43, 3, 2, 2, 2, 258, 262, 5, 
+	60, 31, 2, 259, 261, 9, 4, 2, 2, 260, 259, 3, 2, 2, 2, 261, 264, 3, 2, 
+	2, 2, 262, 260, 3, 2, 2, 2, 262, 263, 3, 2, 2, 2, 263, 45, 3, 2, 2, 2, 
+	264, 262, 3, 2, 2, 2, 265, 270, 5, 44, 23, 2, 266, 267, 7, 101, 2, 2, 267, 
+	269, 5, 44, 23, 2, 268, 266, 3, 2, 2, 2, 269, 272, 3, 2, 2, 2, 270, 268, 
+	3, 2, 2, 2, 270, 271, 3, 2, 2, 2, 271, 47, 3, 2, 2, 2, 272, 270, 3, 2, 
+	2, 2, 273, 274, 7, 53, 2, 2, 274, 275, 5, 50, 26, 2, 275, 49, 3, 2, 2, 
+	2, 276, 277, 8, 26, 1, 2, 277, 278, 7, 106, 2, 2, 278, 279, 5, 50, 26, 
+	2, 279, 280, 7, 107, 2, 2, 280, 283, 3, 2, 2, 2, 281, 283, 5, 54, 28, 2, 
+	282, 276, 3, 2, 2, 2, 282, 281, 3, 2, 2, 2, 283, 290, 3, 2, 2, 2, 284, 
+	285, 12, 4, 2, 2, 285, 286, 5, 52, 27, 2, 286, 287, 5, 50, 26, 5, 287, 
+	289, 3, 2, 2, 2, 288, 284, 3, 2, 2, 2, 289, 292, 3, 2, 2, 2, 290, 288, 
+	3, 2, 2, 2, 290, 291, 3, 2, 2, 2, 291, 51, 3, 2, 2, 2, 292, 290, 3, 2, 
+	2, 2, 293, 294, 9, 2, 2, 2, 294, 53, 3, 2, 2, 2, 295, 296, 5, 56, 29, 2, 
+	296, 55, 3, 2, 2, 2, 297, 298, 5, 60, 31, 2, 298, 299, 5, 58, 30, 2, 299, 
+	300, 5, 60, 31, 2, 300, 57, 3, 2, 2, 2, 301, 310, 7, 92, 2, 2, 302, 310, 
+	7, 93, 2, 2, 303, 310, 7, 94, 2, 2, 304, 310, 7, 97, 2, 2, 305, 310, 7, 
+	98, 2, 2, 306, 310, 7, 95, 2, 2, 307, 310, 7, 96, 2, 2, 308, 310, 9, 5, 
+	2, 2, 309, 301, 3, 2, 2, 2, 309, 302, 3, 2, 2, 2, 309, 303, 3, 2, 2, 2, 
+	309, 304, 3, 2, 2, 2, 309, 305, 3, 2, 2, 2, 309, 306, 3, 2, 2, 2, 309, 
+	307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 59, 3, 2, 2, 2, 311, 312, 8, 
+	31, 1, 2, 312, 313, 7, 106, 2, 2, 313, 314, 5, 60, 31, 2, 314, 315, 7, 
+	107, 2, 2, 315, 320, 3, 2, 2, 2, 316, 320, 5, 66, 34, 2, 317, 320, 5, 74, 
 	38, 2, 318, 320, 5, 62, 32, 2, 319, 311, 3, 2, 2, 2, 319, 316, 3, 2, 2, 
 	2, 319, 317, 3, 2, 2, 2, 319, 318, 3, 2, 2, 2, 320, 335, 3, 2, 2, 2, 321, 
-	322, 12, 10, 2, 2, 322, 323, 7, 96, 2, 2, 323, 334, 5, 60, 31, 11, 324, 
-	325, 12, 9, 2, 2, 325, 326, 7, 95, 2, 2, 326, 334, 5, 60, 31, 10, 327, 
-	328, 12, 8, 2, 2, 328, 329, 7, 93, 2, 2, 329, 334, 5, 60, 31, 9, 330, 331, 
-	12, 7, 2, 2, 331, 332, 7, 94, 2, 2, 332, 334, 5, 60, 31, 8, 333, 321, 3, 
-	2, 2, 2, 333, 324, 3, 2, 2, 2, 333, 327, 3, 2, 2, 2, 333, 330, 3, 2, 2, 
-	2, 334, 337, 3, 2, 2, 2, 335, 333, 3, 2, 2, 2, 335, 336, 3, 2, 2, 2, 336, 
-	61, 3, 2, 2, 2, 337, 335, 3, 2, 2, 2, 338, 339, 5, 78, 40, 2, 339, 340, 
-	5, 64, 33, 2, 340, 63, 3, 2, 2, 2, 341, 342, 9, 6, 2, 2, 342, 65, 3, 2, 
-	2, 2, 343, 344, 5, 68, 35, 2, 344, 346, 7, 91, 2, 2, 345, 347, 5, 70, 36, 
-	2, 346, 345, 3, 2, 2, 2, 346, 347, 3, 2, 2, 2, 347, 348, 3, 2, 2, 2, 348, 
-	349, 7, 92, 2, 2, 349, 67, 3, 2, 2, 2, 350, 351, 9, 7, 2, 2, 351, 69, 3, 
-	2, 2, 2, 352, 357, 5, 72, 37, 2, 353, 354, 7, 86, 2, 2, 354, 356, 5, 72, 
-	37, 2, 355, 353, 3, 2, 2, 2, 356, 359, 3, 2, 2, 2, 357, 355, 3, 2, 2, 2, 
-	357, 358, 3, 2, 2, 2, 358, 71, 3, 2, 2, 2, 359, 357, 3, 2, 2, 2, 360, 363, 
-	5, 60, 31, 2, 361, 363, 5, 22, 12, 2, 362, 360, 3, 2, 2, 2, 362, 361, 3, 
-	2, 2, 2, 363, 73, 3, 2, 2, 2, 364, 366, 5, 90, 46, 2, 365, 367, 5, 76, 
-	39, 2, 366, 365, 3, 2, 2, 2, 366, 367, 3, 2, 2, 2, 367, 371, 3, 2, 2, 2, 
-	368, 371, 5, 80, 41, 2, 369, 371, 5, 78, 40, 2, 370, 364, 3, 2, 2, 2, 370, 
-	368, 3, 2, 2, 2, 370, 369, 3, 2, 2, 2, 371, 75, 3, 2, 2, 2, 372, 373, 7, 
-	89, 2, 2, 373, 374, 5, 22, 12, 2, 374, 375, 7, 90, 2, 2, 375, 77, 3, 2, 
-	2, 2, 376, 378, 9, 8, 2, 2, 377, 376, 3, 2, 2, 2, 377, 378, 3, 2, 2, 2, 
-	378, 379, 3, 2, 2, 2, 379, 380, 7, 99, 2, 2, 380, 79, 3, 2, 2, 2, 381, 
-	383, 9, 8, 2, 2, 382, 381, 3, 2, 2, 2, 382, 383, 3, 2, 2, 2, 383, 384, 
-	3, 2, 2, 2, 384, 385, 7, 100, 2, 2, 385, 81, 3, 2, 2, 2, 386, 387, 7, 33, 
-	2, 2, 387, 388, 7, 99, 2, 2, 388, 83, 3, 2, 2, 2, 389, 390, 5, 90, 46, 
-	2, 390, 85, 3, 2, 2, 2, 391, 392, 5, 90, 46, 2, 392, 87, 3, 2, 2, 2, 393, 
-	394, 5, 90, 46, 2, 394, 89, 3, 2, 2, 2, 395, 398, 7, 98, 2, 2, 396, 398, 
-	5, 92, 47, 2, 397, 395, 3, 2, 2, 2, 397, 396, 3, 2, 2, 2, 398, 406, 3, 
-	2, 2, 2, 399, 402, 7, 75, 2, 2, 400, 403, 7, 98, 2, 2, 401, 403, 5, 92, 
-	47, 2, 402, 400, 3, 2, 2, 2, 402, 401, 3, 2, 2, 2, 403, 405, 3, 2, 2, 2, 
-	404, 399, 3, 2, 2, 2, 405, 408, 3, 2, 2, 2, 406, 404, 3, 2, 2, 2, 406, 
-	407, 3, 2, 2, 2, 407, 91, 3, 2, 2, 2, 408, 406, 3, 2, 2, 2, 409, 410, 9, 
-	9, 2, 2, 410, 93, 3, 2, 2, 2, 43, 100, 105, 108, 111, 114, 117, 127, 132, 
-	151, 153, 169, 177, 183, 190, 198, 204, 210, 214, 219, 231, 234, 241, 250, 
-	262, 270, 282, 290, 309, 319, 333, 335, 346, 357, 362, 366, 370, 377, 382, 
-	397, 402, 406,
+	322, 12, 17, 2, 2, 322, 323, 7, 111, 2, 2, 323, 334, 5, 60, 31, 18, 324, 
+	325, 12, 16, 2, 2, 325, 326, 7, 110, 2, 2, 326, 334, 5, 60, 31, 17, 327, 
+	328, 12, 15, 2, 2, 328, 329, 7, 108, 2, 2, 329, 334, 5, 60, 31, 16, 330, 
+	331, 12, 14, 2, 2, 331, 332, 7, 109, 2, 2, 332, 334, 5, 60, 31, 15, 333, 
+	321, 3, 2, 2, 2, 333, 324, 3, 2, 2, 2, 333, 327, 3, 2, 2, 2, 333, 330, 
+	3, 2, 2, 2, 333, 440, 3, 2, 2, 2, 333, 443, 3, 2, 2, 2, 333, 446, 3, 2, 
+	2, 2, 333, 449, 3, 2, 2, 2, 333, 452, 3, 2, 2, 2, 333, 455, 3, 2, 2, 2, 
+	333, 458, 3, 2, 2, 2, 334, 337, 3, 2, 2, 2, 335, 333, 3, 2, 2, 2, 335, 
+	336, 3, 2, 2, 2, 336, 61, 3, 2, 2, 2, 337, 335, 3, 2, 2, 2, 338, 339, 5, 
+	78, 40, 2, 339, 340, 5, 64, 33, 2, 340, 63, 3, 2, 2, 2, 341, 342, 9, 6, 
+	2, 2, 342, 65, 3, 2, 2, 2, 343, 344, 5, 68, 35, 2, 344, 346, 7, 106, 2, 
+	2, 345, 347, 5, 70, 36, 2, 346, 345, 3, 2, 2, 2, 346, 347, 3, 2, 2, 2, 
+	347, 348, 3, 2, 2, 2, 348, 349, 7, 107, 2, 2, 349, 67, 3, 2, 2, 2, 350, 
+	351, 9, 7, 2, 2, 351, 69, 3, 2, 2, 2, 352, 357, 5, 72, 37, 2, 353, 354, 
+	7, 101, 2, 2, 354, 356, 5, 72, 37, 2, 355, 353, 3, 2, 2, 2, 356, 359, 3, 
+	2, 2, 2, 357, 355, 3, 2, 2, 2, 357, 358, 3, 2, 2, 2, 358, 71, 3, 2, 2, 
+	2, 359, 357, 3, 2, 2, 2, 360, 363, 5, 60, 31, 2, 361, 363, 5, 22, 12, 2, 
+	362, 360, 3, 2, 2, 2, 362, 361, 3, 2, 2, 2, 363, 73, 3, 2, 2, 2, 364, 366, 
+	5, 90, 46, 2, 365, 367, 5, 76, 39, 2, 366, 365, 3, 2, 2, 2, 366, 367, 3, 
+	2, 2, 2, 367, 371, 3, 2, 2, 2, 368, 371, 5, 80, 41, 2, 369, 371, 5, 78, 
+	40, 2, 370, 364, 3, 2, 2, 2, 370, 368, 3, 2, 2, 2, 370, 369, 3, 2, 2, 2, 
+	371, 75, 3, 2, 2, 2, 372, 373, 7, 104, 2, 2, 373, 374, 5, 22, 12, 2, 374, 
+	375, 7, 105, 2, 2, 375, 77, 3, 2, 2, 2, 376, 378, 9, 8, 2, 2, 377, 376, 
+	3, 2, 2, 2, 377, 378, 3, 2, 2, 2, 378, 379, 3, 2, 2, 2, 379, 380, 7, 114, 
+	2, 2, 380, 79, 3, 2, 2, 2, 381, 383, 9, 8, 2, 2, 382, 381, 3, 2, 2, 2, 
+	382, 383, 3, 2, 2, 2, 383, 384, 3, 2, 2, 2, 384, 385, 7, 115, 2, 2, 385, 
+	81, 3, 2, 2, 2, 386, 387, 7, 33, 2, 2, 387, 388, 7, 114, 2, 2, 388, 83, 
+	3, 2, 2, 2, 389, 390, 5, 90, 46, 2, 390, 85, 3, 2, 2, 2, 391, 392, 5, 90, 
+	46, 2, 392, 87, 3, 2, 2, 2, 393, 394, 5, 90, 46, 2, 394, 89, 3, 2, 2, 2, 
+	395, 398, 7, 113, 2, 2, 396, 398, 5, 92, 47, 2, 397, 395, 3, 2, 2, 2, 397, 
+	396, 3, 2, 2, 2, 398, 406, 3, 2, 2, 2, 399, 402, 7, 90, 2, 2, 400, 403, 
+	7, 113, 2, 2, 401, 403, 5, 92, 47, 2, 402, 400, 3, 2, 2, 2, 402, 401, 3, 
+	2, 2, 2, 403, 405, 3, 2, 2, 2, 404, 399, 3, 2, 2, 2, 405, 408, 3, 2, 2, 
+	2, 406, 404, 3, 2, 2, 2, 406, 407, 3, 2, 2, 2, 407, 91, 3, 2, 2, 2, 408, 
+	406, 3, 2, 2, 2, 409, 410, 9, 9, 2, 2, 410, 93, 3, 2, 2, 2, 412, 211, 7, 
+	114, 2, 2, 413, 415, 3, 2, 2, 2, 415, 416, 7, 59, 2, 2, 416, 417, 7, 106, 
+	2, 2, 417, 418, 5, 90, 46, 2, 418, 419, 7, 107, 2, 2, 419, 414, 3, 2, 2, 
+	2, 421, 422, 3, 2, 2, 2, 421, 420, 3, 2, 2, 2, 422, 420, 5, 413, 48, 2, 
+	420, 117, 3, 2, 2, 2, 423, 425, 3, 2, 2, 2, 423, 427, 3, 2, 2, 2, 425, 
+	428, 3, 2, 2, 2, 428, 429, 7, 101, 2, 2, 429, 424, 5, 84, 43, 2, 424, 426, 
+	3, 2, 2, 2, 426, 423, 3, 2, 2, 2, 427, 436, 3, 2, 2, 2, 430, 432, 3, 2, 
+	2, 2, 432, 433, 7, 106, 2, 2, 433, 434, 5, 6, 4, 2, 434, 435, 7, 107, 2, 
+	2, 435, 431, 3, 2, 2, 2, 437, 438, 3, 2, 2, 2, 437, 439, 3, 2, 2, 2, 438, 
+	138, 3, 2, 2, 2, 439, 436, 5, 430, 49, 2, 436, 139, 3, 2, 2, 2, 440, 441, 
+	12, 13, 2, 2, 441, 442, 7, 92, 2, 2, 442, 334, 5, 60, 31, 14, 443, 444, 
+	12, 12, 2, 2, 444, 445, 7, 93, 2, 2, 445, 334, 5, 60, 31, 13, 446, 447, 
+	12, 11, 2, 2, 447, 448, 7, 94, 2, 2, 448, 334, 5, 60, 31, 12, 449, 450, 
+	12, 10, 2, 2, 450, 451, 7, 97, 2, 2, 451, 334, 5, 60, 31, 11, 452, 453, 
+	12, 9, 2, 2, 453, 454, 7, 98, 2, 2, 454, 334, 5, 60, 31, 10, 455, 456, 
+	12, 8, 2, 2, 456, 457, 7, 95, 2, 2, 457, 334, 5, 60, 31, 9, 458, 459, 12, 
+	7, 2, 2, 459, 460, 7, 96, 2, 2, 460, 334, 5, 60, 31, 8, 46, 100, 105, 108, 
+	111, 114, 117, 127, 132, 151, 153, 169, 177, 183, 190, 198, 204, 210, 214, 
+	219, 231, 234, 241, 250, 262, 270, 282, 290, 309, 319, 333, 335, 346, 357, 
+	362, 366, 370, 377, 382, 397, 402, 406, 421, 423, 437,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -207,10 +231,11 @@ var literalNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
-	"", "", "", "", "", "", "", "", "", "", "", "", "", "'m'", "", "", "", 
-	"'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", 
-	"'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", 
-	"'-'", "'/'", "'*'", "'%'",
+	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
+	"", "", "", "", "", "", "", "", "", "", "'m'", "", "", "", "'M'", "", "'.'", 
+	"':'", "'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", "'=~'", "'!~'", 
+	"','", "'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", "'-'", "'/'", "'*'", 
+	"'%'",
 }
 var symbolicNames = []string{
 	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_INTERVAL", "T_INTERVAL_NAME", 
@@ -221,10 +246,12 @@ var symbolicNames = []string{
 	"T_EXPLAIN", "T_WITH_VALUE", "T_SELECT", "T_AS", "T_AND", "T_OR", "T_FILL", 
 	"T_NULL", "T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", 
 	"T_BETWEEN", "T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", 
-	"T_TIME", "T_NOW", "T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", 
-	"T_AVG", "T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", 
-	"T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", 
-	"T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", 
+	"T_TIME", "T_NOW", "T_TZ", "T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", 
+	"T_MAX", "T_AVG", "T_STDDEV", "T_HISTOGRAM", "T_COUNT", "T_FIRST", "T_LAST", 
+	"T_TOPK", "T_BOTTOMK", "T_TIME_SHIFT", "T_ABS", "T_CEIL", "T_FLOOR", "T_ROUND", 
+	"T_LOG10", "T_SQRT", "T_CLAMP_MIN", "T_CLAMP_MAX", "T_SECOND", "T_MINUTE", 
+	"T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", 
+	"T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", 
 	"T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", 
 	"T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", 
 	"T_MOD", "L_ID", "L_INT", "L_DEC", "WS",
@@ -239,7 +266,7 @@ var ruleNames = []string{
 	"binaryExpr", "binaryOperator", "fieldExpr", "durationLit", "intervalItem", 
 	"exprFunc", "funcName", "exprFuncParams", "funcParam", "exprAtom", "identFilter", 
 	"intNumber", "decNumber", "limitClause", "metricName", "tagKey", "tagValue", 
-	"ident", "nonReservedWords",
+	"ident", "nonReservedWords", "timeZoneClause", "subQuery",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -326,49 +353,64 @@ const (
 	SQLParserT_STATS = 54
 	SQLParserT_TIME = 55
 	SQLParserT_NOW = 56
-	SQLParserT_IN = 57
-	SQLParserT_LOG = 58
-	SQLParserT_PROFILE = 59
-	SQLParserT_SUM = 60
-	SQLParserT_MIN = 61
-	SQLParserT_MAX = 62
-	SQLParserT_AVG = 63
-	SQLParserT_STDDEV = 64
-	SQLParserT_HISTOGRAM = 65
-	SQLParserT_SECOND = 66
-	SQLParserT_MINUTE = 67
-	SQLParserT_HOUR = 68
-	SQLParserT_DAY = 69
-	SQLParserT_WEEK = 70
-	SQLParserT_MONTH = 71
-	SQLParserT_YEAR = 72
-	SQLParserT_DOT = 73
-	SQLParserT_COLON = 74
-	SQLParserT_EQUAL = 75
-	SQLParserT_NOTEQUAL = 76
-	SQLParserT_NOTEQUAL2 = 77
-	SQLParserT_GREATER = 78
-	SQLParserT_GREATEREQUAL = 79
-	SQLParserT_LESS = 80
-	SQLParserT_LESSEQUAL = 81
-	SQLParserT_REGEXP = 82
-	SQLParserT_NEQREGEXP = 83
-	SQLParserT_COMMA = 84
-	SQLParserT_OPEN_B = 85
-	SQLParserT_CLOSE_B = 86
-	SQLParserT_OPEN_SB = 87
-	SQLParserT_CLOSE_SB = 88
-	SQLParserT_OPEN_P = 89
-	SQLParserT_CLOSE_P = 90
-	SQLParserT_ADD = 91
-	SQLParserT_SUB = 92
-	SQLParserT_DIV = 93
-	SQLParserT_MUL = 94
-	SQLParserT_MOD = 95
-	SQLParserL_ID = 96
-	SQLParserL_INT = 97
-	SQLParserL_DEC = 98
-	SQLParserWS = 99
+	SQLParserT_TZ = 57
+	SQLParserT_IN = 58
+	SQLParserT_LOG = 59
+	SQLParserT_PROFILE = 60
+	SQLParserT_SUM = 61
+	SQLParserT_MIN = 62
+	SQLParserT_MAX = 63
+	SQLParserT_AVG = 64
+	SQLParserT_STDDEV = 65
+	SQLParserT_HISTOGRAM = 66
+	SQLParserT_COUNT = 67
+	SQLParserT_FIRST = 68
+	SQLParserT_LAST = 69
+	SQLParserT_TOPK = 70
+	SQLParserT_BOTTOMK = 71
+	SQLParserT_TIME_SHIFT = 72
+	SQLParserT_ABS = 73
+	SQLParserT_CEIL = 74
+	SQLParserT_FLOOR = 75
+	SQLParserT_ROUND = 76
+	SQLParserT_LOG10 = 77
+	SQLParserT_SQRT = 78
+	SQLParserT_CLAMP_MIN = 79
+	SQLParserT_CLAMP_MAX = 80
+	SQLParserT_SECOND = 81
+	SQLParserT_MINUTE = 82
+	SQLParserT_HOUR = 83
+	SQLParserT_DAY = 84
+	SQLParserT_WEEK = 85
+	SQLParserT_MONTH = 86
+	SQLParserT_YEAR = 87
+	SQLParserT_DOT = 88
+	SQLParserT_COLON = 89
+	SQLParserT_EQUAL = 90
+	SQLParserT_NOTEQUAL = 91
+	SQLParserT_NOTEQUAL2 = 92
+	SQLParserT_GREATER = 93
+	SQLParserT_GREATEREQUAL = 94
+	SQLParserT_LESS = 95
+	SQLParserT_LESSEQUAL = 96
+	SQLParserT_REGEXP = 97
+	SQLParserT_NEQREGEXP = 98
+	SQLParserT_COMMA = 99
+	SQLParserT_OPEN_B = 100
+	SQLParserT_CLOSE_B = 101
+	SQLParserT_OPEN_SB = 102
+	SQLParserT_CLOSE_SB = 103
+	SQLParserT_OPEN_P = 104
+	SQLParserT_CLOSE_P = 105
+	SQLParserT_ADD = 106
+	SQLParserT_SUB = 107
+	SQLParserT_DIV = 108
+	SQLParserT_MUL = 109
+	SQLParserT_MOD = 110
+	SQLParserL_ID = 111
+	SQLParserL_INT = 112
+	SQLParserL_DEC = 113
+	SQLParserWS = 114
 )
 
 // SQLParser rules.
@@ -419,6 +461,8 @@ const (
 	SQLParserRULE_tagValue = 43
 	SQLParserRULE_ident = 44
 	SQLParserRULE_nonReservedWords = 45
+	SQLParserRULE_timeZoneClause = 46
+	SQLParserRULE_subQuery = 47
 )
 
 // IStatementContext is an interface to support dynamic dispatch.
@@ -743,6 +787,16 @@ func (s *QueryStmtContext) T_WITH_VALUE() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_WITH_VALUE, 0)
 }
 
+func (s *QueryStmtContext) TimeZoneClause() ITimeZoneClauseContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ITimeZoneClauseContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITimeZoneClauseContext)
+}
+
 func (s *QueryStmtContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -857,6 +911,18 @@ func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 			p.LimitClause()
 		}
 
+	}
+	p.SetState(419)
+	p.GetErrorHandler().Sync(p)
+	_la = p.GetTokenStream().LA(1)
+
+
+	if _la == SQLParserT_TZ {
+		{
+			p.SetState(420)
+			p.TimeZoneClause()
+		}
+
 	}
 	p.SetState(115)
 	p.GetErrorHandler().Sync(p)
@@ -1418,8 +1484,21 @@ func (s *FromClauseContext) T_FROM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_FROM, 0)
 }
 
-func (s *FromClauseContext) MetricName() IMetricNameContext {
-	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMetricNameContext)(nil)).Elem(), 0)
+func (s *FromClauseContext) AllMetricName() []IMetricNameContext {
+	var ts = s.GetTypedRuleContexts(reflect.TypeOf((*IMetricNameContext)(nil)).Elem())
+	var tst = make([]IMetricNameContext, len(ts))
+
+	for i, t := range ts {
+		if t != nil {
+			tst[i] = t.(IMetricNameContext)
+		}
+	}
+
+	return tst
+}
+
+func (s *FromClauseContext) MetricName(i int) IMetricNameContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IMetricNameContext)(nil)).Elem(), i)
 
 	if t == nil {
 		return nil
@@ -1428,6 +1507,24 @@ func (s *FromClauseContext) MetricName() IMetricNameContext {
 	return t.(IMetricNameContext)
 }
 
+func (s *FromClauseContext) SubQuery() ISubQueryContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*ISubQueryContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ISubQueryContext)
+}
+
+func (s *FromClauseContext) AllT_COMMA() []antlr.TerminalNode {
+	return s.GetTokens(SQLParserT_COMMA)
+}
+
+func (s *FromClauseContext) T_COMMA(i int) antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COMMA, i)
+}
+
 func (s *FromClauseContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -1455,6 +1552,8 @@ func (s *FromClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 	localctx = NewFromClauseContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 14, SQLParserRULE_fromClause)
+	var _la int
+
 
 	defer func() {
 		p.ExitRule()
@@ -1477,9 +1576,47 @@ func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 		p.SetState(135)
 		p.Match(SQLParserT_FROM)
 	}
-	{
-		p.SetState(136)
-		p.MetricName()
+	p.SetState(435)
+	p.GetErrorHandler().Sync(p)
+
+	switch p.GetTokenStream().LA(1) {
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_TZ, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_COUNT, SQLParserT_FIRST, SQLParserT_LAST, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_TIME_SHIFT, SQLParserT_ABS, SQLParserT_CEIL, SQLParserT_FLOOR, SQLParserT_ROUND, SQLParserT_LOG, SQLParserT_LOG10, SQLParserT_SQRT, SQLParserT_CLAMP_MIN, SQLParserT_CLAMP_MAX, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
+		{
+			p.SetState(136)
+			p.MetricName()
+		}
+		p.SetState(421)
+		p.GetErrorHandler().Sync(p)
+		_la = p.GetTokenStream().LA(1)
+
+
+		for _la == SQLParserT_COMMA {
+			{
+				p.SetState(426)
+				p.Match(SQLParserT_COMMA)
+			}
+			{
+				p.SetState(427)
+				p.MetricName()
+			}
+
+
+			p.SetState(424)
+			p.GetErrorHandler().Sync(p)
+			_la = p.GetTokenStream().LA(1)
+		}
+
+
+	case SQLParserT_OPEN_P:
+		{
+			p.SetState(437)
+			p.SubQuery()
+		}
+
+
+
+	default:
+		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
 
 
@@ -2501,6 +2638,10 @@ func (s *TimeExprContext) Ident() IIdentContext {
 	return t.(IIdentContext)
 }
 
+func (s *TimeExprContext) L_INT() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_INT, 0)
+}
+
 func (s *TimeExprContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -2565,13 +2706,20 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_TZ, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_COUNT, SQLParserT_FIRST, SQLParserT_LAST, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_TIME_SHIFT, SQLParserT_ABS, SQLParserT_CEIL, SQLParserT_FLOOR, SQLParserT_ROUND, SQLParserT_LOG, SQLParserT_LOG10, SQLParserT_SQRT, SQLParserT_CLAMP_MIN, SQLParserT_CLAMP_MAX, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
 		{
 			p.SetState(207)
 			p.Ident()
 		}
 
 
+	case SQLParserL_INT:
+		{
+			p.SetState(410)
+			p.Match(SQLParserL_INT)
+		}
+
+
 
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
@@ -2697,7 +2845,7 @@ func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if ((((_la - 91)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 91))) & ((1 << (SQLParserT_ADD - 91)) | (1 << (SQLParserT_SUB - 91)) | (1 << (SQLParserL_INT - 91)))) != 0) {
+	if ((((_la - 106)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 106))) & ((1 << (SQLParserT_ADD - 106)) | (1 << (SQLParserT_SUB - 106)) | (1 << (SQLParserL_INT - 106)))) != 0) {
 		{
 			p.SetState(211)
 			p.DurationLit()
@@ -2831,7 +2979,7 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)) | (1 << (SQLParserT_AVG - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)) | (1 << (SQLParserT_SUB - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_TZ - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_FIRST - 64)) | (1 << (SQLParserT_LAST - 64)) | (1 << (SQLParserT_TOPK - 64)) | (1 << (SQLParserT_BOTTOMK - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_CEIL - 64)) | (1 << (SQLParserT_FLOOR - 64)) | (1 << (SQLParserT_ROUND - 64)) | (1 << (SQLParserT_LOG10 - 64)) | (1 << (SQLParserT_SQRT - 64)) | (1 << (SQLParserT_CLAMP_MIN - 64)) | (1 << (SQLParserT_CLAMP_MAX - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0) || ((((_la - 104)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 104))) & ((1 << (SQLParserT_OPEN_P - 104)) | (1 << (SQLParserT_ADD - 104)) | (1 << (SQLParserT_SUB - 104)) | (1 << (SQLParserL_ID - 104)) | (1 << (SQLParserL_INT - 104)) | (1 << (SQLParserL_DEC - 104)))) != 0) {
 		{
 			p.SetState(216)
 			p.ExprFuncParams()
@@ -4861,6 +5009,34 @@ func (s *FieldExprContext) T_SUB() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_SUB, 0)
 }
 
+func (s *FieldExprContext) T_EQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_EQUAL, 0)
+}
+
+func (s *FieldExprContext) T_NOTEQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NOTEQUAL, 0)
+}
+
+func (s *FieldExprContext) T_NOTEQUAL2() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_NOTEQUAL2, 0)
+}
+
+func (s *FieldExprContext) T_LESS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESS, 0)
+}
+
+func (s *FieldExprContext) T_LESSEQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LESSEQUAL, 0)
+}
+
+func (s *FieldExprContext) T_GREATER() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATER, 0)
+}
+
+func (s *FieldExprContext) T_GREATEREQUAL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_GREATEREQUAL, 0)
+}
+
 func (s *FieldExprContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -4976,8 +5152,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
 				p.SetState(319)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 15)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 15)", ""))
 				}
 				{
 					p.SetState(320)
@@ -4985,7 +5161,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				}
 				{
 					p.SetState(321)
-					p.fieldExpr(9)
+					p.fieldExpr(16)
 				}
 
 
@@ -4994,8 +5170,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
 				p.SetState(322)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 14)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 14)", ""))
 				}
 				{
 					p.SetState(323)
@@ -5003,7 +5179,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				}
 				{
 					p.SetState(324)
-					p.fieldExpr(8)
+					p.fieldExpr(15)
 				}
 
 
@@ -5012,8 +5188,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
 				p.SetState(325)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 13)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 13)", ""))
 				}
 				{
 					p.SetState(326)
@@ -5021,7 +5197,7 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				}
 				{
 					p.SetState(327)
-					p.fieldExpr(7)
+					p.fieldExpr(14)
 				}
 
 
@@ -5030,8 +5206,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
 				p.SetState(328)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
-					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
 				}
 				{
 					p.SetState(329)
@@ -5039,6 +5215,132 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				}
 				{
 					p.SetState(330)
+					p.fieldExpr(13)
+				}
+
+
+			case 5:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(438)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
+				}
+				{
+					p.SetState(439)
+					p.Match(SQLParserT_EQUAL)
+				}
+				{
+					p.SetState(440)
+					p.fieldExpr(12)
+				}
+
+
+			case 6:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(441)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
+				}
+				{
+					p.SetState(442)
+					p.Match(SQLParserT_NOTEQUAL)
+				}
+				{
+					p.SetState(443)
+					p.fieldExpr(11)
+				}
+
+
+			case 7:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(444)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
+				}
+				{
+					p.SetState(445)
+					p.Match(SQLParserT_NOTEQUAL2)
+				}
+				{
+					p.SetState(446)
+					p.fieldExpr(10)
+				}
+
+
+			case 8:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(447)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
+				}
+				{
+					p.SetState(448)
+					p.Match(SQLParserT_LESS)
+				}
+				{
+					p.SetState(449)
+					p.fieldExpr(9)
+				}
+
+
+			case 9:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(450)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
+				}
+				{
+					p.SetState(451)
+					p.Match(SQLParserT_LESSEQUAL)
+				}
+				{
+					p.SetState(452)
+					p.fieldExpr(8)
+				}
+
+
+			case 10:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(453)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
+				}
+				{
+					p.SetState(454)
+					p.Match(SQLParserT_GREATER)
+				}
+				{
+					p.SetState(455)
+					p.fieldExpr(7)
+				}
+
+
+			case 11:
+				localctx = NewFieldExprContext(p, _parentctx, _parentState)
+				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
+				p.SetState(456)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
+					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
+				}
+				{
+					p.SetState(457)
+					p.Match(SQLParserT_GREATEREQUAL)
+				}
+				{
+					p.SetState(458)
 					p.fieldExpr(6)
 				}
 
@@ -5291,7 +5593,7 @@ func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 		p.SetState(339)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 66)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 66))) & ((1 << (SQLParserT_SECOND - 66)) | (1 << (SQLParserT_MINUTE - 66)) | (1 << (SQLParserT_HOUR - 66)) | (1 << (SQLParserT_DAY - 66)) | (1 << (SQLParserT_WEEK - 66)) | (1 << (SQLParserT_MONTH - 66)) | (1 << (SQLParserT_YEAR - 66)))) != 0)) {
+		if !(((((_la - 81)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 81))) & ((1 << (SQLParserT_SECOND - 81)) | (1 << (SQLParserT_MINUTE - 81)) | (1 << (SQLParserT_HOUR - 81)) | (1 << (SQLParserT_DAY - 81)) | (1 << (SQLParserT_WEEK - 81)) | (1 << (SQLParserT_MONTH - 81)) | (1 << (SQLParserT_YEAR - 81)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -5431,7 +5733,7 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)) | (1 << (SQLParserT_AVG - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)) | (1 << (SQLParserT_SUB - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_TZ - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_FIRST - 64)) | (1 << (SQLParserT_LAST - 64)) | (1 << (SQLParserT_TOPK - 64)) | (1 << (SQLParserT_BOTTOMK - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_CEIL - 64)) | (1 << (SQLParserT_FLOOR - 64)) | (1 << (SQLParserT_ROUND - 64)) | (1 << (SQLParserT_LOG10 - 64)) | (1 << (SQLParserT_SQRT - 64)) | (1 << (SQLParserT_CLAMP_MIN - 64)) | (1 << (SQLParserT_CLAMP_MAX - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0) || ((((_la - 104)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 104))) & ((1 << (SQLParserT_OPEN_P - 104)) | (1 << (SQLParserT_ADD - 104)) | (1 << (SQLParserT_SUB - 104)) | (1 << (SQLParserL_ID - 104)) | (1 << (SQLParserL_INT - 104)) | (1 << (SQLParserL_DEC - 104)))) != 0) {
 		{
 			p.SetState(343)
 			p.ExprFuncParams()
@@ -5511,6 +5813,66 @@ func (s *FuncNameContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *FuncNameContext) T_COUNT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COUNT, 0)
+}
+
+func (s *FuncNameContext) T_FIRST() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FIRST, 0)
+}
+
+func (s *FuncNameContext) T_LAST() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LAST, 0)
+}
+
+func (s *FuncNameContext) T_TOPK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOPK, 0)
+}
+
+func (s *FuncNameContext) T_BOTTOMK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOMK, 0)
+}
+
+func (s *FuncNameContext) T_TIME_SHIFT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TIME_SHIFT, 0)
+}
+
+func (s *FuncNameContext) T_ABS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ABS, 0)
+}
+
+func (s *FuncNameContext) T_CEIL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CEIL, 0)
+}
+
+func (s *FuncNameContext) T_FLOOR() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FLOOR, 0)
+}
+
+func (s *FuncNameContext) T_ROUND() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ROUND, 0)
+}
+
+func (s *FuncNameContext) T_LOG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG, 0)
+}
+
+func (s *FuncNameContext) T_LOG10() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG10, 0)
+}
+
+func (s *FuncNameContext) T_SQRT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SQRT, 0)
+}
+
+func (s *FuncNameContext) T_CLAMP_MIN() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLAMP_MIN, 0)
+}
+
+func (s *FuncNameContext) T_CLAMP_MAX() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLAMP_MAX, 0)
+}
+
 func (s *FuncNameContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -5562,7 +5924,7 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 		p.SetState(348)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 60)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 60))) & ((1 << (SQLParserT_SUM - 60)) | (1 << (SQLParserT_MIN - 60)) | (1 << (SQLParserT_MAX - 60)) | (1 << (SQLParserT_AVG - 60)) | (1 << (SQLParserT_STDDEV - 60)) | (1 << (SQLParserT_HISTOGRAM - 60)))) != 0)) {
+		if !(((((_la - 59)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 59))) & ((1 << (SQLParserT_LOG - 59)) | (1 << (SQLParserT_SUM - 59)) | (1 << (SQLParserT_MIN - 59)) | (1 << (SQLParserT_MAX - 59)) | (1 << (SQLParserT_AVG - 59)) | (1 << (SQLParserT_STDDEV - 59)) | (1 << (SQLParserT_HISTOGRAM - 59)) | (1 << (SQLParserT_COUNT - 59)) | (1 << (SQLParserT_FIRST - 59)) | (1 << (SQLParserT_LAST - 59)) | (1 << (SQLParserT_TOPK - 59)) | (1 << (SQLParserT_BOTTOMK - 59)) | (1 << (SQLParserT_TIME_SHIFT - 59)) | (1 << (SQLParserT_ABS - 59)) | (1 << (SQLParserT_CEIL - 59)) | (1 << (SQLParserT_FLOOR - 59)) | (1 << (SQLParserT_ROUND - 59)) | (1 << (SQLParserT_LOG10 - 59)) | (1 << (SQLParserT_SQRT - 59)) | (1 << (SQLParserT_CLAMP_MIN - 59)) | (1 << (SQLParserT_CLAMP_MAX - 59)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -6944,7 +7306,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_TZ, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_COUNT, SQLParserT_FIRST, SQLParserT_LAST, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_TIME_SHIFT, SQLParserT_ABS, SQLParserT_CEIL, SQLParserT_FLOOR, SQLParserT_ROUND, SQLParserT_LOG, SQLParserT_LOG10, SQLParserT_SQRT, SQLParserT_CLAMP_MIN, SQLParserT_CLAMP_MAX, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 		{
 			p.SetState(394)
 			p.NonReservedWords()
@@ -6976,7 +7338,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 				}
 
 
-			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_TZ, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_COUNT, SQLParserT_FIRST, SQLParserT_LAST, SQLParserT_TOPK, SQLParserT_BOTTOMK, SQLParserT_TIME_SHIFT, SQLParserT_ABS, SQLParserT_CEIL, SQLParserT_FLOOR, SQLParserT_ROUND, SQLParserT_LOG, SQLParserT_LOG10, SQLParserT_SQRT, SQLParserT_CLAMP_MIN, SQLParserT_CLAMP_MAX, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 				{
 					p.SetState(399)
 					p.NonReservedWords()
@@ -7211,6 +7573,10 @@ func (s *NonReservedWordsContext) T_TIME() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_TIME, 0)
 }
 
+func (s *NonReservedWordsContext) T_TZ() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TZ, 0)
+}
+
 func (s *NonReservedWordsContext) T_FOR() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_FOR, 0)
 }
@@ -7267,6 +7633,66 @@ func (s *NonReservedWordsContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *NonReservedWordsContext) T_COUNT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_COUNT, 0)
+}
+
+func (s *NonReservedWordsContext) T_FIRST() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FIRST, 0)
+}
+
+func (s *NonReservedWordsContext) T_LAST() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LAST, 0)
+}
+
+func (s *NonReservedWordsContext) T_TOPK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOPK, 0)
+}
+
+func (s *NonReservedWordsContext) T_BOTTOMK() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOMK, 0)
+}
+
+func (s *NonReservedWordsContext) T_TIME_SHIFT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TIME_SHIFT, 0)
+}
+
+func (s *NonReservedWordsContext) T_ABS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ABS, 0)
+}
+
+func (s *NonReservedWordsContext) T_CEIL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CEIL, 0)
+}
+
+func (s *NonReservedWordsContext) T_FLOOR() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_FLOOR, 0)
+}
+
+func (s *NonReservedWordsContext) T_ROUND() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ROUND, 0)
+}
+
+func (s *NonReservedWordsContext) T_LOG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG, 0)
+}
+
+func (s *NonReservedWordsContext) T_LOG10() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG10, 0)
+}
+
+func (s *NonReservedWordsContext) T_SQRT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SQRT, 0)
+}
+
+func (s *NonReservedWordsContext) T_CLAMP_MIN() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLAMP_MIN, 0)
+}
+
+func (s *NonReservedWordsContext) T_CLAMP_MAX() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLAMP_MAX, 0)
+}
+
 func (s *NonReservedWordsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -7318,7 +7744,7 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 		p.SetState(407)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)) | (1 << (SQLParserT_AVG - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES) | (1 << SQLParserT_FROM) | (1 << SQLParserT_WHERE) | (1 << SQLParserT_LIMIT))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_TZ - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)) | (1 << (SQLParserT_MIN - 32)) | (1 << (SQLParserT_MAX - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_FIRST - 64)) | (1 << (SQLParserT_LAST - 64)) | (1 << (SQLParserT_TOPK - 64)) | (1 << (SQLParserT_BOTTOMK - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_CEIL - 64)) | (1 << (SQLParserT_FLOOR - 64)) | (1 << (SQLParserT_ROUND - 64)) | (1 << (SQLParserT_LOG10 - 64)) | (1 << (SQLParserT_SQRT - 64)) | (1 << (SQLParserT_CLAMP_MIN - 64)) | (1 << (SQLParserT_CLAMP_MAX - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -7332,6 +7758,254 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 }
 
 
+// ITimeZoneClauseContext is an interface to support dynamic dispatch.
+type ITimeZoneClauseContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsTimeZoneClauseContext differentiates from other interfaces.
+	IsTimeZoneClauseContext()
+}
+
+type TimeZoneClauseContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyTimeZoneClauseContext() *TimeZoneClauseContext {
+	var p = new(TimeZoneClauseContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_timeZoneClause
+	return p
+}
+
+func (*TimeZoneClauseContext) IsTimeZoneClauseContext() {}
+
+func NewTimeZoneClauseContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *TimeZoneClauseContext {
+	var p = new(TimeZoneClauseContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_timeZoneClause
+
+	return p
+}
+
+func (s *TimeZoneClauseContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *TimeZoneClauseContext) T_TZ() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TZ, 0)
+}
+
+func (s *TimeZoneClauseContext) T_OPEN_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_OPEN_P, 0)
+}
+
+func (s *TimeZoneClauseContext) Ident() IIdentContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IIdentContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentContext)
+}
+
+func (s *TimeZoneClauseContext) T_CLOSE_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLOSE_P, 0)
+}
+
+func (s *TimeZoneClauseContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TimeZoneClauseContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *TimeZoneClauseContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterTimeZoneClause(s)
+	}
+}
+
+func (s *TimeZoneClauseContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitTimeZoneClause(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) TimeZoneClause() (localctx ITimeZoneClauseContext) {
+	localctx = NewTimeZoneClauseContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 411, SQLParserRULE_timeZoneClause)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(413)
+		p.Match(SQLParserT_TZ)
+	}
+	{
+		p.SetState(414)
+		p.Match(SQLParserT_OPEN_P)
+	}
+	{
+		p.SetState(415)
+		p.Ident()
+	}
+	{
+		p.SetState(416)
+		p.Match(SQLParserT_CLOSE_P)
+	}
+
+
+
+	return localctx
+}
+
+
+// ISubQueryContext is an interface to support dynamic dispatch.
+type ISubQueryContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsSubQueryContext differentiates from other interfaces.
+	IsSubQueryContext()
+}
+
+type SubQueryContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptySubQueryContext() *SubQueryContext {
+	var p = new(SubQueryContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_subQuery
+	return p
+}
+
+func (*SubQueryContext) IsSubQueryContext() {}
+
+func NewSubQueryContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *SubQueryContext {
+	var p = new(SubQueryContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_subQuery
+
+	return p
+}
+
+func (s *SubQueryContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *SubQueryContext) T_OPEN_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_OPEN_P, 0)
+}
+
+func (s *SubQueryContext) QueryStmt() IQueryStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IQueryStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IQueryStmtContext)
+}
+
+func (s *SubQueryContext) T_CLOSE_P() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_CLOSE_P, 0)
+}
+
+func (s *SubQueryContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *SubQueryContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *SubQueryContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterSubQuery(s)
+	}
+}
+
+func (s *SubQueryContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitSubQuery(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) SubQuery() (localctx ISubQueryContext) {
+	localctx = NewSubQueryContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 428, SQLParserRULE_subQuery)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(430)
+		p.Match(SQLParserT_OPEN_P)
+	}
+	{
+		p.SetState(431)
+		p.QueryStmt()
+	}
+	{
+		p.SetState(432)
+		p.Match(SQLParserT_CLOSE_P)
+	}
+
+
+
+	return localctx
+}
+
+
 func (p *SQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
 	case 10:
@@ -7378,15 +8052,36 @@ func (p *SQLParser) BoolExpr_Sempred(localctx antlr.RuleContext, predIndex int)
 func (p *SQLParser) FieldExpr_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 2:
-			return p.Precpred(p.GetParserRuleContext(), 8)
+			return p.Precpred(p.GetParserRuleContext(), 15)
 
 	case 3:
-			return p.Precpred(p.GetParserRuleContext(), 7)
+			return p.Precpred(p.GetParserRuleContext(), 14)
 
 	case 4:
-			return p.Precpred(p.GetParserRuleContext(), 6)
+			return p.Precpred(p.GetParserRuleContext(), 13)
 
 	case 5:
+			return p.Precpred(p.GetParserRuleContext(), 12)
+
+	case 6:
+			return p.Precpred(p.GetParserRuleContext(), 11)
+
+	case 7:
+			return p.Precpred(p.GetParserRuleContext(), 10)
+
+	case 8:
+			return p.Precpred(p.GetParserRuleContext(), 9)
+
+	case 9:
+			return p.Precpred(p.GetParserRuleContext(), 8)
+
+	case 10:
+			return p.Precpred(p.GetParserRuleContext(), 7)
+
+	case 11:
+			return p.Precpred(p.GetParserRuleContext(), 6)
+
+	case 12:
 			return p.Precpred(p.GetParserRuleContext(), 5)
 
 	default:
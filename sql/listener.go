@@ -8,11 +8,30 @@ import (
 type listener struct {
 	*grammar.BaseSQLListener
 	stmt *queryStmtParse
+	// outer query statement parsers when parsing sub query
+	outerStmts []*queryStmtParse
 }
 
 // EnterQueryStmt is called when production queryStmt is entered.
 func (l *listener) EnterQueryStmt(ctx *grammar.QueryStmtContext) {
-	l.stmt = newQueryStmtParse()
+	if l.stmt != nil {
+		// sub query, keeps the outer query statement parser
+		l.outerStmts = append(l.outerStmts, l.stmt)
+	}
+	l.stmt = newQueryStmtParse(ctx.T_EXPLAIN() != nil)
+}
+
+// ExitQueryStmt is called when production queryStmt is exited.
+func (l *listener) ExitQueryStmt(ctx *grammar.QueryStmtContext) {
+	size := len(l.outerStmts)
+	if size == 0 {
+		return
+	}
+	// sub query completed, sets it to the outer query statement
+	outer := l.outerStmts[size-1]
+	l.outerStmts = l.outerStmts[:size-1]
+	outer.visitSubQuery(l.stmt.build())
+	l.stmt = outer
 }
 
 // EnterMetricName is called when production metricName is entered.
@@ -113,6 +132,13 @@ func (l *listener) EnterTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 	}
 }
 
+// EnterTimeZoneClause is called when production timeZoneClause is entered.
+func (l *listener) EnterTimeZoneClause(ctx *grammar.TimeZoneClauseContext) {
+	if l.stmt != nil {
+		l.stmt.visitTimeZone(ctx)
+	}
+}
+
 // EnterGroupByClause is called when production groupByClause is entered.
 func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	if l.stmt != nil {
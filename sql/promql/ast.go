@@ -0,0 +1,115 @@
+package promql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Expr represents the expression of prometheus query language
+type Expr interface {
+	// String returns the expression string
+	String() string
+}
+
+// MatchType represents the type of label matcher
+type MatchType int
+
+const (
+	MatchEqual MatchType = iota + 1
+	MatchNotEqual
+	MatchRegexp
+	MatchNotRegexp
+)
+
+// String returns the operator of label matcher
+func (t MatchType) String() string {
+	switch t {
+	case MatchEqual:
+		return "="
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	default:
+		return "unknown"
+	}
+}
+
+// LabelMatcher represents the label matcher of vector selector, like job="api"
+type LabelMatcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+}
+
+// VectorSelector represents the instant vector selector,
+// range vector selector if range duration > 0, like http_requests_total{job="api"}[5m]
+type VectorSelector struct {
+	Name     string
+	Matchers []*LabelMatcher
+	Range    int64 // range duration(millisecond) of range vector selector
+}
+
+// Call represents the function call, like rate(http_requests_total[5m])
+type Call struct {
+	Func string
+	Args []Expr
+}
+
+// AggregateExpr represents the aggregation operation on vector, like sum by (job) (http_requests_total)
+type AggregateExpr struct {
+	Op       string
+	Grouping []string
+	Expr     Expr
+}
+
+// NumberLiteral represents the number literal, like the quantile param of histogram_quantile
+type NumberLiteral struct {
+	Val float64
+}
+
+// String returns the label matcher string
+func (m *LabelMatcher) String() string {
+	return fmt.Sprintf("%s%s%s", m.Name, m.Type, strconv.Quote(m.Value))
+}
+
+// String returns the vector selector string
+func (e *VectorSelector) String() string {
+	var matchers []string
+	for _, m := range e.Matchers {
+		matchers = append(matchers, m.String())
+	}
+	result := e.Name
+	if len(matchers) > 0 {
+		result += "{" + strings.Join(matchers, ",") + "}"
+	}
+	if e.Range > 0 {
+		result += fmt.Sprintf("[%dms]", e.Range)
+	}
+	return result
+}
+
+// String returns the function call string
+func (e *Call) String() string {
+	var args []string
+	for _, arg := range e.Args {
+		args = append(args, arg.String())
+	}
+	return fmt.Sprintf("%s(%s)", e.Func, strings.Join(args, ","))
+}
+
+// String returns the aggregation expression string
+func (e *AggregateExpr) String() string {
+	if len(e.Grouping) == 0 {
+		return fmt.Sprintf("%s(%s)", e.Op, e.Expr)
+	}
+	return fmt.Sprintf("%s by (%s) (%s)", e.Op, strings.Join(e.Grouping, ","), e.Expr)
+}
+
+// String returns the number literal string
+func (e *NumberLiteral) String() string {
+	return strconv.FormatFloat(e.Val, 'f', -1, 64)
+}
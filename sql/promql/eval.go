@@ -0,0 +1,192 @@
+package promql
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// Series represents the grouped series of query result, values are down sampled by the step of query
+type Series struct {
+	Tags   map[string]string
+	Values collections.FloatArray
+}
+
+// Sample represents the value of series at the evaluation timestamp
+type Sample struct {
+	Timestamp int64
+	Value     float64
+}
+
+// Result represents the time series of prometheus query result
+type Result struct {
+	Labels  map[string]string
+	Samples []Sample
+}
+
+// ResultName returns the result name of query statement's select item
+func (q *Query) ResultName() string {
+	return resultName
+}
+
+// PointCount returns the num. of time slots of query statement
+func (q *Query) PointCount() int {
+	return timeutil.CalPointCount(q.Statement.TimeRange.Start, q.Statement.TimeRange.End, q.Step)
+}
+
+// Eval evaluates the samples of each evaluation timestamp based on the series of query statement,
+// returns the results order by labels.
+func (q *Query) Eval(series []*Series) []*Result {
+	var results []*Result
+	for _, s := range series {
+		labels := make(map[string]string, len(s.Tags)+1)
+		for key, value := range s.Tags {
+			labels[key] = value
+		}
+		if q.keepName {
+			labels[metricNameLabel] = q.Statement.MetricName
+		}
+		result := &Result{Labels: labels, Samples: q.evalSamples(s.Values)}
+		if len(result.Samples) > 0 {
+			results = append(results, result)
+		}
+	}
+	if q.hasQuantile {
+		results = q.evalQuantile(results)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return models.TagsAsString(results[i].Labels) < models.TagsAsString(results[j].Labels)
+	})
+	return results
+}
+
+// evalSamples evaluates the samples of series, the sample of evaluation timestamp t is evaluated
+// based on the values of time slots in range (t-range, t].
+func (q *Query) evalSamples(values collections.FloatArray) []Sample {
+	if values == nil {
+		return nil
+	}
+	var samples []Sample
+	for timestamp, slot := q.Start, 0; timestamp <= q.End; timestamp, slot = timestamp+q.Step, slot+1 {
+		if !q.rate {
+			lastSlot := slot + q.rangeSlots - 1
+			if values.HasValue(lastSlot) {
+				samples = append(samples, Sample{Timestamp: timestamp, Value: values.GetValue(lastSlot)})
+			}
+			continue
+		}
+		sum := 0.0
+		found := false
+		for i := slot; i < slot+q.rangeSlots; i++ {
+			if values.HasValue(i) {
+				sum += values.GetValue(i)
+				found = true
+			}
+		}
+		if found {
+			seconds := float64(int64(q.rangeSlots)*q.Step) / float64(timeutil.OneSecond)
+			samples = append(samples, Sample{Timestamp: timestamp, Value: sum / seconds})
+		}
+	}
+	return samples
+}
+
+// bucket represents the histogram bucket of quantile calculation
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// evalQuantile calculates the quantile from the histogram buckets which have the same labels except le label
+func (q *Query) evalQuantile(results []*Result) []*Result {
+	type group struct {
+		labels  map[string]string
+		buckets map[int64][]bucket
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for _, result := range results {
+		upperBound, err := strconv.ParseFloat(result.Labels[BucketLabel], 64)
+		if err != nil {
+			continue
+		}
+		labels := make(map[string]string, len(result.Labels))
+		for key, value := range result.Labels {
+			if key != BucketLabel {
+				labels[key] = value
+			}
+		}
+		key := models.TagsAsString(labels)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{labels: labels, buckets: make(map[int64][]bucket)}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		for _, sample := range result.Samples {
+			g.buckets[sample.Timestamp] = append(g.buckets[sample.Timestamp],
+				bucket{upperBound: upperBound, count: sample.Value})
+		}
+	}
+	var quantileResults []*Result
+	for _, key := range keys {
+		g := groups[key]
+		result := &Result{Labels: g.labels}
+		for timestamp := q.Start; timestamp <= q.End; timestamp += q.Step {
+			buckets, ok := g.buckets[timestamp]
+			if !ok {
+				continue
+			}
+			value := bucketQuantile(q.quantile, buckets)
+			if !math.IsNaN(value) {
+				result.Samples = append(result.Samples, Sample{Timestamp: timestamp, Value: value})
+			}
+		}
+		if len(result.Samples) > 0 {
+			quantileResults = append(quantileResults, result)
+		}
+	}
+	return quantileResults
+}
+
+// bucketQuantile calculates the quantile from cumulative histogram buckets as prometheus does,
+// assumes the values are distributed linearly in the bucket, returns NaN if no +Inf bucket or no observations.
+func bucketQuantile(quantile float64, buckets []bucket) float64 {
+	switch {
+	case quantile < 0:
+		return math.Inf(-1)
+	case quantile > 1:
+		return math.Inf(+1)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].upperBound < buckets[j].upperBound
+	})
+	last := len(buckets) - 1
+	if len(buckets) < 2 || !math.IsInf(buckets[last].upperBound, +1) || buckets[last].count == 0 {
+		return math.NaN()
+	}
+	rank := quantile * buckets[last].count
+	idx := sort.Search(last, func(i int) bool {
+		return buckets[i].count >= rank
+	})
+	if idx == last {
+		// quantile in +Inf bucket, returns the upper bound of the last finite bucket
+		return buckets[last-1].upperBound
+	}
+	if idx == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+	bucketStart := 0.0
+	bucketEnd := buckets[idx].upperBound
+	count := buckets[idx].count
+	if idx > 0 {
+		bucketStart = buckets[idx-1].upperBound
+		count -= buckets[idx-1].count
+		rank -= buckets[idx-1].count
+	}
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
@@ -0,0 +1,110 @@
+package promql
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestQuery_Eval_Selector(t *testing.T) {
+	start := int64(1000 * timeutil.OneMinute)
+	q, err := Translate(`cpu`, start, start+2*timeutil.OneMinute, timeutil.OneMinute)
+	assert.NoError(t, err)
+	results := q.Eval([]*Series{
+		{Tags: map[string]string{"host": "b"}, Values: mockFloatArray(map[int]float64{0: 1, 2: 3})},
+		{Tags: map[string]string{"host": "a"}, Values: mockFloatArray(map[int]float64{1: 2})},
+		{Tags: map[string]string{"host": "c"}, Values: mockFloatArray(nil)},
+		{Tags: map[string]string{"host": "d"}},
+	})
+	assert.Equal(t, []*Result{
+		{
+			Labels:  map[string]string{"host": "a", "__name__": "cpu"},
+			Samples: []Sample{{Timestamp: start + timeutil.OneMinute, Value: 2}},
+		},
+		{
+			Labels: map[string]string{"host": "b", "__name__": "cpu"},
+			Samples: []Sample{
+				{Timestamp: start, Value: 1},
+				{Timestamp: start + 2*timeutil.OneMinute, Value: 3},
+			},
+		},
+	}, results)
+}
+
+func TestQuery_Eval_Rate(t *testing.T) {
+	start := int64(1000 * timeutil.OneMinute)
+	q, err := Translate(`sum(rate(requests[2m]))`, start, start+2*timeutil.OneMinute, timeutil.OneMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, q.PointCount())
+	results := q.Eval([]*Series{
+		{Tags: map[string]string{}, Values: mockFloatArray(map[int]float64{0: 60, 1: 120, 3: 240})},
+	})
+	assert.Len(t, results, 1)
+	assert.Empty(t, results[0].Labels)
+	assert.Equal(t, []Sample{
+		{Timestamp: start, Value: 1.5},
+		{Timestamp: start + timeutil.OneMinute, Value: 1},
+		{Timestamp: start + 2*timeutil.OneMinute, Value: 2},
+	}, results[0].Samples)
+}
+
+func TestQuery_Eval_HistogramQuantile(t *testing.T) {
+	end := int64(1000 * timeutil.OneMinute)
+	q, err := Translate(`histogram_quantile(0.5, sum by (le, host) (latency_bucket))`, end, end, 0)
+	assert.NoError(t, err)
+	bucket := func(host, le string, count float64) *Series {
+		return &Series{
+			Tags:   map[string]string{"host": host, "le": le},
+			Values: mockFloatArray(map[int]float64{0: count}),
+		}
+	}
+	results := q.Eval([]*Series{
+		bucket("a", "0.1", 10),
+		bucket("a", "0.5", 30),
+		bucket("a", "+Inf", 40),
+		bucket("b", "1", 0),
+		bucket("b", "+Inf", 0),
+		bucket("c", "x", 1),
+	})
+	assert.Len(t, results, 1)
+	assert.Equal(t, map[string]string{"host": "a"}, results[0].Labels)
+	assert.Len(t, results[0].Samples, 1)
+	assert.Equal(t, end, results[0].Samples[0].Timestamp)
+	assert.InDelta(t, 0.3, results[0].Samples[0].Value, 1e-9)
+}
+
+func TestBucketQuantile(t *testing.T) {
+	buckets := func() []bucket {
+		return []bucket{
+			{upperBound: math.Inf(1), count: 100},
+			{upperBound: 1, count: 50},
+			{upperBound: 2, count: 90},
+		}
+	}
+	assert.Equal(t, math.Inf(-1), bucketQuantile(-1, buckets()))
+	assert.Equal(t, math.Inf(1), bucketQuantile(2, buckets()))
+	assert.Equal(t, 0.5, bucketQuantile(0.25, buckets()))
+	assert.Equal(t, 1.5, bucketQuantile(0.7, buckets()))
+	// in +Inf bucket
+	assert.Equal(t, 2.0, bucketQuantile(0.99, buckets()))
+	// first bucket's upper bound <= 0
+	assert.Equal(t, -1.0, bucketQuantile(0.1, []bucket{
+		{upperBound: -1, count: 10},
+		{upperBound: math.Inf(1), count: 20},
+	}))
+	// no +Inf bucket
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, []bucket{{upperBound: 1, count: 1}, {upperBound: 2, count: 2}})))
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, []bucket{{upperBound: math.Inf(1), count: 2}})))
+}
+
+func mockFloatArray(values map[int]float64) collections.FloatArray {
+	array := collections.NewFloatArray(10)
+	for slot, value := range values {
+		array.SetValue(slot, value)
+	}
+	return array
+}
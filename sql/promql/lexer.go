@@ -0,0 +1,162 @@
+package promql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenType represents the type of lexical token
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota + 1
+	tokenIdentifier
+	tokenNumber
+	tokenString
+	tokenDuration
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBrace
+	tokenRightBrace
+	tokenLeftBracket
+	tokenRightBracket
+	tokenComma
+	tokenEqual
+	tokenNotEqual
+	tokenRegexp
+	tokenNotRegexp
+)
+
+// token represents the lexical token of prometheus query language
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+// lexer splits the input into tokens
+type lexer struct {
+	input string
+	pos   int
+	// duration is only allowed in brackets of range vector selector
+	inBracket bool
+}
+
+// newLexer creates the lexer for the input
+func newLexer(input string) *lexer {
+	return &lexer{input: input}
+}
+
+// next returns the next token, if failure return error
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{typ: tokenEOF, pos: start}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case l.inBracket && c != ']':
+		for l.pos < len(l.input) && isAlphaNumeric(l.input[l.pos]) {
+			l.pos++
+		}
+		if start == l.pos {
+			return token{}, fmt.Errorf("unexpected character %q in range at position %d", c, start)
+		}
+		return token{typ: tokenDuration, val: l.input[start:l.pos], pos: start}, nil
+	case isIdentifierStart(c):
+		for l.pos < len(l.input) && (isAlphaNumeric(l.input[l.pos]) || l.input[l.pos] == ':') {
+			l.pos++
+		}
+		return token{typ: tokenIdentifier, val: l.input[start:l.pos], pos: start}, nil
+	case c >= '0' && c <= '9' || c == '.':
+		for l.pos < len(l.input) && (isAlphaNumeric(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		return token{typ: tokenNumber, val: l.input[start:l.pos], pos: start}, nil
+	case c == '"' || c == '\'':
+		return l.lexString(c)
+	}
+	l.pos++
+	switch c {
+	case '(':
+		return token{typ: tokenLeftParen, val: "(", pos: start}, nil
+	case ')':
+		return token{typ: tokenRightParen, val: ")", pos: start}, nil
+	case '{':
+		return token{typ: tokenLeftBrace, val: "{", pos: start}, nil
+	case '}':
+		return token{typ: tokenRightBrace, val: "}", pos: start}, nil
+	case '[':
+		l.inBracket = true
+		return token{typ: tokenLeftBracket, val: "[", pos: start}, nil
+	case ']':
+		l.inBracket = false
+		return token{typ: tokenRightBracket, val: "]", pos: start}, nil
+	case ',':
+		return token{typ: tokenComma, val: ",", pos: start}, nil
+	case '=':
+		if l.accept('~') {
+			return token{typ: tokenRegexp, val: "=~", pos: start}, nil
+		}
+		return token{typ: tokenEqual, val: "=", pos: start}, nil
+	case '!':
+		if l.accept('=') {
+			return token{typ: tokenNotEqual, val: "!=", pos: start}, nil
+		}
+		if l.accept('~') {
+			return token{typ: tokenNotRegexp, val: "!~", pos: start}, nil
+		}
+	}
+	return token{}, fmt.Errorf("unexpected character %q at position %d", c, start)
+}
+
+// accept consumes the next character if it's the expected character
+func (l *lexer) accept(c byte) bool {
+	if l.pos < len(l.input) && l.input[l.pos] == c {
+		l.pos++
+		return true
+	}
+	return false
+}
+
+// lexString scans the quoted string, supports the escape sequences of go string
+func (l *lexer) lexString(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.input) {
+		switch l.input[l.pos] {
+		case '\\':
+			l.pos += 2
+			continue
+		case quote:
+			l.pos++
+			str := l.input[start:l.pos]
+			if quote == '\'' {
+				// converts to double-quoted string for unquoting
+				str = `"` + strings.ReplaceAll(strings.ReplaceAll(str[1:len(str)-1], `\'`, `'`), `"`, `\"`) + `"`
+			}
+			value, err := strconv.Unquote(str)
+			if err != nil {
+				return token{}, fmt.Errorf("invalid string at position %d: %s", start, err)
+			}
+			return token{typ: tokenString, val: value, pos: start}, nil
+		}
+		l.pos++
+	}
+	return token{}, fmt.Errorf("unterminated string at position %d", start)
+}
+
+// isIdentifierStart checks if the character is the start of identifier
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == ':' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isAlphaNumeric checks if the character is letter, digit or underscore
+func isAlphaNumeric(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,55 @@
+package promql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_Next(t *testing.T) {
+	lex := newLexer(`sum by (job) (rate(http:requests{code!~'5\'x', path="a\"b"}[5m])) 0.5`)
+	var tokens []token
+	for {
+		tok, err := lex.next()
+		assert.NoError(t, err)
+		tokens = append(tokens, tok)
+		if tok.typ == tokenEOF {
+			break
+		}
+	}
+	var types []tokenType
+	var values []string
+	for _, tok := range tokens {
+		types = append(types, tok.typ)
+		values = append(values, tok.val)
+	}
+	assert.Equal(t, []tokenType{
+		tokenIdentifier, tokenIdentifier, tokenLeftParen, tokenIdentifier, tokenRightParen,
+		tokenLeftParen, tokenIdentifier, tokenLeftParen, tokenIdentifier,
+		tokenLeftBrace, tokenIdentifier, tokenNotRegexp, tokenString, tokenComma,
+		tokenIdentifier, tokenEqual, tokenString, tokenRightBrace,
+		tokenLeftBracket, tokenDuration, tokenRightBracket, tokenRightParen, tokenRightParen,
+		tokenNumber, tokenEOF,
+	}, types)
+	assert.Equal(t, "http:requests", values[8])
+	assert.Equal(t, "5'x", values[12])
+	assert.Equal(t, `a"b`, values[16])
+	assert.Equal(t, "5m", values[19])
+	assert.Equal(t, "0.5", values[23])
+	assert.Equal(t, 0, tokens[0].pos)
+}
+
+func TestLexer_Fail(t *testing.T) {
+	for _, input := range []string{`"abc`, `"\q"`, `!`, `#`, `[,]`} {
+		lex := newLexer(input)
+		var err error
+		for err == nil {
+			var tok token
+			tok, err = lex.next()
+			if tok.typ == tokenEOF {
+				break
+			}
+		}
+		assert.Error(t, err, input)
+	}
+}
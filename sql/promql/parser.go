@@ -0,0 +1,329 @@
+package promql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// metricNameLabel is the label name of metric name
+const metricNameLabel = "__name__"
+
+// aggregateOps represents the supported aggregation operators
+var aggregateOps = map[string]struct{}{
+	"sum": {},
+	"min": {},
+	"max": {},
+	"avg": {},
+}
+
+// parser parses the practical subset of prometheus query language, includes:
+// 1) vector selector with label matchers, like http_requests_total{job="api",code=~"5.."}
+// 2) range vector selector, like http_requests_total[5m]
+// 3) function call, like rate(http_requests_total[5m]), histogram_quantile(0.99, ...)
+// 4) aggregation with grouping, like sum by (job) (...) or sum(...) by (job)
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// Parse parses the prometheus query language to expression, if failure return error
+func Parse(input string) (Expr, error) {
+	lex := newLexer(input)
+	p := &parser{}
+	for {
+		tok, err := lex.next()
+		if err != nil {
+			return nil, err
+		}
+		p.tokens = append(p.tokens, tok)
+		if tok.typ == tokenEOF {
+			break
+		}
+	}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.typ != tokenEOF {
+		return nil, p.unexpected(tok)
+	}
+	return expr, nil
+}
+
+// parseExpr parses the expression
+func (p *parser) parseExpr() (Expr, error) {
+	tok := p.peek()
+	switch tok.typ {
+	case tokenNumber:
+		p.pos++
+		val, err := strconv.ParseFloat(tok.val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.val, tok.pos)
+		}
+		return &NumberLiteral{Val: val}, nil
+	case tokenLeftBrace:
+		return p.parseVectorSelector("")
+	case tokenIdentifier:
+		next := p.peekN(1)
+		if _, ok := aggregateOps[strings.ToLower(tok.val)]; ok &&
+			(next.typ == tokenLeftParen || next.typ == tokenIdentifier) {
+			return p.parseAggregateExpr()
+		}
+		p.pos++
+		if next.typ == tokenLeftParen {
+			return p.parseCall(tok.val)
+		}
+		return p.parseVectorSelector(tok.val)
+	default:
+		return nil, p.unexpected(tok)
+	}
+}
+
+// parseAggregateExpr parses the aggregation expression, grouping can be before or after the expression
+func (p *parser) parseAggregateExpr() (Expr, error) {
+	expr := &AggregateExpr{Op: strings.ToLower(p.next().val)}
+	if p.peek().typ == tokenIdentifier {
+		grouping, err := p.parseGrouping()
+		if err != nil {
+			return nil, err
+		}
+		expr.Grouping = grouping
+	}
+	if _, err := p.expect(tokenLeftParen); err != nil {
+		return nil, err
+	}
+	inner, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	expr.Expr = inner
+	if _, err := p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+	if p.peek().typ == tokenIdentifier {
+		if len(expr.Grouping) > 0 {
+			return nil, fmt.Errorf("duplicate grouping at position %d", p.peek().pos)
+		}
+		grouping, err := p.parseGrouping()
+		if err != nil {
+			return nil, err
+		}
+		expr.Grouping = grouping
+	}
+	return expr, nil
+}
+
+// parseGrouping parses the grouping labels, only supports by clause
+func (p *parser) parseGrouping() ([]string, error) {
+	tok := p.next()
+	if !strings.EqualFold(tok.val, "by") {
+		return nil, fmt.Errorf("unsupported grouping %q at position %d, only by is supported", tok.val, tok.pos)
+	}
+	if _, err := p.expect(tokenLeftParen); err != nil {
+		return nil, err
+	}
+	var grouping []string
+	for p.peek().typ != tokenRightParen {
+		label, err := p.expect(tokenIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		grouping = append(grouping, label.val)
+		if p.peek().typ == tokenComma {
+			p.pos++
+		}
+	}
+	p.pos++
+	return grouping, nil
+}
+
+// parseCall parses the function call
+func (p *parser) parseCall(funcName string) (Expr, error) {
+	call := &Call{Func: funcName}
+	p.pos++
+	for p.peek().typ != tokenRightParen {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if p.peek().typ != tokenComma {
+			break
+		}
+		p.pos++
+	}
+	if _, err := p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// parseVectorSelector parses the vector selector, the metric name can be in label matchers
+func (p *parser) parseVectorSelector(metricName string) (Expr, error) {
+	selector := &VectorSelector{Name: metricName}
+	if p.peek().typ == tokenLeftBrace {
+		p.pos++
+		for p.peek().typ != tokenRightBrace {
+			matcher, err := p.parseLabelMatcher()
+			if err != nil {
+				return nil, err
+			}
+			if matcher.Name == metricNameLabel {
+				if matcher.Type != MatchEqual || len(selector.Name) > 0 {
+					return nil, fmt.Errorf("metric name must be specified once by equal matcher")
+				}
+				selector.Name = matcher.Value
+			} else {
+				selector.Matchers = append(selector.Matchers, matcher)
+			}
+			if p.peek().typ != tokenComma {
+				break
+			}
+			p.pos++
+		}
+		if _, err := p.expect(tokenRightBrace); err != nil {
+			return nil, err
+		}
+	}
+	if len(selector.Name) == 0 {
+		return nil, fmt.Errorf("vector selector must contain metric name")
+	}
+	if p.peek().typ == tokenLeftBracket {
+		p.pos++
+		tok, err := p.expect(tokenDuration)
+		if err != nil {
+			return nil, err
+		}
+		selector.Range, err = ParseDuration(tok.val)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightBracket); err != nil {
+			return nil, err
+		}
+	}
+	return selector, nil
+}
+
+// parseLabelMatcher parses the label matcher, like job="api"
+func (p *parser) parseLabelMatcher() (*LabelMatcher, error) {
+	label, err := p.expect(tokenIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	matcher := &LabelMatcher{Name: label.val}
+	op := p.next()
+	switch op.typ {
+	case tokenEqual:
+		matcher.Type = MatchEqual
+	case tokenNotEqual:
+		matcher.Type = MatchNotEqual
+	case tokenRegexp:
+		matcher.Type = MatchRegexp
+	case tokenNotRegexp:
+		matcher.Type = MatchNotRegexp
+	default:
+		return nil, p.unexpected(op)
+	}
+	value, err := p.expect(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	matcher.Value = value.val
+	return matcher, nil
+}
+
+// peek returns the current token without consuming it
+func (p *parser) peek() token {
+	return p.peekN(0)
+}
+
+// peekN returns the token after n tokens of the current token, returns eof if out of range
+func (p *parser) peekN(n int) token {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+// next consumes the current token
+func (p *parser) next() token {
+	tok := p.peek()
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+// expect consumes the current token if it's the expected type, else returns error
+func (p *parser) expect(typ tokenType) (token, error) {
+	tok := p.peek()
+	if tok.typ != typ {
+		return tok, p.unexpected(tok)
+	}
+	p.next()
+	return tok, nil
+}
+
+// unexpected returns the error of unexpected token
+func (p *parser) unexpected(tok token) error {
+	if tok.typ == tokenEOF {
+		return fmt.Errorf("unexpected end of input")
+	}
+	return fmt.Errorf("unexpected %q at position %d", tok.val, tok.pos)
+}
+
+// ParseDuration parses the prometheus duration to milliseconds, like 5m, 1h30m, 500ms,
+// supports the units: ms/s/m/h/d/w/y
+func ParseDuration(durationStr string) (int64, error) {
+	var duration int64
+	str := durationStr
+	for len(str) > 0 {
+		idx := 0
+		for idx < len(str) && str[idx] >= '0' && str[idx] <= '9' {
+			idx++
+		}
+		if idx == 0 {
+			return 0, fmt.Errorf("invalid duration %q", durationStr)
+		}
+		num, err := strconv.ParseInt(str[:idx], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", durationStr)
+		}
+		str = str[idx:]
+		var unit int64
+		switch {
+		case strings.HasPrefix(str, "ms"):
+			unit = 1
+			str = str[2:]
+		case strings.HasPrefix(str, "s"):
+			unit = timeutil.OneSecond
+			str = str[1:]
+		case strings.HasPrefix(str, "m"):
+			unit = timeutil.OneMinute
+			str = str[1:]
+		case strings.HasPrefix(str, "h"):
+			unit = timeutil.OneHour
+			str = str[1:]
+		case strings.HasPrefix(str, "d"):
+			unit = timeutil.OneDay
+			str = str[1:]
+		case strings.HasPrefix(str, "w"):
+			unit = 7 * timeutil.OneDay
+			str = str[1:]
+		case strings.HasPrefix(str, "y"):
+			unit = 365 * timeutil.OneDay
+			str = str[1:]
+		default:
+			return 0, fmt.Errorf("invalid duration unit of %q", durationStr)
+		}
+		duration += num * unit
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", durationStr)
+	}
+	return duration, nil
+}
@@ -0,0 +1,137 @@
+package promql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestParse_VectorSelector(t *testing.T) {
+	expr, err := Parse(`http_requests_total{job="api", code=~"5..", method!="GET", path!~'/admin.*',}`)
+	assert.NoError(t, err)
+	selector := expr.(*VectorSelector)
+	assert.Equal(t, "http_requests_total", selector.Name)
+	assert.Equal(t, []*LabelMatcher{
+		{Type: MatchEqual, Name: "job", Value: "api"},
+		{Type: MatchRegexp, Name: "code", Value: "5.."},
+		{Type: MatchNotEqual, Name: "method", Value: "GET"},
+		{Type: MatchNotRegexp, Name: "path", Value: "/admin.*"},
+	}, selector.Matchers)
+	assert.Equal(t, int64(0), selector.Range)
+	assert.Equal(t, `http_requests_total{job="api",code=~"5..",method!="GET",path!~"/admin.*"}`, expr.String())
+
+	expr, err = Parse(`{__name__="cpu", host="1.1.1.1"}[1h30m]`)
+	assert.NoError(t, err)
+	selector = expr.(*VectorSelector)
+	assert.Equal(t, "cpu", selector.Name)
+	assert.Len(t, selector.Matchers, 1)
+	assert.Equal(t, timeutil.OneHour+30*timeutil.OneMinute, selector.Range)
+	assert.Equal(t, `cpu{host="1.1.1.1"}[5400000ms]`, expr.String())
+
+	expr, err = Parse(`cpu`)
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", expr.String())
+}
+
+func TestParse_Aggregate(t *testing.T) {
+	expr, err := Parse(`sum by (job, le) (rate(http_request_duration_bucket[5m]))`)
+	assert.NoError(t, err)
+	agg := expr.(*AggregateExpr)
+	assert.Equal(t, "sum", agg.Op)
+	assert.Equal(t, []string{"job", "le"}, agg.Grouping)
+	call := agg.Expr.(*Call)
+	assert.Equal(t, "rate", call.Func)
+	assert.Equal(t, 5*timeutil.OneMinute, call.Args[0].(*VectorSelector).Range)
+	assert.Equal(t, "sum by (job,le) (rate(http_request_duration_bucket[300000ms]))", expr.String())
+
+	// grouping after expression
+	expr, err = Parse(`MAX(cpu) BY (host)`)
+	assert.NoError(t, err)
+	agg = expr.(*AggregateExpr)
+	assert.Equal(t, "max", agg.Op)
+	assert.Equal(t, []string{"host"}, agg.Grouping)
+
+	expr, err = Parse(`avg(cpu)`)
+	assert.NoError(t, err)
+	assert.Equal(t, "avg(cpu)", expr.String())
+
+	_, err = Parse(`sum by (host) (cpu) by (host)`)
+	assert.Error(t, err)
+	_, err = Parse(`sum without (host) (cpu)`)
+	assert.Error(t, err)
+	_, err = Parse(`sum by host (cpu)`)
+	assert.Error(t, err)
+	_, err = Parse(`sum by (host) cpu`)
+	assert.Error(t, err)
+	_, err = Parse(`sum(cpu`)
+	assert.Error(t, err)
+}
+
+func TestParse_Call(t *testing.T) {
+	expr, err := Parse(`histogram_quantile(0.99, sum(rate(latency_bucket[1m])) by (le))`)
+	assert.NoError(t, err)
+	call := expr.(*Call)
+	assert.Equal(t, "histogram_quantile", call.Func)
+	assert.Len(t, call.Args, 2)
+	assert.Equal(t, 0.99, call.Args[0].(*NumberLiteral).Val)
+	assert.Equal(t, "histogram_quantile(0.99,sum by (le) (rate(latency_bucket[60000ms])))", expr.String())
+
+	_, err = Parse(`rate(cpu[5m]`)
+	assert.Error(t, err)
+	_, err = Parse(`rate(cpu[5m] cpu)`)
+	assert.Error(t, err)
+	_, err = Parse(`histogram_quantile(0.9.9, cpu)`)
+	assert.Error(t, err)
+}
+
+func TestParse_Fail(t *testing.T) {
+	cases := []string{
+		``,
+		`cpu{`,
+		`cpu{host}`,
+		`cpu{host=}`,
+		`cpu{host=1}`,
+		`cpu{host="1"`,
+		`{host="1"}`,
+		`{__name__=~"cpu"}`,
+		`cpu{__name__="cpu"}`,
+		`cpu[5m`,
+		`cpu[5x]`,
+		`cpu[]`,
+		`cpu[-]`,
+		`cpu cpu`,
+		`cpu}`,
+		`cpu{host!"1"}`,
+		`cpu{host="1}`,
+		`cpu{host="\x"}`,
+		`)`,
+	}
+	for _, c := range cases {
+		_, err := Parse(c)
+		assert.Error(t, err, c)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]int64{
+		"500ms":  500,
+		"10s":    10 * timeutil.OneSecond,
+		"5m":     5 * timeutil.OneMinute,
+		"1h30m":  timeutil.OneHour + 30*timeutil.OneMinute,
+		"2d":     2 * timeutil.OneDay,
+		"1w":     7 * timeutil.OneDay,
+		"1y":     365 * timeutil.OneDay,
+		"1m500s": timeutil.OneMinute + 500*timeutil.OneSecond,
+	}
+	for str, expect := range cases {
+		duration, err := ParseDuration(str)
+		assert.NoError(t, err, str)
+		assert.Equal(t, expect, duration, str)
+	}
+	for _, str := range []string{"", "m", "5", "5x", "0s", "99999999999999999999s"} {
+		_, err := ParseDuration(str)
+		assert.Error(t, err, str)
+	}
+}
@@ -0,0 +1,227 @@
+package promql
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+const (
+	// DefaultFieldName is the field name of metric for vector selector, because prometheus metric has only one value
+	DefaultFieldName = "value"
+	// FieldLabel is the label name which selects other field of metric, like http_requests{__field__="count"}
+	FieldLabel = "__field__"
+	// BucketLabel is the label name of histogram bucket's upper bound
+	BucketLabel = "le"
+	// resultName is the alias of select item for reading the result of expression
+	resultName = "value"
+	// defaultLookback is the step of instant query if no range vector selector
+	defaultLookback = 5 * timeutil.OneMinute
+)
+
+// Query represents the prometheus query which is translated into the query statement of lindb,
+// the statement down samples the data by step, then the functions which need the values of
+// multi-step(rate) or multi-series(histogram_quantile) are evaluated on the results in broker side.
+type Query struct {
+	Statement *stmt.Query
+
+	Start int64 // evaluation start time
+	End   int64 // evaluation end time
+	Step  int64 // evaluation step
+
+	rangeDuration int64 // range duration of range vector selector
+	rangeSlots    int   // num. of time slots in the range of range vector selector
+	rate          bool  // calculates per-second rate of range vector
+	hasQuantile   bool  // calculates quantile from histogram buckets
+	quantile      float64
+	keepName      bool // keeps the metric name in result labels if no function/aggregation
+}
+
+// Translate translates the prometheus query language into the query,
+// step <= 0 means instant query at end time.
+func Translate(input string, start, end, step int64) (*Query, error) {
+	expr, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, fmt.Errorf("end time cannot be before start time")
+	}
+	q := &Query{Start: start, End: end, Step: step}
+	statement, err := q.translate(expr)
+	if err != nil {
+		return nil, err
+	}
+	q.rangeSlots = 1
+	switch {
+	case q.Step <= 0:
+		// instant query evaluates the range of range vector selector, or the default lookback
+		q.Step = defaultLookback
+		if q.rangeDuration > 0 {
+			q.Step = q.rangeDuration
+		}
+		q.Start = q.End
+	case q.rangeDuration > q.Step:
+		// range of range vector selector is aligned by step
+		q.rangeSlots = int((q.rangeDuration + q.Step - 1) / q.Step)
+	}
+	statement.Interval = q.Step
+	statement.TimeRange = timeutil.TimeRange{
+		Start: q.Start - int64(q.rangeSlots)*q.Step,
+		End:   q.End,
+	}
+	q.Statement = statement
+	return q, nil
+}
+
+// translate translates the expression into query statement, supports as below:
+// 1) histogram_quantile(φ, aggregation by (le))
+// 2) aggregation of vector selector or rate function
+// 3) rate of range vector selector
+// 4) vector selector
+func (q *Query) translate(expr Expr) (*stmt.Query, error) {
+	switch e := expr.(type) {
+	case *Call:
+		if e.Func != "histogram_quantile" {
+			return q.translateRate(e)
+		}
+		if len(e.Args) != 2 {
+			return nil, fmt.Errorf("histogram_quantile expects 2 arguments")
+		}
+		quantile, ok := e.Args[0].(*NumberLiteral)
+		if !ok {
+			return nil, fmt.Errorf("the first argument of histogram_quantile must be number")
+		}
+		agg, ok := e.Args[1].(*AggregateExpr)
+		if !ok || !containsLabel(agg.Grouping, BucketLabel) {
+			return nil, fmt.Errorf("histogram_quantile expects aggregation by %s label", BucketLabel)
+		}
+		q.hasQuantile = true
+		q.quantile = quantile.Val
+		return q.translateAggregate(agg)
+	case *AggregateExpr:
+		return q.translateAggregate(e)
+	case *VectorSelector:
+		q.keepName = true
+		return q.translateSelector(e, nil)
+	default:
+		return nil, fmt.Errorf("unsupported expression: %s", expr)
+	}
+}
+
+// translateAggregate translates the aggregation into the function call and group by of query statement
+func (q *Query) translateAggregate(agg *AggregateExpr) (*stmt.Query, error) {
+	var funcType function.FuncType
+	switch agg.Op {
+	case "sum":
+		funcType = function.Sum
+	case "min":
+		funcType = function.Min
+	case "max":
+		funcType = function.Max
+	case "avg":
+		funcType = function.Avg
+	}
+	var statement *stmt.Query
+	var err error
+	switch e := agg.Expr.(type) {
+	case *Call:
+		// sum(rate(x[5m])) equals rate(sum(x[5m])), because rate is linear
+		if funcType != function.Sum {
+			return nil, fmt.Errorf("only sum aggregation supported for function: %s", e.Func)
+		}
+		statement, err = q.translateRate(e)
+	case *VectorSelector:
+		statement, err = q.translateSelector(e, &funcType)
+	default:
+		return nil, fmt.Errorf("unsupported expression of aggregation: %s", agg.Expr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	statement.GroupBy = agg.Grouping
+	return statement, nil
+}
+
+// translateRate translates the rate function of range vector selector
+func (q *Query) translateRate(call *Call) (*stmt.Query, error) {
+	if call.Func != "rate" {
+		return nil, fmt.Errorf("unsupported function: %s", call.Func)
+	}
+	if len(call.Args) != 1 {
+		return nil, fmt.Errorf("rate expects 1 argument")
+	}
+	selector, ok := call.Args[0].(*VectorSelector)
+	if !ok || selector.Range <= 0 {
+		return nil, fmt.Errorf("rate expects range vector selector")
+	}
+	q.rate = true
+	funcType := function.Sum
+	statement, err := q.translateSelector(selector, &funcType)
+	if err != nil {
+		return nil, err
+	}
+	q.rangeDuration = selector.Range
+	return statement, nil
+}
+
+// translateSelector translates the vector selector into the metric/field/condition of query statement
+func (q *Query) translateSelector(selector *VectorSelector, funcType *function.FuncType) (*stmt.Query, error) {
+	if selector.Range > 0 && !q.rate {
+		return nil, fmt.Errorf("range vector selector only supported in rate function")
+	}
+	fieldName := DefaultFieldName
+	var condition stmt.Expr
+	for _, matcher := range selector.Matchers {
+		if matcher.Name == FieldLabel {
+			if matcher.Type != MatchEqual {
+				return nil, fmt.Errorf("field must be selected by equal matcher")
+			}
+			fieldName = matcher.Value
+			continue
+		}
+		var expr stmt.Expr
+		switch matcher.Type {
+		case MatchEqual:
+			expr = &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}
+		case MatchNotEqual:
+			expr = &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}}
+		case MatchRegexp:
+			// prometheus regular expression is fully anchored
+			expr = &stmt.RegexExpr{Key: matcher.Name, Regexp: anchoredRegexp(matcher.Value)}
+		case MatchNotRegexp:
+			expr = &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: matcher.Name, Regexp: anchoredRegexp(matcher.Value)}}
+		}
+		if condition == nil {
+			condition = expr
+		} else {
+			condition = &stmt.BinaryExpr{Left: condition, Right: expr, Operator: stmt.AND}
+		}
+	}
+	var selectItem stmt.Expr = &stmt.FieldExpr{Name: fieldName}
+	if funcType != nil {
+		selectItem = &stmt.CallExpr{FuncType: *funcType, Params: []stmt.Expr{selectItem}}
+	}
+	return &stmt.Query{
+		MetricName:  selector.Name,
+		SelectItems: []stmt.Expr{&stmt.SelectItem{Expr: selectItem, Alias: resultName}},
+		Condition:   condition,
+	}, nil
+}
+
+// anchoredRegexp returns the fully anchored regular expression
+func anchoredRegexp(regexp string) string {
+	return "^(?:" + regexp + ")$"
+}
+
+// containsLabel checks if the labels contain the label
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
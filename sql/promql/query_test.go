@@ -0,0 +1,123 @@
+package promql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestTranslate_Selector(t *testing.T) {
+	end := int64(1000 * timeutil.OneMinute)
+	q, err := Translate(`cpu{host="1.1.1.1", ip!~"10.*", __field__="load"}`, end, end, 0)
+	assert.NoError(t, err)
+	statement := q.Statement
+	assert.Equal(t, "cpu", statement.MetricName)
+	assert.Equal(t, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "load"}, Alias: "value"}},
+		statement.SelectItems)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"},
+		Right:    &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: "ip", Regexp: "^(?:10.*)$"}},
+		Operator: stmt.AND,
+	}, statement.Condition)
+	assert.Empty(t, statement.GroupBy)
+	// instant query uses default lookback
+	assert.Equal(t, defaultLookback, q.Step)
+	assert.Equal(t, defaultLookback, statement.Interval)
+	assert.Equal(t, timeutil.TimeRange{Start: end - defaultLookback, End: end}, statement.TimeRange)
+	assert.Equal(t, 1, q.PointCount())
+	assert.True(t, q.keepName)
+	assert.Equal(t, "value", q.ResultName())
+
+	q, err = Translate(`cpu{host!="1.1.1.1", ip=~"10.*"}`, end, end, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: DefaultFieldName}, Alias: "value"}},
+		q.Statement.SelectItems)
+	assert.Equal(t, "not host=1.1.1.1andip=~^(?:10.*)$", q.Statement.Condition.Rewrite())
+}
+
+func TestTranslate_Aggregate(t *testing.T) {
+	start := int64(1000 * timeutil.OneMinute)
+	end := start + 10*timeutil.OneMinute
+	q, err := Translate(`max by (host) (cpu)`, start, end, timeutil.OneMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"host"}, q.Statement.GroupBy)
+	assert.Equal(t, "max(value) as value", q.Statement.SelectItems[0].Rewrite())
+	assert.Equal(t, timeutil.OneMinute, q.Statement.Interval)
+	assert.Equal(t, timeutil.TimeRange{Start: start - timeutil.OneMinute, End: end}, q.Statement.TimeRange)
+	assert.Equal(t, 11, q.PointCount())
+	assert.False(t, q.keepName)
+
+	for _, op := range []string{"sum", "min", "avg"} {
+		q, err = Translate(op+`(cpu)`, start, end, timeutil.OneMinute)
+		assert.NoError(t, err)
+		assert.Equal(t, op+"(value) as value", q.Statement.SelectItems[0].Rewrite())
+	}
+}
+
+func TestTranslate_Rate(t *testing.T) {
+	start := int64(1000 * timeutil.OneMinute)
+	end := start + 10*timeutil.OneMinute
+	q, err := Translate(`sum by (host) (rate(requests{__field__="count"}[5m]))`, start, end, timeutil.OneMinute)
+	assert.NoError(t, err)
+	assert.True(t, q.rate)
+	assert.Equal(t, 5, q.rangeSlots)
+	assert.Equal(t, "sum(count) as value", q.Statement.SelectItems[0].Rewrite())
+	assert.Equal(t, []string{"host"}, q.Statement.GroupBy)
+	assert.Equal(t, timeutil.TimeRange{Start: start - 5*timeutil.OneMinute, End: end}, q.Statement.TimeRange)
+	assert.Equal(t, 15, q.PointCount())
+
+	// range less than step
+	q, err = Translate(`rate(requests[30s])`, start, end, timeutil.OneMinute)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, q.rangeSlots)
+	assert.Empty(t, q.Statement.GroupBy)
+
+	// instant query uses the range as step
+	q, err = Translate(`rate(requests[5m])`, end, end, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, q.rangeSlots)
+	assert.Equal(t, 5*timeutil.OneMinute, q.Statement.Interval)
+	assert.Equal(t, timeutil.TimeRange{Start: end - 5*timeutil.OneMinute, End: end}, q.Statement.TimeRange)
+}
+
+func TestTranslate_HistogramQuantile(t *testing.T) {
+	end := int64(1000 * timeutil.OneMinute)
+	q, err := Translate(`histogram_quantile(0.99, sum by (le, host) (rate(latency_bucket[1m])))`,
+		end, end, 0)
+	assert.NoError(t, err)
+	assert.True(t, q.hasQuantile)
+	assert.Equal(t, 0.99, q.quantile)
+	assert.Equal(t, "latency_bucket", q.Statement.MetricName)
+	assert.Equal(t, []string{"le", "host"}, q.Statement.GroupBy)
+}
+
+func TestTranslate_Fail(t *testing.T) {
+	cases := []string{
+		`cpu{`,
+		`cpu[5m]`,
+		`sum(cpu[5m])`,
+		`max(rate(cpu[5m]))`,
+		`rate(cpu)`,
+		`rate(sum(cpu))`,
+		`rate(cpu[5m], cpu[5m])`,
+		`irate(cpu[5m])`,
+		`sum(sum(cpu))`,
+		`sum(histogram_quantile(0.9, sum by (le) (cpu)))`,
+		`histogram_quantile(0.9)`,
+		`histogram_quantile(cpu, sum by (le) (cpu))`,
+		`histogram_quantile(0.9, sum by (host) (cpu))`,
+		`histogram_quantile(0.9, cpu)`,
+		`histogram_quantile(0.9, sum by (le) (rate(cpu)))`,
+		`cpu{__field__=~"f"}`,
+		`0.9`,
+	}
+	for _, c := range cases {
+		_, err := Translate(c, 0, timeutil.OneHour, timeutil.OneMinute)
+		assert.Error(t, err, c)
+	}
+	_, err := Translate(`cpu`, timeutil.OneHour, 0, timeutil.OneMinute)
+	assert.Error(t, err)
+}
@@ -2,7 +2,9 @@ package sql
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
@@ -14,20 +16,30 @@ import (
 
 // queryStmtParse represents query statement parser using visitor
 type queryStmtParse struct {
-	metricName string
+	explain     bool
+	metricName  string
+	metricNames []string
 
 	selectItems []stmt.Expr
 
 	startTime int64
 	endTime   int64
+	// time literals are resolved after parsing, because time zone clause is after where clause
+	startTimeLiteral string
+	endTimeLiteral   string
+	timeZone         string
+	location         *time.Location
 
 	condition stmt.Expr
 	//orderByExpr stmt.Expr
 	//desc        bool
-	limit    int
-	groupBy  []string
-	interval int64
-	fieldID  int
+	limit        int
+	groupBy      []string
+	interval     int64
+	intervalUnit timeutil.IntervalUnit // unit of group by time interval, like day/month
+	fieldID      int
+
+	subQuery *stmt.Query
 
 	exprStack *collections.Stack
 
@@ -35,8 +47,9 @@ type queryStmtParse struct {
 }
 
 // newQueryStmtParse create a query statement parser
-func newQueryStmtParse() *queryStmtParse {
+func newQueryStmtParse(explain bool) *queryStmtParse {
 	return &queryStmtParse{
+		explain:   explain,
 		limit:     20,
 		fieldID:   1,
 		exprStack: collections.NewStack(),
@@ -50,10 +63,20 @@ func (q *queryStmtParse) build() (*stmt.Query, error) {
 	}
 
 	query := &stmt.Query{}
+	query.Explain = q.explain
 	query.MetricName = q.metricName
 	query.SelectItems = q.selectItems
 	query.Condition = q.condition
+	if len(q.metricNames) > 1 {
+		query.MetricNames = q.metricNames
+		if err := q.validateCrossMetricFields(query); err != nil {
+			return nil, err
+		}
+	}
 
+	if err := q.resolveTimeLiterals(); err != nil {
+		return nil, err
+	}
 	now := timeutil.Now()
 	query.TimeRange = timeutil.TimeRange{Start: q.startTime, End: q.endTime}
 	if query.TimeRange.Start <= 0 {
@@ -67,25 +90,407 @@ func (q *queryStmtParse) build() (*stmt.Query, error) {
 	}
 
 	query.Interval = q.interval
+	query.TimeZone = q.timeZone
+	if q.isCalendarInterval() {
+		// the buckets are calculated by calendar in the time zone, aligns start time to the first bucket
+		query.IntervalUnit = q.intervalUnit
+		calendar, _ := query.CalendarInterval()
+		query.TimeRange.Start = calendar.Truncate(query.TimeRange.Start)
+	} else if q.location != nil {
+		// aligns start time to the bucket boundary of local wall clock in the given time zone
+		query.TimeRange.Start = timeutil.Truncate(query.TimeRange.Start, q.interval, q.location)
+	}
 	query.GroupBy = q.groupBy
 	query.Limit = q.limit
+	if q.subQuery != nil {
+		if err := q.buildSubQuery(query); err != nil {
+			return nil, err
+		}
+	}
 	return query, nil
 }
 
+// buildSubQuery builds the outer query of sub query, the outer query uses the time range/interval of sub query,
+// and the group by tags must be included in the group by tags of sub query.
+func (q *queryStmtParse) buildSubQuery(query *stmt.Query) error {
+	subQuery := q.subQuery
+	if q.condition != nil || q.startTime > 0 || q.endTime > 0 ||
+		len(q.startTimeLiteral) > 0 || len(q.endTimeLiteral) > 0 {
+		return fmt.Errorf("where clause not support for query from sub query")
+	}
+	if q.interval > 0 {
+		return fmt.Errorf("group by time not support for query from sub query")
+	}
+	for _, tagKey := range q.groupBy {
+		found := false
+		for _, subTagKey := range subQuery.GroupBy {
+			if tagKey == subTagKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("group by tag key[%s] not in group by of sub query", tagKey)
+		}
+	}
+	for _, item := range subQuery.SelectItems {
+		// the series of sub query are aggregated by outer query, cannot be selected before aggregating
+		if selectItem, ok := item.(*stmt.SelectItem); ok {
+			if callExpr, ok := selectItem.Expr.(*stmt.CallExpr); ok &&
+				(callExpr.FuncType == function.TopK || callExpr.FuncType == function.BottomK) {
+				return fmt.Errorf("%s not support in sub query", function.FuncTypeString(callExpr.FuncType))
+			}
+		}
+	}
+	if subQuery.IsTimeShift() {
+		return fmt.Errorf("time_shift not support in sub query")
+	}
+	resultNames := make(map[string]struct{})
+	for _, name := range subQuery.ResultNames() {
+		resultNames[name] = struct{}{}
+	}
+	var err error
+	var validate func(expr stmt.Expr)
+	validate = func(expr stmt.Expr) {
+		if err != nil {
+			return
+		}
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			validate(e.Expr)
+		case *stmt.CallExpr:
+			for _, param := range e.Params {
+				validate(param)
+			}
+		case *stmt.ParenExpr:
+			validate(e.Expr)
+		case *stmt.BinaryExpr:
+			validate(e.Left)
+			validate(e.Right)
+		case *stmt.FieldExpr:
+			if _, ok := resultNames[e.Name]; !ok {
+				err = fmt.Errorf("field[%s] not in select list of sub query", e.Name)
+			}
+		}
+	}
+	for _, selectItem := range query.SelectItems {
+		validate(selectItem)
+	}
+	if err != nil {
+		return err
+	}
+
+	query.SubQuery = subQuery
+	query.TimeRange = subQuery.TimeRange
+	query.Interval = subQuery.Interval
+	query.IntervalType = subQuery.IntervalType
+	query.IntervalUnit = subQuery.IntervalUnit
+	query.TimeZone = subQuery.TimeZone
+	return nil
+}
+
+// resolveTimeLiterals parses the time literals of time range using the time zone if set
+func (q *queryStmtParse) resolveTimeLiterals() error {
+	loc := q.location
+	if loc == nil {
+		loc = time.Local
+	}
+	if len(q.startTimeLiteral) > 0 {
+		timestamp, err := timeutil.ParseTimestampInLocation(q.startTimeLiteral, loc)
+		if err != nil {
+			return err
+		}
+		q.startTime = timestamp
+	}
+	if len(q.endTimeLiteral) > 0 {
+		timestamp, err := timeutil.ParseTimestampInLocation(q.endTimeLiteral, loc)
+		if err != nil {
+			return err
+		}
+		q.endTime = timestamp
+	}
+	return nil
+}
+
 // validation tests data if invalid
 func (q *queryStmtParse) validation() error {
 	if q.err != nil {
 		return q.err
 	}
-	if len(q.metricName) == 0 {
+	if len(q.metricName) == 0 && q.subQuery == nil {
 		return fmt.Errorf("metric name cannot be empty")
 	}
 	if len(q.selectItems) == 0 {
 		return fmt.Errorf("select fields cannbe be empty")
 	}
+	if err := q.validateSelectorFuncs(); err != nil {
+		return err
+	}
+	if err := q.validateTimeShiftFuncs(); err != nil {
+		return err
+	}
+	return q.validateScalarExprs()
+}
+
+// validateSelectorFuncs tests if the series selector functions(topk/bottomk) are valid,
+// 1) selector function must be the outermost function of select item
+// 2) the syntax is topk(k, expr[, rankFunc()]), k must be a positive integer,
+// the optional rank function(like avg()) selects the series over the whole time range.
+func (q *queryStmtParse) validateSelectorFuncs() error {
+	var err error
+	var validate func(expr stmt.Expr, top bool)
+	validate = func(expr stmt.Expr, top bool) {
+		if err != nil {
+			return
+		}
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			validate(e.Expr, true)
+		case *stmt.CallExpr:
+			if e.FuncType == function.TopK || e.FuncType == function.BottomK {
+				if !top {
+					err = fmt.Errorf("%s must be the outermost function of select item",
+						function.FuncTypeString(e.FuncType))
+					return
+				}
+				if err = validateSelectorParams(e); err != nil {
+					return
+				}
+				validate(e.Params[1], false)
+				return
+			}
+			for _, param := range e.Params {
+				validate(param, false)
+			}
+		case *stmt.ParenExpr:
+			validate(e.Expr, false)
+		case *stmt.BinaryExpr:
+			validate(e.Left, false)
+			validate(e.Right, false)
+		}
+	}
+	for _, selectItem := range q.selectItems {
+		validate(selectItem, true)
+	}
+	return err
+}
+
+// validateTimeShiftFuncs tests if the time shift functions are valid, the syntax is time_shift(expr, 1w),
+// the expr is evaluated over the time range shifted to the past, then aligned back to the query time range,
+// so the shift must be a multiple of the interval, and the time shift function cannot be nested.
+func (q *queryStmtParse) validateTimeShiftFuncs() error {
+	query := stmt.Query{SelectItems: q.selectItems}
+	if !query.IsTimeShift() {
+		return nil
+	}
+	if q.subQuery != nil {
+		return fmt.Errorf("time_shift not support for query from sub query")
+	}
+	if len(q.metricNames) > 1 {
+		return fmt.Errorf("time_shift not support for cross-metric query")
+	}
+	var err error
+	var validate func(expr stmt.Expr, shifted bool)
+	validate = func(expr stmt.Expr, shifted bool) {
+		if err != nil {
+			return
+		}
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			validate(e.Expr, shifted)
+		case *stmt.CallExpr:
+			if e.FuncType != function.TimeShift {
+				for _, param := range e.Params {
+					validate(param, shifted)
+				}
+				return
+			}
+			if shifted {
+				err = fmt.Errorf("time_shift cannot be nested")
+				return
+			}
+			if len(e.Params) != 2 {
+				err = fmt.Errorf("time_shift requires 2 params, like time_shift(f, 1w)")
+				return
+			}
+			shift, ok := e.Params[1].(*stmt.DurationLiteral)
+			if !ok || shift.Val <= 0 {
+				err = fmt.Errorf("time_shift requires a positive duration as second param")
+				return
+			}
+			// the interval of storage is unknown when parsing, so the interval must be given explicitly
+			// for aligning the time slots of shifted series with the query
+			if q.interval <= 0 {
+				err = fmt.Errorf("time_shift requires group by time(interval), like group by time(1m)")
+				return
+			}
+			if q.isCalendarInterval() {
+				err = fmt.Errorf("time_shift not support with calendar interval, like month/year or day/week with time zone")
+				return
+			}
+			if shift.Val%q.interval != 0 {
+				err = fmt.Errorf("shift of time_shift must be a multiple of interval")
+				return
+			}
+			validate(e.Params[0], true)
+		case *stmt.ParenExpr:
+			validate(e.Expr, shifted)
+		case *stmt.BinaryExpr:
+			validate(e.Left, shifted)
+			validate(e.Right, shifted)
+		}
+	}
+	for _, selectItem := range q.selectItems {
+		validate(selectItem, false)
+	}
+	return err
+}
+
+// validateScalarExprs tests if the math functions and the comparisons with scalar are valid,
+// 1) the syntax of math function is func(expr[, number]), like abs(f), round(f, 2), clamp_min(f, 0)
+// 2) the comparison filters the points of series by a number, like f>10 or 10<f
+func (q *queryStmtParse) validateScalarExprs() error {
+	var err error
+	var validate func(expr stmt.Expr)
+	validate = func(expr stmt.Expr) {
+		if err != nil {
+			return
+		}
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			validate(e.Expr)
+		case *stmt.CallExpr:
+			if function.IsMathFunc(e.FuncType) {
+				if err = validateMathFuncParams(e); err != nil {
+					return
+				}
+			}
+			for _, param := range e.Params {
+				validate(param)
+			}
+		case *stmt.ParenExpr:
+			validate(e.Expr)
+		case *stmt.BinaryExpr:
+			if stmt.IsComparisonOP(e.Operator) {
+				_, leftScalar := e.Left.(*stmt.NumberLiteral)
+				_, rightScalar := e.Right.(*stmt.NumberLiteral)
+				if leftScalar == rightScalar {
+					err = fmt.Errorf("comparison requires a number on one side, like f>10: %s", e.Rewrite())
+					return
+				}
+			}
+			validate(e.Left)
+			validate(e.Right)
+		}
+	}
+	for _, selectItem := range q.selectItems {
+		validate(selectItem)
+	}
+	return err
+}
+
+// validateMathFuncParams tests if the params of math function are valid
+func validateMathFuncParams(e *stmt.CallExpr) error {
+	funcName := function.FuncTypeString(e.FuncType)
+	switch e.FuncType {
+	case function.Round:
+		if len(e.Params) != 1 && len(e.Params) != 2 {
+			return fmt.Errorf("%s requires 1 or 2 params, like %s(f, 2)", funcName, funcName)
+		}
+		if len(e.Params) == 2 {
+			n, ok := e.Params[1].(*stmt.NumberLiteral)
+			if !ok || n.Val < 0 || n.Val != math.Trunc(n.Val) {
+				return fmt.Errorf("%s requires a non-negative integer as second param", funcName)
+			}
+		}
+	case function.ClampMin, function.ClampMax:
+		if len(e.Params) != 2 {
+			return fmt.Errorf("%s requires 2 params, like %s(f, 0)", funcName, funcName)
+		}
+		if _, ok := e.Params[1].(*stmt.NumberLiteral); !ok {
+			return fmt.Errorf("%s requires a number as second param", funcName)
+		}
+	default:
+		if len(e.Params) != 1 {
+			return fmt.Errorf("%s requires 1 param, like %s(f)", funcName, funcName)
+		}
+	}
+	if _, ok := e.Params[0].(*stmt.NumberLiteral); ok {
+		return fmt.Errorf("%s requires a series as first param", funcName)
+	}
 	return nil
 }
 
+// validateSelectorParams tests if the params of series selector function are valid
+func validateSelectorParams(e *stmt.CallExpr) error {
+	funcName := function.FuncTypeString(e.FuncType)
+	if len(e.Params) != 2 && len(e.Params) != 3 {
+		return fmt.Errorf("%s requires 2 or 3 params, like %s(5, f)", funcName, funcName)
+	}
+	k, ok := e.Params[0].(*stmt.NumberLiteral)
+	if !ok || k.Val < 1 || k.Val != math.Trunc(k.Val) {
+		return fmt.Errorf("%s requires a positive integer as first param", funcName)
+	}
+	if len(e.Params) == 3 {
+		rankFunc, ok := e.Params[2].(*stmt.CallExpr)
+		if !ok || len(rankFunc.Params) > 0 {
+			return fmt.Errorf("%s requires a rank function without params as third param, like avg()", funcName)
+		}
+		switch rankFunc.FuncType {
+		case function.Sum, function.Min, function.Max, function.Avg, function.Last:
+		default:
+			return fmt.Errorf("%s not support rank function: %s", funcName, function.FuncTypeString(rankFunc.FuncType))
+		}
+	}
+	return nil
+}
+
+// validateCrossMetricFields tests if all fields are qualified by metric name(like a.f) for cross-metric query,
+// the param of aggregate function must be a field, because the fields of each metric are aggregated separately,
+// like sum(a.f)+sum(b.f) instead of sum(a.f+b.f).
+func (q *queryStmtParse) validateCrossMetricFields(query *stmt.Query) error {
+	metrics := make(map[string]struct{})
+	for _, metricName := range q.metricNames {
+		if _, ok := metrics[metricName]; ok {
+			return fmt.Errorf("duplicate metric name: %s", metricName)
+		}
+		metrics[metricName] = struct{}{}
+	}
+	var err error
+	var validate func(expr stmt.Expr)
+	validate = func(expr stmt.Expr) {
+		if err != nil {
+			return
+		}
+		switch e := expr.(type) {
+		case *stmt.SelectItem:
+			validate(e.Expr)
+		case *stmt.CallExpr:
+			for _, param := range e.Params {
+				if _, ok := param.(*stmt.FieldExpr); !ok && function.IsAggFunc(e.FuncType) {
+					err = fmt.Errorf("param of %s must be a field for cross-metric query: %s",
+						function.FuncTypeString(e.FuncType), param.Rewrite())
+					return
+				}
+				validate(param)
+			}
+		case *stmt.ParenExpr:
+			validate(e.Expr)
+		case *stmt.BinaryExpr:
+			validate(e.Left)
+			validate(e.Right)
+		case *stmt.FieldExpr:
+			if _, _, ok := query.FieldMetric(e.Name); !ok {
+				err = fmt.Errorf("field must be qualified by metric name for cross-metric query: %s", e.Name)
+			}
+		}
+	}
+	for _, selectItem := range query.SelectItems {
+		validate(selectItem)
+	}
+	return err
+}
+
 // resetExprStack resets expr stack for next parse fragment
 func (q *queryStmtParse) resetExprStack() {
 	q.exprStack = collections.NewStack()
@@ -112,12 +517,39 @@ func (q *queryStmtParse) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 		q.groupBy = append(q.groupBy, tagKey)
 	case ctx.DurationLit() != nil:
 		q.interval = q.parseDuration(ctx.DurationLit())
+		q.intervalUnit = q.parseIntervalUnit(ctx.DurationLit())
+	}
+}
+
+// isCalendarInterval returns if the buckets of group by time are calculated by calendar,
+// month/year interval has no fixed length, day/week interval in time zone has 23/25 hours when DST changes
+func (q *queryStmtParse) isCalendarInterval() bool {
+	switch q.intervalUnit {
+	case timeutil.MonthUnit, timeutil.YearUnit:
+		return true
+	case timeutil.DayUnit, timeutil.WeekUnit:
+		return q.location != nil
+	default:
+		return false
+	}
+}
+
+// visitSubQuery visits when production sub query is exited, sets the sub query's statement
+func (q *queryStmtParse) visitSubQuery(subQuery *stmt.Query, err error) {
+	if err != nil {
+		q.err = err
+		return
 	}
+	q.subQuery = subQuery
 }
 
 // visitMetricName visits when production metricName expression is entered
 func (q *queryStmtParse) visitMetricName(ctx *grammar.MetricNameContext) {
-	q.metricName = strutil.GetStringValue(ctx.Ident().GetText())
+	metricName := strutil.GetStringValue(ctx.Ident().GetText())
+	if len(q.metricName) == 0 {
+		q.metricName = metricName
+	}
+	q.metricNames = append(q.metricNames, metricName)
 }
 
 // visitTimeRangeExpr visits when production timeRange expression is entered
@@ -129,10 +561,14 @@ func (q *queryStmtParse) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 			continue
 		}
 		var timestamp int64
+		var literal string
 		var err error
 		switch {
 		case timeExprCtx.Ident() != nil:
-			timestamp, err = timeutil.ParseTimestamp(strutil.GetStringValue(timeExprCtx.Ident().GetText()))
+			literal = strutil.GetStringValue(timeExprCtx.Ident().GetText())
+		case timeExprCtx.L_INT() != nil:
+			// epoch milliseconds
+			timestamp, err = strconv.ParseInt(timeExprCtx.L_INT().GetText(), 10, 64)
 		case timeExprCtx.NowExpr() != nil:
 			timestamp = timeutil.Now()
 			durationExpr, ok := timeExprCtx.NowExpr().(*grammar.NowExprContext)
@@ -154,13 +590,30 @@ func (q *queryStmtParse) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 		}
 		if binaryOpCtx.T_GREATER() != nil || binaryOpCtx.T_GREATEREQUAL() != nil {
 			q.startTime = timestamp
+			q.startTimeLiteral = literal
 		}
 		if binaryOpCtx.T_LESS() != nil || binaryOpCtx.T_LESSEQUAL() != nil {
 			q.endTime = timestamp
+			q.endTimeLiteral = literal
 		}
 	}
 }
 
+// visitTimeZone visits when production time zone clause is entered
+func (q *queryStmtParse) visitTimeZone(ctx *grammar.TimeZoneClauseContext) {
+	if ctx.Ident() == nil {
+		return
+	}
+	timeZone := strutil.GetStringValue(ctx.Ident().GetText())
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		q.err = err
+		return
+	}
+	q.timeZone = timeZone
+	q.location = loc
+}
+
 // parseDuration parses time duration from duration string
 func (q *queryStmtParse) parseDuration(ctx grammar.IDurationLitContext) int64 {
 	if ctx == nil {
@@ -203,6 +656,30 @@ func (q *queryStmtParse) parseDuration(ctx grammar.IDurationLitContext) int64 {
 	return result
 }
 
+// parseIntervalUnit parses the calendar unit of duration, returns fixed unit for second/minute/hour
+func (q *queryStmtParse) parseIntervalUnit(ctx grammar.IDurationLitContext) timeutil.IntervalUnit {
+	durationCtx, ok := ctx.(*grammar.DurationLitContext)
+	if !ok || durationCtx.IntervalItem() == nil {
+		return timeutil.FixedUnit
+	}
+	unit, ok := durationCtx.IntervalItem().(*grammar.IntervalItemContext)
+	if !ok {
+		return timeutil.FixedUnit
+	}
+	switch {
+	case nil != unit.T_DAY():
+		return timeutil.DayUnit
+	case nil != unit.T_WEEK():
+		return timeutil.WeekUnit
+	case nil != unit.T_MONTH():
+		return timeutil.MonthUnit
+	case nil != unit.T_YEAR():
+		return timeutil.YearUnit
+	default:
+		return timeutil.FixedUnit
+	}
+}
+
 // visitTagFilterExpr visits when production tag filter expression is entered
 func (q *queryStmtParse) visitTagFilterExpr(ctx *grammar.TagFilterExprContext) {
 	tagKey := ctx.TagKey()
@@ -322,6 +799,21 @@ func (q *queryStmtParse) visitFieldExpr(ctx *grammar.FieldExprContext) {
 		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.ADD})
 	case ctx.T_SUB() != nil:
 		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.SUB})
+	case ctx.T_EQUAL() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.EQUAL})
+	case ctx.T_NOTEQUAL() != nil || ctx.T_NOTEQUAL2() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.NOTEQUAL})
+	case ctx.T_LESS() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.LESS})
+	case ctx.T_LESSEQUAL() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.LESSEQUAL})
+	case ctx.T_GREATER() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.GREATER})
+	case ctx.T_GREATEREQUAL() != nil:
+		q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.GREATEREQUAL})
+	case ctx.DurationLit() != nil:
+		// duration is only used as the param of function, like time_shift(f, 1w)
+		q.setExprParam(&stmt.DurationLiteral{Val: q.parseDuration(ctx.DurationLit())})
 	}
 }
 
@@ -358,6 +850,36 @@ func (q *queryStmtParse) visitFuncName(ctx *grammar.FuncNameContext) {
 		callExpr.FuncType = function.Stddev
 	case ctx.T_HISTOGRAM() != nil:
 		callExpr.FuncType = function.Histogram
+	case ctx.T_COUNT() != nil:
+		callExpr.FuncType = function.Count
+	case ctx.T_FIRST() != nil:
+		callExpr.FuncType = function.First
+	case ctx.T_LAST() != nil:
+		callExpr.FuncType = function.Last
+	case ctx.T_TOPK() != nil:
+		callExpr.FuncType = function.TopK
+	case ctx.T_BOTTOMK() != nil:
+		callExpr.FuncType = function.BottomK
+	case ctx.T_TIME_SHIFT() != nil:
+		callExpr.FuncType = function.TimeShift
+	case ctx.T_ABS() != nil:
+		callExpr.FuncType = function.Abs
+	case ctx.T_CEIL() != nil:
+		callExpr.FuncType = function.Ceil
+	case ctx.T_FLOOR() != nil:
+		callExpr.FuncType = function.Floor
+	case ctx.T_ROUND() != nil:
+		callExpr.FuncType = function.Round
+	case ctx.T_LOG() != nil:
+		callExpr.FuncType = function.Log
+	case ctx.T_LOG10() != nil:
+		callExpr.FuncType = function.Log10
+	case ctx.T_SQRT() != nil:
+		callExpr.FuncType = function.Sqrt
+	case ctx.T_CLAMP_MIN() != nil:
+		callExpr.FuncType = function.ClampMin
+	case ctx.T_CLAMP_MAX() != nil:
+		callExpr.FuncType = function.ClampMax
 	}
 }
 
@@ -385,6 +907,15 @@ func (q *queryStmtParse) visitExprAtom(ctx *grammar.ExprAtomContext) {
 		} else {
 			q.setExprParam(&stmt.FieldExpr{Name: val})
 		}
+	case ctx.IntNumber() != nil || ctx.DecNumber() != nil:
+		// number is only used as the param of expression, like topk(5, f)
+		text := ctx.GetText()
+		val, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			q.err = fmt.Errorf("invalid number: %s", text)
+			return
+		}
+		q.setExprParam(&stmt.NumberLiteral{Val: val})
 	default:
 	}
 }
@@ -420,6 +951,13 @@ func (q *queryStmtParse) completeFieldExpr(ctx *grammar.FieldExprContext) {
 	case ctx.T_DIV() != nil:
 	case ctx.T_ADD() != nil:
 	case ctx.T_SUB() != nil:
+	case ctx.T_EQUAL() != nil:
+	case ctx.T_NOTEQUAL() != nil:
+	case ctx.T_NOTEQUAL2() != nil:
+	case ctx.T_LESS() != nil:
+	case ctx.T_LESSEQUAL() != nil:
+	case ctx.T_GREATER() != nil:
+	case ctx.T_GREATEREQUAL() != nil:
 	default:
 		return
 	}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -21,6 +22,101 @@ func TestMetricName(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestCrossMetric(t *testing.T) {
+	sql := "select sum(http_errors.count)/sum(http_requests.count) as ratio from http_errors, http_requests" +
+		" where region='sh' group by host"
+	query, err := Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, "http_errors", query.MetricName)
+	assert.Equal(t, []string{"http_errors", "http_requests"}, query.MetricNames)
+	assert.True(t, query.IsCrossMetric())
+	assert.Equal(t, []string{"host"}, query.GroupBy)
+	selectItem := query.SelectItems[0].(*stmt.SelectItem)
+	assert.Equal(t, "ratio", selectItem.Alias)
+	assert.Equal(t, "sum(http_errors.count)/sum(http_requests.count)", selectItem.Expr.Rewrite())
+
+	// field not qualified by metric name
+	sql = "select http_errors.count/count from http_errors, http_requests"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// expression under aggregate function
+	sql = "select sum(http_errors.count+http_requests.count) from http_errors, http_requests"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	sql = "select max((http_errors.count)) from http_errors, http_requests"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// expression under non-aggregate function
+	sql = "select abs(http_errors.count-http_requests.count) from http_errors, http_requests"
+	_, err = Parse(sql)
+	assert.NoError(t, err)
+	// duplicate metric name
+	sql = "select http_errors.count from http_errors, http_errors"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// single metric
+	sql = "select f from cpu"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Empty(t, query.MetricNames)
+}
+
+func TestSubQuery(t *testing.T) {
+	sql := "select max(v) as max_v from (select sum(requests) as v from http" +
+		" where region='sh' and time>'2019-07-29 00:00:00' group by host, region, time(1m)) group by region"
+	query, err := Parse(sql)
+	assert.NoError(t, err)
+	assert.Empty(t, query.MetricName)
+	assert.Equal(t, []string{"region"}, query.GroupBy)
+	assert.Equal(t, []string{"max_v"}, query.ResultNames())
+	subQuery := query.SubQuery
+	assert.NotNil(t, subQuery)
+	assert.Equal(t, "http", subQuery.MetricName)
+	assert.Equal(t, []string{"host", "region"}, subQuery.GroupBy)
+	assert.Equal(t, timeutil.OneMinute, subQuery.Interval)
+	assert.Equal(t, "region=sh", subQuery.Condition.Rewrite())
+	assert.Equal(t, subQuery.TimeRange, query.TimeRange)
+	assert.Equal(t, subQuery.Interval, query.Interval)
+
+	// nested sub query
+	sql = "select max(v) from (select sum(v) as v from (select sum(f) as v from cpu group by host, ip) group by host)"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", query.LeafQuery().MetricName)
+
+	// field not in sub query's select list
+	sql = "select max(f) from (select sum(f) as v from cpu group by host)"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// group by tag not in sub query's group by
+	sql = "select max(v) from (select sum(f) as v from cpu group by host) group by ip"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// where/group by time not support for outer query
+	sql = "select max(v) from (select sum(f) as v from cpu group by host) where host='1.1.1.1'"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	sql = "select max(v) from (select sum(f) as v from cpu group by host) group by time(1m)"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// sub query parse error
+	sql = "select max(v) from (select sum(f) as v from cpu where time>'2019-07-29T00:00' group by host)"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+}
+
+func TestExplain(t *testing.T) {
+	sql := "explain select f from cpu"
+	query, err := Parse(sql)
+	assert.Nil(t, err)
+	assert.True(t, query.Explain)
+
+	sql = "select f from cpu"
+	query, err = Parse(sql)
+	assert.Nil(t, err)
+	assert.False(t, query.Explain)
+}
+
 func TestSingleSelectItem(t *testing.T) {
 	sql := "select f from memory"
 	query, err := Parse(sql)
@@ -40,6 +136,168 @@ func TestSingleSelectItem(t *testing.T) {
 	assert.Equal(t, stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "f"}, Alias: "f1"}, *selectItem)
 }
 
+func TestCountFirstLast(t *testing.T) {
+	query, err := Parse("select count(f), first(f), last(f), count from memory")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]stmt.Expr{
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Count, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.First, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Last, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+			// keyword of function also can be used as field name
+			&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "count"}},
+		},
+		query.SelectItems)
+}
+
+func TestTopKBottomK(t *testing.T) {
+	query, err := Parse("select topk(5, sum(f)), bottomk(3, f*2, avg()) from memory group by host")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]stmt.Expr{
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.TopK, Params: []stmt.Expr{
+				&stmt.NumberLiteral{Val: 5},
+				&stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+			}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.BottomK, Params: []stmt.Expr{
+				&stmt.NumberLiteral{Val: 3},
+				&stmt.BinaryExpr{Left: &stmt.FieldExpr{Name: "f"}, Operator: stmt.MUL, Right: &stmt.NumberLiteral{Val: 2}},
+				&stmt.CallExpr{FuncType: function.Avg},
+			}}},
+		},
+		query.SelectItems)
+
+	_, err = Parse("select topk(f) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(0, f) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(1.5, f) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(f, 5) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(5, f, f) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(5, f, count()) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select sum(topk(5, f)) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(5, f)+1 from memory")
+	assert.Error(t, err)
+	_, err = Parse("select topk(5, f) from (select f from memory group by host)")
+	assert.NoError(t, err)
+	_, err = Parse("select f from (select topk(5, f) as f from memory group by host)")
+	assert.Error(t, err)
+}
+
+func TestTimeShift(t *testing.T) {
+	query, err := Parse("select sum(f), sum(f)/time_shift(sum(f), 1w) from memory group by host, time(1h)")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]stmt.Expr{
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+			&stmt.SelectItem{Expr: &stmt.BinaryExpr{
+				Left:     &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+				Operator: stmt.DIV,
+				Right: &stmt.CallExpr{FuncType: function.TimeShift, Params: []stmt.Expr{
+					&stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+					&stmt.DurationLiteral{Val: timeutil.OneWeek},
+				}},
+			}},
+		},
+		query.SelectItems)
+	assert.Equal(t, []string{"sum(f)", "sum(f)/time_shift(sum(f),1w)"}, query.ResultNames())
+	assert.True(t, query.IsTimeShift())
+
+	_, err = Parse("select time_shift(f) from memory group by time(1h)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(f, 5) from memory group by time(1h)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(f, -1d) from memory group by time(1h)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(time_shift(f, 1d), 1d) from memory group by time(1h)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(f, 90m) from memory group by time(1h)")
+	assert.Error(t, err)
+	// interval is required
+	_, err = Parse("select time_shift(f, 1d) from memory group by host")
+	assert.EqualError(t, err, "time_shift requires group by time(interval), like group by time(1m)")
+	_, err = Parse("select time_shift(a.f, 1d) from a, b group by time(1h)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(v, 1d) from (select sum(f) as v from memory group by host)")
+	assert.Error(t, err)
+	_, err = Parse("select v from (select time_shift(sum(f), 1d) as v from memory group by host)")
+	assert.Error(t, err)
+}
+
+func TestMathFunc(t *testing.T) {
+	query, err := Parse("select abs(f), round(sum(f)/1024, 2), clamp_max(ceil(f), 100), log10(log) from memory")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]stmt.Expr{
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Abs, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Round, Params: []stmt.Expr{
+				&stmt.BinaryExpr{
+					Left:     &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+					Operator: stmt.DIV,
+					Right:    &stmt.NumberLiteral{Val: 1024},
+				},
+				&stmt.NumberLiteral{Val: 2},
+			}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.ClampMax, Params: []stmt.Expr{
+				&stmt.CallExpr{FuncType: function.Ceil, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+				&stmt.NumberLiteral{Val: 100},
+			}}},
+			&stmt.SelectItem{Expr: &stmt.CallExpr{FuncType: function.Log10, Params: []stmt.Expr{&stmt.FieldExpr{Name: "log"}}}},
+		},
+		query.SelectItems)
+	assert.Equal(t, []string{"abs(f)", "round(sum(f)/1024,2)", "clamp_max(ceil(f),100)", "log10(log)"}, query.ResultNames())
+
+	_, err = Parse("select floor(f), sqrt(f), log(f), clamp_min(f, 0) from memory")
+	assert.NoError(t, err)
+	_, err = Parse("select abs(f, 1) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select abs(1) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select round(f, 1.5) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select clamp_min(f) from memory")
+	assert.Error(t, err)
+	_, err = Parse("select clamp_min(f, g) from memory")
+	assert.Error(t, err)
+}
+
+func TestComparison(t *testing.T) {
+	query, err := Parse("select f>10, sum(f)*100<=50, 0.5!=f, f<>1, f=1, f<1, f>=1 from memory")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		&stmt.SelectItem{Expr: &stmt.BinaryExpr{
+			Left:     &stmt.FieldExpr{Name: "f"},
+			Operator: stmt.GREATER,
+			Right:    &stmt.NumberLiteral{Val: 10},
+		}},
+		query.SelectItems[0])
+	// comparison has lower precedence than arithmetic
+	assert.Equal(t,
+		&stmt.SelectItem{Expr: &stmt.BinaryExpr{
+			Left: &stmt.BinaryExpr{
+				Left:     &stmt.CallExpr{FuncType: function.Sum, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
+				Operator: stmt.MUL,
+				Right:    &stmt.NumberLiteral{Val: 100},
+			},
+			Operator: stmt.LESSEQUAL,
+			Right:    &stmt.NumberLiteral{Val: 50},
+		}},
+		query.SelectItems[1])
+	assert.Equal(t,
+		[]string{"f>10", "sum(f)*100<=50", "0.5!=f", "f!=1", "f=1", "f<1", "f>=1"},
+		query.ResultNames())
+
+	_, err = Parse("select f>g from memory")
+	assert.Error(t, err)
+	_, err = Parse("select 1>2 from memory")
+	assert.Error(t, err)
+}
+
 func TestFieldExpression(t *testing.T) {
 	//TODO need add test
 }
@@ -210,6 +468,93 @@ func TestTimeRange(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestTimeRange_Absolute(t *testing.T) {
+	sql := "select f from cpu where time>=1564369200000 and time<'2019-07-29T12:00:00+08:00'"
+	query, err := Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1564369200000), query.TimeRange.Start)
+	assert.Equal(t, int64(1564372800000), query.TimeRange.End)
+	assert.Empty(t, query.TimeZone)
+
+	// time literal without offset uses the time zone
+	sql = "select f from cpu where time>='2019-07-29 00:00:00' and time<'2019-07-30T00:00:00' tz('Asia/Shanghai')"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Shanghai", query.TimeZone)
+	assert.Equal(t, int64(1564329600000), query.TimeRange.Start)
+	assert.Equal(t, int64(1564416000000), query.TimeRange.End)
+
+	sql = "select f from cpu where time>='2019-07-29 00:00:00' tz('Unknown/Zone')"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	sql = "select f from cpu where time>='2019-07-29T00:00' and time<'2019-07-30 00:00:00'"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	sql = "select f from cpu where time>'2019-07-29 00:00:00' and time<='2019-07-29T00:00'"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// metric name same as keyword
+	sql = "select f from tz"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, "tz", query.MetricName)
+}
+
+func TestTimeZone_Interval(t *testing.T) {
+	// 2019-07-31 05:23:10 +08:00, wednesday
+	sql := "select f from cpu where time>=1564521790000 and time<1564590000000 group by time(1d) tz('Asia/Shanghai')"
+	query, err := Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.OneDay, query.Interval)
+	assert.Equal(t, timeutil.DayUnit, query.IntervalUnit)
+	assert.Equal(t, int64(1564502400000), query.TimeRange.Start)
+
+	sql = "select f from cpu where time>=1564521790000 group by time(1w) tz('Asia/Shanghai')"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.WeekUnit, query.IntervalUnit)
+	assert.Equal(t, int64(1564329600000), query.TimeRange.Start)
+
+	// month/year buckets are calculated by calendar, aligns to the first day of month/year
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	query, err = Parse("select f from cpu where time>=1564521790000 group by time(3M) tz('Asia/Shanghai')")
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.MonthUnit, query.IntervalUnit)
+	assert.Equal(t, time.Date(2019, 7, 1, 0, 0, 0, 0, loc).UnixNano()/1000000, query.TimeRange.Start)
+	calendar, ok := query.CalendarInterval()
+	assert.True(t, ok)
+	assert.Equal(t, timeutil.CalendarInterval{Unit: timeutil.MonthUnit, Count: 3, Location: loc}, calendar)
+	query, err = Parse("select f from cpu where time>=1564521790000 group by time(1y) tz('Asia/Shanghai')")
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.YearUnit, query.IntervalUnit)
+	assert.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, loc).UnixNano()/1000000, query.TimeRange.Start)
+	// month interval without time zone uses local time zone
+	query, err = Parse("select f from cpu where time>=1564521790000 group by time(1M)")
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.MonthUnit, query.IntervalUnit)
+	assert.Equal(t, time.Date(2019, 7, 1, 0, 0, 0, 0, time.Local).UnixNano()/1000000, query.TimeRange.Start)
+	// the shifted time range of calendar interval isn't aligned with the buckets
+	_, err = Parse("select time_shift(f, 1M) from cpu group by time(1M)")
+	assert.Error(t, err)
+	_, err = Parse("select time_shift(f, 1d) from cpu group by time(1d) tz('Asia/Shanghai')")
+	assert.Error(t, err)
+
+	// without time zone, day interval has fixed length, start time not aligned
+	sql = "select f from cpu where time>=1564521790000 group by time(1d)"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1564521790000), query.TimeRange.Start)
+	assert.Equal(t, timeutil.FixedUnit, query.IntervalUnit)
+	_, ok = query.CalendarInterval()
+	assert.False(t, ok)
+	// hour interval in time zone aligns to local wall clock
+	sql = "select f from cpu where time>=1564521790000 group by time(1h) tz('Asia/Kolkata')"
+	query, err = Parse(sql)
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.FixedUnit, query.IntervalUnit)
+	assert.Equal(t, int64(1564518600000), query.TimeRange.Start)
+}
+
 func TestInterval(t *testing.T) {
 	sql := "select f from cpu where region='sh'"
 	query, err := Parse(sql)
@@ -12,6 +12,13 @@ const (
 	MUL
 	DIV
 
+	EQUAL
+	NOTEQUAL
+	LESS
+	LESSEQUAL
+	GREATER
+	GREATEREQUAL
+
 	UNKNOWN
 )
 
@@ -30,7 +37,29 @@ func BinaryOPString(op BinaryOP) string {
 		return "*"
 	case DIV:
 		return "/"
+	case EQUAL:
+		return "="
+	case NOTEQUAL:
+		return "!="
+	case LESS:
+		return "<"
+	case LESSEQUAL:
+		return "<="
+	case GREATER:
+		return ">"
+	case GREATEREQUAL:
+		return ">="
 	default:
 		return "unknown"
 	}
 }
+
+// IsComparisonOP returns if the binary operator compares two values, like >, <= etc.
+func IsComparisonOP(op BinaryOP) bool {
+	switch op {
+	case EQUAL, NOTEQUAL, LESS, LESSEQUAL, GREATER, GREATEREQUAL:
+		return true
+	default:
+		return false
+	}
+}
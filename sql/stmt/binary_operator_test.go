@@ -15,5 +15,23 @@ func TestBinaryOPString(t *testing.T) {
 	assert.Equal(t, "*", BinaryOPString(MUL))
 	assert.Equal(t, "/", BinaryOPString(DIV))
 
+	assert.Equal(t, "=", BinaryOPString(EQUAL))
+	assert.Equal(t, "!=", BinaryOPString(NOTEQUAL))
+	assert.Equal(t, "<", BinaryOPString(LESS))
+	assert.Equal(t, "<=", BinaryOPString(LESSEQUAL))
+	assert.Equal(t, ">", BinaryOPString(GREATER))
+	assert.Equal(t, ">=", BinaryOPString(GREATEREQUAL))
+
 	assert.Equal(t, "unknown", BinaryOPString(UNKNOWN))
 }
+
+func TestIsComparisonOP(t *testing.T) {
+	assert.True(t, IsComparisonOP(EQUAL))
+	assert.True(t, IsComparisonOP(NOTEQUAL))
+	assert.True(t, IsComparisonOP(LESS))
+	assert.True(t, IsComparisonOP(LESSEQUAL))
+	assert.True(t, IsComparisonOP(GREATER))
+	assert.True(t, IsComparisonOP(GREATEREQUAL))
+	assert.False(t, IsComparisonOP(ADD))
+	assert.False(t, IsComparisonOP(AND))
+}
@@ -3,10 +3,12 @@ package stmt
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 // exprData represents inner wrapper of expr for json marshal
@@ -44,6 +46,16 @@ type FieldExpr struct {
 	Name string `json:"name"`
 }
 
+// NumberLiteral represents a number literal, like the param of function call
+type NumberLiteral struct {
+	Val float64 `json:"val"`
+}
+
+// DurationLiteral represents a duration literal(ms), like the shift of time_shift(f, 1w)
+type DurationLiteral struct {
+	Val int64 `json:"val"`
+}
+
 // CallExpr represents a function call expression
 type CallExpr struct {
 	FuncType function.FuncType
@@ -118,6 +130,35 @@ func (e *FieldExpr) Rewrite() string {
 	return e.Name
 }
 
+// Rewrite rewrites the number literal after parse
+func (e *NumberLiteral) Rewrite() string {
+	return strconv.FormatFloat(e.Val, 'f', -1, 64)
+}
+
+// durationUnits are the units of duration literal, from largest to smallest
+var durationUnits = []struct {
+	unit     string
+	duration int64
+}{
+	{unit: "y", duration: timeutil.OneYear},
+	{unit: "M", duration: timeutil.OneMonth},
+	{unit: "w", duration: timeutil.OneWeek},
+	{unit: "d", duration: timeutil.OneDay},
+	{unit: "h", duration: timeutil.OneHour},
+	{unit: "m", duration: timeutil.OneMinute},
+	{unit: "s", duration: timeutil.OneSecond},
+}
+
+// Rewrite rewrites the duration literal after parse, using the largest unit which divides the duration
+func (e *DurationLiteral) Rewrite() string {
+	for _, u := range durationUnits {
+		if e.Val != 0 && e.Val%u.duration == 0 {
+			return fmt.Sprintf("%d%s", e.Val/u.duration, u.unit)
+		}
+	}
+	return fmt.Sprintf("%dms", e.Val)
+}
+
 // Rewrite rewrites the call expr after parse
 func (e *CallExpr) Rewrite() string {
 	var params []string
@@ -175,6 +216,10 @@ func Marshal(expr Expr) []byte {
 		return encoding.JSONMarshal(&exprData{Type: "equals", Expr: encoding.JSONMarshal(expr)})
 	case *FieldExpr:
 		return encoding.JSONMarshal(&exprData{Type: "field", Expr: encoding.JSONMarshal(expr)})
+	case *NumberLiteral:
+		return encoding.JSONMarshal(&exprData{Type: "number", Expr: encoding.JSONMarshal(expr)})
+	case *DurationLiteral:
+		return encoding.JSONMarshal(&exprData{Type: "duration", Expr: encoding.JSONMarshal(expr)})
 	case *NotExpr:
 		return encoding.JSONMarshal(&exprData{Type: "not", Expr: Marshal(e.Expr)})
 	case *ParenExpr:
@@ -228,6 +273,10 @@ func Unmarshal(value []byte) (Expr, error) {
 		return unmarshal(&exprData, &EqualsExpr{})
 	case "field":
 		return unmarshal(&exprData, &FieldExpr{})
+	case "number":
+		return unmarshal(&exprData, &NumberLiteral{})
+	case "duration":
+		return unmarshal(&exprData, &DurationLiteral{})
 	case "paren":
 		e, err := Unmarshal(exprData.Expr)
 		if err != nil {
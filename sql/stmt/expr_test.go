@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestExpr_Rewrite(t *testing.T) {
@@ -16,6 +17,18 @@ func TestExpr_Rewrite(t *testing.T) {
 
 	assert.Equal(t, "sum(f)", (&CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}).Rewrite())
 	assert.Equal(t, "sum()", (&CallExpr{FuncType: function.Sum}).Rewrite())
+	assert.Equal(t, "topk(5,f)", (&CallExpr{FuncType: function.TopK,
+		Params: []Expr{&NumberLiteral{Val: 5}, &FieldExpr{Name: "f"}}}).Rewrite())
+
+	assert.Equal(t, "5", (&NumberLiteral{Val: 5}).Rewrite())
+	assert.Equal(t, "0.5", (&NumberLiteral{Val: 0.5}).Rewrite())
+
+	assert.Equal(t, "1w", (&DurationLiteral{Val: timeutil.OneWeek}).Rewrite())
+	assert.Equal(t, "2w", (&DurationLiteral{Val: 14 * timeutil.OneDay}).Rewrite())
+	assert.Equal(t, "90m", (&DurationLiteral{Val: 90 * timeutil.OneMinute}).Rewrite())
+	assert.Equal(t, "-1d", (&DurationLiteral{Val: -timeutil.OneDay}).Rewrite())
+	assert.Equal(t, "1500ms", (&DurationLiteral{Val: 1500}).Rewrite())
+	assert.Equal(t, "0ms", (&DurationLiteral{}).Rewrite())
 
 	assert.Equal(t, "(sum(f)+a)", (&ParenExpr{
 		Expr: &BinaryExpr{
@@ -145,6 +158,28 @@ func TestSelectItem_Marshal(t *testing.T) {
 	assert.Equal(t, *expr, *e)
 }
 
+func TestNumberLiteral_Marshal(t *testing.T) {
+	expr := &NumberLiteral{Val: 1.5}
+	data := Marshal(expr)
+	exprData, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := exprData.(*NumberLiteral)
+	assert.Equal(t, *expr, *e)
+}
+
+func TestDurationLiteral_Marshal(t *testing.T) {
+	expr := &DurationLiteral{Val: timeutil.OneWeek}
+	data := Marshal(expr)
+	exprData, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := exprData.(*DurationLiteral)
+	assert.Equal(t, *expr, *e)
+}
+
 func TestCallExpr_Marshal(t *testing.T) {
 	expr := &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}
 	data := Marshal(expr)
@@ -2,7 +2,10 @@ package stmt
 
 import (
 	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/interval"
 	"github.com/lindb/lindb/pkg/timeutil"
@@ -10,42 +13,59 @@ import (
 
 // Query represents search statement
 type Query struct {
-	MetricName  string // like table name
-	SelectItems []Expr // select list, such as field, function call, math expression etc.
-	Condition   Expr   // tag filter condition expression
+	Explain     bool     // need to explain query execute stat
+	MetricName  string   // like table name
+	MetricNames []string // all metric names for cross-metric query, fields must be qualified by metric name
+	SelectItems []Expr   // select list, such as field, function call, math expression etc.
+	Condition   Expr     // tag filter condition expression
 
-	TimeRange    timeutil.TimeRange // query time range
-	Interval     int64              // down sampling interval
-	IntervalType interval.Type      // interval type calc based on down sampling interval
+	TimeRange    timeutil.TimeRange    // query time range
+	Interval     int64                 // down sampling interval
+	IntervalType interval.Type         // interval type calc based on down sampling interval
+	IntervalUnit timeutil.IntervalUnit // calendar unit of interval, the buckets are calculated by calendar in time zone
+	TimeZone     string                // time zone for time literal and calendar aligned interval, empty means local
 
 	GroupBy []string // group by
 	Limit   int      // num. of time series list for result
+
+	SubQuery *Query // sub query, the outer query aggregates the results of sub query in broker side
 }
 
 // innerQuery represents a wrapper of query for json encoding
 type innerQuery struct {
+	Explain     bool              `json:"explain"`
 	MetricName  string            `json:"metricName"`
+	MetricNames []string          `json:"metricNames,omitempty"`
 	SelectItems []json.RawMessage `json:"selectItems"`
-	Condition   json.RawMessage   `json:"condition"`
+	Condition   json.RawMessage   `json:"condition,omitempty"`
 
-	TimeRange    timeutil.TimeRange `json:"timeRange"`
-	Interval     int64              `json:"interval"`
-	IntervalType interval.Type      `json:"intervalType"`
+	TimeRange    timeutil.TimeRange    `json:"timeRange"`
+	Interval     int64                 `json:"interval"`
+	IntervalType interval.Type         `json:"intervalType"`
+	IntervalUnit timeutil.IntervalUnit `json:"intervalUnit,omitempty"`
+	TimeZone     string                `json:"timeZone,omitempty"`
 
 	GroupBy []string `json:"groupBy"`
 	Limit   int      `json:"limit"`
+
+	SubQuery *Query `json:"subQuery,omitempty"`
 }
 
 // MarshalJSON returns json data of query
 func (q *Query) MarshalJSON() ([]byte, error) {
 	inner := innerQuery{
+		Explain:      q.Explain,
 		MetricName:   q.MetricName,
+		MetricNames:  q.MetricNames,
 		Condition:    Marshal(q.Condition),
 		TimeRange:    q.TimeRange,
 		Interval:     q.Interval,
 		IntervalType: q.IntervalType,
+		IntervalUnit: q.IntervalUnit,
+		TimeZone:     q.TimeZone,
 		GroupBy:      q.GroupBy,
 		Limit:        q.Limit,
+		SubQuery:     q.SubQuery,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
@@ -74,12 +94,107 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		}
 		selectItems = append(selectItems, selectItem)
 	}
+	q.Explain = inner.Explain
 	q.MetricName = inner.MetricName
+	q.MetricNames = inner.MetricNames
 	q.SelectItems = selectItems
 	q.TimeRange = inner.TimeRange
 	q.IntervalType = inner.IntervalType
 	q.Interval = inner.Interval
+	q.IntervalUnit = inner.IntervalUnit
+	q.TimeZone = inner.TimeZone
 	q.GroupBy = inner.GroupBy
 	q.Limit = inner.Limit
+	q.SubQuery = inner.SubQuery
 	return nil
 }
+
+// CalendarInterval returns the calendar interval of group by time in the time zone of query,
+// returns false if the interval has fixed length.
+func (q *Query) CalendarInterval() (timeutil.CalendarInterval, bool) {
+	if q.IntervalUnit == timeutil.FixedUnit {
+		return timeutil.CalendarInterval{}, false
+	}
+	loc := time.Local
+	if len(q.TimeZone) > 0 {
+		var err error
+		if loc, err = time.LoadLocation(q.TimeZone); err != nil {
+			return timeutil.CalendarInterval{}, false
+		}
+	}
+	return timeutil.NewCalendarInterval(q.IntervalUnit, q.Interval, loc)
+}
+
+// IsCrossMetric returns if the query selects fields from multi-metric
+func (q *Query) IsCrossMetric() bool {
+	return len(q.MetricNames) > 1
+}
+
+// FieldMetric returns the metric name and field name of the qualified field name(like a.f) for cross-metric query,
+// matches the longest metric name because metric name maybe contains '.'
+func (q *Query) FieldMetric(qualifiedName string) (metricName, fieldName string, ok bool) {
+	for _, name := range q.MetricNames {
+		if len(name) > len(metricName) && strings.HasPrefix(qualifiedName, name+".") {
+			metricName = name
+		}
+	}
+	if len(metricName) == 0 {
+		return "", "", false
+	}
+	return metricName, qualifiedName[len(metricName)+1:], true
+}
+
+// IsTimeShift returns if the select list includes time shift function, like time_shift(f, 1w)
+func (q *Query) IsTimeShift() bool {
+	var isTimeShift func(expr Expr) bool
+	isTimeShift = func(expr Expr) bool {
+		switch e := expr.(type) {
+		case *SelectItem:
+			return isTimeShift(e.Expr)
+		case *CallExpr:
+			if e.FuncType == function.TimeShift {
+				return true
+			}
+			for _, param := range e.Params {
+				if isTimeShift(param) {
+					return true
+				}
+			}
+		case *ParenExpr:
+			return isTimeShift(e.Expr)
+		case *BinaryExpr:
+			return isTimeShift(e.Left) || isTimeShift(e.Right)
+		}
+		return false
+	}
+	for _, selectItem := range q.SelectItems {
+		if isTimeShift(selectItem) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeafQuery returns the innermost sub query which is executed by storage nodes,
+// returns itself if no sub query
+func (q *Query) LeafQuery() *Query {
+	query := q
+	for query.SubQuery != nil {
+		query = query.SubQuery
+	}
+	return query
+}
+
+// ResultNames returns the result names of select list, alias name if set, else the rewrite expression
+func (q *Query) ResultNames() []string {
+	var names []string
+	for _, selectItem := range q.SelectItems {
+		item, ok := selectItem.(*SelectItem)
+		if ok && len(item.Alias) > 0 {
+			names = append(names, item.Alias)
+		} else {
+			names = append(names, selectItem.Rewrite())
+		}
+	}
+	return names
+}
@@ -13,7 +13,9 @@ import (
 
 func TestQuery_Marshal(t *testing.T) {
 	query := Query{
-		MetricName: "test",
+		Explain:     true,
+		MetricName:  "test",
+		MetricNames: []string{"test", "test1"},
 		SelectItems: []Expr{
 			&SelectItem{Expr: &FieldExpr{Name: "a"}},
 			&SelectItem{Expr: &FieldExpr{Name: "b"}},
@@ -46,10 +48,17 @@ func TestQuery_Marshal(t *testing.T) {
 			}},
 		},
 		TimeRange:    timeutil.TimeRange{Start: 10, End: 30},
-		Interval:     1000,
+		Interval:     timeutil.OneMonth,
+		TimeZone:     "Asia/Shanghai",
 		IntervalType: "10s",
+		IntervalUnit: timeutil.MonthUnit,
 		GroupBy:      []string{"a", "b", "c"},
 		Limit:        100,
+		SubQuery: &Query{
+			MetricName:  "sub",
+			SelectItems: []Expr{&SelectItem{Expr: &FieldExpr{Name: "a"}}},
+			GroupBy:     []string{"a", "b", "c", "d"},
+		},
 	}
 
 	data := encoding.JSONMarshal(&query)
@@ -62,6 +71,18 @@ func TestQuery_Marshal(t *testing.T) {
 	assert.Equal(t, query, query1)
 }
 
+func TestQuery_Marshal_Without_Condition(t *testing.T) {
+	query := Query{
+		MetricName:  "test",
+		SelectItems: []Expr{&SelectItem{Expr: &FieldExpr{Name: "a"}}},
+	}
+	data := encoding.JSONMarshal(&query)
+	query1 := Query{}
+	err := encoding.JSONUnmarshal(data, &query1)
+	assert.Nil(t, err)
+	assert.Equal(t, query, query1)
+}
+
 func TestQuery_Marshal_Fail(t *testing.T) {
 	query := &Query{}
 	err := query.UnmarshalJSON([]byte{1, 2, 3})
@@ -71,3 +92,50 @@ func TestQuery_Marshal_Fail(t *testing.T) {
 	err = query.UnmarshalJSON([]byte("{\"selectItems\":[\"123\"]}"))
 	assert.NotNil(t, err)
 }
+
+func TestQuery_FieldMetric(t *testing.T) {
+	query := Query{MetricName: "cpu", MetricNames: []string{"cpu", "system.cpu", "system"}}
+	assert.True(t, query.IsCrossMetric())
+
+	metricName, fieldName, ok := query.FieldMetric("cpu.f")
+	assert.True(t, ok)
+	assert.Equal(t, "cpu", metricName)
+	assert.Equal(t, "f", fieldName)
+	metricName, fieldName, ok = query.FieldMetric("system.cpu.load.f")
+	assert.True(t, ok)
+	assert.Equal(t, "system.cpu", metricName)
+	assert.Equal(t, "load.f", fieldName)
+	_, _, ok = query.FieldMetric("mem.f")
+	assert.False(t, ok)
+	_, _, ok = query.FieldMetric("cpu")
+	assert.False(t, ok)
+
+	query = Query{MetricName: "cpu"}
+	assert.False(t, query.IsCrossMetric())
+}
+
+func TestQuery_IsTimeShift(t *testing.T) {
+	query := &Query{SelectItems: []Expr{&SelectItem{Expr: &FieldExpr{Name: "f"}}}}
+	assert.False(t, query.IsTimeShift())
+	query.SelectItems = append(query.SelectItems, &SelectItem{Expr: &BinaryExpr{
+		Left:     &FieldExpr{Name: "f"},
+		Operator: DIV,
+		Right: &ParenExpr{Expr: &CallExpr{FuncType: function.TimeShift,
+			Params: []Expr{&FieldExpr{Name: "f"}, &DurationLiteral{Val: timeutil.OneWeek}}}},
+	}})
+	assert.True(t, query.IsTimeShift())
+}
+
+func TestQuery_SubQuery(t *testing.T) {
+	leaf := &Query{
+		MetricName: "cpu",
+		SelectItems: []Expr{
+			&SelectItem{Expr: &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}, Alias: "v"},
+			&SelectItem{Expr: &FieldExpr{Name: "f"}},
+		},
+	}
+	query := &Query{SubQuery: &Query{SubQuery: leaf}}
+	assert.Equal(t, leaf, query.LeafQuery())
+	assert.Equal(t, leaf, leaf.LeafQuery())
+	assert.Equal(t, []string{"v", "f"}, leaf.ResultNames())
+}
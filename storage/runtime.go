@@ -215,7 +215,12 @@ func (r *runtime) startTCPServer() {
 func (r *runtime) bindRPCHandlers() {
 	//FIXME: (stone1100) need close
 	dispatcher := taskHandler.NewLeafTaskDispatcher(r.node, r.srv.storageService,
-		query.NewExecutorFactory(), r.factory.taskServer)
+		query.NewExecutorFactory(query.ExecutorOption{
+			Limit:    r.config.Query,
+			ScanPool: query.NewScanPool(r.config.QueryPool.ScanWorkers),
+		}),
+		r.factory.taskServer,
+		r.config.QueryPool.Workers, r.config.QueryPool.QueueSize, r.config.TaskPayload.GetCompressThreshold())
 
 	r.handler = &rpcHandler{
 		writer: handler.NewWriter(r.srv.storageService, r.srv.sequenceManager),
@@ -41,6 +41,8 @@ type Engine interface {
 	GetShard(shardID int32) Shard
 	// GetIDGetter returns id getter for metric level metadata
 	GetIDGetter() diskdb.IDGetter
+	// GetOption returns the engine option of time series engine
+	GetOption() option.EngineOption
 	// Close closed engine then release resource
 	Close() error
 
@@ -238,6 +240,11 @@ func (e *engine) GetIDGetter() diskdb.IDGetter {
 	return e.index.GetIDSequencer()
 }
 
+// GetOption returns the engine option of time series engine
+func (e *engine) GetOption() option.EngineOption {
+	return e.info.Engine
+}
+
 // Close closed engine then release resource
 func (e *engine) Close() error {
 	e.index.Close()
@@ -48,6 +48,7 @@ func TestNew(t *testing.T) {
 
 	assert.Nil(t, factory.GetEngine("no_exist"))
 	assert.NotNil(t, engine.GetIDGetter())
+	assert.Equal(t, validOption, engine.GetOption())
 
 	factory.Close()
 
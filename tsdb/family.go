@@ -5,6 +5,8 @@ import (
 	"github.com/lindb/lindb/tsdb/series"
 )
 
+//go:generate mockgen -source=./family.go -destination=./family_mock.go -package=tsdb
+
 // DataFamily represents a storage unit for time series data, support multi-version.
 type DataFamily interface {
 	// series.DataFamilyScanner returns a iterator which scans time series data based on query condition
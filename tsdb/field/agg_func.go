@@ -6,6 +6,9 @@ func init() {
 	registerFunc(Sum, &sumAgg{})
 	registerFunc(Min, &minAgg{})
 	registerFunc(Max, &maxAgg{})
+	registerFunc(Count, &countAgg{})
+	registerFunc(First, &firstAgg{})
+	registerFunc(Last, &lastAgg{})
 }
 
 // FuncType represents field's aggregator function type
@@ -87,3 +90,45 @@ func (m *maxAgg) AggregateFloat(a, b float64) float64 {
 	}
 	return b
 }
+
+// countAgg represents count aggregator, the count values are merged by adding
+type countAgg struct {
+}
+
+// AggregateInt returns a+b for int64 count value
+func (c *countAgg) AggregateInt(a, b int64) int64 {
+	return a + b
+}
+
+// AggregateFloat returns a+b for float64 count value
+func (c *countAgg) AggregateFloat(a, b float64) float64 {
+	return a + b
+}
+
+// firstAgg represents first value aggregator, the values are aggregated in time order
+type firstAgg struct {
+}
+
+// AggregateInt returns the first int64 value
+func (f *firstAgg) AggregateInt(a, b int64) int64 {
+	return a
+}
+
+// AggregateFloat returns the first float64 value
+func (f *firstAgg) AggregateFloat(a, b float64) float64 {
+	return a
+}
+
+// lastAgg represents last value aggregator, the values are aggregated in time order
+type lastAgg struct {
+}
+
+// AggregateInt returns the last int64 value
+func (l *lastAgg) AggregateInt(a, b int64) int64 {
+	return b
+}
+
+// AggregateFloat returns the last float64 value
+func (l *lastAgg) AggregateFloat(a, b float64) float64 {
+	return b
+}
@@ -10,6 +10,9 @@ func TestGetAggFunc(t *testing.T) {
 	assert.NotNil(t, GetAggFunc(Sum))
 	assert.NotNil(t, GetAggFunc(Min))
 	assert.NotNil(t, GetAggFunc(Max))
+	assert.NotNil(t, GetAggFunc(Count))
+	assert.NotNil(t, GetAggFunc(First))
+	assert.NotNil(t, GetAggFunc(Last))
 	assert.Nil(t, GetAggFunc(1000))
 }
 
@@ -35,6 +38,24 @@ func TestMaxAgg(t *testing.T) {
 	assert.Equal(t, float64(99.0), agg.AggregateFloat(99.0, 1))
 }
 
+func TestCountAgg(t *testing.T) {
+	agg := GetAggFunc(Count)
+	assert.Equal(t, int64(100), agg.AggregateInt(1, 99))
+	assert.Equal(t, float64(100.0), agg.AggregateFloat(1, 99.0))
+}
+
+func TestFirstAgg(t *testing.T) {
+	agg := GetAggFunc(First)
+	assert.Equal(t, int64(1), agg.AggregateInt(1, 99))
+	assert.Equal(t, float64(1.0), agg.AggregateFloat(1, 99.0))
+}
+
+func TestLastAgg(t *testing.T) {
+	agg := GetAggFunc(Last)
+	assert.Equal(t, int64(99), agg.AggregateInt(1, 99))
+	assert.Equal(t, float64(99.0), agg.AggregateFloat(1, 99.0))
+}
+
 func Test_registerPanic(t *testing.T) {
 	assert.Panics(t, func() {
 		registerFunc(Sum, &sumAgg{})
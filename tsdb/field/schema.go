@@ -4,17 +4,63 @@ import (
 	"github.com/lindb/lindb/aggregation/function"
 )
 
+// schema represents the primitive fields of field type, the primitive fields include the stored primitive field
+// and the primitive fields aggregated from it when down sampling, so that one stored field can be scanned once
+// for multi-functions, like min/max/avg of sum field.
 type schema interface {
+	// getPrimitiveFields returns the aggregated primitive fields(id => agg type) which the function need
 	getPrimitiveFields(funcType function.FuncType) map[uint16]AggType
+	// getStoredFieldID returns the id of primitive field which is stored for the field type
+	getStoredFieldID() uint16
+	// getSourceFieldID returns the stored primitive field id which the aggregated primitive field reads from
+	getSourceFieldID(primitiveID uint16) uint16
+	// getAggType returns the agg type of the aggregated primitive field
+	getAggType(primitiveID uint16) (AggType, bool)
+	// getTimeFieldID returns the primitive field id which keeps the timestamps of the primitive field's values,
+	// the values are selected by timestamps when merging, like first/last.
+	getTimeFieldID(primitiveID uint16) (uint16, bool)
+	// isTimeField returns if the primitive field keeps the timestamps of other primitive field's values
+	isTimeField(primitiveID uint16) bool
 }
 
+// primitive field ids of sum field, only sum primitive field is stored,
+// the others are aggregated from sum primitive field when down sampling,
+// first/last primitive field keeps the timestamps of values in first/last time primitive field.
+const (
+	sumPrimitiveID uint16 = iota + 1
+	minPrimitiveID
+	maxPrimitiveID
+	countPrimitiveID
+	firstPrimitiveID
+	firstTimePrimitiveID
+	lastPrimitiveID
+	lastTimePrimitiveID
+)
+
 type sumSchema struct {
 	primitiveFieldID uint16
+	aggTypes         map[uint16]AggType
+	timeFields       map[uint16]uint16 // primitive field id => time primitive field id
 }
 
 func newSumSchema() schema {
 	return &sumSchema{
-		primitiveFieldID: uint16(1),
+		primitiveFieldID: sumPrimitiveID,
+		// the first value has the min timestamp, the last value has the max timestamp
+		aggTypes: map[uint16]AggType{
+			sumPrimitiveID:       Sum,
+			minPrimitiveID:       Min,
+			maxPrimitiveID:       Max,
+			countPrimitiveID:     Count,
+			firstPrimitiveID:     First,
+			firstTimePrimitiveID: Min,
+			lastPrimitiveID:      Last,
+			lastTimePrimitiveID:  Max,
+		},
+		timeFields: map[uint16]uint16{
+			firstPrimitiveID: firstTimePrimitiveID,
+			lastPrimitiveID:  lastTimePrimitiveID,
+		},
 	}
 }
 
@@ -22,7 +68,87 @@ func (s *sumSchema) getPrimitiveFields(funcType function.FuncType) map[uint16]Ag
 	switch funcType {
 	case function.Sum:
 		return map[uint16]AggType{s.primitiveFieldID: Sum}
+	case function.Min:
+		return map[uint16]AggType{minPrimitiveID: Min}
+	case function.Max:
+		return map[uint16]AggType{maxPrimitiveID: Max}
+	case function.Avg:
+		return map[uint16]AggType{s.primitiveFieldID: Sum, countPrimitiveID: Count}
+	case function.Count:
+		return map[uint16]AggType{countPrimitiveID: Count}
+	case function.First:
+		return map[uint16]AggType{firstPrimitiveID: First, firstTimePrimitiveID: Min}
+	case function.Last:
+		return map[uint16]AggType{lastPrimitiveID: Last, lastTimePrimitiveID: Max}
 	default:
 		return nil
 	}
 }
+
+func (s *sumSchema) getStoredFieldID() uint16 {
+	return s.primitiveFieldID
+}
+
+func (s *sumSchema) getSourceFieldID(primitiveID uint16) uint16 {
+	return s.primitiveFieldID
+}
+
+func (s *sumSchema) getAggType(primitiveID uint16) (AggType, bool) {
+	aggType, ok := s.aggTypes[primitiveID]
+	return aggType, ok
+}
+
+func (s *sumSchema) getTimeFieldID(primitiveID uint16) (uint16, bool) {
+	timeFieldID, ok := s.timeFields[primitiveID]
+	return timeFieldID, ok
+}
+
+func (s *sumSchema) isTimeField(primitiveID uint16) bool {
+	return primitiveID == firstTimePrimitiveID || primitiveID == lastTimePrimitiveID
+}
+
+// singleSchema represents the field type which has only one stored primitive field aggregated by one function,
+// like min/max field.
+type singleSchema struct {
+	primitiveFieldID uint16
+	funcType         function.FuncType
+	aggType          AggType
+}
+
+func newSingleSchema(funcType function.FuncType, aggType AggType) schema {
+	return &singleSchema{
+		primitiveFieldID: uint16(1),
+		funcType:         funcType,
+		aggType:          aggType,
+	}
+}
+
+func (s *singleSchema) getPrimitiveFields(funcType function.FuncType) map[uint16]AggType {
+	if funcType != s.funcType {
+		return nil
+	}
+	return map[uint16]AggType{s.primitiveFieldID: s.aggType}
+}
+
+func (s *singleSchema) getStoredFieldID() uint16 {
+	return s.primitiveFieldID
+}
+
+func (s *singleSchema) getSourceFieldID(primitiveID uint16) uint16 {
+	return s.primitiveFieldID
+}
+
+func (s *singleSchema) getAggType(primitiveID uint16) (AggType, bool) {
+	if primitiveID != s.primitiveFieldID {
+		return 0, false
+	}
+	return s.aggType, true
+}
+
+func (s *singleSchema) getTimeFieldID(primitiveID uint16) (uint16, bool) {
+	return 0, false
+}
+
+func (s *singleSchema) isTimeField(primitiveID uint16) bool {
+	return false
+}
@@ -13,3 +13,55 @@ func Test_getPrimitiveFields(t *testing.T) {
 
 	assert.Nil(t, newSumSchema().getPrimitiveFields(function.FuncType(128)))
 }
+
+func Test_sumSchema_multiFuncs(t *testing.T) {
+	s := newSumSchema()
+	assert.Equal(t, map[uint16]AggType{2: Min}, s.getPrimitiveFields(function.Min))
+	assert.Equal(t, map[uint16]AggType{3: Max}, s.getPrimitiveFields(function.Max))
+	assert.Equal(t, map[uint16]AggType{1: Sum, 4: Count}, s.getPrimitiveFields(function.Avg))
+	// all primitive fields read from the stored sum primitive field
+	for _, id := range []uint16{1, 2, 3, 4} {
+		assert.Equal(t, uint16(1), s.getSourceFieldID(id))
+	}
+	aggType, ok := s.getAggType(4)
+	assert.True(t, ok)
+	assert.Equal(t, Count, aggType)
+	_, ok = s.getAggType(10)
+	assert.False(t, ok)
+}
+
+func Test_singleSchema(t *testing.T) {
+	s := newSingleSchema(function.Max, Max)
+	assert.Equal(t, map[uint16]AggType{1: Max}, s.getPrimitiveFields(function.Max))
+	assert.Nil(t, s.getPrimitiveFields(function.Sum))
+	assert.Equal(t, uint16(1), s.getSourceFieldID(1))
+	aggType, ok := s.getAggType(1)
+	assert.True(t, ok)
+	assert.Equal(t, Max, aggType)
+	_, ok = s.getAggType(2)
+	assert.False(t, ok)
+}
+
+func Test_sumSchema_selectFuncs(t *testing.T) {
+	s := newSumSchema()
+	assert.Equal(t, map[uint16]AggType{4: Count}, s.getPrimitiveFields(function.Count))
+	assert.Equal(t, map[uint16]AggType{5: First, 6: Min}, s.getPrimitiveFields(function.First))
+	assert.Equal(t, map[uint16]AggType{7: Last, 8: Max}, s.getPrimitiveFields(function.Last))
+
+	timeFieldID, ok := s.getTimeFieldID(5)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(6), timeFieldID)
+	timeFieldID, ok = s.getTimeFieldID(7)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(8), timeFieldID)
+	_, ok = s.getTimeFieldID(1)
+	assert.False(t, ok)
+	assert.True(t, s.isTimeField(6))
+	assert.True(t, s.isTimeField(8))
+	assert.False(t, s.isTimeField(5))
+
+	single := newSingleSchema(function.Min, Min)
+	_, ok = single.getTimeFieldID(1)
+	assert.False(t, ok)
+	assert.False(t, single.isTimeField(1))
+}
@@ -21,6 +21,9 @@ const (
 	Sum AggType = iota + 1
 	Min
 	Max
+	Count
+	First
+	Last
 )
 
 // Type represents field type for LinDB support
@@ -40,6 +43,8 @@ var schemas = map[Type]schema{}
 
 func init() {
 	schemas[SumField] = newSumSchema()
+	schemas[MinField] = newSingleSchema(function.Min, Min)
+	schemas[MaxField] = newSingleSchema(function.Max, Max)
 }
 
 // GetPrimitiveFields returns the primitive fields for down sampling
@@ -51,6 +56,54 @@ func GetPrimitiveFields(fieldType Type, funcType function.FuncType) map[uint16]A
 	return schema.getPrimitiveFields(funcType)
 }
 
+// GetStoredFieldID returns the id of primitive field which is stored for the field type,
+// the storage returns the values of field with this primitive field id when scanning
+func GetStoredFieldID(fieldType Type) (uint16, bool) {
+	schema := schemas[fieldType]
+	if schema == nil {
+		return 0, false
+	}
+	return schema.getStoredFieldID(), true
+}
+
+// GetSourceFieldID returns the stored primitive field id which the aggregated primitive field reads from,
+// returns the primitive field id if the field type not found
+func GetSourceFieldID(fieldType Type, primitiveID uint16) uint16 {
+	schema := schemas[fieldType]
+	if schema == nil {
+		return primitiveID
+	}
+	return schema.getSourceFieldID(primitiveID)
+}
+
+// GetTimeFieldID returns the primitive field id which keeps the timestamps of the values of primitive field,
+// if the values are selected by timestamps when merging, like first/last.
+func GetTimeFieldID(fieldType Type, primitiveID uint16) (uint16, bool) {
+	schema := schemas[fieldType]
+	if schema == nil {
+		return 0, false
+	}
+	return schema.getTimeFieldID(primitiveID)
+}
+
+// IsTimeField returns if the primitive field keeps the timestamps of other primitive field's values
+func IsTimeField(fieldType Type, primitiveID uint16) bool {
+	schema := schemas[fieldType]
+	if schema == nil {
+		return false
+	}
+	return schema.isTimeField(primitiveID)
+}
+
+// GetAggType returns the agg type of the aggregated primitive field
+func GetAggType(fieldType Type, primitiveID uint16) (AggType, bool) {
+	schema := schemas[fieldType]
+	if schema == nil {
+		return 0, false
+	}
+	return schema.getAggType(primitiveID)
+}
+
 func GetPrimitiveFieldsValue() {
 
 }
@@ -15,3 +15,42 @@ func Test_GetPrimitiveFields(t *testing.T) {
 
 	GetPrimitiveFieldsValue()
 }
+
+func Test_GetStoredFieldID(t *testing.T) {
+	id, ok := GetStoredFieldID(SumField)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(1), id)
+	id, ok = GetStoredFieldID(MaxField)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(1), id)
+	_, ok = GetStoredFieldID(Type(128))
+	assert.False(t, ok)
+}
+
+func Test_GetSourceFieldID(t *testing.T) {
+	assert.Equal(t, uint16(1), GetSourceFieldID(SumField, 3))
+	assert.Equal(t, uint16(3), GetSourceFieldID(Type(128), 3))
+}
+
+func Test_GetAggType(t *testing.T) {
+	aggType, ok := GetAggType(SumField, 2)
+	assert.True(t, ok)
+	assert.Equal(t, Min, aggType)
+	aggType, ok = GetAggType(MinField, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Min, aggType)
+	_, ok = GetAggType(Type(128), 1)
+	assert.False(t, ok)
+}
+
+func Test_GetTimeFieldID(t *testing.T) {
+	timeFieldID, ok := GetTimeFieldID(SumField, 7)
+	assert.True(t, ok)
+	assert.Equal(t, uint16(8), timeFieldID)
+	_, ok = GetTimeFieldID(Type(128), 7)
+	assert.False(t, ok)
+
+	assert.True(t, IsTimeField(SumField, 8))
+	assert.False(t, IsTimeField(SumField, 7))
+	assert.False(t, IsTimeField(Type(128), 8))
+}
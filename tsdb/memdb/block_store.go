@@ -1,6 +1,7 @@
 package memdb
 
 import (
+	"fmt"
 	"math"
 	"math/bits"
 	"sync"
@@ -100,6 +101,11 @@ type block interface {
 	getEndTime() int
 	// compact compress block data with agg func for rollup operation
 	compact(aggFunc field.AggFunc) (startSlot, endSlot int, err error)
+	// snapshot returns the compress data merged with block values for reading,
+	// the compress data of block isn't changed, so that the values aren't rollup twice when compacting
+	snapshot(aggFunc field.AggFunc) (data []byte, startSlot, endSlot int, err error)
+	// slotRange returns the time slot range of compress data and block values, ok is false if no data
+	slotRange() (startSlot, endSlot int, ok bool)
 	// reset cleans block data, just reset container mark
 	reset()
 	// bytes returns compress data for block data
@@ -171,6 +177,40 @@ func (c *container) bytes() []byte {
 	return c.compress
 }
 
+// slotRange returns the time slot range of compress data and block values, ok is false if no data
+func (c *container) slotRange() (startSlot, endSlot int, ok bool) {
+	if len(c.compress) > 0 {
+		startSlot, endSlot = encoding.DecodeTSDTime(c.compress)
+		ok = true
+	}
+	if c.container == 0 {
+		return
+	}
+	if !ok || c.startTime < startSlot {
+		startSlot = c.startTime
+	}
+	if end := c.getEndTime(); !ok || end > endSlot {
+		endSlot = end
+	}
+	return startSlot, endSlot, true
+}
+
+// snapshot merges values and compress data of container like merge, but keeps the compress data unchanged
+func (c *container) snapshot(valueType field.ValueType,
+	values []uint64, aggFunc field.AggFunc) (data []byte, start, end int, err error) {
+	if c.container == 0 {
+		// no value in current time window
+		if len(c.compress) == 0 {
+			return nil, 0, 0, fmt.Errorf("block is empty")
+		}
+		start, end = encoding.DecodeTSDTime(c.compress)
+		return c.compress, start, end, nil
+	}
+	merger := newMerger(c, valueType, values, c.compress, aggFunc)
+	data, err = merger.merge()
+	return data, merger.startTime, merger.endTime, err
+}
+
 // merge merges values and compress data of container based on value type nad agg func
 func (c *container) merge(valueType field.ValueType,
 	values []uint64, aggFunc field.AggFunc) (start, end int, err error) {
@@ -211,12 +251,22 @@ func (b *intBlock) getIntValue(pos int) int64 {
 
 // compact compress block data
 func (b *intBlock) compact(aggFunc field.AggFunc) (startSlot, endSlot int, err error) {
+	return b.merge(field.Integer, b.encodeValues(), aggFunc)
+}
+
+// snapshot returns the compress data merged with block values, the compress data isn't changed
+func (b *intBlock) snapshot(aggFunc field.AggFunc) (data []byte, startSlot, endSlot int, err error) {
+	return b.container.snapshot(field.Integer, b.encodeValues(), aggFunc)
+}
+
+// encodeValues encodes the int64 values of block with zigzag encoding
+func (b *intBlock) encodeValues() []uint64 {
 	length := len(b.values)
 	values := make([]uint64, length)
 	for i := 0; i < length; i++ {
 		values[i] = encoding.ZigZagEncode(b.values[i])
 	}
-	return b.merge(field.Integer, values, aggFunc)
+	return values
 }
 
 // floatBlock represents a float block for storing metric point in memory
@@ -245,12 +295,22 @@ func (b *floatBlock) getFloatValue(pos int) float64 {
 
 // compact compress block data
 func (b *floatBlock) compact(aggFunc field.AggFunc) (startSlot, endSlot int, err error) {
+	return b.merge(field.Float, b.encodeValues(), aggFunc)
+}
+
+// snapshot returns the compress data merged with block values, the compress data isn't changed
+func (b *floatBlock) snapshot(aggFunc field.AggFunc) (data []byte, startSlot, endSlot int, err error) {
+	return b.container.snapshot(field.Float, b.encodeValues(), aggFunc)
+}
+
+// encodeValues encodes the float64 values of block to bits
+func (b *floatBlock) encodeValues() []uint64 {
 	length := len(b.values)
 	values := make([]uint64, length)
 	for i := 0; i < length; i++ {
 		values[i] = math.Float64bits(b.values[i])
 	}
-	return b.merge(field.Float, values, aggFunc)
+	return values
 }
 
 // merger is merge operation which provides compress block data.
@@ -293,3 +293,61 @@ func TestCompactFloatBlock(t *testing.T) {
 	assert.True(t, tsd.HasValueWithSlot(41))
 	assert.Equal(t, 90.0, math.Float64frombits(tsd.Value()))
 }
+
+func TestSnapshotBlock(t *testing.T) {
+	bs := newBlockStore(30)
+	aggFunc := field.GetAggFunc(field.Sum)
+	b1 := bs.allocBlock(field.Float)
+	_, _, ok := b1.slotRange()
+	assert.False(t, ok)
+	_, _, _, err := b1.snapshot(aggFunc)
+	assert.NotNil(t, err)
+
+	b1.setStartTime(10)
+	b1.setFloatValue(0, 1.5)
+	_, _, _ = b1.compact(aggFunc)
+	// no value in current time window
+	b1.setStartTime(20)
+	data, start, end, err := b1.snapshot(aggFunc)
+	assert.Nil(t, err)
+	assert.Equal(t, b1.bytes(), data)
+	assert.Equal(t, 10, start)
+	assert.Equal(t, 10, end)
+
+	b1.setFloatValue(2, 2.5)
+	start, end, ok = b1.slotRange()
+	assert.True(t, ok)
+	assert.Equal(t, 10, start)
+	assert.Equal(t, 22, end)
+	// snapshot twice, the compress data isn't changed
+	for i := 0; i < 2; i++ {
+		data, start, end, err = b1.snapshot(aggFunc)
+		assert.Nil(t, err)
+		assert.Equal(t, 10, start)
+		assert.Equal(t, 22, end)
+		tsd := encoding.NewTSDDecoder(data)
+		values := make(map[int]float64)
+		for slot := 0; tsd.Next(); slot++ {
+			if tsd.HasValue() {
+				values[slot] = math.Float64frombits(tsd.Value())
+			}
+		}
+		assert.Equal(t, map[int]float64{0: 1.5, 12: 2.5}, values)
+	}
+	startTime, endTime := encoding.DecodeTSDTime(b1.bytes())
+	assert.Equal(t, 10, startTime)
+	assert.Equal(t, 10, endTime)
+
+	// int block
+	b2 := bs.allocBlock(field.Integer)
+	b2.setStartTime(5)
+	b2.setIntValue(1, 10)
+	data, start, end, err = b2.snapshot(aggFunc)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, start)
+	assert.Equal(t, 6, end)
+	tsd := encoding.NewTSDDecoder(data)
+	assert.False(t, tsd.HasValueWithSlot(0))
+	assert.True(t, tsd.HasValueWithSlot(1))
+	assert.Equal(t, int64(10), encoding.ZigZagDecode(tsd.Value()))
+}
@@ -495,13 +495,12 @@ func (md *memoryDatabase) SuggestTagValues(metricName, tagKey, tagValuePrefix st
 	return mStore.suggestTagValues(tagKey, tagValuePrefix, limit)
 }
 
-// Scan scans data from memory by scan-context
+// Scan scans data of the family from memory by scan-context, the family time is given by the scan-context
 func (md *memoryDatabase) Scan(sCtx series.ScanContext) series.VersionIterator {
 	mStore, ok := md.getMStoreByMetricID(sCtx.MetricID)
 	if !ok {
 		return nil
 	}
 	sCtx.TimeInterval = md.interval
-	// todo: fixme, add family-time
 	return mStore.scan(sCtx)
 }
@@ -256,7 +256,7 @@ func (ms *metricStore) write(metric *pb.Metric, writeCtx writeContext) error {
 		return series.ErrTooManyTags
 	}
 	var err error
-	tagsStr := models.TagsAsString(metric.Tags)
+	tagsStr := models.TagsAsKeyValues(metric.Tags)
 	tStore, ok := ms.getTStore(tagsStr)
 	if !ok {
 		ms.mutex4Mutable.Lock()
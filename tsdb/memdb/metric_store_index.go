@@ -42,6 +42,9 @@ type tagIndexINTF interface {
 	tagsUsed() int
 	// tagsInUse returns how many tags are still in use, it is used for evicting
 	tagsInUse() int
+	// getSeriesTags returns the tag values of series by tag keys,
+	// the value is empty if the series hasn't the tag key
+	getSeriesTags(seriesID uint32, tagKeys []string) map[string]string
 	// allTStores returns the map of seriesID and tStores
 	allTStores() map[uint32]tStoreINTF
 	// flushMetricTo flush metric to the tableFlusher
@@ -251,6 +254,25 @@ func (index *tagIndex) tagsInUse() int {
 	return len(index.seriesID2TStore)
 }
 
+// getSeriesTags returns the tag values of series by tag keys, the value is empty if the series hasn't the tag key
+func (index *tagIndex) getSeriesTags(seriesID uint32, tagKeys []string) map[string]string {
+	tags := make(map[string]string, len(tagKeys))
+	for _, tagKey := range tagKeys {
+		tags[tagKey] = ""
+		entrySet, ok := index.getTagKVEntrySet(tagKey)
+		if !ok {
+			continue
+		}
+		for tagValue, bitmap := range entrySet.values {
+			if bitmap.Contains(seriesID) {
+				tags[tagKey] = tagValue
+				break
+			}
+		}
+	}
+	return tags
+}
+
 // allTStores returns the map of seriesID and tStores
 func (index *tagIndex) allTStores() map[uint32]tStoreINTF {
 	return index.seriesID2TStore
@@ -27,7 +27,8 @@ func (ms *metricStore) scan(sCtx series.ScanContext) series.VersionIterator {
 }
 
 //////////////////////////////////////////////////////
-// tStoreIterator implements series.Iterator
+// tStoreIterator implements series.Iterator over the fields of a series,
+// also implements series.GroupedIterator if scanning with group by
 //////////////////////////////////////////////////////
 type tStoreIterator struct {
 	tagIndex    tagIndexINTF
@@ -35,10 +36,11 @@ type tStoreIterator struct {
 	intItr      roaring.IntIterable // bitmap iterator
 	fStoreItr   *fStoreIterator     // reusable
 	seriesID    uint32              // current ts id
+	groupBy     []string            // tag keys of group by
 }
 
 func newTStoreIterator(metas fieldsMetas, sCtx series.ScanContext) *tStoreIterator {
-	return &tStoreIterator{fStoreItr: newFStoreIterator(metas, sCtx)}
+	return &tStoreIterator{fStoreItr: newFStoreIterator(metas, sCtx), groupBy: sCtx.GroupBy}
 }
 
 // reset resets the multiTimeSeries to a different tStore
@@ -48,17 +50,24 @@ func (tsi *tStoreIterator) reset(tagIndex tagIndexINTF, itr roaring.IntIterable)
 }
 
 func (tsi *tStoreIterator) SeriesID() uint32 { return tsi.seriesID }
-func (tsi *tStoreIterator) HasNext() bool {
+
+// Tags returns the tag values of current series by the tag keys of group by
+func (tsi *tStoreIterator) Tags() map[string]string {
+	if tsi.tagIndex == nil || len(tsi.groupBy) == 0 {
+		return nil
+	}
+	return tsi.tagIndex.getSeriesTags(tsi.seriesID, tsi.groupBy)
+}
+
+// nextSeries moves to the next series of the tagIndex, holds the lock of tStore until moving to next series
+func (tsi *tStoreIterator) nextSeries() bool {
 	if tsi.intItr == nil {
 		return false
 	}
 Loop:
 	{
-		if tsi.releaseFunc != nil {
-			// release lock of tStore
-			tsi.releaseFunc()
-			tsi.releaseFunc = nil
-		}
+		// release lock of tStore
+		tsi.release()
 		if !tsi.intItr.HasNext() {
 			return false
 		}
@@ -75,12 +84,25 @@ Loop:
 	}
 }
 
+// release releases the lock of current tStore
+func (tsi *tStoreIterator) release() {
+	if tsi.releaseFunc != nil {
+		tsi.releaseFunc()
+		tsi.releaseFunc = nil
+	}
+}
+
+// HasNext returns if the series has more field to scan
+func (tsi *tStoreIterator) HasNext() bool {
+	return tsi.fStoreItr.nextField()
+}
+
 func (tsi *tStoreIterator) Next() series.FieldIterator {
 	return tsi.fStoreItr
 }
 
 //////////////////////////////////////////////////////
-// mStoreIterator implements series.VersionIterator
+// mStoreIterator implements series.VersionIterator over the series of all versions
 //////////////////////////////////////////////////////
 type mStoreIterator struct {
 	releaseFunc func() // release lock handler for mStore
@@ -98,8 +120,12 @@ func newMStoreIterator(mStore *metricStore, sCtx series.ScanContext) *mStoreIter
 	}
 	msi.releaseFunc = mStore.retain()
 
-	// collect all tagIndexes whose version matches the idSet
+	// collect all tagIndexes whose version matches the idSet, or all tagIndexes if scans all series
 	collectOnVersionMatch := func(idx tagIndexINTF) {
+		if msi.sCtx.SeriesIDSet == nil {
+			msi.tagIndexes = append(msi.tagIndexes, idx)
+			return
+		}
 		if _, ok := msi.sCtx.SeriesIDSet.Versions()[idx.getVersion()]; ok {
 			msi.tagIndexes = append(msi.tagIndexes, idx)
 		}
@@ -112,6 +138,7 @@ func newMStoreIterator(mStore *metricStore, sCtx series.ScanContext) *mStoreIter
 }
 
 func (msi *mStoreIterator) Close() error {
+	msi.tStoreItr.release()
 	if msi.releaseFunc != nil {
 		msi.releaseFunc()
 		msi.releaseFunc = nil
@@ -123,7 +150,20 @@ func (msi *mStoreIterator) Version() uint32 {
 	return msi.version
 }
 
+// HasNext returns if there are more series to scan under current version or next versions
 func (msi *mStoreIterator) HasNext() bool {
+	for {
+		if msi.tStoreItr.nextSeries() {
+			return true
+		}
+		if !msi.nextVersion() {
+			return false
+		}
+	}
+}
+
+// nextVersion moves to the next tagIndex whose time range overlaps the query
+func (msi *mStoreIterator) nextVersion() bool {
 Loop:
 	{ // version exhaustion
 		if len(msi.tagIndexes) == 0 {
@@ -131,10 +171,6 @@ Loop:
 		}
 		thisTagIndex := msi.tagIndexes[0]
 		msi.tagIndexes = msi.tagIndexes[1:]
-		msi.version = thisTagIndex.getVersion()
-		intItr := msi.sCtx.SeriesIDSet.Versions()[msi.version].Iterator()
-		msi.tStoreItr.reset(thisTagIndex, intItr)
-
 		startTime, endTime := thisTagIndex.getTimeRange()
 		if !msi.sCtx.TimeRange.Overlap(&timeutil.TimeRange{
 			Start: int64(startTime) * 1000, End: int64(endTime) * 1000}) {
@@ -143,10 +179,24 @@ Loop:
 			msi.version = 0
 			goto Loop
 		}
+		msi.version = thisTagIndex.getVersion()
+		msi.tStoreItr.reset(thisTagIndex, msi.seriesIDs(thisTagIndex).Iterator())
 		return true
 	}
 }
 
+// seriesIDs returns the series ids to scan under the tagIndex, all series of tagIndex if scans all series
+func (msi *mStoreIterator) seriesIDs(tagIndex tagIndexINTF) *roaring.Bitmap {
+	if msi.sCtx.SeriesIDSet != nil {
+		return msi.sCtx.SeriesIDSet.Versions()[msi.version]
+	}
+	seriesIDs := roaring.New()
+	for seriesID := range tagIndex.allTStores() {
+		seriesIDs.Add(seriesID)
+	}
+	return seriesIDs
+}
+
 func (msi *mStoreIterator) Next() series.Iterator {
 	return msi.tStoreItr
 }
@@ -1,12 +1,14 @@
 package memdb
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
 	"github.com/lindb/lindb/tsdb/field"
 	"github.com/lindb/lindb/tsdb/series"
 )
@@ -70,35 +72,130 @@ func Test_mStore_scan(t *testing.T) {
 	assert.Zero(t, itr2.Version())
 	assert.True(t, itr2.HasNext())
 	tsItr2 := itr2.Next()
-	// default tStore value
-	assert.Zero(t, tsItr2.SeriesID())
 	assert.Equal(t, uint32(2), itr2.Version())
-	// next ts
-	assert.True(t, tsItr2.HasNext())
 	assert.Equal(t, uint32(5), tsItr2.SeriesID())
-	assert.NotNil(t, tsItr2.Next())
-	// test field-iterator, todo: fixme
+	// no group by
+	assert.Nil(t, tsItr2.(series.GroupedIterator).Tags())
+	// no data in fStore
+	assert.False(t, tsItr2.HasNext())
 	fItr5 := tsItr2.Next()
 	assert.False(t, fItr5.HasNext())
-	assert.NotNil(t, fItr5.Next())
 	assert.Zero(t, fItr5.FieldID())
 	assert.Zero(t, fItr5.FieldType())
 	assert.Len(t, fItr5.FieldName(), 0)
 
 	// seriesID: 7
-	assert.True(t, tsItr2.HasNext())
-	assert.Equal(t, uint32(7), tsItr2.SeriesID())
-	assert.False(t, tsItr2.HasNext())
+	assert.True(t, itr2.HasNext())
+	assert.Equal(t, uint32(7), itr2.Next().SeriesID())
+	assert.False(t, itr2.HasNext())
+	assert.Nil(t, itr2.Close())
+}
+
+func Test_mStore_scan_allSeries(t *testing.T) {
+	mStore := newMetricStore(100).(*metricStore)
+	mStore.fieldsMetas = fieldsMetas{{"sum", 1, field.SumField}}
+	ti := newTagIndex().(*tagIndex)
+	ti.version = 1
+	ti.startTime, ti.endTime = 100, 200
+	_, _ = ti.getOrCreateTStore("host=1.1.1.1,zone=sh")
+	_, _ = ti.getOrCreateTStore("host=1.1.1.2")
+	mStore.mutable = ti
 
+	// scans all series without series id set, returns the tags of group by
+	itr := mStore.scan(series.ScanContext{
+		GroupBy:   []string{"zone"},
+		TimeRange: timeutil.TimeRange{Start: 100 * 1000, End: 500 * 1000}})
+	defer func() {
+		_ = itr.Close()
+	}()
+
+	tags := make(map[uint32]map[string]string)
+	for itr.HasNext() {
+		assert.Equal(t, uint32(1), itr.Version())
+		tsItr := itr.Next()
+		grouped, ok := tsItr.(series.GroupedIterator)
+		assert.True(t, ok)
+		tags[tsItr.SeriesID()] = grouped.Tags()
+	}
+	assert.Equal(t, map[uint32]map[string]string{
+		1: {"zone": "sh"},
+		2: {"zone": ""},
+	}, tags)
+}
+
+func Test_mStore_scan_fields(t *testing.T) {
+	mStore := newMetricStore(100).(*metricStore)
+	mStore.fieldsMetas = fieldsMetas{{"sum1", 1, field.SumField}, {"sum2", 2, field.SumField}}
+	ti := newTagIndex().(*tagIndex)
+	ti.startTime, ti.endTime = 100, 200
+	tStore, _ := ti.getOrCreateTStore("host=1.1.1.1")
+	mStore.mutable = ti
+	writeCtx := writeContext{blockStore: newBlockStore(30), familyTime: 100 * 1000}
+	for _, fieldID := range []uint16{1, 2} {
+		fStore := newFieldStore(fieldID)
+		writeCtx.slotIndex = int(fieldID)
+		fStore.Write(&pb.Field{Field: &pb.Field_Sum{Sum: &pb.Sum{Value: float64(fieldID)}}}, writeCtx)
+		tStore.(*timeSeriesStore).insertFStore(fStore)
+	}
+
+	// scans the fields of series in the family, each field has one stored primitive field
+	itr := mStore.scan(series.ScanContext{
+		FieldIDs:     []uint16{1, 2, 3},
+		FamilyTime:   100 * 1000,
+		TimeInterval: 1000,
+		TimeRange:    timeutil.TimeRange{Start: 100 * 1000, End: 500 * 1000}})
+	assert.True(t, itr.HasNext())
+	tsItr := itr.Next()
+	assert.Equal(t, uint32(1), tsItr.SeriesID())
+	for _, fieldID := range []uint16{1, 2} {
+		assert.True(t, tsItr.HasNext())
+		fItr := tsItr.Next()
+		assert.Equal(t, fieldID, fItr.FieldID())
+		assert.Equal(t, fmt.Sprintf("sum%d", fieldID), fItr.FieldName())
+		assert.Equal(t, field.SumField, fItr.FieldType())
+		assert.True(t, fItr.HasNext())
+		pItr := fItr.Next()
+		assert.False(t, fItr.HasNext())
+		assert.Equal(t, uint16(1), pItr.FieldID())
+		assert.True(t, pItr.HasNext())
+		slot, value := pItr.Next()
+		assert.Equal(t, int(fieldID), slot)
+		assert.Equal(t, float64(fieldID), value)
+		assert.False(t, pItr.HasNext())
+	}
+	assert.False(t, tsItr.HasNext())
+	assert.False(t, itr.HasNext())
+	assert.Nil(t, itr.Close())
 }
 
 func Test_primitiveIterator(t *testing.T) {
-	// todo: fixme
-	pi := newPrimitiveIterator(series.ScanContext{})
-	pi.reset(nil)
+	pi := newPrimitiveIterator(series.ScanContext{FamilyTime: 10})
+	pi.reset(nil, field.SumField)
 	assert.Zero(t, pi.FieldID())
 	assert.False(t, pi.HasNext())
-	timeSlot, value := pi.Next()
-	assert.Zero(t, timeSlot)
-	assert.Zero(t, value)
+
+	fStore := newFieldStore(10)
+	writeCtx := writeContext{blockStore: newBlockStore(30), familyTime: 10}
+	for _, slot := range []int{5, 6, 5, 40} {
+		writeCtx.slotIndex = slot
+		fStore.Write(&pb.Field{Name: "sum", Field: &pb.Field_Sum{Sum: &pb.Sum{Value: 1.5}}}, writeCtx)
+	}
+	// family not exist
+	pi = newPrimitiveIterator(series.ScanContext{FamilyTime: 20})
+	pi.reset(fStore, field.SumField)
+	assert.False(t, pi.HasNext())
+
+	// reads the compacted data and the values in current time window twice, the data isn't compacted again
+	pi = newPrimitiveIterator(series.ScanContext{FamilyTime: 10})
+	for i := 0; i < 2; i++ {
+		pi.reset(fStore, field.SumField)
+		assert.Equal(t, uint16(1), pi.FieldID())
+		points := make(map[int]float64)
+		for pi.HasNext() {
+			timeSlot, value := pi.Next()
+			points[timeSlot] = value
+		}
+		assert.Equal(t, map[int]float64{5: 3.0, 6: 1.5, 40: 1.5}, points)
+		assert.False(t, pi.HasNext())
+	}
 }
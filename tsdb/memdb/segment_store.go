@@ -3,7 +3,6 @@ package memdb
 import (
 	"fmt"
 
-	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/tsdb/field"
 )
@@ -16,6 +15,9 @@ type sStoreINTF interface {
 	getFamilyTime() int64
 	slotRange() (startSlot, endSlot int, err error)
 	bytes() (data []byte, startSlot, endSlot int, err error)
+	// snapshot returns the data merged with the values which are not compacted yet for reading,
+	// it doesn't compact the block like bytes.
+	snapshot() (data []byte, startSlot, endSlot int, err error)
 	writeInt(value int64, writeCtx writeContext)
 	writeFloat(value float64, writeCtx writeContext)
 }
@@ -120,11 +122,23 @@ func (fs *simpleFieldStore) bytes() (data []byte, startSlot, endSlot int, err er
 	return
 }
 
+func (fs *simpleFieldStore) snapshot() (data []byte, startSlot, endSlot int, err error) {
+	if fs.block == nil {
+		err = fmt.Errorf("block is empty")
+		return
+	}
+	return fs.block.snapshot(fs.aggFunc)
+}
+
+// slotRange returns the time slot range of the compacted data and the values in current time window
 func (fs *simpleFieldStore) slotRange() (startSlot, endSlot int, err error) {
 	if fs.block == nil {
 		err = fmt.Errorf("block is empty")
 		return
 	}
-	startSlot, endSlot = encoding.DecodeTSDTime(fs.block.bytes())
+	startSlot, endSlot, ok := fs.block.slotRange()
+	if !ok {
+		err = fmt.Errorf("block is empty")
+	}
 	return
 }
@@ -22,6 +22,8 @@ func TestSimpleSegmentStore(t *testing.T) {
 
 	_, _, err := ss.slotRange()
 	assert.NotNil(t, err)
+	_, _, _, err = ss.snapshot()
+	assert.NotNil(t, err)
 
 	compress, startSlot, endSlot, err := store.bytes()
 	assert.Nil(t, compress)
@@ -54,7 +56,18 @@ func TestSimpleSegmentStore(t *testing.T) {
 	writeCtx.slotIndex = 41
 	ss.writeInt(50, writeCtx)
 
+	// the value in current time window isn't compacted yet
+	startSlot, endSlot, err = store.slotRange()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, startSlot)
+	assert.Equal(t, 41, endSlot)
+	snapshot, startSlot, endSlot, err := store.snapshot()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, startSlot)
+	assert.Equal(t, 41, endSlot)
+
 	compress, startSlot, endSlot, err = store.bytes()
+	assert.Equal(t, snapshot, compress)
 	assert.Nil(t, err)
 	assert.Equal(t, 10, startSlot)
 	assert.Equal(t, 41, endSlot)
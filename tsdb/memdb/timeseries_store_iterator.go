@@ -1,6 +1,9 @@
 package memdb
 
 import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/tsdb/field"
 	"github.com/lindb/lindb/tsdb/series"
 )
@@ -9,59 +12,72 @@ import (
 // primitiveIterator implements PrimitiveIterator
 //////////////////////////////////////////////////////
 type primitiveIterator struct {
-	fStore fStoreINTF
-	sStore sStoreINTF
-	sCtx   series.ScanContext
-	data   []byte
+	fStore    fStoreINTF
+	fieldID   uint16 // stored primitive field id of field type
+	sCtx      series.ScanContext
+	tsd       *encoding.TSDDecoder // decoder of the family data, nil if not read yet
+	timeSlot  int
+	value     float64
+	exhausted bool
 }
 
 func newPrimitiveIterator(sCtx series.ScanContext) *primitiveIterator {
 	return &primitiveIterator{sCtx: sCtx}
 }
 
-func (pi *primitiveIterator) reset(fStore fStoreINTF) {
+func (pi *primitiveIterator) reset(fStore fStoreINTF, fieldType field.Type) {
 	pi.fStore = fStore
-	pi.sStore = nil
-	pi.data = pi.data[:0]
+	pi.fieldID, _ = field.GetStoredFieldID(fieldType)
+	pi.tsd = nil
+	pi.exhausted = false
 }
 
 func (pi *primitiveIterator) FieldID() uint16 {
 	if pi.fStore == nil {
 		return 0
 	}
-	return pi.fStore.GetFieldID()
+	return pi.fieldID
 }
 
+// HasNext returns if the family data has more data points,
+// the data of family is read from the snapshot of sStore, which includes the values not compacted yet.
 func (pi *primitiveIterator) HasNext() bool {
-	if pi.fStore == nil {
+	if pi.fStore == nil || pi.exhausted {
 		return false
 	}
-	if pi.sStore == nil {
-		var ok bool
-		if pi.sStore, ok = pi.fStore.GetSStore(pi.sCtx.FamilyTime); !ok {
+	if pi.tsd == nil {
+		sStore, ok := pi.fStore.GetSStore(pi.sCtx.FamilyTime)
+		if !ok {
+			pi.exhausted = true
 			return false
 		}
-	}
-	if len(pi.data) == 0 {
-		data, startSlot, endSlot, err := pi.sStore.bytes()
+		data, _, _, err := sStore.snapshot()
 		if err != nil {
+			pi.exhausted = true
 			return false
 		}
-		// todo: fixme
-		_ = data
-		_ = startSlot
-		_ = endSlot
+		pi.tsd = encoding.NewTSDDecoder(data)
+		pi.timeSlot = pi.tsd.StartTime() - 1
 	}
-
+	for pi.tsd.Next() {
+		pi.timeSlot++
+		if pi.tsd.HasValue() {
+			// only float value is written into memory database
+			pi.value = math.Float64frombits(pi.tsd.Value())
+			return true
+		}
+	}
+	pi.exhausted = true
 	return false
 }
 
+// Next returns the time slot under family and the value of current data point
 func (pi *primitiveIterator) Next() (timeSlot int, value float64) {
-	return 0, 0
+	return pi.timeSlot, pi.value
 }
 
 //////////////////////////////////////////////////////
-// fStoreIterator implements FieldIterator
+// fStoreIterator implements FieldIterator over the stored primitive field of a field
 //////////////////////////////////////////////////////
 type fStoreIterator struct {
 	tStore       tStoreINTF
@@ -72,6 +88,7 @@ type fStoreIterator struct {
 	fieldIDs     []uint16 // on iterating
 	fieldMetas   fieldsMetas
 	primitiveItr *primitiveIterator
+	hasNext      bool // if the primitive field isn't returned yet
 }
 
 func newFStoreIterator(metas fieldsMetas, sCtx series.ScanContext) *fStoreIterator {
@@ -102,13 +119,15 @@ func (fsi *fStoreIterator) findID(fieldID uint16) bool {
 	return false
 }
 
-func (fsi *fStoreIterator) HasNext() bool {
+// nextField moves to the next field of the series which has data in the time range of query
+func (fsi *fStoreIterator) nextField() bool {
 Loop:
 	{
 		if len(fsi.fieldIDs) == 0 {
 			fsi.fieldID = 0
 			fsi.fieldName = ""
 			fsi.fieldType = 0
+			fsi.hasNext = false
 			return false
 		}
 		thisFieldID := fsi.fieldIDs[0]
@@ -127,11 +146,18 @@ Loop:
 		if !timeRange.Overlap(&fsi.sCtx.TimeRange) {
 			goto Loop
 		}
-		fsi.primitiveItr.reset(fStore)
+		fsi.primitiveItr.reset(fStore, fsi.fieldType)
+		fsi.hasNext = true
 		return true
 	}
 }
 
+// HasNext returns if the field has more primitive field, the field in memory has only one stored primitive field
+func (fsi *fStoreIterator) HasNext() bool {
+	return fsi.hasNext
+}
+
 func (fsi *fStoreIterator) Next() series.PrimitiveIterator {
+	fsi.hasNext = false
 	return fsi.primitiveItr
 }
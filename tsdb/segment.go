@@ -143,8 +143,8 @@ func (s *intervalSegment) getSegment(segmentName string) Segment {
 type Segment interface {
 	// BaseTime returns segment base time
 	BaseTime() int64
-	// GetDataFamilyScanners returns data family list by time range, return nil if not match
-	GetDataFamilyScanners(timeRange timeutil.TimeRange) []series.DataFamilyScanner
+	// GetDataFamilies returns data family list by time range, return nil if not match
+	GetDataFamilies(timeRange timeutil.TimeRange) []DataFamily
 	// GetDataFamily returns the data family based on timestamp
 	GetDataFamily(timestamp int64) (DataFamily, error)
 	// Close closes segment, include kv store
@@ -194,7 +194,8 @@ func (s *segment) BaseTime() int64 {
 	return s.baseTime
 }
 
-func (s *segment) GetDataFamilyScanners(timeRange timeutil.TimeRange) []series.DataFamilyScanner {
+// GetDataFamilies returns data family list by time range, return nil if not match
+func (s *segment) GetDataFamilies(timeRange timeutil.TimeRange) []DataFamily {
 	//TODO need impl
 	return nil
 }
@@ -42,6 +42,15 @@ func (mv *MultiVerSeriesIDSet) IsEmpty() bool {
 	return true
 }
 
+// Cardinality returns the num. of series ids under all versions
+func (mv *MultiVerSeriesIDSet) Cardinality() uint64 {
+	var count uint64
+	for _, ids := range mv.versions {
+		count += ids.GetCardinality()
+	}
+	return count
+}
+
 // And computes the intersection between two set and stores the result in the current set
 func (mv *MultiVerSeriesIDSet) And(other *MultiVerSeriesIDSet) {
 	// 1. computes the intersection between two version
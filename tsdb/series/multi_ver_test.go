@@ -18,6 +18,14 @@ func TestMultiVerSeriesIDSet_IsEmpty(t *testing.T) {
 	assert.Len(t, multiVer1.Versions(), 1)
 }
 
+func TestMultiVerSeriesIDSet_Cardinality(t *testing.T) {
+	multiVer1 := NewMultiVerSeriesIDSet()
+	assert.Equal(t, uint64(0), multiVer1.Cardinality())
+	multiVer1.Add(uint32(12), roaring.BitmapOf(1, 2, 3))
+	multiVer1.Add(uint32(13), roaring.BitmapOf(1, 2, 3, 4))
+	assert.Equal(t, uint64(7), multiVer1.Cardinality())
+}
+
 func TestMultiVerSeriesIDSet_And(t *testing.T) {
 	multiVer1 := NewMultiVerSeriesIDSet()
 	multiVer1.Add(uint32(12), roaring.BitmapOf(1, 2, 3, 4))
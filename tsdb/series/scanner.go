@@ -12,8 +12,11 @@ type ScanContext struct {
 	MetricID  uint32
 	FieldIDs  []uint16
 	TimeRange timeutil.TimeRange
-	// optional, if SeriesIDSet is nil, just search metric level data
+	// optional, if SeriesIDSet is nil, scans all series of metric
 	SeriesIDSet *MultiVerSeriesIDSet
+	// optional, the tag keys of group by, if not empty,
+	// the series iterator implements GroupedIterator which returns the tags of series by these keys
+	GroupBy []string
 	// for context usage
 	TimeInterval int64 // database interval in seconds
 	FamilyTime   int64 // family time